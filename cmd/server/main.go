@@ -14,9 +14,11 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/core/presentation/controllers"
 	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/configuration"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
 	"github.com/iota-uz/iota-sdk/pkg/logging"
+	"github.com/iota-uz/iota-sdk/pkg/scheduler"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/lib/pq"
@@ -49,16 +51,60 @@ func main() {
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
-	pool, err := pgxpool.New(ctx, conf.Database.Opts)
+	pool, err := newPool(ctx, conf.Database.Opts, conf.Database.TenantRLS)
 	if err != nil {
 		panic(err)
 	}
+	var replicas *composables.ReadReplicas
+	if replicaOpts := conf.Database.ReplicaConnectionStrings(); len(replicaOpts) > 0 {
+		replicaPools := make([]*pgxpool.Pool, 0, len(replicaOpts))
+		for _, opts := range replicaOpts {
+			replicaPool, err := newPool(ctx, opts, conf.Database.TenantRLS)
+			if err != nil {
+				panic(err)
+			}
+			replicaPools = append(replicaPools, replicaPool)
+		}
+		replicas = composables.NewReadReplicas(replicaPools...)
+		defer replicas.Close()
+	}
 	bundle := application.LoadBundle()
+	eventRegistry := eventbus.NewRegistry()
+	var eventBus eventbus.EventBus = eventbus.NewEventPublisher(logger)
+	if conf.EventBus.Async {
+		asyncBus := eventbus.NewAsyncEventPublisher(eventbus.AsyncOptions{
+			Logger:      logger,
+			Workers:     conf.EventBus.Workers,
+			DeadLetters: eventbus.NewPgDeadLetterStore(pool),
+			Registry:    eventRegistry,
+		})
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			if err := asyncBus.Shutdown(ctx); err != nil {
+				logger.Errorf("failed to drain event bus: %v", err)
+			}
+		}()
+		eventBus = asyncBus
+	}
+	if conf.EventBus.Distributed {
+		distributedBus := eventbus.NewDistributedEventPublisher(eventbus.DistributedOptions{
+			Pool:     pool,
+			Local:    eventBus,
+			Registry: eventRegistry,
+			Logger:   logger,
+			Channel:  conf.EventBus.Channel,
+		})
+		distributedBus.Start(context.Background())
+		defer distributedBus.Stop()
+		eventBus = distributedBus
+	}
 	app := application.New(&application.ApplicationOptions{
-		Pool:     pool,
-		Bundle:   bundle,
-		EventBus: eventbus.NewEventPublisher(logger),
-		Logger:   logger,
+		Pool:          pool,
+		Bundle:        bundle,
+		EventBus:      eventBus,
+		EventRegistry: eventRegistry,
+		Logger:        logger,
 		Huber: application.NewHub(&application.HuberOptions{
 			Pool:           pool,
 			Logger:         logger,
@@ -83,7 +129,30 @@ func main() {
 		Configuration: conf,
 		Application:   app,
 		Pool:          pool,
+		Replicas:      replicas,
 	}
+	outboxDispatcher := eventbus.NewOutboxDispatcher(eventbus.OutboxDispatcherOptions{
+		Pool:      pool,
+		EventBus:  app.EventPublisher(),
+		Registry:  app.EventRegistry(),
+		Logger:    logger,
+		Retention: 7 * 24 * time.Hour,
+	})
+	outboxDispatcher.Start(context.Background())
+	defer outboxDispatcher.Stop()
+
+	if conf.Scheduler.Enabled {
+		taskScheduler := scheduler.New(scheduler.Options{
+			Pool:             pool,
+			Registry:         app.SchedulerRegistry(),
+			Tenants:          persistence.NewTenantRepository(),
+			Logger:           logger,
+			HistoryRetention: conf.Scheduler.HistoryRetention,
+		})
+		taskScheduler.Start(context.Background())
+		defer taskScheduler.Stop()
+	}
+
 	serverInstance, err := server.Default(options)
 	if err != nil {
 		log.Fatalf("failed to create server: %v", err)
@@ -93,3 +162,15 @@ func main() {
 		log.Fatalf("failed to start server: %v", err)
 	}
 }
+
+// newPool connects to connString, scoping connections to the request tenant when tenantRLS is set.
+func newPool(ctx context.Context, connString string, tenantRLS bool) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, err
+	}
+	if tenantRLS {
+		composables.EnableTenantRLS(config)
+	}
+	return pgxpool.NewWithConfig(ctx, config)
+}
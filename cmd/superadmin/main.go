@@ -20,6 +20,7 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/superadmin"
 	superadminMiddleware "github.com/iota-uz/iota-sdk/modules/superadmin/middleware"
 	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/configuration"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
 	"github.com/iota-uz/iota-sdk/pkg/logging"
@@ -60,6 +61,19 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	var replicas *composables.ReadReplicas
+	if replicaOpts := conf.Database.ReplicaConnectionStrings(); len(replicaOpts) > 0 {
+		replicaPools := make([]*pgxpool.Pool, 0, len(replicaOpts))
+		for _, opts := range replicaOpts {
+			replicaPool, err := pgxpool.New(ctx, opts)
+			if err != nil {
+				panic(err)
+			}
+			replicaPools = append(replicaPools, replicaPool)
+		}
+		replicas = composables.NewReadReplicas(replicaPools...)
+		defer replicas.Close()
+	}
 	bundle := application.LoadBundle()
 	app := application.New(&application.ApplicationOptions{
 		Pool:     pool,
@@ -108,7 +122,7 @@ func main() {
 		services.NewUserQueryService(userQueryRepo),
 		services.NewGroupQueryService(groupQueryRepo),
 		services.NewSessionService(persistence.NewSessionRepository(), app.EventPublisher()),
-		services.NewExcelExportService(app.DB(), uploadService),
+		services.NewExcelExportService(app.DB(), uploadService, app.Jobs()),
 	)
 	// Register second batch (including AuthService which depends on UserService)
 	app.RegisterServices(
@@ -151,6 +165,7 @@ func main() {
 		Configuration: conf,
 		Application:   app,
 		Pool:          pool,
+		Replicas:      replicas,
 	}
 
 	// Create server first - this sets up core middleware including RequestParams
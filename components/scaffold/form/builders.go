@@ -597,7 +597,8 @@ func (b *URLFieldBuilder) Build() URLField {
 type SelectFieldBuilder struct {
 	key, label, defaultVal string
 	options                []Option
-	required               bool
+	selected               []string
+	required, multiple     bool
 	attrs                  templ.Attributes
 	validators             []Validator
 }
@@ -621,6 +622,13 @@ func (b *SelectFieldBuilder) Required() *SelectFieldBuilder {
 	return b
 }
 
+// Multiple allows selecting several options, preselecting the ones with the given values
+func (b *SelectFieldBuilder) Multiple(selected ...string) *SelectFieldBuilder {
+	b.multiple = true
+	b.selected = selected
+	return b
+}
+
 func (b *SelectFieldBuilder) Attrs(a templ.Attributes) *SelectFieldBuilder {
 	b.attrs = a
 	return b
@@ -637,7 +645,9 @@ func (b *SelectFieldBuilder) Build() SelectField {
 		label:      b.label,
 		defaultVal: b.defaultVal,
 		options:    b.options,
+		selected:   b.selected,
 		required:   b.required,
+		multiple:   b.multiple,
 		attrs:      b.attrs,
 		validators: b.validators,
 	}
@@ -851,3 +861,30 @@ func (b *ComboboxFieldBuilder) Build() ComboboxField {
 		validators:  b.validators,
 	}
 }
+
+// HiddenFieldBuilder builds a HiddenField
+type HiddenFieldBuilder struct {
+	key, defaultVal string
+	attrs           templ.Attributes
+}
+
+func Hidden(key string) *HiddenFieldBuilder {
+	return &HiddenFieldBuilder{key: key, attrs: templ.Attributes{}}
+}
+
+func (b *HiddenFieldBuilder) Default(val string) *HiddenFieldBuilder {
+	b.defaultVal = val
+	return b
+}
+func (b *HiddenFieldBuilder) Attrs(a templ.Attributes) *HiddenFieldBuilder {
+	b.attrs = a
+	return b
+}
+
+func (b *HiddenFieldBuilder) Build() HiddenField {
+	return &hiddenField{
+		key:        b.key,
+		defaultVal: b.defaultVal,
+		attrs:      b.attrs,
+	}
+}
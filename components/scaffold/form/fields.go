@@ -4,6 +4,7 @@ import (
 	"context"
 	"html"
 	"io"
+	"slices"
 	"strconv"
 	"time"
 
@@ -32,6 +33,7 @@ const (
 	FieldTypeURL           FieldType = "url"
 	FieldTypeTextarea      FieldType = "textarea"
 	FieldTypeSelect        FieldType = "select"
+	FieldTypeHidden        FieldType = "hidden"
 )
 
 // Option for SelectField and RadioField choices
@@ -152,6 +154,11 @@ type SelectField interface {
 	Options() []Option
 }
 
+// HiddenField for values submitted with the form but not shown, e.g. versions
+type HiddenField interface {
+	GenericField[string]
+}
+
 // SearchSelectField for async search dropdowns
 type SearchSelectField interface {
 	GenericField[string]
@@ -682,7 +689,9 @@ type selectField struct {
 	value      string
 	defaultVal string
 	options    []Option
+	selected   []string
 	required   bool
+	multiple   bool
 	attrs      templ.Attributes
 	validators []Validator
 }
@@ -694,6 +703,9 @@ func (f *selectField) Component() templ.Component {
 	if f.required {
 		attrs["required"] = true
 	}
+	if f.multiple {
+		attrs["multiple"] = true
+	}
 	for k, v := range f.attrs {
 		attrs[k] = v
 	}
@@ -705,7 +717,7 @@ func (f *selectField) Component() templ.Component {
 	for _, opt := range f.options {
 		optValue := opt.Value
 		optLabel := opt.Label
-		selected := optValue == currentValue
+		selected := optValue == currentValue || slices.Contains(f.selected, optValue)
 
 		optionComponents = append(optionComponents, templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
 			if selected {
@@ -720,6 +732,14 @@ func (f *selectField) Component() templ.Component {
 
 	// Return component that renders the select with options
 	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		// Browsers don't submit multiple selects without selected options,
+		// so an empty value tells that all of them were deselected
+		if f.multiple {
+			if _, err := io.WriteString(w, `<input type="hidden" name="`+html.EscapeString(f.key)+`" value="">`); err != nil {
+				return err
+			}
+		}
+
 		// First render the opening of the select component
 		err := base.Select(&base.SelectProps{
 			Label:       f.label,
@@ -894,3 +914,44 @@ func (f *comboboxField) WithValue(value string) GenericField[string] {
 	newField.value = value
 	return &newField
 }
+
+type hiddenField struct {
+	key        string
+	value      string
+	defaultVal string
+	attrs      templ.Attributes
+}
+
+func (f *hiddenField) Component() templ.Component {
+	attrs := templ.Attributes{
+		"name":  f.key,
+		"type":  string(FieldTypeHidden),
+		"value": mapping.Or(f.value, f.defaultVal),
+	}
+	for k, v := range f.attrs {
+		attrs[k] = v
+	}
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		if _, err := io.WriteString(w, "<input"); err != nil {
+			return err
+		}
+		if err := templ.RenderAttributes(ctx, w, attrs); err != nil {
+			return err
+		}
+		_, err := io.WriteString(w, ">")
+		return err
+	})
+}
+func (f *hiddenField) Key() string             { return f.key }
+func (f *hiddenField) Label() string           { return "" }
+func (f *hiddenField) Type() FieldType         { return FieldTypeHidden }
+func (f *hiddenField) Value() string           { return f.value }
+func (f *hiddenField) Required() bool          { return false }
+func (f *hiddenField) Attrs() templ.Attributes { return f.attrs }
+func (f *hiddenField) Validators() []Validator { return nil }
+func (f *hiddenField) Default() string         { return f.defaultVal }
+func (f *hiddenField) WithValue(value string) GenericField[string] {
+	newField := *f // Create a copy
+	newField.value = value
+	return &newField
+}
@@ -0,0 +1,272 @@
+// Package renderers provides the scaffold renderers of the crud field types
+// without a form input of their own: money, enum, file and geopoint fields.
+package renderers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/a-h/templ"
+	"github.com/iota-uz/go-i18n/v2/i18n"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+)
+
+// UploadLoader loads the upload with id, which file fields reference
+type UploadLoader func(ctx context.Context, id uint) (upload.Upload, error)
+
+// Register registers the renderers of money, enum, file and geopoint fields in
+// registry under the names of their field types, which fields of these types
+// render with by default. Form controls are labelled like the other fields of
+// the schema named schemaName.
+func Register(registry *crud.RendererRegistry, schemaName string, loadUpload UploadLoader) {
+	registry.Register(string(crud.MoneyFieldType), NewMoneyRenderer(schemaName))
+	registry.Register(string(crud.EnumFieldType), NewEnumRenderer(schemaName))
+	registry.Register(string(crud.FileFieldType), NewFileRenderer(schemaName, loadUpload))
+	registry.Register(string(crud.GeoPointFieldType), NewGeoPointRenderer(schemaName))
+}
+
+// MoneyRenderer renders money fields formatted in their currency, with an
+// input of amounts in major units in forms
+type MoneyRenderer struct {
+	schemaName string
+}
+
+func NewMoneyRenderer(schemaName string) *MoneyRenderer {
+	return &MoneyRenderer{schemaName: schemaName}
+}
+
+func (r *MoneyRenderer) RenderTableCell(ctx context.Context, field crud.Field, value crud.FieldValue) templ.Component {
+	return moneyText(value)
+}
+
+func (r *MoneyRenderer) RenderDetails(ctx context.Context, field crud.Field, value crud.FieldValue) templ.Component {
+	return moneyText(value)
+}
+
+func (r *MoneyRenderer) RenderFormControl(ctx context.Context, field crud.Field, value crud.FieldValue) templ.Component {
+	mf, ok := field.(crud.MoneyField)
+	if !ok {
+		return templ.Raw("")
+	}
+	step := "1"
+	if fraction := mf.Fraction(); fraction > 0 {
+		step = fmt.Sprintf("0.%0*d", fraction, 1)
+	}
+	return MoneyInput(MoneyInputProps{
+		Name:     field.Name(),
+		Label:    fieldLabel(ctx, r.schemaName, field),
+		Value:    formValue(value),
+		Currency: mf.Currency(),
+		Step:     step,
+		Readonly: readonly(ctx, field),
+	})
+}
+
+// EnumRenderer renders enum fields with the translated labels of their values
+// and a select of the values in forms
+type EnumRenderer struct {
+	schemaName string
+}
+
+func NewEnumRenderer(schemaName string) *EnumRenderer {
+	return &EnumRenderer{schemaName: schemaName}
+}
+
+func (r *EnumRenderer) RenderTableCell(ctx context.Context, field crud.Field, value crud.FieldValue) templ.Component {
+	return templ.Raw(templ.EscapeString(enumLabel(ctx, field, value)))
+}
+
+func (r *EnumRenderer) RenderDetails(ctx context.Context, field crud.Field, value crud.FieldValue) templ.Component {
+	return templ.Raw(templ.EscapeString(enumLabel(ctx, field, value)))
+}
+
+func (r *EnumRenderer) RenderFormControl(ctx context.Context, field crud.Field, value crud.FieldValue) templ.Component {
+	ef, ok := field.(crud.EnumField)
+	if !ok {
+		return templ.Raw("")
+	}
+	options := make([]EnumOption, len(ef.Values()))
+	for i, v := range ef.Values() {
+		options[i] = EnumOption{Value: v, Label: localizeWithDefault(ctx, ef.LabelKey(v), v)}
+	}
+	return EnumSelect(EnumSelectProps{
+		Name:     field.Name(),
+		Label:    fieldLabel(ctx, r.schemaName, field),
+		Value:    formValue(value),
+		Options:  options,
+		Readonly: readonly(ctx, field),
+	})
+}
+
+// FileRenderer renders file fields as links to their uploads, previewing images
+// in details, and an upload input in forms
+type FileRenderer struct {
+	schemaName string
+	loadUpload UploadLoader
+}
+
+func NewFileRenderer(schemaName string, loadUpload UploadLoader) *FileRenderer {
+	return &FileRenderer{schemaName: schemaName, loadUpload: loadUpload}
+}
+
+func (r *FileRenderer) RenderTableCell(ctx context.Context, field crud.Field, value crud.FieldValue) templ.Component {
+	u := r.upload(ctx, value)
+	if u == nil {
+		return templ.Raw(templ.EscapeString(formValue(value)))
+	}
+	return FileLink(u)
+}
+
+func (r *FileRenderer) RenderDetails(ctx context.Context, field crud.Field, value crud.FieldValue) templ.Component {
+	u := r.upload(ctx, value)
+	if u == nil {
+		return templ.Raw(templ.EscapeString(formValue(value)))
+	}
+	return FileDetails(u)
+}
+
+func (r *FileRenderer) RenderFormControl(ctx context.Context, field crud.Field, value crud.FieldValue) templ.Component {
+	var accept []string
+	if ff, ok := field.(crud.FileField); ok {
+		accept = ff.Accept()
+	}
+	return FileInput(FileInputProps{
+		Name:     field.Name(),
+		Label:    fieldLabel(ctx, r.schemaName, field),
+		Accept:   accept,
+		Upload:   r.upload(ctx, value),
+		Readonly: readonly(ctx, field),
+	})
+}
+
+// upload loads the upload value references, nil when there is none or it can't be loaded
+func (r *FileRenderer) upload(ctx context.Context, value crud.FieldValue) upload.Upload {
+	if value == nil || value.IsZero() || r.loadUpload == nil {
+		return nil
+	}
+	id, err := value.AsUploadID()
+	if err != nil {
+		return nil
+	}
+	u, err := r.loadUpload(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return u
+}
+
+// GeoPointRenderer renders geopoint fields as coordinates linked to a map in
+// details and an input of "lat,lng" coordinates in forms
+type GeoPointRenderer struct {
+	schemaName string
+}
+
+func NewGeoPointRenderer(schemaName string) *GeoPointRenderer {
+	return &GeoPointRenderer{schemaName: schemaName}
+}
+
+func (r *GeoPointRenderer) RenderTableCell(ctx context.Context, field crud.Field, value crud.FieldValue) templ.Component {
+	return templ.Raw(templ.EscapeString(coordinates(value)))
+}
+
+func (r *GeoPointRenderer) RenderDetails(ctx context.Context, field crud.Field, value crud.FieldValue) templ.Component {
+	if value == nil || value.IsZero() {
+		return templ.Raw("")
+	}
+	p, err := value.AsGeoPoint()
+	if err != nil || p == nil {
+		return templ.Raw("")
+	}
+	return GeoPointLink(coordinates(value), mapURL(p.Lat(), p.Lng()))
+}
+
+func (r *GeoPointRenderer) RenderFormControl(ctx context.Context, field crud.Field, value crud.FieldValue) templ.Component {
+	return GeoPointInput(GeoPointInputProps{
+		Name:     field.Name(),
+		Label:    fieldLabel(ctx, r.schemaName, field),
+		Value:    formValue(value),
+		Readonly: readonly(ctx, field),
+	})
+}
+
+func moneyText(value crud.FieldValue) templ.Component {
+	if value == nil || value.IsZero() {
+		return templ.Raw("")
+	}
+	m, err := value.AsMoney()
+	if err != nil || m == nil {
+		return templ.Raw("")
+	}
+	return templ.Raw(templ.EscapeString(m.Display()))
+}
+
+func enumLabel(ctx context.Context, field crud.Field, value crud.FieldValue) string {
+	if value == nil || value.IsZero() {
+		return ""
+	}
+	s, err := value.AsString()
+	if err != nil {
+		return ""
+	}
+	if ef, ok := field.(crud.EnumField); ok {
+		return localizeWithDefault(ctx, ef.LabelKey(s), s)
+	}
+	return s
+}
+
+func coordinates(value crud.FieldValue) string {
+	if value == nil || value.IsZero() {
+		return ""
+	}
+	p, err := value.AsGeoPoint()
+	if err != nil || p == nil {
+		return ""
+	}
+	return fmt.Sprintf("%.6f, %.6f", p.Lat(), p.Lng())
+}
+
+func mapURL(lat, lng float64) templ.SafeURL {
+	return templ.SafeURL(fmt.Sprintf("https://www.openstreetmap.org/?mlat=%f&mlon=%f#map=16/%f/%f", lat, lng, lat, lng))
+}
+
+func formValue(value crud.FieldValue) string {
+	if value == nil {
+		return ""
+	}
+	return crud.FormValue(value)
+}
+
+func readonly(ctx context.Context, field crud.Field) bool {
+	return field.Readonly() || !crud.CanWriteField(ctx, field)
+}
+
+// fieldLabel localizes the label of field like the crud controller does
+func fieldLabel(ctx context.Context, schemaName string, field crud.Field) string {
+	key := field.LocalizationKey()
+	if key == "" {
+		key = schemaName + ".Fields." + field.Name()
+	}
+	return localizeWithDefault(ctx, key, field.Name())
+}
+
+// localizeWithDefault localizes a message with a fallback default
+func localizeWithDefault(ctx context.Context, messageID string, defaultMessage string) string {
+	l, ok := intl.UseLocalizer(ctx)
+	if !ok {
+		return defaultMessage
+	}
+	result, err := l.Localize(&i18n.LocalizeConfig{
+		MessageID: messageID,
+		DefaultMessage: &i18n.Message{
+			ID:    messageID,
+			Other: defaultMessage,
+		},
+	})
+	if err != nil {
+		return defaultMessage
+	}
+	return result
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"maps"
 	"net/http"
 	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/a-h/templ"
@@ -80,12 +82,24 @@ func (c *tableCellImpl) convertValueToString(value any, fieldType crud.FieldType
 	case crud.JSONFieldType:
 		// For JSON fields, return as string
 		return fmt.Sprintf("%v", value)
+	case crud.RelationFieldType:
+		// Related records are shown by their labels
+		if records, ok := value.([]crud.RelatedRecord); ok {
+			labels := make([]string, len(records))
+			for i, record := range records {
+				labels[i] = record.Label
+			}
+			return strings.Join(labels, ", ")
+		}
 	case crud.DateFieldType, crud.TimeFieldType, crud.DateTimeFieldType, crud.TimestampFieldType:
 		// For date/time types, format as string
 		if t, ok := value.(time.Time); ok {
 			return t.Format(time.RFC3339)
 		}
 		return fmt.Sprintf("%v", value)
+	case crud.MoneyFieldType, crud.EnumFieldType, crud.FileFieldType, crud.GeoPointFieldType:
+		// Amounts in minor units, enum values, upload IDs and points
+		return fmt.Sprintf("%v", value)
 	}
 
 	// Default: convert to string
@@ -142,7 +156,7 @@ func (c *tableCellImpl) handleSelectField(ctx context.Context, selectField crud.
 			fieldAttrs["data-placeholder"] = selectField.Placeholder()
 		}
 
-		if selectField.Readonly() {
+		if selectField.Readonly() || !crud.CanWriteField(ctx, selectField) {
 			fieldAttrs["disabled"] = true
 		}
 
@@ -163,7 +177,7 @@ func (c *tableCellImpl) handleSelectField(ctx context.Context, selectField crud.
 			Endpoint(selectField.Endpoint()).
 			Placeholder(selectField.Placeholder())
 
-		if selectField.Readonly() {
+		if selectField.Readonly() || !crud.CanWriteField(ctx, selectField) {
 			fieldAttrs["disabled"] = true
 		}
 
@@ -185,7 +199,7 @@ func (c *tableCellImpl) handleSelectField(ctx context.Context, selectField crud.
 			Placeholder(selectField.Placeholder()).
 			Multiple(selectField.Multiple())
 
-		if selectField.Readonly() {
+		if selectField.Readonly() || !crud.CanWriteField(ctx, selectField) {
 			fieldAttrs["disabled"] = true
 		}
 
@@ -211,402 +225,429 @@ func (c *tableCellImpl) Classes() templ.CSSClasses {
 func (c *tableCellImpl) Component(col TableColumn, editMode bool, withValue bool, fieldAttrs templ.Attributes) templ.Component {
 	field := col.EditableField()
 	if col.Editable() && field != nil && editMode {
-		if field.Hidden() {
+		// Inputs depend on the permissions of the user they are rendered for
+		return templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+			if component := c.editComponent(ctx, field, withValue, fieldAttrs); component != nil {
+				return component.Render(ctx, w)
+			}
 			return nil
+		})
+	}
+	return c.component
+}
+
+// editComponent returns the input editing field in edit mode, which is disabled
+// when the user in ctx may not change field and missing when they may not read it
+func (c *tableCellImpl) editComponent(ctx context.Context, field crud.Field, withValue bool, fieldAttrs templ.Attributes) templ.Component {
+	if field.Hidden() || !crud.CanReadField(ctx, field) {
+		return nil
+	}
+	if field.Key() && field.Readonly() {
+		return nil
+	}
+	readonly := field.Readonly() || !crud.CanWriteField(ctx, field)
+
+	var currentValue any
+	if withValue {
+		if c.value != nil && !(c.value == nil || reflect.ValueOf(c.value).IsZero()) {
+			currentValue = c.value
+		} else if field.InitialValue(ctx) != nil {
+			currentValue = field.InitialValue(ctx)
 		}
-		if field.Key() && field.Readonly() {
-			return nil
+	}
+	switch field.Type() {
+	case crud.StringFieldType:
+		// Check if this is actually a select field
+		if selectField, ok := field.(crud.SelectField); ok {
+			return c.handleSelectField(ctx, selectField, currentValue, fieldAttrs)
 		}
 
-		ctx := context.TODO()
-		var currentValue any
-		if withValue {
-			if c.value != nil && !(c.value == nil || reflect.ValueOf(c.value).IsZero()) {
-				currentValue = c.value
-			} else if field.InitialValue(ctx) != nil {
-				currentValue = field.InitialValue(ctx)
-			}
+		sf, err := field.AsStringField()
+		if err != nil {
+			return nil
 		}
-		switch field.Type() {
-		case crud.StringFieldType:
-			// Check if this is actually a select field
-			if selectField, ok := field.(crud.SelectField); ok {
-				return c.handleSelectField(ctx, selectField, currentValue, fieldAttrs)
-			}
 
-			sf, err := field.AsStringField()
-			if err != nil {
-				return nil
-			}
+		builder := form.Text(field.Name(), "")
 
-			builder := form.Text(field.Name(), "")
+		if sf.MaxLen() > 0 {
+			builder = builder.MaxLen(sf.MaxLen())
+		}
+		if sf.MinLen() > 0 {
+			builder = builder.MinLen(sf.MinLen())
+		}
 
+		if sf.Multiline() {
+			textareaBuilder := form.Textarea(field.Name(), "")
 			if sf.MaxLen() > 0 {
-				builder = builder.MaxLen(sf.MaxLen())
+				textareaBuilder = textareaBuilder.MaxLen(sf.MaxLen())
 			}
 			if sf.MinLen() > 0 {
-				builder = builder.MinLen(sf.MinLen())
-			}
-
-			if sf.Multiline() {
-				textareaBuilder := form.Textarea(field.Name(), "")
-				if sf.MaxLen() > 0 {
-					textareaBuilder = textareaBuilder.MaxLen(sf.MaxLen())
-				}
-				if sf.MinLen() > 0 {
-					textareaBuilder = textareaBuilder.MinLen(sf.MinLen())
-				}
-
-				if field.Readonly() {
-					textareaBuilder = textareaBuilder.Attrs(templ.Attributes{"disabled": true})
-				}
-
-				if len(field.Rules()) > 0 {
-					textareaBuilder = textareaBuilder.Required()
-				}
-
-				if currentValue != nil {
-					if strVal, ok := currentValue.(string); ok {
-						textareaBuilder = textareaBuilder.Default(strVal)
-					}
-				}
-
-				return textareaBuilder.Build().Component()
+				textareaBuilder = textareaBuilder.MinLen(sf.MinLen())
 			}
 
-			if field.Readonly() {
-				fieldAttrs["disabled"] = true
+			if readonly {
+				textareaBuilder = textareaBuilder.Attrs(templ.Attributes{"disabled": true})
 			}
 
 			if len(field.Rules()) > 0 {
-				builder = builder.Required()
+				textareaBuilder = textareaBuilder.Required()
 			}
 
 			if currentValue != nil {
 				if strVal, ok := currentValue.(string); ok {
-					builder = builder.Default(strVal)
+					textareaBuilder = textareaBuilder.Default(strVal)
 				}
 			}
 
-			return builder.Attrs(fieldAttrs).Build().Component()
+			return textareaBuilder.Build().Component()
+		}
 
-		case crud.IntFieldType:
-			// Check if this is actually a select field with int values
-			if selectField, ok := field.(crud.SelectField); ok {
-				return c.handleSelectField(ctx, selectField, currentValue, fieldAttrs)
-			}
+		if readonly {
+			fieldAttrs["disabled"] = true
+		}
+
+		if len(field.Rules()) > 0 {
+			builder = builder.Required()
+		}
 
-			intField, err := field.AsIntField()
-			if err != nil {
-				return nil
+		if currentValue != nil {
+			if strVal, ok := currentValue.(string); ok {
+				builder = builder.Default(strVal)
 			}
+		}
 
-			builder := form.NewNumberField(field.Name(), "")
+		return builder.Attrs(fieldAttrs).Build().Component()
 
-			if intField.Min() != 0 {
-				builder = builder.Min(float64(intField.Min()))
-			}
-			if intField.Max() != 0 {
-				builder = builder.Max(float64(intField.Max()))
-			}
+	case crud.IntFieldType:
+		// Check if this is actually a select field with int values
+		if selectField, ok := field.(crud.SelectField); ok {
+			return c.handleSelectField(ctx, selectField, currentValue, fieldAttrs)
+		}
 
-			if field.Readonly() {
-				fieldAttrs["disabled"] = true
-			}
+		intField, err := field.AsIntField()
+		if err != nil {
+			return nil
+		}
 
-			if len(field.Rules()) > 0 {
-				builder = builder.Required()
-			}
+		builder := form.NewNumberField(field.Name(), "")
 
-			if currentValue != nil {
-				switch v := currentValue.(type) {
-				case int:
-					builder = builder.Default(float64(v))
-				case int64:
-					builder = builder.Default(float64(v))
-				case float64:
-					builder = builder.Default(v)
-				}
-			}
+		if intField.Min() != 0 {
+			builder = builder.Min(float64(intField.Min()))
+		}
+		if intField.Max() != 0 {
+			builder = builder.Max(float64(intField.Max()))
+		}
 
-			return builder.Attrs(fieldAttrs).Build().Component()
+		if readonly {
+			fieldAttrs["disabled"] = true
+		}
 
-		case crud.BoolFieldType:
-			// Check if this is actually a select field with bool values
-			if selectField, ok := field.(crud.SelectField); ok {
-				return c.handleSelectField(ctx, selectField, currentValue, fieldAttrs)
+		if len(field.Rules()) > 0 {
+			builder = builder.Required()
+		}
+
+		if currentValue != nil {
+			switch v := currentValue.(type) {
+			case int:
+				builder = builder.Default(float64(v))
+			case int64:
+				builder = builder.Default(float64(v))
+			case float64:
+				builder = builder.Default(v)
 			}
+		}
 
-			builder := form.Checkbox(field.Name(), "")
+		return builder.Attrs(fieldAttrs).Build().Component()
 
-			if field.Readonly() {
-				fieldAttrs["disabled"] = true
-			}
+	case crud.BoolFieldType:
+		// Check if this is actually a select field with bool values
+		if selectField, ok := field.(crud.SelectField); ok {
+			return c.handleSelectField(ctx, selectField, currentValue, fieldAttrs)
+		}
 
-			if len(field.Rules()) > 0 {
-				builder = builder.Required()
-			}
+		builder := form.Checkbox(field.Name(), "")
 
-			if currentValue != nil {
-				if boolVal, ok := currentValue.(bool); ok {
-					builder = builder.Default(boolVal)
-				}
-			}
+		if readonly {
+			fieldAttrs["disabled"] = true
+		}
 
-			return builder.Attrs(fieldAttrs).Build().Component()
+		if len(field.Rules()) > 0 {
+			builder = builder.Required()
+		}
 
-		case crud.FloatFieldType:
-			floatField, err := field.AsFloatField()
-			if err != nil {
-				return nil
+		if currentValue != nil {
+			if boolVal, ok := currentValue.(bool); ok {
+				builder = builder.Default(boolVal)
 			}
+		}
 
-			builder := form.NewNumberField(field.Name(), "")
+		return builder.Attrs(fieldAttrs).Build().Component()
 
-			if floatField.Min() != 0 {
-				builder = builder.Min(floatField.Min())
-			}
-			if floatField.Max() != 0 {
-				builder = builder.Max(floatField.Max())
-			}
+	case crud.FloatFieldType:
+		floatField, err := field.AsFloatField()
+		if err != nil {
+			return nil
+		}
 
-			attrs := templ.Attributes{}
-			if floatField.Step() != 0 {
-				attrs["step"] = fmt.Sprintf("%f", floatField.Step())
-			} else {
-				attrs["step"] = "any"
-			}
+		builder := form.NewNumberField(field.Name(), "")
 
-			if field.Readonly() {
-				attrs["disabled"] = true
-			}
+		if floatField.Min() != 0 {
+			builder = builder.Min(floatField.Min())
+		}
+		if floatField.Max() != 0 {
+			builder = builder.Max(floatField.Max())
+		}
 
-			maps.Copy(attrs, fieldAttrs)
+		attrs := templ.Attributes{}
+		if floatField.Step() != 0 {
+			attrs["step"] = fmt.Sprintf("%f", floatField.Step())
+		} else {
+			attrs["step"] = "any"
+		}
 
-			if len(field.Rules()) > 0 {
-				builder = builder.Required()
-			}
+		if readonly {
+			attrs["disabled"] = true
+		}
 
-			if currentValue != nil {
-				if floatVal, ok := currentValue.(float64); ok {
-					builder = builder.Default(floatVal)
-				}
+		maps.Copy(attrs, fieldAttrs)
+
+		if len(field.Rules()) > 0 {
+			builder = builder.Required()
+		}
+
+		if currentValue != nil {
+			if floatVal, ok := currentValue.(float64); ok {
+				builder = builder.Default(floatVal)
 			}
+		}
 
-			return builder.Attrs(attrs).Build().Component()
+		return builder.Attrs(attrs).Build().Component()
 
-		case crud.DateFieldType:
-			builder := form.Date(field.Name(), "")
+	case crud.DateFieldType:
+		builder := form.Date(field.Name(), "")
 
-			dateField, err := field.AsDateField()
-			if err == nil {
-				if !dateField.MinDate().IsZero() {
-					builder = builder.Min(dateField.MinDate())
-				}
-				if !dateField.MaxDate().IsZero() {
-					builder = builder.Max(dateField.MaxDate())
-				}
+		dateField, err := field.AsDateField()
+		if err == nil {
+			if !dateField.MinDate().IsZero() {
+				builder = builder.Min(dateField.MinDate())
 			}
-
-			if field.Readonly() {
-				fieldAttrs["disabled"] = true
+			if !dateField.MaxDate().IsZero() {
+				builder = builder.Max(dateField.MaxDate())
 			}
+		}
 
-			if len(field.Rules()) > 0 {
-				builder = builder.Required()
-			}
+		if readonly {
+			fieldAttrs["disabled"] = true
+		}
 
-			if currentValue != nil {
-				if timeVal, ok := currentValue.(time.Time); ok && !timeVal.IsZero() {
-					builder = builder.Default(timeVal)
-				}
+		if len(field.Rules()) > 0 {
+			builder = builder.Required()
+		}
+
+		if currentValue != nil {
+			if timeVal, ok := currentValue.(time.Time); ok && !timeVal.IsZero() {
+				builder = builder.Default(timeVal)
 			}
+		}
 
-			return builder.Attrs(fieldAttrs).Build().Component()
+		return builder.Attrs(fieldAttrs).Build().Component()
 
-		case crud.TimeFieldType:
-			builder := form.Time(field.Name(), "")
+	case crud.TimeFieldType:
+		builder := form.Time(field.Name(), "")
 
-			if field.Readonly() {
-				fieldAttrs["disabled"] = true
-			}
+		if readonly {
+			fieldAttrs["disabled"] = true
+		}
 
-			if len(field.Rules()) > 0 {
-				builder = builder.Required()
-			}
+		if len(field.Rules()) > 0 {
+			builder = builder.Required()
+		}
 
-			if currentValue != nil {
-				if timeVal, ok := currentValue.(time.Time); ok && !timeVal.IsZero() {
-					builder = builder.Default(timeVal.Format("15:04"))
-				}
+		if currentValue != nil {
+			if timeVal, ok := currentValue.(time.Time); ok && !timeVal.IsZero() {
+				builder = builder.Default(timeVal.Format("15:04"))
 			}
-			return builder.Attrs(fieldAttrs).Build().Component()
+		}
+		return builder.Attrs(fieldAttrs).Build().Component()
 
-		case crud.DateTimeFieldType:
-			builder := form.DateTime(field.Name(), "")
+	case crud.DateTimeFieldType:
+		builder := form.DateTime(field.Name(), "")
 
-			dateTimeField, err := field.AsDateTimeField()
-			if err == nil {
-				if !dateTimeField.MinDateTime().IsZero() {
-					builder = builder.Min(dateTimeField.MinDateTime())
-				}
-				if !dateTimeField.MaxDateTime().IsZero() {
-					builder = builder.Max(dateTimeField.MaxDateTime())
-				}
+		dateTimeField, err := field.AsDateTimeField()
+		if err == nil {
+			if !dateTimeField.MinDateTime().IsZero() {
+				builder = builder.Min(dateTimeField.MinDateTime())
 			}
-
-			if field.Readonly() {
-				fieldAttrs["disabled"] = true
+			if !dateTimeField.MaxDateTime().IsZero() {
+				builder = builder.Max(dateTimeField.MaxDateTime())
 			}
+		}
 
-			if len(field.Rules()) > 0 {
-				builder = builder.Required()
-			}
+		if readonly {
+			fieldAttrs["disabled"] = true
+		}
 
-			if currentValue != nil {
-				if timeVal, ok := currentValue.(time.Time); ok && !timeVal.IsZero() {
-					builder = builder.Default(timeVal)
-				}
+		if len(field.Rules()) > 0 {
+			builder = builder.Required()
+		}
+
+		if currentValue != nil {
+			if timeVal, ok := currentValue.(time.Time); ok && !timeVal.IsZero() {
+				builder = builder.Default(timeVal)
 			}
+		}
 
-			return builder.Attrs(fieldAttrs).Build().Component()
+		return builder.Attrs(fieldAttrs).Build().Component()
 
-		case crud.UUIDFieldType:
-			// Check if this is actually a select field
-			if selectField, ok := field.(crud.SelectField); ok {
-				return c.handleSelectField(ctx, selectField, currentValue, fieldAttrs)
-			}
+	case crud.UUIDFieldType:
+		// Check if this is actually a select field
+		if selectField, ok := field.(crud.SelectField); ok {
+			return c.handleSelectField(ctx, selectField, currentValue, fieldAttrs)
+		}
 
-			builder := form.Text(field.Name(), "")
+		builder := form.Text(field.Name(), "")
 
-			if field.Readonly() {
-				fieldAttrs["disabled"] = true
-			}
+		if readonly {
+			fieldAttrs["disabled"] = true
+		}
 
-			if len(field.Rules()) > 0 {
-				builder = builder.Required()
-			}
+		if len(field.Rules()) > 0 {
+			builder = builder.Required()
+		}
 
-			if currentValue != nil {
-				switch v := currentValue.(type) {
-				case string:
-					builder = builder.Default(v)
-				case uuid.UUID:
-					builder = builder.Default(v.String())
-				}
+		if currentValue != nil {
+			switch v := currentValue.(type) {
+			case string:
+				builder = builder.Default(v)
+			case uuid.UUID:
+				builder = builder.Default(v.String())
 			}
+		}
 
-			return builder.Attrs(fieldAttrs).Build().Component()
+		return builder.Attrs(fieldAttrs).Build().Component()
 
-		case crud.TimestampFieldType:
-			// Timestamp fields are treated like datetime fields
-			builder := form.DateTime(field.Name(), "")
+	case crud.TimestampFieldType:
+		// Timestamp fields are treated like datetime fields
+		builder := form.DateTime(field.Name(), "")
 
-			if field.Readonly() {
-				fieldAttrs["disabled"] = true
-			}
+		if readonly {
+			fieldAttrs["disabled"] = true
+		}
 
-			if len(field.Rules()) > 0 {
-				builder = builder.Required()
-			}
+		if len(field.Rules()) > 0 {
+			builder = builder.Required()
+		}
 
-			if currentValue != nil {
-				switch v := currentValue.(type) {
-				case time.Time:
-					builder = builder.Default(v)
-				}
+		if currentValue != nil {
+			switch v := currentValue.(type) {
+			case time.Time:
+				builder = builder.Default(v)
 			}
+		}
 
-			return builder.Attrs(fieldAttrs).Build().Component()
+		return builder.Attrs(fieldAttrs).Build().Component()
 
-		case crud.DecimalFieldType:
-			decimalField, err := field.AsDecimalField()
-			if err != nil {
-				return nil
-			}
+	case crud.DecimalFieldType:
+		decimalField, err := field.AsDecimalField()
+		if err != nil {
+			return nil
+		}
 
-			builder := form.NewNumberField(field.Name(), "")
+		builder := form.NewNumberField(field.Name(), "")
 
-			if decimalField.Min() != "" {
-				if minVal, err := strconv.ParseFloat(decimalField.Min(), 64); err == nil {
-					builder = builder.Min(minVal)
-				}
+		if decimalField.Min() != "" {
+			if minVal, err := strconv.ParseFloat(decimalField.Min(), 64); err == nil {
+				builder = builder.Min(minVal)
 			}
-			if decimalField.Max() != "" {
-				if maxVal, err := strconv.ParseFloat(decimalField.Max(), 64); err == nil {
-					builder = builder.Max(maxVal)
-				}
+		}
+		if decimalField.Max() != "" {
+			if maxVal, err := strconv.ParseFloat(decimalField.Max(), 64); err == nil {
+				builder = builder.Max(maxVal)
 			}
+		}
 
-			attrs := templ.Attributes{}
-			if decimalField.Scale() > 0 {
-				step := 1.0
-				for range decimalField.Scale() {
-					step /= 10
-				}
-				attrs["step"] = fmt.Sprintf("%f", step)
-			} else {
-				attrs["step"] = "any"
+		attrs := templ.Attributes{}
+		if decimalField.Scale() > 0 {
+			step := 1.0
+			for range decimalField.Scale() {
+				step /= 10
 			}
+			attrs["step"] = fmt.Sprintf("%f", step)
+		} else {
+			attrs["step"] = "any"
+		}
 
-			if field.Readonly() {
-				attrs["disabled"] = true
-			}
+		if readonly {
+			attrs["disabled"] = true
+		}
 
-			maps.Copy(attrs, fieldAttrs)
+		maps.Copy(attrs, fieldAttrs)
 
-			if len(field.Rules()) > 0 {
-				builder = builder.Required()
-			} else if currentValue != nil {
-				// Handle direct decimal values (fallback for when value is nil)
-				if strVal, ok := currentValue.(string); ok {
-					if floatVal, err := strconv.ParseFloat(strVal, 64); err == nil {
-						builder = builder.Default(floatVal)
-					}
+		if len(field.Rules()) > 0 {
+			builder = builder.Required()
+		} else if currentValue != nil {
+			// Handle direct decimal values (fallback for when value is nil)
+			if strVal, ok := currentValue.(string); ok {
+				if floatVal, err := strconv.ParseFloat(strVal, 64); err == nil {
+					builder = builder.Default(floatVal)
 				}
 			}
+		}
 
-			return builder.Attrs(attrs).Build().Component()
+		return builder.Attrs(attrs).Build().Component()
 
-		case crud.JSONFieldType:
-			// Handle JSON field as a textarea for editing
-			builder := form.Textarea(field.Name(), "")
+	case crud.JSONFieldType:
+		// Handle JSON field as a textarea for editing
+		builder := form.Textarea(field.Name(), "")
 
-			if field.Readonly() {
-				fieldAttrs["disabled"] = true
-			}
+		if readonly {
+			fieldAttrs["disabled"] = true
+		}
 
-			if len(field.Rules()) > 0 {
-				builder = builder.Required()
-			}
+		if len(field.Rules()) > 0 {
+			builder = builder.Required()
+		}
 
-			// Convert JSON value to formatted string for editing
-			if currentValue != nil {
-				var jsonStr string
-				if str, ok := currentValue.(string); ok {
-					jsonStr = str
+		// Convert JSON value to formatted string for editing
+		if currentValue != nil {
+			var jsonStr string
+			if str, ok := currentValue.(string); ok {
+				jsonStr = str
+			} else {
+				// Pretty print JSON for better editing experience
+				if jsonBytes, err := json.MarshalIndent(currentValue, "", "  "); err == nil {
+					jsonStr = string(jsonBytes)
 				} else {
-					// Pretty print JSON for better editing experience
-					if jsonBytes, err := json.MarshalIndent(currentValue, "", "  "); err == nil {
-						jsonStr = string(jsonBytes)
-					} else {
-						jsonStr = fmt.Sprintf("%v", currentValue)
-					}
+					jsonStr = fmt.Sprintf("%v", currentValue)
 				}
-				builder = builder.Default(jsonStr)
 			}
+			builder = builder.Default(jsonStr)
+		}
 
-			return builder.Attrs(fieldAttrs).Build().Component()
+		return builder.Attrs(fieldAttrs).Build().Component()
 
-		default:
-			builder := form.Text(field.Name(), field.Name())
-			if currentValue != nil {
-				builder = builder.Default(fmt.Sprintf("%v", currentValue))
-			}
-			return builder.Build().Component()
+	case crud.RelationFieldType:
+		// Has-many and many-to-many relations aren't edited inline
+		return templ.Raw(c.convertValueToString(currentValue, crud.RelationFieldType))
+
+	case crud.MoneyFieldType, crud.GeoPointFieldType, crud.EnumFieldType, crud.FileFieldType:
+		// Edited as the text crud.ParseAPIValue takes, e.g. money in major units
+		builder := form.Text(field.Name(), "")
+		if readonly {
+			fieldAttrs["disabled"] = true
+		}
+		if currentValue != nil {
+			builder = builder.Default(crud.FormValue(field.Value(currentValue)))
 		}
+		return builder.Attrs(fieldAttrs).Build().Component()
+
+	default:
+		builder := form.Text(field.Name(), field.Name())
+		if currentValue != nil {
+			builder = builder.Default(fmt.Sprintf("%v", currentValue))
+		}
+		return builder.Build().Component()
 	}
-	return c.component
 }
 
 type TableRow interface {
@@ -756,6 +797,14 @@ func WithInfiniteScroll(hasMore bool, page, perPage int) TableConfigOpt {
 	}
 }
 
+// WithNextCursor makes infinite scroll request the next chunk by keyset cursor
+// instead of by page offset.
+func WithNextCursor(cursor string) TableConfigOpt {
+	return func(c *TableConfig) {
+		c.Infinite.Cursor = cursor
+	}
+}
+
 func WithSearchPlaceholder(placeholder string) TableConfigOpt {
 	return func(c *TableConfig) {
 		c.SearchPlaceholder = placeholder
@@ -766,6 +815,9 @@ type InfiniteScrollConfig struct {
 	HasMore bool
 	Page    int
 	PerPage int
+	// Cursor is the keyset cursor of the next chunk, see repo.SortBy.Cursor.
+	// When empty the next chunk is requested by page.
+	Cursor string
 }
 
 type TableEditableConfig struct {
@@ -784,6 +836,7 @@ type TableConfig struct {
 	Actions           []templ.Component // Actions like Create button
 	Columns           []TableColumn
 	Rows              []TableRow
+	Totals            []TableRow // Aggregates shown above the rows, not repeated in infinite scroll chunks
 	Infinite          *InfiniteScrollConfig
 	SideFilter        templ.Component
 	Editable          TableEditableConfig
@@ -895,6 +948,11 @@ func (c *TableConfig) AddRows(rows ...TableRow) *TableConfig {
 	return c
 }
 
+func (c *TableConfig) AddTotals(rows ...TableRow) *TableConfig {
+	c.Totals = append(c.Totals, rows...)
+	return c
+}
+
 func (c *TableConfig) SetSideFilter(filter templ.Component) *TableConfig {
 	c.SideFilter = filter
 	return c
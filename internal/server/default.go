@@ -6,6 +6,7 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/core/presentation/controllers"
 	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/layouts"
 	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/configuration"
 	"github.com/iota-uz/iota-sdk/pkg/constants"
 	"github.com/iota-uz/iota-sdk/pkg/middleware"
@@ -20,6 +21,8 @@ type DefaultOptions struct {
 	Configuration *configuration.Configuration
 	Application   application.Application
 	Pool          *pgxpool.Pool
+	// Replicas receive read-only queries when set, see composables.WithReadOnly
+	Replicas *composables.ReadReplicas
 }
 
 func Default(options *DefaultOptions) (*server.HTTPServer, error) {
@@ -35,10 +38,16 @@ func Default(options *DefaultOptions) (*server.HTTPServer, error) {
 		middleware.Provide(constants.HeadKey, layouts.DefaultHead()),
 		middleware.Provide(constants.LogoKey, assets.DefaultLogo()),
 		middleware.Provide(constants.PoolKey, options.Pool),
-
+	}
+	if options.Replicas != nil {
+		middlewares = append(middlewares,
+			middleware.ReadReplicas(options.Replicas, options.Configuration.Database.ReplicaStickiness),
+		)
+	}
+	middlewares = append(middlewares,
 		middleware.TracedMiddleware("cors"),
 		middleware.Cors("http://localhost:3000", "ws://localhost:3000"),
-	}
+	)
 
 	// Add rate limiting middleware if enabled
 	if options.Configuration.RateLimit.Enabled {
@@ -0,0 +1,22 @@
+-- Migration: Create eventbus outbox table
+-- Date: 2025-11-17
+-- Purpose: Persist domain events inside the producing transaction so they survive crashes and rollbacks
+
+-- +migrate Up
+CREATE TABLE eventbus_outbox (
+    id bigserial PRIMARY KEY,
+    tenant_id uuid REFERENCES tenants (id) ON DELETE CASCADE,
+    event_type varchar(255) NOT NULL,
+    dedup_key varchar(255) NOT NULL UNIQUE,
+    payload jsonb NOT NULL,
+    attempts int NOT NULL DEFAULT 0,
+    last_error text,
+    next_attempt_at timestamp with time zone NOT NULL DEFAULT now(),
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    dispatched_at timestamp with time zone
+);
+
+CREATE INDEX eventbus_outbox_pending_idx ON eventbus_outbox (id) WHERE dispatched_at IS NULL;
+
+-- +migrate Down
+DROP TABLE IF EXISTS eventbus_outbox;
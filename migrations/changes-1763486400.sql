@@ -0,0 +1,20 @@
+-- Migration: Create eventbus dead letters table
+-- Date: 2025-11-18
+-- Purpose: Record asynchronous event deliveries that exhausted their retries so they can be inspected and replayed
+
+-- +migrate Up
+CREATE TABLE eventbus_dead_letters (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    subscriber varchar(512) NOT NULL,
+    args jsonb NOT NULL,
+    error text NOT NULL,
+    attempts int NOT NULL DEFAULT 0,
+    status varchar(32) NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX eventbus_dead_letters_status_idx ON eventbus_dead_letters (status);
+
+-- +migrate Down
+DROP TABLE IF EXISTS eventbus_dead_letters;
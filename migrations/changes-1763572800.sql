@@ -0,0 +1,31 @@
+-- Migration: Create eventbus event store tables
+-- Date: 2025-11-19
+-- Purpose: Keep an append-only history of aggregate events and the checkpoints of projections built from it
+
+-- +migrate Up
+CREATE TABLE eventbus_events (
+    position bigserial PRIMARY KEY,
+    tenant_id uuid REFERENCES tenants (id) ON DELETE CASCADE,
+    aggregate_type varchar(255) NOT NULL,
+    aggregate_id varchar(255) NOT NULL,
+    version int NOT NULL,
+    event_type varchar(255) NOT NULL,
+    payload jsonb NOT NULL,
+    occurred_at timestamp with time zone NOT NULL DEFAULT now(),
+    UNIQUE (aggregate_type, aggregate_id, version)
+);
+
+CREATE INDEX eventbus_events_tenant_id_idx ON eventbus_events (tenant_id);
+
+CREATE INDEX eventbus_events_occurred_at_idx ON eventbus_events (occurred_at);
+
+CREATE TABLE eventbus_projection_checkpoints (
+    name varchar(255) PRIMARY KEY,
+    position bigint NOT NULL DEFAULT 0,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS eventbus_projection_checkpoints;
+
+DROP TABLE IF EXISTS eventbus_events;
@@ -0,0 +1,35 @@
+-- Migration: Create jobs table
+-- Date: 2025-11-20
+-- Purpose: Store background jobs claimed by workers with FOR UPDATE SKIP LOCKED
+
+-- +migrate Up
+CREATE TABLE jobs (
+    id bigserial PRIMARY KEY,
+    tenant_id uuid REFERENCES tenants (id) ON DELETE CASCADE,
+    queue varchar(255) NOT NULL DEFAULT 'default',
+    kind varchar(255) NOT NULL,
+    payload jsonb NOT NULL,
+    status varchar(16) NOT NULL DEFAULT 'pending',
+    priority int NOT NULL DEFAULT 0,
+    attempts int NOT NULL DEFAULT 0,
+    max_attempts int NOT NULL DEFAULT 5,
+    unique_key varchar(255),
+    last_error text,
+    run_at timestamp with time zone NOT NULL DEFAULT now(),
+    locked_at timestamp with time zone,
+    locked_by varchar(255),
+    finished_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX jobs_unique_key_idx ON jobs (kind, unique_key) WHERE status IN ('pending', 'running');
+
+CREATE INDEX jobs_ready_idx ON jobs (queue, priority DESC, run_at) WHERE status = 'pending';
+
+CREATE INDEX jobs_status_idx ON jobs (status);
+
+CREATE INDEX jobs_tenant_id_idx ON jobs (tenant_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS jobs;
@@ -0,0 +1,21 @@
+-- Migration: Create scheduler_runs table
+-- Date: 2025-11-21
+-- Purpose: Record scheduled task runs so each activation runs once per cluster and missed ones can be caught up
+
+-- +migrate Up
+CREATE TABLE scheduler_runs (
+    id bigserial PRIMARY KEY,
+    task varchar(255) NOT NULL,
+    scheduled_at timestamp with time zone NOT NULL,
+    status varchar(16) NOT NULL DEFAULT 'running',
+    error text,
+    node varchar(255) NOT NULL,
+    started_at timestamp with time zone NOT NULL DEFAULT now(),
+    finished_at timestamp with time zone,
+    UNIQUE (task, scheduled_at)
+);
+
+CREATE INDEX scheduler_runs_started_at_idx ON scheduler_runs (started_at);
+
+-- +migrate Down
+DROP TABLE IF EXISTS scheduler_runs;
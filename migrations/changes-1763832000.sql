@@ -0,0 +1,66 @@
+-- Migration: Record scheduler runs per tenant and keep a catalogue of scheduled tasks
+-- Date: 2025-11-22
+-- Purpose: Let superadmins inspect, retry and trigger scheduled task runs for each tenant
+
+-- +migrate Up
+CREATE TABLE scheduler_tasks (
+    name varchar(255) PRIMARY KEY,
+    spec varchar(255) NOT NULL,
+    per_tenant boolean NOT NULL DEFAULT false,
+    catch_up boolean NOT NULL DEFAULT true,
+    next_run_at timestamp with time zone,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+ALTER TABLE scheduler_runs
+    ADD COLUMN tenant_id uuid REFERENCES tenants (id) ON DELETE CASCADE,
+    ADD COLUMN triggered_by varchar(16) NOT NULL DEFAULT 'schedule',
+    ADD COLUMN stack_trace text,
+    ADD COLUMN created_at timestamp with time zone NOT NULL DEFAULT now(),
+    ALTER COLUMN node DROP NOT NULL,
+    ALTER COLUMN started_at DROP NOT NULL,
+    ALTER COLUMN started_at DROP DEFAULT,
+    DROP CONSTRAINT scheduler_runs_task_scheduled_at_key,
+    ADD CONSTRAINT scheduler_runs_task_scheduled_at_tenant_id_key UNIQUE NULLS NOT DISTINCT (task, scheduled_at, tenant_id);
+
+UPDATE scheduler_runs SET created_at = started_at;
+
+DROP INDEX IF EXISTS scheduler_runs_started_at_idx;
+
+CREATE INDEX scheduler_runs_created_at_idx ON scheduler_runs (created_at);
+
+CREATE INDEX scheduler_runs_tenant_id_idx ON scheduler_runs (tenant_id);
+
+CREATE INDEX scheduler_runs_status_idx ON scheduler_runs (status);
+
+-- +migrate Down
+DROP INDEX IF EXISTS scheduler_runs_status_idx;
+
+DROP INDEX IF EXISTS scheduler_runs_tenant_id_idx;
+
+DROP INDEX IF EXISTS scheduler_runs_created_at_idx;
+
+DELETE FROM scheduler_runs
+WHERE status = 'pending'
+    OR id NOT IN (
+        SELECT MIN(id) FROM scheduler_runs GROUP BY task, scheduled_at
+    );
+
+UPDATE scheduler_runs SET started_at = created_at WHERE started_at IS NULL;
+
+UPDATE scheduler_runs SET node = '' WHERE node IS NULL;
+
+ALTER TABLE scheduler_runs
+    DROP CONSTRAINT scheduler_runs_task_scheduled_at_tenant_id_key,
+    ADD CONSTRAINT scheduler_runs_task_scheduled_at_key UNIQUE (task, scheduled_at),
+    ALTER COLUMN started_at SET DEFAULT now(),
+    ALTER COLUMN started_at SET NOT NULL,
+    ALTER COLUMN node SET NOT NULL,
+    DROP COLUMN created_at,
+    DROP COLUMN stack_trace,
+    DROP COLUMN triggered_by,
+    DROP COLUMN tenant_id;
+
+CREATE INDEX scheduler_runs_started_at_idx ON scheduler_runs (started_at);
+
+DROP TABLE IF EXISTS scheduler_tasks;
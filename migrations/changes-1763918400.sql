@@ -0,0 +1,9 @@
+-- Migration: Enable trigram similarity search
+-- Date: 2025-11-23
+-- Purpose: Support repo.Similar/repo.WordSimilar filters and trigram search on crud fields
+
+-- +migrate Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- +migrate Down
+DROP EXTENSION IF EXISTS pg_trgm;
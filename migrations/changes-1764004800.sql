@@ -0,0 +1,64 @@
+-- Migration: Row-level security for tenant-owned tables
+-- Date: 2025-11-24
+-- Purpose: Filter tenant-owned rows by the app.tenant_id setting of the connection.
+--          Connections that don't set app.tenant_id (DB_TENANT_RLS=false, migrations,
+--          background jobs) are unrestricted. Superusers and BYPASSRLS roles always are.
+
+-- +migrate Up
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION enable_tenant_isolation(tbl regclass) RETURNS void AS $$
+BEGIN
+    EXECUTE format('ALTER TABLE %s ENABLE ROW LEVEL SECURITY', tbl);
+    EXECUTE format('ALTER TABLE %s FORCE ROW LEVEL SECURITY', tbl);
+    EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %s', tbl);
+    EXECUTE format(
+        'CREATE POLICY tenant_isolation ON %s
+            USING (COALESCE(current_setting(''app.tenant_id'', true), '''') = ''''
+                OR tenant_id = current_setting(''app.tenant_id'', true)::uuid)
+            WITH CHECK (COALESCE(current_setting(''app.tenant_id'', true), '''') = ''''
+                OR tenant_id = current_setting(''app.tenant_id'', true)::uuid)',
+        tbl
+    );
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+-- Infrastructure tables are read across tenants by workers and session middleware
+-- +migrate StatementBegin
+DO $$
+DECLARE
+    t text;
+BEGIN
+    FOR t IN
+        SELECT c.table_name
+        FROM information_schema.columns c
+        JOIN information_schema.tables tb ON tb.table_schema = c.table_schema AND tb.table_name = c.table_name
+        WHERE c.table_schema = 'public'
+          AND c.column_name = 'tenant_id'
+          AND tb.table_type = 'BASE TABLE'
+          AND c.table_name NOT IN ('sessions', 'eventbus_outbox', 'eventbus_events', 'jobs', 'scheduler_runs')
+    LOOP
+        PERFORM enable_tenant_isolation(t::regclass);
+    END LOOP;
+END;
+$$;
+-- +migrate StatementEnd
+
+-- +migrate Down
+-- +migrate StatementBegin
+DO $$
+DECLARE
+    t record;
+BEGIN
+    FOR t IN
+        SELECT schemaname, tablename FROM pg_policies WHERE policyname = 'tenant_isolation'
+    LOOP
+        EXECUTE format('DROP POLICY tenant_isolation ON %I.%I', t.schemaname, t.tablename);
+        EXECUTE format('ALTER TABLE %I.%I NO FORCE ROW LEVEL SECURITY', t.schemaname, t.tablename);
+        EXECUTE format('ALTER TABLE %I.%I DISABLE ROW LEVEL SECURITY', t.schemaname, t.tablename);
+    END LOOP;
+END;
+$$;
+-- +migrate StatementEnd
+
+DROP FUNCTION IF EXISTS enable_tenant_isolation(regclass);
@@ -0,0 +1,16 @@
+-- Migration: Optimistic concurrency versions
+-- Date: 2025-11-25
+-- Purpose: Let updates of money accounts, payments, employees and warehouse positions
+--          detect that someone else saved the row since it was read
+
+-- +migrate Up
+ALTER TABLE money_accounts ADD COLUMN IF NOT EXISTS version int NOT NULL DEFAULT 1;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS version int NOT NULL DEFAULT 1;
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS version int NOT NULL DEFAULT 1;
+ALTER TABLE warehouse_positions ADD COLUMN IF NOT EXISTS version int NOT NULL DEFAULT 1;
+
+-- +migrate Down
+ALTER TABLE warehouse_positions DROP COLUMN IF EXISTS version;
+ALTER TABLE employees DROP COLUMN IF EXISTS version;
+ALTER TABLE payments DROP COLUMN IF EXISTS version;
+ALTER TABLE money_accounts DROP COLUMN IF EXISTS version;
@@ -0,0 +1,45 @@
+-- Migration: Soft delete
+-- Date: 2025-11-26
+-- Purpose: Move deleted counterparties, clients, products and users to a trash they can be
+--          restored from until they are purged after the retention period
+
+-- +migrate Up
+ALTER TABLE counterparty ADD COLUMN IF NOT EXISTS deleted_at timestamp with time zone;
+ALTER TABLE clients ADD COLUMN IF NOT EXISTS deleted_at timestamp with time zone;
+ALTER TABLE warehouse_products ADD COLUMN IF NOT EXISTS deleted_at timestamp with time zone;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at timestamp with time zone;
+
+-- Entities in the trash must not block creating new ones with the same TIN, RFID, email or phone
+ALTER TABLE counterparty DROP CONSTRAINT IF EXISTS counterparty_tenant_id_tin_key;
+CREATE UNIQUE INDEX IF NOT EXISTS counterparty_tenant_id_tin_key ON counterparty (tenant_id, tin) WHERE deleted_at IS NULL;
+ALTER TABLE warehouse_products DROP CONSTRAINT IF EXISTS warehouse_products_tenant_id_rfid_key;
+CREATE UNIQUE INDEX IF NOT EXISTS warehouse_products_tenant_id_rfid_key ON warehouse_products (tenant_id, rfid) WHERE deleted_at IS NULL;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_tenant_id_email_key;
+CREATE UNIQUE INDEX IF NOT EXISTS users_tenant_id_email_key ON users (tenant_id, email) WHERE deleted_at IS NULL;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_tenant_id_phone_key;
+CREATE UNIQUE INDEX IF NOT EXISTS users_tenant_id_phone_key ON users (tenant_id, phone) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS counterparty_trash_idx ON counterparty (tenant_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS clients_trash_idx ON clients (tenant_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS warehouse_products_trash_idx ON warehouse_products (tenant_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS users_trash_idx ON users (tenant_id, deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS users_trash_idx;
+DROP INDEX IF EXISTS warehouse_products_trash_idx;
+DROP INDEX IF EXISTS clients_trash_idx;
+DROP INDEX IF EXISTS counterparty_trash_idx;
+
+DROP INDEX IF EXISTS users_tenant_id_phone_key;
+ALTER TABLE users ADD CONSTRAINT users_tenant_id_phone_key UNIQUE (tenant_id, phone);
+DROP INDEX IF EXISTS users_tenant_id_email_key;
+ALTER TABLE users ADD CONSTRAINT users_tenant_id_email_key UNIQUE (tenant_id, email);
+DROP INDEX IF EXISTS warehouse_products_tenant_id_rfid_key;
+ALTER TABLE warehouse_products ADD CONSTRAINT warehouse_products_tenant_id_rfid_key UNIQUE (tenant_id, rfid);
+DROP INDEX IF EXISTS counterparty_tenant_id_tin_key;
+ALTER TABLE counterparty ADD CONSTRAINT counterparty_tenant_id_tin_key UNIQUE (tenant_id, tin);
+
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE warehouse_products DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE clients DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE counterparty DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,24 @@
+-- Migration: Crud history
+-- Date: 2025-11-27
+-- Purpose: Record field-level changes of entities of crud schemas created WithHistory,
+--          with who made them and when, so they can be shown and reverted
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS crud_history (
+    id bigserial PRIMARY KEY,
+    tenant_id uuid REFERENCES tenants (id) ON DELETE CASCADE,
+    schema_name varchar(255) NOT NULL,
+    entity_key varchar(255) NOT NULL,
+    action varchar(16) NOT NULL,
+    changes jsonb NOT NULL DEFAULT '[]',
+    user_id int REFERENCES users (id) ON DELETE SET NULL,
+    user_name varchar(511) NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS crud_history_entity_idx ON crud_history (schema_name, entity_key, id);
+
+SELECT enable_tenant_isolation('crud_history');
+
+-- +migrate Down
+DROP TABLE IF EXISTS crud_history;
@@ -23,3 +23,6 @@ CREATE INDEX dialogues_tenant_id_idx ON dialogues (tenant_id);
 
 CREATE INDEX prompts_tenant_id_idx ON prompts (tenant_id);
 
+-- Row-level security: rows are only visible to the tenant in app.tenant_id
+SELECT enable_tenant_isolation('prompts');
+SELECT enable_tenant_isolation('dialogues');
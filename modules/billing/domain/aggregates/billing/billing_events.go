@@ -7,6 +7,9 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/billing/domain/aggregates/details"
 )
 
+// AggregateType identifies transactions in the event store.
+const AggregateType = "billing.transaction"
+
 func NewCreatedEvent(_ context.Context, result Transaction) (*CreatedEvent, error) {
 	return &CreatedEvent{
 		Result: result,
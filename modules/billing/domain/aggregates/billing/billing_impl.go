@@ -63,6 +63,13 @@ type amount struct {
 	currency Currency
 }
 
+func NewAmount(quantity float64, currency Currency) Amount {
+	return &amount{
+		quantity: quantity,
+		currency: currency,
+	}
+}
+
 func (m *amount) Quantity() float64 {
 	return m.quantity
 }
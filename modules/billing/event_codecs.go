@@ -0,0 +1,197 @@
+package billing
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/modules/billing/domain/aggregates/billing"
+	"github.com/iota-uz/iota-sdk/modules/billing/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/billing/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+// Transaction events hold interfaces (Transaction, Amount, Details), so they are
+// stored as snapshots built with the persistence mappers.
+
+type transactionEventPayload struct {
+	Data   *models.Transaction `json:"data,omitempty"`
+	Result *models.Transaction `json:"result,omitempty"`
+}
+
+type amountPayload struct {
+	Quantity float64          `json:"quantity"`
+	Currency billing.Currency `json:"currency"`
+}
+
+type amountChangedPayload struct {
+	TransactionID uuid.UUID      `json:"transaction_id"`
+	Data          *amountPayload `json:"data,omitempty"`
+	Result        *amountPayload `json:"result,omitempty"`
+}
+
+type detailsChangedPayload struct {
+	TransactionID uuid.UUID       `json:"transaction_id"`
+	Gateway       billing.Gateway `json:"gateway"`
+	Data          json.RawMessage `json:"data,omitempty"`
+	Result        json.RawMessage `json:"result,omitempty"`
+}
+
+type eventCodec struct {
+	encode func(event interface{}) (interface{}, error)
+	decode func(payload []byte) (interface{}, error)
+}
+
+func (c eventCodec) Encode(event interface{}) ([]byte, error) {
+	v, err := c.encode(event)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+func (c eventCodec) Decode(payload []byte) (interface{}, error) {
+	return c.decode(payload)
+}
+
+func registerEventCodecs(registry *eventbus.Registry) {
+	registry.Register("billing.transaction.status_changed", &billing.StatusChangedEvent{})
+	registry.RegisterCodec("billing.transaction.created", &billing.CreatedEvent{}, eventCodec{
+		encode: func(event interface{}) (interface{}, error) {
+			return encodeTransactions(nil, event.(*billing.CreatedEvent).Result)
+		},
+		decode: func(payload []byte) (interface{}, error) {
+			_, result, err := decodeTransactions(payload)
+			return &billing.CreatedEvent{Result: result}, err
+		},
+	})
+	registry.RegisterCodec("billing.transaction.updated", &billing.UpdatedEvent{}, eventCodec{
+		encode: func(event interface{}) (interface{}, error) {
+			e := event.(*billing.UpdatedEvent)
+			return encodeTransactions(e.Data, e.Result)
+		},
+		decode: func(payload []byte) (interface{}, error) {
+			data, result, err := decodeTransactions(payload)
+			return &billing.UpdatedEvent{Data: data, Result: result}, err
+		},
+	})
+	registry.RegisterCodec("billing.transaction.deleted", &billing.DeletedEvent{}, eventCodec{
+		encode: func(event interface{}) (interface{}, error) {
+			return encodeTransactions(nil, event.(*billing.DeletedEvent).Result)
+		},
+		decode: func(payload []byte) (interface{}, error) {
+			_, result, err := decodeTransactions(payload)
+			return &billing.DeletedEvent{Result: result}, err
+		},
+	})
+	registry.RegisterCodec("billing.transaction.amount_changed", &billing.AmountChangedEvent{}, eventCodec{
+		encode: func(event interface{}) (interface{}, error) {
+			e := event.(*billing.AmountChangedEvent)
+			return &amountChangedPayload{
+				TransactionID: e.TransactionID,
+				Data:          toAmountPayload(e.Data),
+				Result:        toAmountPayload(e.Result),
+			}, nil
+		},
+		decode: func(payload []byte) (interface{}, error) {
+			var p amountChangedPayload
+			if err := json.Unmarshal(payload, &p); err != nil {
+				return nil, err
+			}
+			return &billing.AmountChangedEvent{
+				TransactionID: p.TransactionID,
+				Data:          toDomainAmount(p.Data),
+				Result:        toDomainAmount(p.Result),
+			}, nil
+		},
+	})
+	registry.RegisterCodec("billing.transaction.details_changed", &billing.DetailsChangedEvent{}, eventCodec{
+		encode: func(event interface{}) (interface{}, error) {
+			e := event.(*billing.DetailsChangedEvent)
+			gateway, err := persistence.DetailsGateway(e.Result)
+			if err != nil {
+				return nil, err
+			}
+			p := &detailsChangedPayload{TransactionID: e.TransactionID, Gateway: gateway}
+			if p.Result, err = persistence.ToDbDetails(e.Result); err != nil {
+				return nil, err
+			}
+			if e.Data != nil {
+				if p.Data, err = persistence.ToDbDetails(e.Data); err != nil {
+					return nil, err
+				}
+			}
+			return p, nil
+		},
+		decode: func(payload []byte) (interface{}, error) {
+			var p detailsChangedPayload
+			if err := json.Unmarshal(payload, &p); err != nil {
+				return nil, err
+			}
+			e := &billing.DetailsChangedEvent{TransactionID: p.TransactionID}
+			var err error
+			if e.Result, err = persistence.ToDomainDetails(p.Gateway, p.Result); err != nil {
+				return nil, err
+			}
+			if len(p.Data) > 0 {
+				if e.Data, err = persistence.ToDomainDetails(p.Gateway, p.Data); err != nil {
+					return nil, err
+				}
+			}
+			return e, nil
+		},
+	})
+}
+
+func encodeTransactions(data, result billing.Transaction) (*transactionEventPayload, error) {
+	p := &transactionEventPayload{}
+	var err error
+	if data != nil {
+		if p.Data, err = persistence.ToDBTransaction(data); err != nil {
+			return nil, err
+		}
+	}
+	if result == nil {
+		return nil, fmt.Errorf("transaction event has no result")
+	}
+	if p.Result, err = persistence.ToDBTransaction(result); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func decodeTransactions(payload []byte) (billing.Transaction, billing.Transaction, error) {
+	var p transactionEventPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, nil, err
+	}
+	var (
+		data, result billing.Transaction
+		err          error
+	)
+	if p.Data != nil {
+		if data, err = persistence.ToDomainTransaction(p.Data); err != nil {
+			return nil, nil, err
+		}
+	}
+	if p.Result != nil {
+		if result, err = persistence.ToDomainTransaction(p.Result); err != nil {
+			return nil, nil, err
+		}
+	}
+	return data, result, nil
+}
+
+func toAmountPayload(a billing.Amount) *amountPayload {
+	if a == nil {
+		return nil
+	}
+	return &amountPayload{Quantity: a.Quantity(), Currency: a.Currency()}
+}
+
+func toDomainAmount(p *amountPayload) billing.Amount {
+	if p == nil {
+		return nil
+	}
+	return billing.NewAmount(p.Quantity, p.Currency)
+}
@@ -220,6 +220,26 @@ func ToDomainDetails(gateway billing.Gateway, data json.RawMessage) (details.Det
 	}
 }
 
+// DetailsGateway returns the gateway the details belong to.
+func DetailsGateway(data details.Details) (billing.Gateway, error) {
+	switch data.(type) {
+	case details.ClickDetails:
+		return billing.Click, nil
+	case details.PaymeDetails:
+		return billing.Payme, nil
+	case details.OctoDetails:
+		return billing.Octo, nil
+	case details.StripeDetails:
+		return billing.Stripe, nil
+	case details.CashDetails:
+		return billing.Cash, nil
+	case details.IntegratorDetails:
+		return billing.Integrator, nil
+	default:
+		return "", fmt.Errorf("unsupported details type: %T", data)
+	}
+}
+
 func ToDbDetails(data details.Details) (json.RawMessage, error) {
 	switch d := data.(type) {
 	case details.ClickDetails:
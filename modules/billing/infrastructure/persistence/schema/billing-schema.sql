@@ -16,3 +16,5 @@ CREATE INDEX idx_billing_transactions_gateway ON billing_transactions (gateway);
 
 CREATE INDEX idx_billing_transactions_tenant_id ON billing_transactions (tenant_id);
 
+-- Row-level security: rows are only visible to the tenant in app.tenant_id
+SELECT enable_tenant_isolation('billing_transactions');
@@ -90,10 +90,13 @@ func (m *Module) Register(app application.Application) error {
 
 	billingRepo := persistence.NewBillingRepository()
 
+	registerEventCodecs(app.EventRegistry())
 	billingService := services.NewBillingService(
 		billingRepo,
 		billingProviders,
 		app.EventPublisher(),
+		services.WithEventStore(app.EventStore()),
+		services.WithOutbox(app.Outbox()),
 	)
 
 	app.RegisterServices(
@@ -30,28 +30,82 @@ type RefundTransactionCommand struct {
 }
 
 type BillingService struct {
-	repo      billing.Repository
-	providers map[billing.Gateway]billing.Provider
-	publisher eventbus.EventBus
-	callback  billing.TransactionCallback
-	mu        sync.RWMutex
+	repo       billing.Repository
+	providers  map[billing.Gateway]billing.Provider
+	publisher  eventbus.EventBus
+	eventStore eventbus.EventStore
+	outbox     eventbus.Outbox
+	callback   billing.TransactionCallback
+	mu         sync.RWMutex
+}
+
+type BillingServiceOption func(s *BillingService)
+
+// WithEventStore records transaction events in the event store, in the same
+// database transaction as the change that produced them.
+func WithEventStore(store eventbus.EventStore) BillingServiceOption {
+	return func(s *BillingService) {
+		s.eventStore = store
+	}
+}
+
+// WithOutbox enqueues transaction events in the transactional outbox, in the
+// same database transaction as the change that produced them, instead of
+// publishing them after it commits. The outbox dispatcher delivers them.
+func WithOutbox(outbox eventbus.Outbox) BillingServiceOption {
+	return func(s *BillingService) {
+		s.outbox = outbox
+	}
 }
 
 func NewBillingService(
 	repo billing.Repository,
 	providers []billing.Provider,
 	publisher eventbus.EventBus,
+	opts ...BillingServiceOption,
 ) *BillingService {
 	providerMap := make(map[billing.Gateway]billing.Provider)
 	for _, provider := range providers {
 		providerMap[provider.Gateway()] = provider
 	}
 
-	return &BillingService{
+	s := &BillingService{
 		repo:      repo,
 		providers: providerMap,
 		publisher: publisher,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// record appends events of the transaction to the event store and enqueues
+// them in the outbox when those are configured. ctx must carry the transaction.
+func (s *BillingService) record(ctx context.Context, id uuid.UUID, events ...interface{}) error {
+	if s.eventStore != nil {
+		if _, err := s.eventStore.Append(ctx, billing.AggregateType, id.String(), eventbus.AnyVersion, events...); err != nil {
+			return err
+		}
+	}
+	if s.outbox != nil {
+		for _, e := range events {
+			if err := s.outbox.Enqueue(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// publish delivers events of a committed change, unless they went through the outbox
+func (s *BillingService) publish(events ...interface{}) {
+	if s.outbox != nil {
+		return
+	}
+	for _, e := range events {
+		s.publisher.Publish(e)
+	}
 }
 
 func (s *BillingService) Count(ctx context.Context, params *billing.FindParams) (int64, error) {
@@ -99,19 +153,24 @@ func (s *BillingService) Create(ctx context.Context, cmd *CreateTransactionComma
 				return err
 			}
 			createdTransaction, err = s.repo.Save(txCtx, providedTransaction)
-			return err
+			if err != nil {
+				return err
+			}
+		} else {
+			// For Details-only gateways (Cash, Integrator), save directly
+			createdTransaction, err = s.repo.Save(txCtx, entity)
+			if err != nil {
+				return err
+			}
 		}
-
-		// For Details-only gateways (Cash, Integrator), save directly
-		createdTransaction, err = s.repo.Save(txCtx, entity)
-		return err
+		createdEvent.Result = createdTransaction
+		return s.record(txCtx, createdTransaction.ID(), createdEvent)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	createdEvent.Result = createdTransaction
-	s.publisher.Publish(createdEvent)
+	s.publish(createdEvent)
 
 	return createdTransaction, nil
 }
@@ -137,25 +196,32 @@ func (s *BillingService) Save(ctx context.Context, entity billing.Transaction) (
 		}
 	}
 
+	// The saved transaction is reloaded from the database, so the changes are
+	// taken from the entity that was passed in
+	events := entity.Events()
+
 	var savedTransaction billing.Transaction
 	if err := composables.InTx(ctx, func(txCtx context.Context) error {
 		savedTransaction, err = s.repo.Save(txCtx, entity)
-		return err
+		if err != nil {
+			return err
+		}
+		if isCreate {
+			createdEvent.Result = savedTransaction
+			return s.record(txCtx, savedTransaction.ID(), append([]interface{}{createdEvent}, events...)...)
+		}
+		updatedEvent.Result = savedTransaction
+		return s.record(txCtx, savedTransaction.ID(), append(events, updatedEvent)...)
 	}); err != nil {
 		return nil, err
 	}
 
 	if isCreate {
-		createdEvent.Result = savedTransaction
-		s.publisher.Publish(createdEvent)
+		s.publish(createdEvent)
 	} else {
-		updatedEvent.Result = savedTransaction
-		s.publisher.Publish(updatedEvent)
-	}
-
-	for _, e := range savedTransaction.Events() {
-		s.publisher.Publish(e)
+		s.publish(updatedEvent)
 	}
+	s.publish(events...)
 
 	return savedTransaction, nil
 }
@@ -173,7 +239,10 @@ func (s *BillingService) Cancel(ctx context.Context, cmd *CancelTransactionComma
 		return nil, err
 	}
 
-	var updatedTransaction billing.Transaction
+	var (
+		updatedTransaction billing.Transaction
+		events             []interface{}
+	)
 	err = composables.InTx(ctx, func(txCtx context.Context) error {
 		// If provider exists, use it
 		if provider != nil {
@@ -181,24 +250,31 @@ func (s *BillingService) Cancel(ctx context.Context, cmd *CancelTransactionComma
 			if err != nil {
 				return err
 			}
+			events = providedTransaction.Events()
 			updatedTransaction, err = s.repo.Save(txCtx, providedTransaction)
-			return err
+			if err != nil {
+				return err
+			}
+			updatedEvent.Result = updatedTransaction
+			return s.record(txCtx, updatedTransaction.ID(), append(events, updatedEvent)...)
 		}
 
 		// For Details-only gateways, just update status to Canceled
 		entity = entity.SetStatus(billing.Canceled)
+		events = entity.Events()
 		updatedTransaction, err = s.repo.Save(txCtx, entity)
-		return err
+		if err != nil {
+			return err
+		}
+		updatedEvent.Result = updatedTransaction
+		return s.record(txCtx, updatedTransaction.ID(), append(events, updatedEvent)...)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	updatedEvent.Result = updatedTransaction
-	s.publisher.Publish(updatedEvent)
-	for _, e := range updatedTransaction.Events() {
-		s.publisher.Publish(e)
-	}
+	s.publish(updatedEvent)
+	s.publish(events...)
 
 	return updatedTransaction, nil
 }
@@ -216,7 +292,10 @@ func (s *BillingService) Refund(ctx context.Context, cmd *RefundTransactionComma
 		return nil, err
 	}
 
-	var updatedTransaction billing.Transaction
+	var (
+		updatedTransaction billing.Transaction
+		events             []interface{}
+	)
 	err = composables.InTx(ctx, func(txCtx context.Context) error {
 		// If provider exists, use it
 		if provider != nil {
@@ -224,8 +303,13 @@ func (s *BillingService) Refund(ctx context.Context, cmd *RefundTransactionComma
 			if err != nil {
 				return err
 			}
+			events = providedTransaction.Events()
 			updatedTransaction, err = s.repo.Save(txCtx, providedTransaction)
-			return err
+			if err != nil {
+				return err
+			}
+			updatedEvent.Result = updatedTransaction
+			return s.record(txCtx, updatedTransaction.ID(), append(events, updatedEvent)...)
 		}
 
 		// For Details-only gateways, update status based on refund amount
@@ -234,18 +318,20 @@ func (s *BillingService) Refund(ctx context.Context, cmd *RefundTransactionComma
 		} else {
 			entity = entity.SetStatus(billing.PartiallyRefunded)
 		}
+		events = entity.Events()
 		updatedTransaction, err = s.repo.Save(txCtx, entity)
-		return err
+		if err != nil {
+			return err
+		}
+		updatedEvent.Result = updatedTransaction
+		return s.record(txCtx, updatedTransaction.ID(), append(events, updatedEvent)...)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	updatedEvent.Result = updatedTransaction
-	s.publisher.Publish(updatedEvent)
-	for _, e := range updatedTransaction.Events() {
-		s.publisher.Publish(e)
-	}
+	s.publish(updatedEvent)
+	s.publish(events...)
 
 	return updatedTransaction, nil
 }
@@ -268,14 +354,14 @@ func (s *BillingService) Delete(ctx context.Context, id uuid.UUID) (billing.Tran
 		} else {
 			deletedTransaction = entity
 		}
-		return nil
+		deletedEvent.Result = deletedTransaction
+		return s.record(txCtx, id, deletedEvent)
 	})
 	if err != nil {
 		return nil, err
 	}
-	deletedEvent.Result = deletedTransaction
 
-	s.publisher.Publish(deletedEvent)
+	s.publish(deletedEvent)
 
 	return deletedTransaction, nil
 }
@@ -22,6 +22,7 @@ const (
 	CreatedAtField
 	UpdatedAtField
 	TenantIDField
+	IDField
 )
 
 type SortBy = repo.SortBy[Field]
@@ -33,6 +34,61 @@ type FindParams struct {
 	SortBy  SortBy
 	Search  string
 	Filters []Filter
+	// Cursor continues the list after the user it was created for with NextCursor.
+	// When set, Offset is ignored.
+	Cursor string
+}
+
+// KeysetSortBy returns sortBy with the user ID as the last sort field, which keeps
+// the order stable across pages.
+func KeysetSortBy(sortBy SortBy) SortBy {
+	return sortBy.WithTiebreaker(IDField)
+}
+
+// NextCursor returns the cursor continuing a list sorted by sortBy after u.
+func NextCursor(sortBy SortBy, u User) string {
+	keyset := KeysetSortBy(sortBy)
+	return keyset.Cursor(func(field Field) any {
+		return sortValue(u, field)
+	})
+}
+
+// sortValue returns the value a user is sorted by for field, with optional values
+// that are stored as NULL returned as nil.
+func sortValue(u User, field Field) any {
+	switch field {
+	case IDField:
+		return u.ID()
+	case FirstNameField:
+		return u.FirstName()
+	case LastNameField:
+		return u.LastName()
+	case MiddleNameField:
+		if u.MiddleName() == "" {
+			return nil
+		}
+		return u.MiddleName()
+	case EmailField:
+		return u.Email().Value()
+	case PhoneField:
+		if u.Phone() == nil || u.Phone().Value() == "" {
+			return nil
+		}
+		return u.Phone().Value()
+	case LastLoginField:
+		if u.LastLogin().IsZero() {
+			return nil
+		}
+		return u.LastLogin()
+	case CreatedAtField:
+		return u.CreatedAt()
+	case UpdatedAtField:
+		return u.UpdatedAt()
+	case TenantIDField:
+		return u.TenantID()
+	default:
+		return nil
+	}
 }
 
 type Repository interface {
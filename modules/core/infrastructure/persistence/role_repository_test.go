@@ -64,3 +64,14 @@ func TestGormRoleRepository_CRUD(t *testing.T) {
 		},
 	)
 }
+
+func TestGormRoleRepository_TenantIsolation(t *testing.T) {
+	f := setupTest(t)
+
+	roleRepository := persistence.NewRoleRepository()
+	if _, err := roleRepository.Create(f.Ctx, role.New("isolated")); err != nil {
+		t.Fatal(err)
+	}
+
+	f.AssertTenantIsolation(t, "roles")
+}
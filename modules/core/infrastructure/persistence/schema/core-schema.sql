@@ -1,3 +1,5 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
 CREATE TABLE tenants (
     id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
     name varchar(255) NOT NULL UNIQUE,
@@ -102,10 +104,15 @@ CREATE TABLE users (
     last_action timestamp with time zone NULL,
     created_at timestamp with time zone NOT NULL DEFAULT now(),
     updated_at timestamp with time zone NOT NULL DEFAULT now(),
-    UNIQUE (tenant_id, email),
-    UNIQUE (tenant_id, phone)
+    deleted_at timestamp with time zone
 );
 
+CREATE UNIQUE INDEX users_tenant_id_email_key ON users (tenant_id, email) WHERE deleted_at IS NULL;
+
+CREATE UNIQUE INDEX users_tenant_id_phone_key ON users (tenant_id, phone) WHERE deleted_at IS NULL;
+
+CREATE INDEX users_trash_idx ON users (tenant_id, deleted_at) WHERE deleted_at IS NOT NULL;
+
 CREATE TABLE user_roles (
     user_id int NOT NULL REFERENCES users (id) ON DELETE CASCADE,
     role_id int NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
@@ -180,6 +187,105 @@ CREATE TABLE sessions (
     created_at timestamp with time zone NOT NULL DEFAULT now()
 );
 
+CREATE TABLE eventbus_outbox (
+    id bigserial PRIMARY KEY,
+    tenant_id uuid REFERENCES tenants (id) ON DELETE CASCADE,
+    event_type varchar(255) NOT NULL,
+    dedup_key varchar(255) NOT NULL UNIQUE,
+    payload jsonb NOT NULL,
+    attempts int NOT NULL DEFAULT 0,
+    last_error text,
+    next_attempt_at timestamp with time zone NOT NULL DEFAULT now(),
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    dispatched_at timestamp with time zone
+);
+
+CREATE TABLE eventbus_dead_letters (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    subscriber varchar(512) NOT NULL,
+    args jsonb NOT NULL,
+    error text NOT NULL,
+    attempts int NOT NULL DEFAULT 0,
+    status varchar(32) NOT NULL, -- failed, replay_requested, replaying
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE eventbus_events (
+    position bigserial PRIMARY KEY,
+    tenant_id uuid REFERENCES tenants (id) ON DELETE CASCADE,
+    aggregate_type varchar(255) NOT NULL,
+    aggregate_id varchar(255) NOT NULL,
+    version int NOT NULL,
+    event_type varchar(255) NOT NULL,
+    payload jsonb NOT NULL,
+    occurred_at timestamp with time zone NOT NULL DEFAULT now(),
+    UNIQUE (aggregate_type, aggregate_id, version)
+);
+
+CREATE TABLE eventbus_projection_checkpoints (
+    name varchar(255) PRIMARY KEY,
+    position bigint NOT NULL DEFAULT 0,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE jobs (
+    id bigserial PRIMARY KEY,
+    tenant_id uuid REFERENCES tenants (id) ON DELETE CASCADE,
+    queue varchar(255) NOT NULL DEFAULT 'default',
+    kind varchar(255) NOT NULL,
+    payload jsonb NOT NULL,
+    status varchar(16) NOT NULL DEFAULT 'pending',
+    priority int NOT NULL DEFAULT 0,
+    attempts int NOT NULL DEFAULT 0,
+    max_attempts int NOT NULL DEFAULT 5,
+    unique_key varchar(255),
+    last_error text,
+    run_at timestamp with time zone NOT NULL DEFAULT now(),
+    locked_at timestamp with time zone,
+    locked_by varchar(255),
+    finished_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE scheduler_tasks (
+    name varchar(255) PRIMARY KEY,
+    spec varchar(255) NOT NULL,
+    per_tenant boolean NOT NULL DEFAULT false,
+    catch_up boolean NOT NULL DEFAULT true,
+    next_run_at timestamp with time zone,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE scheduler_runs (
+    id bigserial PRIMARY KEY,
+    task varchar(255) NOT NULL,
+    tenant_id uuid REFERENCES tenants (id) ON DELETE CASCADE,
+    triggered_by varchar(16) NOT NULL DEFAULT 'schedule',
+    scheduled_at timestamp with time zone NOT NULL,
+    status varchar(16) NOT NULL DEFAULT 'running',
+    error text,
+    stack_trace text,
+    node varchar(255),
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    UNIQUE NULLS NOT DISTINCT (task, scheduled_at, tenant_id)
+);
+
+CREATE TABLE crud_history (
+    id bigserial PRIMARY KEY,
+    tenant_id uuid REFERENCES tenants (id) ON DELETE CASCADE,
+    schema_name varchar(255) NOT NULL,
+    entity_key varchar(255) NOT NULL,
+    action varchar(16) NOT NULL,
+    changes jsonb NOT NULL DEFAULT '[]',
+    user_id int REFERENCES users (id) ON DELETE SET NULL,
+    user_name varchar(511) NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
 CREATE INDEX users_tenant_id_idx ON users (tenant_id);
 
 CREATE INDEX users_first_name_idx ON users (first_name);
@@ -204,3 +310,35 @@ CREATE INDEX roles_tenant_id_idx ON roles (tenant_id);
 
 CREATE INDEX user_groups_tenant_id_idx ON user_groups (tenant_id);
 
+CREATE INDEX eventbus_outbox_pending_idx ON eventbus_outbox (id) WHERE dispatched_at IS NULL;
+
+CREATE INDEX eventbus_dead_letters_status_idx ON eventbus_dead_letters (status);
+
+CREATE INDEX eventbus_events_tenant_id_idx ON eventbus_events (tenant_id);
+
+CREATE INDEX eventbus_events_occurred_at_idx ON eventbus_events (occurred_at);
+
+CREATE UNIQUE INDEX jobs_unique_key_idx ON jobs (kind, unique_key) WHERE status IN ('pending', 'running');
+
+CREATE INDEX jobs_ready_idx ON jobs (queue, priority DESC, run_at) WHERE status = 'pending';
+
+CREATE INDEX jobs_status_idx ON jobs (status);
+
+CREATE INDEX jobs_tenant_id_idx ON jobs (tenant_id);
+
+CREATE INDEX scheduler_runs_created_at_idx ON scheduler_runs (created_at);
+
+CREATE INDEX scheduler_runs_tenant_id_idx ON scheduler_runs (tenant_id);
+
+CREATE INDEX scheduler_runs_status_idx ON scheduler_runs (status);
+
+CREATE INDEX crud_history_entity_idx ON crud_history (schema_name, entity_key, id);
+
+-- Row-level security: rows are only visible to the tenant in app.tenant_id
+SELECT enable_tenant_isolation('uploads');
+SELECT enable_tenant_isolation('passports');
+SELECT enable_tenant_isolation('companies');
+SELECT enable_tenant_isolation('roles');
+SELECT enable_tenant_isolation('users');
+SELECT enable_tenant_isolation('user_groups');
+SELECT enable_tenant_isolation('crud_history');
@@ -13,6 +13,7 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence/models"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/repo"
+	"github.com/iota-uz/iota-sdk/pkg/trash"
 )
 
 var (
@@ -42,7 +43,7 @@ const (
 
 	userCountQuery = `SELECT COUNT(u.id) FROM users u`
 
-	userCountByTenantQuery = `SELECT COUNT(*) FROM users WHERE tenant_id = $1`
+	userCountByTenantQuery = `SELECT COUNT(*) FROM users WHERE tenant_id = $1 AND deleted_at IS NULL`
 
 	userExistsQuery = `SELECT 1 FROM users u`
 
@@ -50,9 +51,9 @@ const (
 
 	userUpdateLastActionQuery = `UPDATE users SET last_action = NOW() WHERE id = $1 AND tenant_id = $2`
 
-	userDeleteQuery     = `DELETE FROM users WHERE id = $1 AND tenant_id = $2`
-	userRoleDeleteQuery = `DELETE FROM user_roles WHERE user_id = $1`
-	userRoleInsertQuery = `INSERT INTO user_roles (user_id, role_id) VALUES`
+	userSessionDeleteQuery = `DELETE FROM sessions WHERE user_id = $1`
+	userRoleDeleteQuery    = `DELETE FROM user_roles WHERE user_id = $1`
+	userRoleInsertQuery    = `INSERT INTO user_roles (user_id, role_id) VALUES`
 
 	userGroupDeleteQuery = `DELETE FROM group_users WHERE user_id = $1`
 	userGroupInsertQuery = `INSERT INTO group_users (user_id, group_id) VALUES`
@@ -108,6 +109,7 @@ func NewUserRepository(uploadRepo upload.Repository) user.Repository {
 			user.CreatedAtField:    "u.created_at",
 			user.UpdatedAtField:    "u.updated_at",
 			user.TenantIDField:     "u.tenant_id",
+			user.IDField:           "u.id",
 		},
 	}
 }
@@ -118,7 +120,7 @@ func (g *PgUserRepository) buildUserFilters(ctx context.Context, params *user.Fi
 		return nil, nil, errors.Wrap(err, "failed to get tenant from context")
 	}
 
-	where := []string{"u.tenant_id = $1"}
+	where := []string{"u.tenant_id = $1", repo.NotDeleted("u")}
 	args := []interface{}{tenantID}
 
 	for _, filter := range params.Filters {
@@ -171,11 +173,27 @@ func (g *PgUserRepository) GetPaginated(ctx context.Context, params *user.FindPa
 		}
 	}
 
+	sortBy := user.KeysetSortBy(params.SortBy)
+	offset := params.Offset
+	if params.Cursor != "" {
+		cursor, err := repo.DecodeCursor(params.Cursor)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode cursor")
+		}
+		seek, seekArgs, err := sortBy.Seek(g.fieldMap, cursor, len(args)+1)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to build cursor condition")
+		}
+		where = append(where, seek)
+		args = append(args, seekArgs...)
+		offset = 0
+	}
+
 	query := repo.Join(
 		baseQuery,
 		repo.JoinWhere(where...),
-		params.SortBy.ToSQL(g.fieldMap),
-		repo.FormatLimitOffset(params.Limit, params.Offset),
+		sortBy.ToSQL(g.fieldMap),
+		repo.FormatLimitOffset(params.Limit, offset),
 	)
 	users, err := g.queryUsers(ctx, query, args...)
 	if err != nil {
@@ -244,7 +262,7 @@ func (g *PgUserRepository) GetAll(ctx context.Context) ([]user.User, error) {
 		return nil, errors.Wrap(err, "failed to get tenant from context")
 	}
 
-	users, err := g.queryUsers(ctx, userFindQuery+" WHERE u.tenant_id = $1", tenantID)
+	users, err := g.queryUsers(ctx, userFindQuery+" WHERE u.tenant_id = $1 AND u.deleted_at IS NULL", tenantID)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get all users")
 	}
@@ -256,12 +274,12 @@ func (g *PgUserRepository) GetByID(ctx context.Context, id uint) (user.User, err
 
 	var users []user.User
 	if err == nil {
-		users, err = g.queryUsers(ctx, userFindQuery+" WHERE u.id = $1 AND u.tenant_id = $2", id, tenantID)
+		users, err = g.queryUsers(ctx, userFindQuery+" WHERE u.id = $1 AND u.tenant_id = $2 AND u.deleted_at IS NULL", id, tenantID)
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("failed to query user with id: %d", id))
 		}
 	} else {
-		users, err = g.queryUsers(ctx, userFindQuery+" WHERE u.id = $1", id)
+		users, err = g.queryUsers(ctx, userFindQuery+" WHERE u.id = $1 AND u.deleted_at IS NULL", id)
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("failed to query user with id: %d", id))
 		}
@@ -279,13 +297,13 @@ func (g *PgUserRepository) GetByEmail(ctx context.Context, email string) (user.U
 	var users []user.User
 	if err == nil {
 		// If we have a tenant, use it to filter
-		users, err = g.queryUsers(ctx, userFindQuery+" WHERE u.email = $1 AND u.tenant_id = $2", email, tenantID)
+		users, err = g.queryUsers(ctx, userFindQuery+" WHERE u.email = $1 AND u.tenant_id = $2 AND u.deleted_at IS NULL", email, tenantID)
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("failed to query user with email: %s", email))
 		}
 	} else {
 		// If no tenant in context (like during login), get user by email across all tenants
-		users, err = g.queryUsers(ctx, userFindQuery+" WHERE u.email = $1", email)
+		users, err = g.queryUsers(ctx, userFindQuery+" WHERE u.email = $1 AND u.deleted_at IS NULL", email)
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("failed to query user with email: %s", email))
 		}
@@ -304,7 +322,7 @@ func (g *PgUserRepository) GetByPhone(ctx context.Context, phone string) (user.U
 		return nil, errors.Wrap(err, "failed to get tenant from context")
 	}
 
-	users, err := g.queryUsers(ctx, userFindQuery+" WHERE u.phone = $1 AND u.tenant_id = $2", phone, tenantID)
+	users, err := g.queryUsers(ctx, userFindQuery+" WHERE u.phone = $1 AND u.tenant_id = $2 AND u.deleted_at IS NULL", phone, tenantID)
 	if err != nil {
 		return nil, errors.Wrap(err, fmt.Sprintf("failed to query user with phone: %s", phone))
 	}
@@ -320,7 +338,7 @@ func (g *PgUserRepository) PhoneExists(ctx context.Context, phone string) (bool,
 		return false, errors.Wrap(err, "failed to get transaction")
 	}
 
-	base := repo.Join(userExistsQuery, "WHERE u.phone = $1")
+	base := repo.Join(userExistsQuery, "WHERE u.phone = $1 AND u.deleted_at IS NULL")
 	query := repo.Exists(base)
 
 	exists := false
@@ -336,7 +354,7 @@ func (g *PgUserRepository) EmailExists(ctx context.Context, email string) (bool,
 		return false, errors.Wrap(err, "failed to get transaction")
 	}
 
-	base := repo.Join(userExistsQuery, "WHERE u.email = $1")
+	base := repo.Join(userExistsQuery, "WHERE u.email = $1 AND u.deleted_at IS NULL")
 	query := repo.Exists(base)
 
 	exists := false
@@ -545,27 +563,28 @@ func (g *PgUserRepository) UpdateLastAction(ctx context.Context, id uint) error
 	return nil
 }
 
+// Delete moves the user to the trash. Roles, groups and permissions are kept for
+// a restore, sessions are ended right away.
 func (g *PgUserRepository) Delete(ctx context.Context, id uint) error {
 	tenantID, err := composables.UseTenantID(ctx)
 	if err != nil {
 		return errors.Wrap(err, "failed to get tenant from context")
 	}
 
-	if err := g.execQuery(ctx, userRoleDeleteQuery, id); err != nil {
-		return errors.Wrap(err, fmt.Sprintf("failed to delete roles for user ID: %d", id))
-	}
-	if err := g.execQuery(ctx, userGroupDeleteQuery, id); err != nil {
-		return errors.Wrap(err, fmt.Sprintf("failed to delete groups for user ID: %d", id))
-	}
-	if err := g.execQuery(ctx, userPermissionDeleteQuery, id); err != nil {
-		return errors.Wrap(err, fmt.Sprintf("failed to delete permissions for user ID: %d", id))
+	if err := g.execQuery(ctx, userSessionDeleteQuery, id); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to delete sessions for user ID: %d", id))
 	}
-	if err := g.execQuery(ctx, userDeleteQuery, id, tenantID); err != nil {
+	if err := g.execQuery(ctx, repo.SoftDelete("users", "id = $1", "tenant_id = $2"), id, tenantID); err != nil {
 		return errors.Wrap(err, fmt.Sprintf("failed to delete user with ID: %d", id))
 	}
 	return nil
 }
 
+// NewUserTrashBin creates the trash bin of users.
+func NewUserTrashBin(opts ...trash.TableBinOption) trash.Bin {
+	return trash.NewTableBin("Users", "users", "concat_ws(' ', first_name, last_name)", opts...)
+}
+
 func (g *PgUserRepository) queryUsers(ctx context.Context, query string, args ...interface{}) ([]user.User, error) {
 	tx, err := composables.UseTx(ctx)
 	if err != nil {
@@ -538,6 +538,11 @@ func TestPgUserRepository_CRUD(t *testing.T) {
 		_, err = userRepository.GetByID(f.Ctx, createdUser.ID())
 		require.Error(t, err)
 		require.ErrorIs(t, err, persistence.ErrUserNotFound)
+
+		// Trashed users don't hold on to their email
+		exists, err := userRepository.EmailExists(f.Ctx, email.Value())
+		require.NoError(t, err)
+		assert.False(t, exists)
 	})
 }
 
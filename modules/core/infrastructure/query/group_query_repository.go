@@ -31,7 +31,7 @@ const (
 		u.created_at, u.updated_at
 	FROM users u
 	JOIN group_users gu ON u.id = gu.user_id
-	WHERE gu.group_id = $1 AND u.tenant_id = $2`
+	WHERE gu.group_id = $1 AND u.tenant_id = $2 AND u.deleted_at IS NULL`
 
 	selectGroupRolesSQL = `SELECT
 		r.id, r.type, r.name, r.description, r.created_at, r.updated_at
@@ -184,8 +184,8 @@ func (r *pgUserQueryRepository) FindUsers(ctx context.Context, params *FindParam
 		}
 	}
 
-	// Build conditions and args, starting with tenant filter
-	conditions := []string{"u.tenant_id = $1"}
+	// Build conditions and args, starting with tenant filter; trashed users are left out
+	conditions := []string{"u.tenant_id = $1", "u.deleted_at IS NULL"}
 	args := []interface{}{tenantID}
 
 	// Add regular filter conditions
@@ -277,7 +277,7 @@ func (r *pgUserQueryRepository) SearchUsers(ctx context.Context, params *FindPar
 	searchFilter := r.buildSearchFilter(params.Search, 2) // $2 since $1 is tenant_id
 
 	// Build combined conditions and args, starting with tenant filter
-	allConditions := []string{"u.tenant_id = $1", searchFilter.condition}
+	allConditions := []string{"u.tenant_id = $1", "u.deleted_at IS NULL", searchFilter.condition}
 	allArgs := []interface{}{tenantID}
 	allArgs = append(allArgs, searchFilter.args...)
 
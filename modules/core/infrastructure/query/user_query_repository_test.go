@@ -1,9 +1,14 @@
 package query_test
 
 import (
+	"strconv"
 	"testing"
 
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/internet"
+	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/query"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/repo"
 	"github.com/stretchr/testify/require"
 )
@@ -243,3 +248,43 @@ func TestPgUserQueryRepository_FindUsers(t *testing.T) {
 		require.Equal(t, count1, count2)
 	})
 }
+
+func TestPgUserQueryRepository_FindUsers_SkipsTrashed(t *testing.T) {
+	t.Parallel()
+	fixtures := setupTest(t)
+
+	tenantID, err := composables.UseTenantID(fixtures.Ctx)
+	require.NoError(t, err)
+	email, err := internet.NewEmail("trashed@example.com")
+	require.NoError(t, err)
+
+	userRepository := persistence.NewUserRepository(persistence.NewUploadRepository())
+	trashed, err := userRepository.Create(fixtures.Ctx, user.New(
+		"Trashed",
+		"User",
+		email,
+		user.UILanguageEN,
+		user.WithTenantID(tenantID),
+	))
+	require.NoError(t, err)
+
+	userQueryRepo := query.NewPgUserQueryRepository()
+	params := &query.FindParams{
+		Limit: 100,
+		Filters: []query.Filter{
+			{Column: query.FieldEmail, Filter: repo.Eq(email.Value())},
+		},
+	}
+	users, count, err := userQueryRepo.FindUsers(fixtures.Ctx, params)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	require.Len(t, users, 1)
+	require.Equal(t, strconv.FormatUint(uint64(trashed.ID()), 10), users[0].ID)
+
+	require.NoError(t, userRepository.Delete(fixtures.Ctx, trashed.ID()))
+
+	users, count, err = userQueryRepo.FindUsers(fixtures.Ctx, params)
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+	require.Empty(t, users)
+}
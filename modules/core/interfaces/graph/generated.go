@@ -67,15 +67,16 @@ type ComplexityRoot struct {
 	}
 
 	PaginatedUsers struct {
-		Data  func(childComplexity int) int
-		Total func(childComplexity int) int
+		Data       func(childComplexity int) int
+		NextCursor func(childComplexity int) int
+		Total      func(childComplexity int) int
 	}
 
 	Query struct {
 		Hello   func(childComplexity int, name *string) int
 		Uploads func(childComplexity int, filter model.UploadFilter) int
 		User    func(childComplexity int, id int64) int
-		Users   func(childComplexity int, offset int, limit int, sortBy []int, ascending bool) int
+		Users   func(childComplexity int, offset int, limit int, sortBy []int, ascending bool, after *string) int
 	}
 
 	Session struct {
@@ -129,7 +130,7 @@ type QueryResolver interface {
 	Hello(ctx context.Context, name *string) (*string, error)
 	Uploads(ctx context.Context, filter model.UploadFilter) ([]*model.Upload, error)
 	User(ctx context.Context, id int64) (*model.User, error)
-	Users(ctx context.Context, offset int, limit int, sortBy []int, ascending bool) (*model.PaginatedUsers, error)
+	Users(ctx context.Context, offset int, limit int, sortBy []int, ascending bool, after *string) (*model.PaginatedUsers, error)
 }
 type SubscriptionResolver interface {
 	Counter(ctx context.Context) (<-chan int, error)
@@ -255,6 +256,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.PaginatedUsers.Data(childComplexity), true
 
+	case "PaginatedUsers.nextCursor":
+		if e.complexity.PaginatedUsers.NextCursor == nil {
+			break
+		}
+
+		return e.complexity.PaginatedUsers.NextCursor(childComplexity), true
+
 	case "PaginatedUsers.total":
 		if e.complexity.PaginatedUsers.Total == nil {
 			break
@@ -308,7 +316,7 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Query.Users(childComplexity, args["offset"].(int), args["limit"].(int), args["sortBy"].([]int), args["ascending"].(bool)), true
+		return e.complexity.Query.Users(childComplexity, args["offset"].(int), args["limit"].(int), args["sortBy"].([]int), args["ascending"].(bool), args["after"].(*string)), true
 
 	case "Session.createdAt":
 		if e.complexity.Session.CreatedAt == nil {
@@ -1111,6 +1119,11 @@ func (ec *executionContext) field_Query_users_args(ctx context.Context, rawArgs
 		return nil, err
 	}
 	args["ascending"] = arg3
+	arg4, err := ec.field_Query_users_argsAfter(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["after"] = arg4
 	return args, nil
 }
 func (ec *executionContext) field_Query_users_argsOffset(
@@ -1201,6 +1214,28 @@ func (ec *executionContext) field_Query_users_argsAscending(
 	return zeroVal, nil
 }
 
+func (ec *executionContext) field_Query_users_argsAfter(
+	ctx context.Context,
+	rawArgs map[string]interface{},
+) (*string, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["after"]
+	if !ok {
+		var zeroVal *string
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("after"))
+	if tmp, ok := rawArgs["after"]; ok {
+		return ec.unmarshalOString2ᚖstring(ctx, tmp)
+	}
+
+	var zeroVal *string
+	return zeroVal, nil
+}
+
 func (ec *executionContext) field___Type_enumValues_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -1897,6 +1932,47 @@ func (ec *executionContext) fieldContext_PaginatedUsers_total(_ context.Context,
 	return fc, nil
 }
 
+func (ec *executionContext) _PaginatedUsers_nextCursor(ctx context.Context, field graphql.CollectedField, obj *model.PaginatedUsers) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PaginatedUsers_nextCursor(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.NextCursor, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_PaginatedUsers_nextCursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PaginatedUsers",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Query_hello(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Query_hello(ctx, field)
 	if err != nil {
@@ -2108,7 +2184,7 @@ func (ec *executionContext) _Query_users(ctx context.Context, field graphql.Coll
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Users(rctx, fc.Args["offset"].(int), fc.Args["limit"].(int), fc.Args["sortBy"].([]int), fc.Args["ascending"].(bool))
+		return ec.resolvers.Query().Users(rctx, fc.Args["offset"].(int), fc.Args["limit"].(int), fc.Args["sortBy"].([]int), fc.Args["ascending"].(bool), fc.Args["after"].(*string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2137,6 +2213,8 @@ func (ec *executionContext) fieldContext_Query_users(ctx context.Context, field
 				return ec.fieldContext_PaginatedUsers_data(ctx, field)
 			case "total":
 				return ec.fieldContext_PaginatedUsers_total(ctx, field)
+			case "nextCursor":
+				return ec.fieldContext_PaginatedUsers_nextCursor(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type PaginatedUsers", field.Name)
 		},
@@ -5498,6 +5576,8 @@ func (ec *executionContext) _PaginatedUsers(ctx context.Context, sel ast.Selecti
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "nextCursor":
+			out.Values[i] = ec._PaginatedUsers_nextCursor(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -25,8 +25,9 @@ type Mutation struct {
 }
 
 type PaginatedUsers struct {
-	Data  []*User `json:"data"`
-	Total int64   `json:"total"`
+	Data       []*User `json:"data"`
+	Total      int64   `json:"total"`
+	NextCursor *string `json:"nextCursor,omitempty"`
 }
 
 type Query struct {
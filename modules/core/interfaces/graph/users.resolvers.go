@@ -10,6 +10,7 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
 	model "github.com/iota-uz/iota-sdk/modules/core/interfaces/graph/gqlmodels"
 	"github.com/iota-uz/iota-sdk/modules/core/interfaces/graph/mappers"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/mapping"
 	"github.com/iota-uz/iota-sdk/pkg/repo"
 )
@@ -24,7 +25,7 @@ func (r *queryResolver) User(ctx context.Context, id int64) (*model.User, error)
 }
 
 // Users is the resolver for the users field.
-func (r *queryResolver) Users(ctx context.Context, offset int, limit int, sortBy []int, ascending bool) (*model.PaginatedUsers, error) {
+func (r *queryResolver) Users(ctx context.Context, offset int, limit int, sortBy []int, ascending bool, after *string) (*model.PaginatedUsers, error) {
 	var fields []repo.SortByField[user.Field]
 
 	for _, field := range sortBy {
@@ -33,21 +34,32 @@ func (r *queryResolver) Users(ctx context.Context, offset int, limit int, sortBy
 			Ascending: ascending,
 		})
 	}
+	limit = composables.PageLimit(limit)
 	params := &user.FindParams{
-		Limit:  limit,
+		Limit:  limit + 1,
 		Offset: offset,
 		SortBy: user.SortBy{Fields: fields},
 	}
+	if after != nil {
+		params.Cursor = *after
+	}
 	domainUsers, err := r.userService.GetPaginated(ctx, params)
 	if err != nil {
 		return nil, err
 	}
+	var nextCursor *string
+	if len(domainUsers) > limit {
+		domainUsers = domainUsers[:limit]
+		cursor := user.NextCursor(params.SortBy, domainUsers[len(domainUsers)-1])
+		nextCursor = &cursor
+	}
 	total, err := r.userService.Count(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 	return &model.PaginatedUsers{
-		Data:  mapping.MapViewModels(domainUsers, mappers.UserToGraphModel),
-		Total: total,
+		Data:       mapping.MapViewModels(domainUsers, mappers.UserToGraphModel),
+		Total:      total,
+		NextCursor: nextCursor,
 	}, nil
 }
@@ -3,9 +3,14 @@ package core
 import (
 	"embed"
 
+	"github.com/iota-uz/iota-sdk/modules/core/permissions"
 	"github.com/iota-uz/iota-sdk/modules/core/validators"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/jobs"
 	"github.com/iota-uz/iota-sdk/pkg/rbac"
+	"github.com/iota-uz/iota-sdk/pkg/scheduler"
 	"github.com/iota-uz/iota-sdk/pkg/spotlight"
+	"github.com/iota-uz/iota-sdk/pkg/trash"
 
 	icons "github.com/iota-uz/icons/phosphor"
 	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
@@ -68,6 +73,8 @@ func (m *Module) Register(app application.Application) error {
 	// Create services
 	tenantService := services.NewTenantService(tenantRepo)
 	uploadService := services.NewUploadService(uploadRepo, fsStorage, app.EventPublisher())
+	excelExportService := services.NewExcelExportService(app.DB(), uploadService, app.Jobs())
+	jobs.Register(app.JobRegistry(), excelExportService.RunExport)
 
 	app.RegisterServices(
 		uploadService,
@@ -75,7 +82,7 @@ func (m *Module) Register(app application.Application) error {
 		services.NewUserQueryService(userQueryRepo),
 		services.NewGroupQueryService(groupQueryRepo),
 		services.NewSessionService(persistence.NewSessionRepository(), app.EventPublisher()),
-		services.NewExcelExportService(app.DB(), uploadService),
+		excelExportService,
 	)
 	app.RegisterServices(
 		services.NewAuthService(app),
@@ -89,6 +96,9 @@ func (m *Module) Register(app application.Application) error {
 	// handlers.RegisterUserHandler(app)
 
 	//controllers.InitCrudShowcase(app)
+	trashBins := []trash.Bin{
+		persistence.NewUserTrashBin(trash.WithPermission(permissions.UserDelete)),
+	}
 	app.RegisterControllers(
 		controllers.NewHealthController(app),
 		controllers.NewDashboardController(app),
@@ -111,6 +121,12 @@ func (m *Module) Register(app application.Application) error {
 		controllers.NewCrudShowcaseController(app),
 		controllers.NewWebSocketController(app),
 		controllers.NewSettingsController(app),
+		controllers.NewTrashController(app, "/users/trash", trashBins...),
+	)
+	app.SchedulerRegistry().Register(
+		"core.purge_trash", "@daily",
+		trash.PurgeTask(configuration.Use().TrashRetention, trashBins...),
+		scheduler.ForEachTenant(),
 	)
 	app.RegisterHashFsAssets(assets.HashFS)
 	app.RegisterGraphSchema(application.GraphSchema{
@@ -0,0 +1,533 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+)
+
+const (
+	defaultAPILimit = 20
+	maxAPILimit     = 100
+)
+
+// CrudAPIPermissions are the permissions the operations of a CrudAPIController
+// require. Operations with a nil permission only require an authenticated user.
+type CrudAPIPermissions struct {
+	Read   rbac.Permission
+	Create rbac.Permission
+	Update rbac.Permission
+	Delete rbac.Permission
+}
+
+// CrudAPIController serves a JSON REST API for the entities of a crud schema:
+//
+//	GET    {basePath}       lists entities, see List for the query parameters
+//	POST   {basePath}       creates an entity
+//	GET    {basePath}/{id}  gets an entity
+//	PUT    {basePath}/{id}  updates the fields of an entity present in the body
+//	DELETE {basePath}/{id}  deletes an entity
+//
+// Entities are JSON objects holding the fields returned by crud.APIFields. Errors
+// are objects with code and message, and validation failures also hold the
+// serrors.ValidationError of every invalid field under errors.
+type CrudAPIController[TEntity any] struct {
+	basePath    string
+	app         application.Application
+	schema      crud.Schema[TEntity]
+	service     crud.Service[TEntity]
+	permissions CrudAPIPermissions
+}
+
+// CrudAPIOption defines options for CrudAPIController
+type CrudAPIOption[TEntity any] func(*CrudAPIController[TEntity])
+
+// WithAPIPermissions makes the operations of the API require permissions
+func WithAPIPermissions[TEntity any](permissions CrudAPIPermissions) CrudAPIOption[TEntity] {
+	return func(c *CrudAPIController[TEntity]) {
+		c.permissions = permissions
+	}
+}
+
+func NewCrudAPIController[TEntity any](
+	basePath string,
+	app application.Application,
+	builder crud.Builder[TEntity],
+	opts ...CrudAPIOption[TEntity],
+) *CrudAPIController[TEntity] {
+	controller := &CrudAPIController[TEntity]{
+		basePath: basePath,
+		app:      app,
+		schema:   builder.Schema(),
+		service:  builder.Service(),
+	}
+	for _, opt := range opts {
+		opt(controller)
+	}
+	return controller
+}
+
+func (c *CrudAPIController[TEntity]) Key() string {
+	return c.basePath
+}
+
+func (c *CrudAPIController[TEntity]) Register(r *mux.Router) {
+	router := r.PathPrefix(c.basePath).Subrouter()
+	router.Use(
+		middleware.Authorize(),
+		middleware.RequireAuthorization(),
+		middleware.ProvideUser(),
+		middleware.ProvideLocalizer(c.app.Bundle()),
+	)
+
+	router.HandleFunc("", c.List).Methods(http.MethodGet)
+	router.HandleFunc("", c.Create).Methods(http.MethodPost)
+	router.HandleFunc("/{id}", c.Get).Methods(http.MethodGet)
+	router.HandleFunc("/{id}", c.Update).Methods(http.MethodPut)
+	router.HandleFunc("/{id}", c.Delete).Methods(http.MethodDelete)
+}
+
+// OpenAPI describes the operations of the API in doc
+func (c *CrudAPIController[TEntity]) OpenAPI(doc *crud.OpenAPIDocument) {
+	doc.AddResource(c.schema.Name(), c.basePath, c.schema.Fields())
+}
+
+// List returns a chunk of entities as {"data": [...], "next_cursor": "..."}.
+// It takes the limit, offset, cursor, q (search), sort (comma separated fields,
+// prefixed with - for descending order) and include (comma separated relations)
+// query parameters. Any other parameter filters by the field it names: field=value
+// for equality or field[op]=value with one of crud.APIFilterOperators.
+func (c *CrudAPIController[TEntity]) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !c.authorize(w, r, c.permissions.Read) {
+		return
+	}
+
+	params, err := c.findParams(r)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		return
+	}
+
+	// Fetch one extra entity to find out whether there is another chunk
+	limit := params.Limit
+	params.Limit++
+	entities, err := c.service.List(ctx, params)
+	if err != nil {
+		c.handleError(w, r, err)
+		return
+	}
+
+	var nextCursor string
+	if len(entities) > limit {
+		entities = entities[:limit]
+		nextCursor, err = crud.NextCursor(ctx, c.schema, params.SortBy, entities[len(entities)-1])
+		if err != nil {
+			c.handleError(w, r, err)
+			return
+		}
+	}
+
+	data := make([]map[string]any, 0, len(entities))
+	for _, entity := range entities {
+		item, err := c.toJSON(r, entity)
+		if err != nil {
+			c.handleError(w, r, err)
+			return
+		}
+		data = append(data, item)
+	}
+
+	writeAPIJSON(w, http.StatusOK, map[string]any{
+		"data":        data,
+		"next_cursor": nextCursor,
+	})
+}
+
+func (c *CrudAPIController[TEntity]) Get(w http.ResponseWriter, r *http.Request) {
+	if !c.authorize(w, r, c.permissions.Read) {
+		return
+	}
+
+	key, err := c.keyValue(r)
+	if err != nil {
+		writeAPIError(w, http.StatusNotFound, "NOT_FOUND", err.Error())
+		return
+	}
+
+	entity, err := c.service.Get(r.Context(), key)
+	if err != nil {
+		c.handleError(w, r, err)
+		return
+	}
+	c.writeEntity(w, r, http.StatusOK, entity)
+}
+
+func (c *CrudAPIController[TEntity]) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !c.authorize(w, r, c.permissions.Create) {
+		return
+	}
+
+	fieldValues, ok := c.decodeBody(w, r)
+	if !ok {
+		return
+	}
+
+	present := make(map[string]bool, len(fieldValues))
+	for _, fv := range fieldValues {
+		present[fv.Field().Name()] = true
+	}
+	for _, f := range c.schema.Fields().Fields() {
+		if !present[f.Name()] {
+			fieldValues = append(fieldValues, f.Value(f.InitialValue(ctx)))
+		}
+	}
+
+	c.save(w, r, http.StatusCreated, fieldValues)
+}
+
+// Update changes the fields present in the body and keeps the others. Versioned
+// entities also need the version in the body or the If-Match header.
+func (c *CrudAPIController[TEntity]) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !c.authorize(w, r, c.permissions.Update) {
+		return
+	}
+
+	key, err := c.keyValue(r)
+	if err != nil {
+		writeAPIError(w, http.StatusNotFound, "NOT_FOUND", err.Error())
+		return
+	}
+
+	stored, err := c.service.Get(ctx, key)
+	if err != nil {
+		c.handleError(w, r, err)
+		return
+	}
+	storedValues, err := c.schema.Mapper().ToFieldValues(ctx, stored)
+	if err != nil {
+		c.handleError(w, r, err)
+		return
+	}
+
+	fieldValues, ok := c.decodeBody(w, r)
+	if !ok {
+		return
+	}
+
+	// The key is taken from the path, the fields missing from the body from the stored entity
+	fieldValues = slices.DeleteFunc(fieldValues, func(fv crud.FieldValue) bool {
+		return fv.Field().Key()
+	})
+	fieldValues = append(fieldValues, key)
+	present := make(map[string]bool, len(fieldValues))
+	for _, fv := range fieldValues {
+		present[fv.Field().Name()] = true
+	}
+	// Versioned entities are updated from the version the client read, so that
+	// a concurrent change is reported rather than overwritten
+	if versionField := c.schema.Fields().VersionField(); versionField != nil && !present[versionField.Name()] {
+		version, err := ifMatchVersion(r, versionField)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "VERSION_REQUIRED", err.Error())
+			return
+		}
+		fieldValues = append(fieldValues, version)
+		present[versionField.Name()] = true
+	}
+	for _, fv := range storedValues {
+		if !present[fv.Field().Name()] {
+			fieldValues = append(fieldValues, fv)
+		}
+	}
+
+	c.save(w, r, http.StatusOK, fieldValues)
+}
+
+// ifMatchVersion parses the version in the If-Match header of r, e.g. "3" or W/"3"
+func ifMatchVersion(r *http.Request, versionField crud.Field) (crud.FieldValue, error) {
+	header := strings.TrimPrefix(strings.TrimSpace(r.Header.Get("If-Match")), "W/")
+	header = strings.Trim(header, `"`)
+	if header == "" {
+		return nil, fmt.Errorf("%q is required in the body or the If-Match header", versionField.Name())
+	}
+	version, err := crud.ParseAPIValue(versionField, header)
+	if err != nil {
+		return nil, fmt.Errorf("invalid If-Match header: %w", err)
+	}
+	return version, nil
+}
+
+func (c *CrudAPIController[TEntity]) Delete(w http.ResponseWriter, r *http.Request) {
+	if !c.authorize(w, r, c.permissions.Delete) {
+		return
+	}
+
+	key, err := c.keyValue(r)
+	if err != nil {
+		writeAPIError(w, http.StatusNotFound, "NOT_FOUND", err.Error())
+		return
+	}
+
+	entity, err := c.service.Delete(r.Context(), key)
+	if err != nil {
+		c.handleError(w, r, err)
+		return
+	}
+	c.writeEntity(w, r, http.StatusOK, entity)
+}
+
+// save validates fieldValues and saves the entity they make up
+func (c *CrudAPIController[TEntity]) save(w http.ResponseWriter, r *http.Request, status int, fieldValues []crud.FieldValue) {
+	ctx := r.Context()
+
+	if errs := crud.ValidateFieldValues(fieldValues); len(errs) > 0 {
+		writeAPIValidationErrors(w, errs)
+		return
+	}
+
+	entity, err := c.schema.Mapper().ToEntity(ctx, fieldValues)
+	if err != nil {
+		c.handleError(w, r, err)
+		return
+	}
+
+	saved, err := c.service.Save(ctx, entity)
+	if err != nil {
+		c.handleError(w, r, err)
+		return
+	}
+	c.writeEntity(w, r, status, saved)
+}
+
+// decodeBody parses the JSON object in the request body into values of the
+// writable fields. Other members of the object are ignored.
+func (c *CrudAPIController[TEntity]) decodeBody(w http.ResponseWriter, r *http.Request) ([]crud.FieldValue, bool) {
+	var body map[string]any
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "BAD_REQUEST", fmt.Sprintf("invalid JSON body: %v", err))
+		return nil, false
+	}
+
+	fieldValues := make([]crud.FieldValue, 0, len(body))
+	errs := make(serrors.ValidationErrors)
+	for _, f := range crud.APIFields(c.schema.Fields()) {
+		value, ok := body[f.Name()]
+		if !ok || !crud.IsAPIWritable(f) {
+			continue
+		}
+		fv, err := crud.ParseAPIValue(f, value)
+		if err != nil {
+			errs[f.Name()] = crud.NewFieldValidationError(f, err)
+			continue
+		}
+		fieldValues = append(fieldValues, fv)
+	}
+	if len(errs) > 0 {
+		writeAPIValidationErrors(w, errs)
+		return nil, false
+	}
+	return fieldValues, true
+}
+
+// findParams builds the list parameters from the query string
+func (c *CrudAPIController[TEntity]) findParams(r *http.Request) (*crud.FindParams, error) {
+	query := r.URL.Query()
+	params := &crud.FindParams{
+		Limit:  defaultAPILimit,
+		Cursor: query.Get("cursor"),
+		Query:  query.Get("q"),
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			return nil, fmt.Errorf("invalid limit %q", v)
+		}
+		params.Limit = min(limit, maxAPILimit)
+	}
+	if v := query.Get("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return nil, fmt.Errorf("invalid offset %q", v)
+		}
+		params.Offset = offset
+	}
+
+	// Fields the user may not read can't be filtered or sorted by
+	fields := crud.ReadableFields(r.Context(), crud.APIFields(c.schema.Fields()))
+	apiField := func(name string) (crud.Field, bool) {
+		i := slices.IndexFunc(fields, func(f crud.Field) bool { return f.Name() == name })
+		if i < 0 {
+			return nil, false
+		}
+		return fields[i], true
+	}
+
+	if v := query.Get("sort"); v != "" {
+		for _, name := range strings.Split(v, ",") {
+			name, descending := strings.CutPrefix(name, "-")
+			if f, ok := apiField(name); !ok || crud.IsVirtualField(f) {
+				return nil, fmt.Errorf("can't sort by %q", name)
+			}
+			params.SortBy.Fields = append(params.SortBy.Fields, repo.SortByField[string]{
+				Field:     name,
+				Ascending: !descending,
+			})
+		}
+	}
+
+	if v := query.Get("include"); v != "" {
+		for _, name := range strings.Split(v, ",") {
+			if f, ok := apiField(name); !ok || !crud.IsVirtualField(f) {
+				return nil, fmt.Errorf("can't include %q", name)
+			}
+			params.Include = append(params.Include, name)
+		}
+	}
+
+	for param, values := range query {
+		switch param {
+		case "limit", "offset", "cursor", "q", "sort", "include":
+			continue
+		}
+
+		name, op := param, "eq"
+		if i := strings.IndexByte(param, '['); i > 0 && strings.HasSuffix(param, "]") {
+			name, op = param[:i], param[i+1:len(param)-1]
+		}
+		f, ok := apiField(name)
+		if !ok || crud.IsVirtualField(f) {
+			return nil, fmt.Errorf("can't filter by %q", name)
+		}
+
+		for _, value := range values {
+			args := []any{value}
+			if op == "in" {
+				args = nil
+				for _, v := range strings.Split(value, ",") {
+					args = append(args, v)
+				}
+			}
+			filter, err := crud.APIFilter(f, op, args...)
+			if err != nil {
+				return nil, err
+			}
+			params.Filters = append(params.Filters, crud.Filter{Column: name, Filter: filter})
+		}
+	}
+
+	return params, nil
+}
+
+func (c *CrudAPIController[TEntity]) keyValue(r *http.Request) (crud.FieldValue, error) {
+	return crud.ParseAPIValue(c.schema.Fields().KeyField(), mux.Vars(r)["id"])
+}
+
+// authorize writes a 403 response and returns false unless the user has perm
+func (c *CrudAPIController[TEntity]) authorize(w http.ResponseWriter, r *http.Request, perm rbac.Permission) bool {
+	if perm == nil {
+		return true
+	}
+	if err := composables.CanUserAll(r.Context(), perm); err != nil {
+		writeAPIError(w, http.StatusForbidden, "FORBIDDEN", err.Error())
+		return false
+	}
+	return true
+}
+
+func (c *CrudAPIController[TEntity]) toJSON(r *http.Request, entity TEntity) (map[string]any, error) {
+	fieldValues, err := c.schema.Mapper().ToFieldValues(r.Context(), entity)
+	if err != nil {
+		return nil, err
+	}
+
+	exposed := make(map[string]bool)
+	for _, f := range crud.ReadableFields(r.Context(), crud.APIFields(c.schema.Fields())) {
+		exposed[f.Name()] = true
+	}
+	result := make(map[string]any, len(exposed))
+	for _, fv := range fieldValues {
+		if exposed[fv.Field().Name()] {
+			result[fv.Field().Name()] = crud.APIValue(fv)
+		}
+	}
+	return result, nil
+}
+
+func (c *CrudAPIController[TEntity]) writeEntity(w http.ResponseWriter, r *http.Request, status int, entity TEntity) {
+	item, err := c.toJSON(r, entity)
+	if err != nil {
+		c.handleError(w, r, err)
+		return
+	}
+	writeAPIJSON(w, status, item)
+}
+
+// handleError maps errors of the service to responses
+func (c *CrudAPIController[TEntity]) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	var conflict *serrors.ConflictError
+	switch {
+	case errors.Is(err, composables.ErrForbidden):
+		writeAPIError(w, http.StatusForbidden, "FORBIDDEN", err.Error())
+	case errors.Is(err, crud.ErrNotFound):
+		writeAPIError(w, http.StatusNotFound, "NOT_FOUND", err.Error())
+	case errors.As(err, &conflict):
+		writeAPIError(w, http.StatusConflict, conflict.Code, conflict.Message)
+	case errors.Is(err, repo.ErrVersionRequired):
+		writeAPIError(w, http.StatusBadRequest, "VERSION_REQUIRED", err.Error())
+	case errors.Is(err, crud.ErrValidation):
+		if errs := crud.ValidationErrorsOf(err); len(errs) > 0 {
+			writeAPIValidationErrors(w, errs)
+			return
+		}
+		writeAPIError(w, http.StatusUnprocessableEntity, "VALIDATION_ERROR", err.Error())
+	default:
+		composables.UseLogger(r.Context()).WithError(err).Errorf("%s API request failed", c.schema.Name())
+		writeAPIError(w, http.StatusInternalServerError, "INTERNAL", "internal server error")
+	}
+}
+
+type apiErrorResponse struct {
+	Code    string                   `json:"code"`
+	Message string                   `json:"message"`
+	Errors  serrors.ValidationErrors `json:"errors,omitempty"`
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		panic(err)
+	}
+}
+
+func writeAPIError(w http.ResponseWriter, status int, code, message string) {
+	writeAPIJSON(w, status, apiErrorResponse{Code: code, Message: message})
+}
+
+func writeAPIValidationErrors(w http.ResponseWriter, errs serrors.ValidationErrors) {
+	writeAPIJSON(w, http.StatusUnprocessableEntity, apiErrorResponse{
+		Code:    "VALIDATION_ERROR",
+		Message: "validation failed",
+		Errors:  errs,
+	})
+}
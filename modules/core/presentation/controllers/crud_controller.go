@@ -3,11 +3,13 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -18,6 +20,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/iota-uz/go-i18n/v2/i18n"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
+	"github.com/iota-uz/iota-sdk/modules/core/services"
 	"github.com/iota-uz/iota-sdk/pkg/application"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/crud"
@@ -25,9 +29,12 @@ import (
 	"github.com/iota-uz/iota-sdk/pkg/intl"
 	"github.com/iota-uz/iota-sdk/pkg/middleware"
 	"github.com/iota-uz/iota-sdk/pkg/repo"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
 
 	"github.com/iota-uz/iota-sdk/components/scaffold/actions"
 	"github.com/iota-uz/iota-sdk/components/scaffold/form"
+	"github.com/iota-uz/iota-sdk/components/scaffold/renderers"
 	"github.com/iota-uz/iota-sdk/components/scaffold/table"
 )
 
@@ -41,6 +48,7 @@ const (
 	errEntityNotFound   = "Errors.EntityNotFound"
 	errInternalServer   = "Errors.InternalServer"
 	errFailedToRender   = "Errors.FailedToRender"
+	errForbiddenFields  = "Errors.ForbiddenFields"
 )
 
 type CrudController[TEntity any] struct {
@@ -65,6 +73,9 @@ type CrudController[TEntity any] struct {
 	// custom actions
 	customHeaderActions []actions.ActionProps
 	customRowActions    []func(primaryKey any) actions.ActionProps
+
+	// aggregations shown in the totals rows of the list
+	totals []crud.Aggregation
 }
 
 // CrudOption defines options for CrudController
@@ -112,6 +123,19 @@ func WithCustomRowAction[TEntity any](actionBuilder func(primaryKey any) actions
 	}
 }
 
+// WithTotals shows totals rows above the list, one per function of aggregations,
+// computed over all entities matching the search and filters of the list, e.g.
+//
+//	WithTotals[Order](
+//		crud.Aggregation{Func: crud.AggregateSum, Field: "amount"},
+//		crud.Aggregation{Func: crud.AggregateAvg, Field: "amount"},
+//	)
+func WithTotals[TEntity any](aggregations ...crud.Aggregation) CrudOption[TEntity] {
+	return func(c *CrudController[TEntity]) {
+		c.totals = append(c.totals, aggregations...)
+	}
+}
+
 func NewCrudController[TEntity any](
 	basePath string,
 	app application.Application,
@@ -131,6 +155,9 @@ func NewCrudController[TEntity any](
 		customRowActions:    make([]func(primaryKey any) actions.ActionProps, 0),
 	}
 
+	// Money, enum, file and geopoint fields render with these unless options override them
+	renderers.Register(controller.rendererRegistry, controller.schema.Name(), controller.loadUpload)
+
 	// Apply options
 	for _, opt := range opts {
 		opt(controller)
@@ -172,6 +199,13 @@ func (c *CrudController[TEntity]) Register(r *mux.Router) {
 	if c.enableDelete {
 		router.HandleFunc("/{id}", c.Delete).Methods(http.MethodDelete)
 	}
+
+	if c.schema.History() {
+		router.HandleFunc("/{id}/history", c.History).Methods(http.MethodGet)
+		if c.enableEdit {
+			router.HandleFunc("/{id}/history/{entryID}/revert", c.Revert).Methods(http.MethodPost)
+		}
+	}
 }
 
 func (c *CrudController[TEntity]) Key() string {
@@ -183,6 +217,27 @@ func (c *CrudController[TEntity]) RegisterRenderer(rendererType string, renderer
 	c.rendererRegistry.Register(rendererType, renderer)
 }
 
+// loadUpload loads uploads referenced by file fields
+func (c *CrudController[TEntity]) loadUpload(ctx context.Context, id uint) (upload.Upload, error) {
+	return c.app.Service(services.UploadService{}).(*services.UploadService).GetByID(ctx, id)
+}
+
+// checkUpload checks the upload a file field value references against the field's config
+func (c *CrudController[TEntity]) checkUpload(ctx context.Context, field crud.FileField, fv crud.FieldValue) error {
+	if fv.IsZero() {
+		return nil
+	}
+	id, err := fv.AsUploadID()
+	if err != nil {
+		return err
+	}
+	u, err := c.loadUpload(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to load upload for field %s: %w", field.Name(), err)
+	}
+	return field.Check(u)
+}
+
 // initFieldCache pre-computes commonly used field collections
 func (c *CrudController[TEntity]) initFieldCache() {
 	allFields := c.schema.Fields().Fields()
@@ -195,6 +250,12 @@ func (c *CrudController[TEntity]) initFieldCache() {
 			c.primaryKeyField = f
 		}
 
+		// Versions travel with the form as a hidden input but aren't shown anywhere
+		if crud.IsVersionField(f) {
+			c.formFields = append(c.formFields, f)
+			continue
+		}
+
 		if !f.Hidden() {
 			c.visibleFields = append(c.visibleFields, f)
 
@@ -238,7 +299,8 @@ func (c *CrudController[TEntity]) validateID(id string) error {
 		if _, err := uuid.Parse(id); err != nil {
 			return fmt.Errorf("invalid UUID: %s", id)
 		}
-	case crud.StringFieldType, crud.BoolFieldType, crud.FloatFieldType, crud.DecimalFieldType, crud.DateFieldType, crud.TimeFieldType, crud.DateTimeFieldType, crud.TimestampFieldType, crud.JSONFieldType:
+	case crud.StringFieldType, crud.BoolFieldType, crud.FloatFieldType, crud.DecimalFieldType, crud.DateFieldType, crud.TimeFieldType, crud.DateTimeFieldType, crud.TimestampFieldType, crud.JSONFieldType, crud.RelationFieldType,
+		crud.MoneyFieldType, crud.EnumFieldType, crud.FileFieldType, crud.GeoPointFieldType:
 		// These types don't need special validation for ID format
 	}
 	return nil
@@ -264,7 +326,8 @@ func (c *CrudController[TEntity]) parseIDValue(id string) any {
 		}
 		// If parsing fails, return nil UUID instead of nil
 		return uuid.Nil
-	case crud.StringFieldType, crud.BoolFieldType, crud.FloatFieldType, crud.DecimalFieldType, crud.DateFieldType, crud.TimeFieldType, crud.DateTimeFieldType, crud.TimestampFieldType, crud.JSONFieldType:
+	case crud.StringFieldType, crud.BoolFieldType, crud.FloatFieldType, crud.DecimalFieldType, crud.DateFieldType, crud.TimeFieldType, crud.DateTimeFieldType, crud.TimestampFieldType, crud.JSONFieldType, crud.RelationFieldType,
+		crud.MoneyFieldType, crud.EnumFieldType, crud.FileFieldType, crud.GeoPointFieldType:
 		// For all other types, return the string as-is
 		return id
 	}
@@ -287,8 +350,18 @@ func (c *CrudController[TEntity]) buildFieldValuesFromForm(r *http.Request) ([]c
 			continue
 		}
 
-		// Skip readonly fields - they should not be updated from form data
-		if field.Readonly() {
+		// Skip readonly fields and fields the user may not change - they should not be updated from form data
+		if c.isReadonly(r.Context(), field) {
+			continue
+		}
+
+		// Many-to-many relations submit every selected key
+		if rf, ok := field.(crud.RelationField); ok && rf.Relation() == crud.ManyToMany {
+			records, err := c.parseRelatedKeys(rf, r.Form[fieldName])
+			if err != nil {
+				return nil, err
+			}
+			fieldValues = append(fieldValues, field.Value(records))
 			continue
 		}
 
@@ -337,7 +410,8 @@ func (c *CrudController[TEntity]) buildFieldValuesFromForm(r *http.Request) ([]c
 					continue // Skip empty values
 				}
 			case crud.StringFieldType, crud.DecimalFieldType, crud.DateFieldType,
-				crud.TimeFieldType, crud.DateTimeFieldType, crud.TimestampFieldType, crud.JSONFieldType:
+				crud.TimeFieldType, crud.DateTimeFieldType, crud.TimestampFieldType, crud.JSONFieldType, crud.RelationFieldType,
+				crud.MoneyFieldType, crud.EnumFieldType, crud.FileFieldType, crud.GeoPointFieldType:
 				value = formValue
 			default:
 				// Default to string for any unknown types
@@ -387,7 +461,8 @@ func (c *CrudController[TEntity]) buildFieldValuesFromForm(r *http.Request) ([]c
 							formats = []string{"2006-01-02T15:04", "2006-01-02T15:04:05"}
 						case crud.TimestampFieldType:
 							formats = []string{"2006-01-02T15:04", "2006-01-02T15:04:05", time.RFC3339}
-						case crud.StringFieldType, crud.IntFieldType, crud.BoolFieldType, crud.FloatFieldType, crud.DecimalFieldType, crud.UUIDFieldType, crud.JSONFieldType:
+						case crud.StringFieldType, crud.IntFieldType, crud.BoolFieldType, crud.FloatFieldType, crud.DecimalFieldType, crud.UUIDFieldType, crud.JSONFieldType, crud.RelationFieldType,
+							crud.MoneyFieldType, crud.EnumFieldType, crud.FileFieldType, crud.GeoPointFieldType:
 							// These types are handled elsewhere
 							formats = []string{}
 						}
@@ -437,6 +512,24 @@ func (c *CrudController[TEntity]) buildFieldValuesFromForm(r *http.Request) ([]c
 			case crud.StringFieldType:
 				// String fields are handled as strings from forms
 				value = formValue
+			case crud.RelationFieldType:
+				// Has-many relations are readonly and many-to-many ones are handled above
+				continue
+			case crud.MoneyFieldType, crud.EnumFieldType, crud.FileFieldType, crud.GeoPointFieldType:
+				// Money in major units, enum values, upload IDs and "lat,lng" coordinates
+				if formValue == "" {
+					continue
+				}
+				fv, err := crud.ParseAPIValue(field, formValue)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value for field %s: %v", fieldName, err)
+				}
+				if ff, ok := field.(crud.FileField); ok {
+					if err := c.checkUpload(r.Context(), ff, fv); err != nil {
+						return nil, err
+					}
+				}
+				value = fv.Value()
 			}
 		}
 
@@ -450,7 +543,7 @@ func (c *CrudController[TEntity]) buildFieldValuesFromForm(r *http.Request) ([]c
 			continue
 		}
 
-		if field.Type() == crud.BoolFieldType && !field.Hidden() && !field.Readonly() {
+		if field.Type() == crud.BoolFieldType && !field.Hidden() && !c.isReadonly(r.Context(), field) {
 			// Check if this field was already processed
 			found := false
 			for _, fv := range fieldValues {
@@ -470,6 +563,40 @@ func (c *CrudController[TEntity]) buildFieldValuesFromForm(r *http.Request) ([]c
 	return fieldValues, nil
 }
 
+// parseRelatedKeys converts the keys submitted for a many-to-many field to the
+// type of the target key, skipping the empty value sent when nothing is selected
+func (c *CrudController[TEntity]) parseRelatedKeys(field crud.RelationField, formValues []string) ([]crud.RelatedRecord, error) {
+	keyType := field.Config().Target.Fields().KeyField().Type()
+
+	records := make([]crud.RelatedRecord, 0, len(formValues))
+	for _, formValue := range formValues {
+		if formValue == "" {
+			continue
+		}
+
+		var key any
+		switch keyType {
+		case crud.IntFieldType:
+			intVal, err := strconv.ParseInt(formValue, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid integer value for field %s: %v", field.Name(), err)
+			}
+			key = intVal
+		case crud.UUIDFieldType:
+			uid, err := uuid.Parse(formValue)
+			if err != nil {
+				return nil, fmt.Errorf("invalid UUID value for field %s: %v", field.Name(), err)
+			}
+			key = uid
+		case crud.StringFieldType, crud.BoolFieldType, crud.FloatFieldType, crud.DecimalFieldType, crud.DateFieldType, crud.TimeFieldType, crud.DateTimeFieldType, crud.TimestampFieldType, crud.JSONFieldType, crud.RelationFieldType,
+			crud.MoneyFieldType, crud.EnumFieldType, crud.FileFieldType, crud.GeoPointFieldType:
+			key = formValue
+		}
+		records = append(records, crud.RelatedRecord{Key: key})
+	}
+	return records, nil
+}
+
 func (c *CrudController[TEntity]) List(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -501,56 +628,36 @@ func (c *CrudController[TEntity]) List(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Fetch entities and count in parallel for better performance
-	type listResult struct {
-		entities []TEntity
-		err      error
-	}
-	type countResult struct {
-		count int64
-		err   error
-	}
-
-	listCh := make(chan listResult, 1)
-	countCh := make(chan countResult, 1)
-
-	// Fetch entities
-	go func() {
-		entities, err := c.service.List(ctx, params)
-		listCh <- listResult{entities: entities, err: err}
-	}()
-
-	// Count total items
-	go func() {
-		countParams := &crud.FindParams{
-			Query: params.Query, // Include search query in count
+	// Load the has-many and many-to-many relations shown in the table
+	for _, f := range crud.ReadableFields(ctx, c.visibleFields) {
+		if crud.IsVirtualField(f) {
+			params.Include = append(params.Include, f.Name())
 		}
-		count, err := c.service.Count(ctx, countParams)
-		countCh <- countResult{count: count, err: err}
-	}()
-
-	// Wait for results
-	listRes := <-listCh
-	countRes := <-countCh
+	}
 
-	if listRes.err != nil {
-		log.Printf("[CrudController.List] Failed to list entities: %v", listRes.err)
+	// Fetch one extra entity to find out whether there is another chunk
+	// without counting the whole table
+	params.Cursor = paginationParams.Cursor
+	params.Limit = paginationParams.Limit + 1
+	entities, err := c.service.List(ctx, params)
+	if err != nil {
+		log.Printf("[CrudController.List] Failed to list entities: %v", err)
 		errorMsg, _ := c.localize(ctx, errFailedToRetrieve, "Failed to retrieve data")
 		http.Error(w, errorMsg, http.StatusInternalServerError)
 		return
 	}
 
-	entities := listRes.entities
-	totalCount := countRes.count
-	if countRes.err != nil {
-		log.Printf("[CrudController.List] Failed to count entities: %v", countRes.err)
-		// Non-critical error, continue without infinity scroll
-		totalCount = 0
+	hasMore := len(entities) > paginationParams.Limit
+	var nextCursor string
+	if hasMore {
+		entities = entities[:paginationParams.Limit]
+		nextCursor, err = crud.NextCursor(ctx, c.schema, params.SortBy, entities[len(entities)-1])
+		if err != nil {
+			// Non-critical error, the next chunk falls back to page offsets
+			log.Printf("[CrudController.List] Failed to build next cursor: %v", err)
+		}
 	}
 
-	// Calculate if there are more items
-	hasMore := int64(params.Offset+len(entities)) < totalCount
-
 	// Build the data URL with query parameters preserved
 	dataURL := c.basePath
 	if params.Query != "" || sortField != "" {
@@ -586,12 +693,14 @@ func (c *CrudController[TEntity]) List(w http.ResponseWriter, r *http.Request) {
 			tableTitle,
 			dataURL,
 			table.WithInfiniteScroll(hasMore, paginationParams.Page, paginationParams.Limit),
+			table.WithNextCursor(nextCursor),
 		)
 	}
 
 	// Add columns based on visible fields (needed for all requests to maintain table structure)
-	columns := make([]table.TableColumn, 0, len(c.visibleFields)+1)
-	for _, f := range c.visibleFields {
+	visibleFields := crud.ReadableFields(ctx, c.visibleFields)
+	columns := make([]table.TableColumn, 0, len(visibleFields)+1)
+	for _, f := range visibleFields {
 		// Localize field label using custom key if provided, otherwise use default pattern
 		localizationKey := f.LocalizationKey()
 		if localizationKey == "" {
@@ -608,6 +717,7 @@ func (c *CrudController[TEntity]) List(w http.ResponseWriter, r *http.Request) {
 		// Remove pagination params as they should reset on sort
 		currentParams.Del("page")
 		currentParams.Del("limit")
+		currentParams.Del("cursor")
 
 		// Only enable sorting for explicitly sortable fields
 		col := table.Column(f.Name(), fieldLabel)
@@ -638,14 +748,19 @@ func (c *CrudController[TEntity]) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Convert entities to table rows
+	entityValues := make([][]crud.FieldValue, 0, len(entities))
 	for _, entity := range entities {
 		fieldValues, err := c.schema.Mapper().ToFieldValues(ctx, entity)
 		if err != nil {
 			log.Printf("[CrudController.List] Failed to map entity: %v", err)
 			continue
 		}
+		entityValues = append(entityValues, fieldValues)
+	}
 
-		row, err := c.buildTableRow(ctx, fieldValues)
+	related := c.loadBelongsTo(ctx, entityValues)
+	for _, fieldValues := range entityValues {
+		row, err := c.buildTableRow(ctx, fieldValues, related)
 		if err != nil {
 			log.Printf("[CrudController.List] Failed to build row: %v", err)
 			continue
@@ -653,10 +768,22 @@ func (c *CrudController[TEntity]) List(w http.ResponseWriter, r *http.Request) {
 		cfg.AddRows(row)
 	}
 
+	// Totals cover the whole list, so they're left out of the next chunks
+	if len(c.totals) > 0 && paginationParams.Page <= 1 {
+		totals, err := c.buildTotals(ctx, params, visibleFields)
+		if err != nil {
+			// Non-critical error, the list is shown without totals
+			log.Printf("[CrudController.List] Failed to compute totals: %v", err)
+		} else {
+			cfg.AddTotals(totals...)
+		}
+	}
+
 	// For HTMX requests, also configure infinity scroll
 	if htmx.IsHxRequest(r) && hasMore {
 		// Apply infinity scroll configuration for subsequent requests
 		table.WithInfiniteScroll(hasMore, paginationParams.Page, paginationParams.Limit)(cfg)
+		table.WithNextCursor(nextCursor)(cfg)
 	}
 
 	// Render response using ContentHTMX for proper HTMX handling
@@ -722,8 +849,9 @@ func (c *CrudController[TEntity]) Details(w http.ResponseWriter, r *http.Request
 	}
 
 	// Map field values to detail field values
-	detailFields := make([]table.DetailFieldValue, 0, len(c.visibleFields))
-	for _, field := range c.visibleFields {
+	visibleFields := crud.ReadableFields(ctx, c.visibleFields)
+	detailFields := make([]table.DetailFieldValue, 0, len(visibleFields))
+	for _, field := range visibleFields {
 		if fv, exists := fieldValueMap[field.Name()]; exists {
 			// Localize field label using custom key if provided, otherwise use default pattern
 			localizationKey := field.LocalizationKey()
@@ -757,6 +885,8 @@ func (c *CrudController[TEntity]) Details(w http.ResponseWriter, r *http.Request
 						}
 						fieldType = table.DetailFieldTypeHTML
 					}
+				} else if rf, ok := field.(crud.RelationField); ok {
+					valueStr, fieldType = c.relationDetail(ctx, rf, fv)
 				} else if selectField, ok := field.(crud.SelectField); ok {
 					// Get options
 					options := selectField.Options()
@@ -815,9 +945,12 @@ func (c *CrudController[TEntity]) Details(w http.ResponseWriter, r *http.Request
 					case crud.UUIDFieldType:
 						valueStr = fmt.Sprintf("%v", fv.Value())
 						fieldType = table.DetailFieldTypeText
-					case crud.JSONFieldType:
+					case crud.JSONFieldType, crud.RelationFieldType:
 						valueStr = fmt.Sprintf("%v", fv.Value())
 						fieldType = table.DetailFieldTypeText
+					case crud.MoneyFieldType, crud.EnumFieldType, crud.FileFieldType, crud.GeoPointFieldType:
+						valueStr = crud.FormValue(fv)
+						fieldType = table.DetailFieldTypeText
 					default:
 						valueStr = fmt.Sprintf("%v", fv.Value())
 						fieldType = table.DetailFieldTypeText
@@ -837,6 +970,17 @@ func (c *CrudController[TEntity]) Details(w http.ResponseWriter, r *http.Request
 	// Build actions
 	var actions []table.DetailAction
 
+	if c.schema.History() {
+		historyLabel, _ := c.localize(ctx, "Scaffold.History.Title", "History")
+		actions = append(actions, table.DetailAction{
+			Label:  historyLabel,
+			URL:    fmt.Sprintf("%s/%v/history", c.basePath, primaryKey),
+			Method: "GET",
+			Class:  "btn-secondary",
+			Target: "#view-drawer",
+		})
+	}
+
 	if c.enableEdit {
 		editLabel, _ := c.localize(ctx, "Edit", "Edit")
 		actions = append(actions, table.DetailAction{
@@ -880,10 +1024,121 @@ func (c *CrudController[TEntity]) Details(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// buildTableRow creates a table row from field values
-func (c *CrudController[TEntity]) buildTableRow(ctx context.Context, fieldValues []crud.FieldValue) (table.TableRow, error) {
+// relationDetail renders the related records of a relation field for the details drawer:
+// labels for belongs-to and many-to-many fields and a nested table for has-many ones
+func (c *CrudController[TEntity]) relationDetail(ctx context.Context, field crud.RelationField, value crud.FieldValue) (string, table.DetailFieldType) {
+	switch field.Relation() {
+	case crud.BelongsTo:
+		related, err := field.Load(ctx, []any{value.Value()})
+		if err != nil {
+			log.Printf("[CrudController.Details] Failed to load %s: %v", field.Name(), err)
+		}
+		return c.relationLabel(field, value, related), table.DetailFieldTypeText
+	case crud.ManyToMany:
+		return c.relationLabel(field, value, nil), table.DetailFieldTypeText
+	case crud.HasMany:
+	}
+
+	records, err := value.AsRelated()
+	if err != nil || len(records) == 0 {
+		return "", table.DetailFieldTypeText
+	}
+
+	target := field.Config().Target
+	props := table.RelatedTableProps{}
+	columns := make([]crud.Field, 0, len(target.Fields().Fields()))
+	for _, f := range target.Fields().Fields() {
+		if f.Hidden() || crud.IsVirtualField(f) {
+			continue
+		}
+		localizationKey := f.LocalizationKey()
+		if localizationKey == "" {
+			localizationKey = fmt.Sprintf("%s.Fields.%s", target.Name(), f.Name())
+		}
+		label, err := c.localize(ctx, localizationKey, f.Name())
+		if err != nil {
+			label = f.Name()
+		}
+		columns = append(columns, f)
+		props.Columns = append(props.Columns, label)
+	}
+	for _, record := range records {
+		row := make([]string, len(columns))
+		for i, f := range columns {
+			if v := record.Values[f.Name()]; v != nil {
+				row[i] = c.convertValueToString(v, f.Type())
+			}
+		}
+		props.Rows = append(props.Rows, row)
+	}
+
+	var htmlBuffer strings.Builder
+	if err := table.RelatedTable(props).Render(ctx, &htmlBuffer); err != nil {
+		log.Printf("[CrudController.Details] Failed to render %s: %v", field.Name(), err)
+		return "", table.DetailFieldTypeText
+	}
+	return htmlBuffer.String(), table.DetailFieldTypeHTML
+}
+
+// loadBelongsTo fetches the records referenced by the visible belongs-to fields
+// of all rows with one query per field
+func (c *CrudController[TEntity]) loadBelongsTo(ctx context.Context, rows [][]crud.FieldValue) map[string]crud.RelatedRecords {
+	related := make(map[string]crud.RelatedRecords)
+	for _, field := range crud.ReadableFields(ctx, c.visibleFields) {
+		rf, ok := field.(crud.RelationField)
+		if !ok || rf.Relation() != crud.BelongsTo {
+			continue
+		}
+
+		keys := make([]any, 0, len(rows))
+		for _, fieldValues := range rows {
+			for _, fv := range fieldValues {
+				if fv.Field().Name() == field.Name() && !fv.IsZero() {
+					keys = append(keys, fv.Value())
+				}
+			}
+		}
+		if len(keys) == 0 {
+			continue
+		}
+
+		records, err := rf.Load(ctx, keys)
+		if err != nil {
+			log.Printf("[CrudController.List] Failed to load %s: %v", field.Name(), err)
+			continue
+		}
+		related[field.Name()] = records
+	}
+	return related
+}
+
+// relationLabel returns the labels of the records a relation field value links to.
+// Belongs-to labels are looked up in related, falling back to the stored key.
+func (c *CrudController[TEntity]) relationLabel(field crud.RelationField, value crud.FieldValue, related crud.RelatedRecords) string {
+	if !field.Virtual() {
+		if records := related.For(value.Value()); len(records) > 0 {
+			return records[0].Label
+		}
+		return c.convertValueToString(value.Value(), field.Type())
+	}
+
+	records, err := value.AsRelated()
+	if err != nil {
+		return ""
+	}
+	labels := make([]string, len(records))
+	for i, record := range records {
+		labels[i] = record.Label
+	}
+	return strings.Join(labels, ", ")
+}
+
+// buildTableRow creates a table row from field values, with related holding the
+// records of belongs-to fields by field name
+func (c *CrudController[TEntity]) buildTableRow(ctx context.Context, fieldValues []crud.FieldValue, related map[string]crud.RelatedRecords) (table.TableRow, error) {
 	var primaryKey any
-	cells := make([]table.TableCell, 0, len(c.visibleFields)+1)
+	visibleFields := crud.ReadableFields(ctx, c.visibleFields)
+	cells := make([]table.TableCell, 0, len(visibleFields)+1)
 
 	// Create a map for quick field value lookup
 	fieldValueMap := make(map[string]crud.FieldValue, len(fieldValues))
@@ -895,11 +1150,17 @@ func (c *CrudController[TEntity]) buildTableRow(ctx context.Context, fieldValues
 	}
 
 	// Build components in the order of visible fields
-	for _, field := range c.visibleFields {
-		if fv, exists := fieldValueMap[field.Name()]; exists {
-			cells = append(cells, table.Cell(c.fieldValueToTableCell(ctx, field, fv), fv.Value()))
-		} else {
+	for _, field := range visibleFields {
+		fv, exists := fieldValueMap[field.Name()]
+		if !exists {
 			cells = append(cells, table.Cell(templ.Raw(""), ""))
+			continue
+		}
+		if rf, ok := field.(crud.RelationField); ok && field.RendererType() == "" && !fv.IsZero() {
+			label := c.relationLabel(rf, fv, related[field.Name()])
+			cells = append(cells, table.Cell(templ.Raw(label), label))
+		} else {
+			cells = append(cells, table.Cell(c.fieldValueToTableCell(ctx, field, fv), fv.Value()))
 		}
 	}
 
@@ -917,6 +1178,85 @@ func (c *CrudController[TEntity]) buildTableRow(ctx context.Context, fieldValues
 	return table.Row(cells...).ApplyOpts(table.WithDrawer(fetchUrl)), nil
 }
 
+// buildTotals computes the totals of the entities matching the search and filters of
+// params and returns a row for each aggregate function, with the results under the
+// columns of fields. Aggregations of fields the user may not read are left out.
+func (c *CrudController[TEntity]) buildTotals(ctx context.Context, params *crud.FindParams, fields []crud.Field) ([]table.TableRow, error) {
+	var (
+		aggregations []crud.Aggregation
+		funcs        []crud.AggregateFunc
+	)
+	for _, a := range c.totals {
+		if a.Field != "" {
+			f, err := c.schema.Fields().Field(a.Field)
+			if err != nil || !crud.CanReadField(ctx, f) {
+				continue
+			}
+		}
+		aggregations = append(aggregations, a)
+		if !slices.Contains(funcs, a.Func) {
+			funcs = append(funcs, a.Func)
+		}
+	}
+	if len(aggregations) == 0 {
+		return nil, nil
+	}
+
+	results, err := c.service.Aggregate(ctx, &crud.FindParams{
+		Query:   params.Query,
+		Filters: params.Filters,
+		Trashed: params.Trashed,
+	}, crud.AggregateQuery{Aggregations: aggregations})
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	rows := make([]table.TableRow, 0, len(funcs))
+	for _, fn := range funcs {
+		cells := make([]table.TableCell, 0, len(fields)+1)
+		for i, f := range fields {
+			idx := slices.IndexFunc(aggregations, func(a crud.Aggregation) bool {
+				return a.Func == fn && a.Field == f.Name()
+			})
+			switch {
+			case idx >= 0:
+				value := results[0].Values[aggregations[idx].Name()]
+				cells = append(cells, table.Cell(c.totalCell(ctx, f, fn, value), value))
+			case i == 0:
+				label, _ := c.localize(ctx, "Scaffold.Totals."+string(fn), string(fn))
+				cells = append(cells, table.Cell(templ.Raw(label), label))
+			default:
+				cells = append(cells, table.Cell(templ.Raw(""), nil))
+			}
+		}
+		if c.enableEdit || c.enableDelete {
+			cells = append(cells, table.Cell(templ.Raw(""), nil))
+		}
+		rows = append(rows, table.Row(cells...))
+	}
+	return rows, nil
+}
+
+// totalCell renders the result of fn over field in a totals row
+func (c *CrudController[TEntity]) totalCell(ctx context.Context, field crud.Field, fn crud.AggregateFunc, value any) templ.Component {
+	if value == nil {
+		return templ.Raw("")
+	}
+	switch fn {
+	case crud.AggregateCount:
+		return templ.Raw(fmt.Sprint(value))
+	case crud.AggregateAvg:
+		// Averages of int and float fields are floats, decimal and money ones keep their type
+		if f, ok := value.(float64); ok {
+			return templ.Raw(strconv.FormatFloat(f, 'f', 2, 64))
+		}
+	}
+	return c.fieldValueToTableCell(ctx, field, field.Value(value))
+}
+
 // buildHeaderActions creates header actions for the list view
 func (c *CrudController[TEntity]) buildHeaderActions(ctx context.Context) []actions.ActionProps {
 	// Pre-allocate slice with estimated capacity
@@ -1021,8 +1361,9 @@ func (c *CrudController[TEntity]) buildFormFields(ctx context.Context, fieldValu
 		}
 	}
 
-	formFields := make([]form.Field, 0, len(c.formFields))
-	for _, f := range c.formFields {
+	fields := crud.ReadableFields(ctx, c.formFields)
+	formFields := make([]form.Field, 0, len(fields))
+	for _, f := range fields {
 		// Get current value if available
 		var currentValue crud.FieldValue
 		if fieldValueMap != nil {
@@ -1156,6 +1497,12 @@ func (c *CrudController[TEntity]) Create(w http.ResponseWriter, r *http.Request)
 	if err != nil {
 		log.Printf("[CrudController.Create] Failed to save entity: %v", err)
 
+		if errors.Is(err, composables.ErrForbidden) {
+			errorMsg, _ := c.localize(ctx, errForbiddenFields, "You don't have permission to change some of these fields.")
+			http.Error(w, errorMsg, http.StatusForbidden)
+			return
+		}
+
 		// Check if it's a validation error
 		if c.handleValidationError(w, r, ctx, err, fieldValues, true) {
 			return
@@ -1243,6 +1590,14 @@ func (c *CrudController[TEntity]) Update(w http.ResponseWriter, r *http.Request)
 		existingFields[fv.Field().Name()] = true
 	}
 
+	// The version must come from the form, taking the stored one would hide stale saves
+	if versionField := c.schema.Fields().VersionField(); versionField != nil && !existingFields[versionField.Name()] {
+		log.Printf("[CrudController.Update] Missing version of entity %s", id)
+		errorMsg, _ := c.localize(ctx, errInvalidFormData, "Invalid form data")
+		http.Error(w, errorMsg, http.StatusBadRequest)
+		return
+	}
+
 	for _, fv := range dbFvs {
 		_, ok := existingFields[fv.Field().Name()]
 		if !ok {
@@ -1275,6 +1630,16 @@ func (c *CrudController[TEntity]) Update(w http.ResponseWriter, r *http.Request)
 	if err != nil {
 		log.Printf("[CrudController.Update] Failed to update entity %s: %v", id, err)
 
+		if shared.RenderConflict(w, r, err) {
+			return
+		}
+
+		if errors.Is(err, composables.ErrForbidden) {
+			errorMsg, _ := c.localize(ctx, errForbiddenFields, "You don't have permission to change some of these fields.")
+			http.Error(w, errorMsg, http.StatusForbidden)
+			return
+		}
+
 		// Check if it's a validation error
 		if c.handleValidationError(w, r, ctx, err, fieldValues, false) {
 			return
@@ -1328,8 +1693,212 @@ func (c *CrudController[TEntity]) Delete(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// History renders the timeline of the changes recorded for an entity in a drawer
+func (c *CrudController[TEntity]) History(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := mux.Vars(r)["id"]
+
+	if err := c.validateID(id); err != nil {
+		log.Printf("[CrudController.History] Invalid ID format %s: %v", id, err)
+		errorMsg, _ := c.localize(ctx, errInvalidFormData, "Invalid ID format")
+		http.Error(w, errorMsg, http.StatusBadRequest)
+		return
+	}
+
+	entries, err := c.service.History(ctx, c.primaryKeyField.Value(c.parseIDValue(id)))
+	if err != nil {
+		log.Printf("[CrudController.History] Failed to load history of %s: %v", id, err)
+		errorMsg, _ := c.localize(ctx, errFailedToRetrieve, "Failed to retrieve data")
+		http.Error(w, errorMsg, http.StatusInternalServerError)
+		return
+	}
+
+	title, _ := c.localize(ctx, "Scaffold.History.Title", "History")
+	drawerProps := table.HistoryDrawerProps{
+		ID:          fmt.Sprintf("drawer-%d", time.Now().UnixNano()),
+		Title:       title,
+		CallbackURL: c.basePath,
+		DetailsURL:  fmt.Sprintf("%s/%s/details", c.basePath, id),
+		Entries:     c.historyEntries(ctx, id, entries),
+	}
+
+	if err := table.HistoryDrawer(drawerProps).Render(ctx, w); err != nil {
+		log.Printf("[CrudController.History] Failed to render history: %v", err)
+		errorMsg, _ := c.localize(ctx, errFailedToRender, "Failed to render view")
+		http.Error(w, errorMsg, http.StatusInternalServerError)
+	}
+}
+
+// Revert saves an entity as it was after one of the entries of its history
+func (c *CrudController[TEntity]) Revert(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	if err := c.validateID(id); err != nil {
+		log.Printf("[CrudController.Revert] Invalid ID format %s: %v", id, err)
+		errorMsg, _ := c.localize(ctx, errInvalidFormData, "Invalid ID format")
+		http.Error(w, errorMsg, http.StatusBadRequest)
+		return
+	}
+	entryID, err := strconv.ParseInt(vars["entryID"], 10, 64)
+	if err != nil {
+		log.Printf("[CrudController.Revert] Invalid history entry ID %s: %v", vars["entryID"], err)
+		errorMsg, _ := c.localize(ctx, errInvalidFormData, "Invalid ID format")
+		http.Error(w, errorMsg, http.StatusBadRequest)
+		return
+	}
+
+	if _, err := c.service.Revert(ctx, c.primaryKeyField.Value(c.parseIDValue(id)), entryID); err != nil {
+		log.Printf("[CrudController.Revert] Failed to revert entity %s to history entry %d: %v", id, entryID, err)
+
+		if shared.RenderConflict(w, r, err) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, crud.ErrNotFound):
+			errorMsg, _ := c.localize(ctx, errEntityNotFound, "Entity not found")
+			http.Error(w, errorMsg, http.StatusNotFound)
+		case errors.Is(err, composables.ErrForbidden):
+			errorMsg, _ := c.localize(ctx, errForbiddenFields, "You don't have permission to change some of these fields.")
+			http.Error(w, errorMsg, http.StatusForbidden)
+		default:
+			errorMsg, _ := c.localize(ctx, errFailedToUpdate, "Failed to update data")
+			http.Error(w, errorMsg, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if htmx.IsHxRequest(r) {
+		w.Header().Set("HX-Redirect", c.basePath)
+	} else {
+		http.Redirect(w, r, c.basePath, http.StatusSeeOther)
+	}
+}
+
+// historyEntries converts the history of the entity with id to the entries of the
+// timeline. Every entry but the newest, which is the current state, can be reverted to.
+func (c *CrudController[TEntity]) historyEntries(ctx context.Context, id string, entries []crud.HistoryEntry) []table.HistoryEntry {
+	systemLabel, _ := c.localize(ctx, "Scaffold.History.System", "System")
+
+	result := make([]table.HistoryEntry, len(entries))
+	for i, entry := range entries {
+		action, _ := c.localize(ctx, "Scaffold.History.Actions."+string(entry.Action), string(entry.Action))
+		user := strings.TrimSpace(entry.UserName)
+		if user == "" {
+			user = systemLabel
+		}
+
+		changes := make([]table.HistoryChange, 0, len(entry.Changes))
+		for _, change := range entry.Changes {
+			field, err := c.schema.Fields().Field(change.Field)
+			if err != nil {
+				continue
+			}
+			localizationKey := field.LocalizationKey()
+			if localizationKey == "" {
+				localizationKey = fmt.Sprintf("%s.Fields.%s", c.schema.Name(), field.Name())
+			}
+			label, err := c.localize(ctx, localizationKey, field.Name())
+			if err != nil {
+				label = field.Name()
+			}
+			changes = append(changes, table.HistoryChange{
+				Label: label,
+				Old:   c.historyValue(ctx, field, change.Old),
+				New:   c.historyValue(ctx, field, change.New),
+			})
+		}
+
+		result[i] = table.HistoryEntry{
+			Action:    action,
+			User:      user,
+			CreatedAt: entry.CreatedAt.Format("2006-01-02 15:04:05"),
+			Changes:   changes,
+		}
+		if c.enableEdit && i > 0 && entry.Action != crud.HistoryDeleted {
+			result[i].RevertURL = fmt.Sprintf("%s/%s/history/%d/revert", c.basePath, id, entry.ID)
+		}
+	}
+	return result
+}
+
+// historyValue converts a value of field recorded in a history entry to the text
+// the timeline shows for it
+func (c *CrudController[TEntity]) historyValue(ctx context.Context, field crud.Field, value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []any:
+		// Relations are recorded as related records with a key and label
+		labels := make([]string, 0, len(v))
+		for _, item := range v {
+			record, ok := item.(map[string]any)
+			if !ok {
+				labels = append(labels, fmt.Sprint(item))
+				continue
+			}
+			if label, _ := record["label"].(string); label != "" {
+				labels = append(labels, label)
+			} else {
+				labels = append(labels, fmt.Sprint(record["key"]))
+			}
+		}
+		return strings.Join(labels, ", ")
+	case map[string]any:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	}
+
+	fv, err := crud.HistoryValue(field, value)
+	if err != nil || fv.IsZero() {
+		return fmt.Sprint(value)
+	}
+	if selectField, ok := field.(crud.SelectField); ok {
+		options := selectField.Options()
+		if options == nil && selectField.OptionsLoader() != nil {
+			options = selectField.OptionsLoader()(ctx)
+		}
+		for _, opt := range options {
+			if c.compareSelectValues(opt.Value, fv.Value(), selectField.ValueType()) {
+				return opt.Label
+			}
+		}
+	}
+	if t, ok := fv.Value().(time.Time); ok {
+		switch field.Type() {
+		case crud.DateFieldType:
+			return t.Format("2006-01-02")
+		case crud.TimeFieldType:
+			return t.Format("15:04:05")
+		default:
+			return t.Format("2006-01-02 15:04:05")
+		}
+	}
+	return fmt.Sprint(value)
+}
+
+// isReadonly reports whether forms show field as readonly, which they do for
+// readonly fields and fields the user in ctx may not change
+func (c *CrudController[TEntity]) isReadonly(ctx context.Context, field crud.Field) bool {
+	return field.Readonly() || !crud.CanWriteField(ctx, field)
+}
+
 // fieldToFormFieldWithValue creates a form field with a value if provided
 func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context, field crud.Field, value crud.FieldValue) form.Field {
+	// Submit the version the form was rendered with so stale saves are detected
+	if crud.IsVersionField(field) {
+		builder := form.Hidden(field.Name())
+		if value != nil && !value.IsZero() {
+			builder = builder.Default(fmt.Sprint(value.Value()))
+		}
+		return builder.Build()
+	}
+
 	// Skip hidden fields
 	if field.Hidden() {
 		return nil
@@ -1371,6 +1940,10 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 		}
 	}
 
+	if rf, ok := field.(crud.RelationField); ok {
+		return c.handleRelationField(ctx, rf, fieldLabel, currentValue)
+	}
+
 	switch field.Type() {
 	case crud.StringFieldType:
 		// Check if this is actually a select field
@@ -1401,7 +1974,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 				textareaBuilder = textareaBuilder.MinLen(sf.MinLen())
 			}
 
-			if field.Readonly() {
+			if c.isReadonly(ctx, field) {
 				textareaBuilder = textareaBuilder.Attrs(templ.Attributes{"disabled": true})
 			}
 
@@ -1418,7 +1991,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 			return textareaBuilder.Build()
 		}
 
-		if field.Readonly() {
+		if c.isReadonly(ctx, field) {
 			builder = builder.Attrs(templ.Attributes{"disabled": true})
 		}
 
@@ -1454,7 +2027,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 			builder = builder.Max(float64(intField.Max()))
 		}
 
-		if field.Readonly() {
+		if c.isReadonly(ctx, field) {
 			builder = builder.Attrs(templ.Attributes{"disabled": true})
 		}
 
@@ -1483,7 +2056,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 
 		builder := form.Checkbox(field.Name(), fieldLabel)
 
-		if field.Readonly() {
+		if c.isReadonly(ctx, field) {
 			builder = builder.Attrs(templ.Attributes{"disabled": true})
 		}
 
@@ -1521,7 +2094,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 			attrs["step"] = "any"
 		}
 
-		if field.Readonly() {
+		if c.isReadonly(ctx, field) {
 			attrs["disabled"] = true
 		}
 
@@ -1552,7 +2125,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 			}
 		}
 
-		if field.Readonly() {
+		if c.isReadonly(ctx, field) {
 			builder = builder.Attrs(templ.Attributes{"disabled": true})
 		}
 
@@ -1571,7 +2144,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 	case crud.TimeFieldType:
 		builder := form.Time(field.Name(), fieldLabel)
 
-		if field.Readonly() {
+		if c.isReadonly(ctx, field) {
 			builder = builder.Attrs(templ.Attributes{"disabled": true})
 		}
 
@@ -1600,7 +2173,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 			}
 		}
 
-		if field.Readonly() {
+		if c.isReadonly(ctx, field) {
 			builder = builder.Attrs(templ.Attributes{"disabled": true})
 		}
 
@@ -1624,7 +2197,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 
 		builder := form.Text(field.Name(), fieldLabel)
 
-		if field.Readonly() {
+		if c.isReadonly(ctx, field) {
 			builder = builder.Attrs(templ.Attributes{"disabled": true})
 		}
 
@@ -1647,7 +2220,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 		// Timestamp fields are treated like datetime fields
 		builder := form.DateTime(field.Name(), fieldLabel)
 
-		if field.Readonly() {
+		if c.isReadonly(ctx, field) {
 			builder = builder.Attrs(templ.Attributes{"disabled": true})
 		}
 
@@ -1694,7 +2267,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 			attrs["step"] = "any"
 		}
 
-		if field.Readonly() {
+		if c.isReadonly(ctx, field) {
 			attrs["disabled"] = true
 		}
 
@@ -1728,7 +2301,7 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 		// Handle JSON field as a textarea for editing
 		builder := form.Textarea(field.Name(), fieldLabel)
 
-		if field.Readonly() {
+		if c.isReadonly(ctx, field) {
 			builder = builder.Attrs(templ.Attributes{"disabled": true})
 		}
 
@@ -1763,6 +2336,75 @@ func (c *CrudController[TEntity]) fieldToFormFieldWithValue(ctx context.Context,
 	}
 }
 
+// handleRelationField renders belongs-to fields as selects of the target records and
+// many-to-many fields as multiple selects. Has-many relations are edited from the
+// target schema, so they have no form field.
+func (c *CrudController[TEntity]) handleRelationField(ctx context.Context, field crud.RelationField, fieldLabel string, currentValue any) form.Field {
+	config := field.Config()
+	keyType := config.Target.Fields().KeyField().Type()
+
+	var selected []string
+	switch field.Relation() {
+	case crud.HasMany:
+		return nil
+	case crud.BelongsTo:
+		if currentValue != nil {
+			selected = append(selected, c.convertValueToString(currentValue, keyType))
+		}
+		if config.Endpoint != "" {
+			builder := form.SearchSelect().
+				Key(field.Name()).
+				Label(fieldLabel).
+				Endpoint(config.Endpoint)
+
+			if c.isReadonly(ctx, field) {
+				builder = builder.Attrs(templ.Attributes{"disabled": true})
+			}
+			if len(field.Rules()) > 0 {
+				builder = builder.WithRequired(true)
+			}
+			if len(selected) > 0 {
+				builder = builder.WithValue(selected[0])
+			}
+			return builder.Build()
+		}
+	case crud.ManyToMany:
+		if records, ok := currentValue.([]crud.RelatedRecord); ok {
+			for _, key := range crud.RelatedKeys(records) {
+				selected = append(selected, c.convertValueToString(key, keyType))
+			}
+		}
+	}
+
+	options, err := field.Options(ctx)
+	if err != nil {
+		log.Printf("[CrudController] Failed to load options of %s: %v", field.Name(), err)
+	}
+	formOptions := make([]form.Option, len(options))
+	for i, opt := range options {
+		formOptions[i] = form.Option{
+			Value: c.convertValueToString(opt.Value, keyType),
+			Label: opt.Label,
+		}
+	}
+
+	builder := form.Select(field.Name(), fieldLabel).Options(formOptions)
+	if field.Relation() == crud.ManyToMany {
+		builder = builder.Multiple(selected...)
+	} else if len(selected) > 0 {
+		builder = builder.Default(selected[0])
+	}
+
+	if c.isReadonly(ctx, field) {
+		builder = builder.Attrs(templ.Attributes{"disabled": true})
+	}
+	if len(field.Rules()) > 0 {
+		builder = builder.Required()
+	}
+
+	return builder.Build()
+}
+
 // handleSelectField processes select fields and returns appropriate form fields
 func (c *CrudController[TEntity]) handleSelectField(ctx context.Context, selectField crud.SelectField, fieldLabel string, currentValue any) form.Field {
 	// Convert current value to string for comparison
@@ -1815,7 +2457,7 @@ func (c *CrudController[TEntity]) handleSelectField(ctx context.Context, selectF
 			builder = builder.Attrs(templ.Attributes{"data-placeholder": selectField.Placeholder()})
 		}
 
-		if selectField.Readonly() {
+		if c.isReadonly(ctx, selectField) {
 			builder = builder.Attrs(templ.Attributes{"disabled": true})
 		}
 
@@ -1836,7 +2478,7 @@ func (c *CrudController[TEntity]) handleSelectField(ctx context.Context, selectF
 			Endpoint(selectField.Endpoint()).
 			Placeholder(selectField.Placeholder())
 
-		if selectField.Readonly() {
+		if c.isReadonly(ctx, selectField) {
 			builder = builder.Attrs(templ.Attributes{"disabled": true})
 		}
 
@@ -1858,7 +2500,7 @@ func (c *CrudController[TEntity]) handleSelectField(ctx context.Context, selectF
 			Placeholder(selectField.Placeholder()).
 			Multiple(selectField.Multiple())
 
-		if selectField.Readonly() {
+		if c.isReadonly(ctx, selectField) {
 			builder = builder.Attrs(templ.Attributes{"disabled": true})
 		}
 
@@ -1910,12 +2552,23 @@ func (c *CrudController[TEntity]) convertValueToString(value any, fieldType crud
 	case crud.JSONFieldType:
 		// For JSON fields, return as string
 		return fmt.Sprintf("%v", value)
+	case crud.RelationFieldType:
+		// Related records are shown by their labels
+		if records, ok := value.([]crud.RelatedRecord); ok {
+			labels := make([]string, len(records))
+			for i, record := range records {
+				labels[i] = record.Label
+			}
+			return strings.Join(labels, ", ")
+		}
 	case crud.DateFieldType, crud.TimeFieldType, crud.DateTimeFieldType, crud.TimestampFieldType:
 		// For date/time types, format as string
 		if t, ok := value.(time.Time); ok {
 			return t.Format(time.RFC3339)
 		}
 		return fmt.Sprintf("%v", value)
+	case crud.MoneyFieldType, crud.EnumFieldType, crud.FileFieldType, crud.GeoPointFieldType:
+		return fmt.Sprintf("%v", value)
 	}
 
 	// Default: convert to string
@@ -2038,7 +2691,8 @@ func (c *CrudController[TEntity]) compareSelectValues(optionValue, fieldValue an
 		// Fallback to string comparison
 		return fmt.Sprintf("%v", optionValue) == fmt.Sprintf("%v", fieldValue)
 
-	case crud.JSONFieldType:
+	case crud.JSONFieldType, crud.RelationFieldType,
+		crud.MoneyFieldType, crud.EnumFieldType, crud.FileFieldType, crud.GeoPointFieldType:
 		// For JSON fields, use string comparison
 		return fmt.Sprintf("%v", optionValue) == fmt.Sprintf("%v", fieldValue)
 
@@ -2187,28 +2841,70 @@ func (c *CrudController[TEntity]) fieldValueToTableCell(ctx context.Context, fie
 		}
 		return templ.Raw(jsonStr)
 
+	case crud.RelationFieldType:
+		return templ.Raw(c.convertValueToString(value.Value(), crud.RelationFieldType))
+
+	case crud.MoneyFieldType, crud.EnumFieldType, crud.FileFieldType, crud.GeoPointFieldType:
+		return templ.Raw(templ.EscapeString(crud.FormValue(value)))
+
 	default:
 		return templ.Raw(fmt.Sprintf("%v", value.Value()))
 	}
 }
 
 // validateFieldValues validates field values against their field rules
-func (c *CrudController[TEntity]) validateFieldValues(fieldValues []crud.FieldValue) map[string]string {
+func (c *CrudController[TEntity]) validateFieldValues(ctx context.Context, fieldValues []crud.FieldValue) map[string]string {
 	errors := make(map[string]string)
 
-	for _, fv := range fieldValues {
-		field := fv.Field()
-		for _, rule := range field.Rules() {
-			if err := rule(fv); err != nil {
-				errors[field.Name()] = err.Error()
-				break // Only report first error per field
-			}
-		}
+	// Only the first error of every field is reported
+	for name, validationErr := range crud.ValidateFieldValues(fieldValues) {
+		errors[name] = c.localizeValidationError(ctx, validationErr)
 	}
 
 	return errors
 }
 
+// localizeValidationError localizes a validation error of a field along with the
+// field labels it mentions, falling back to the message of the error
+func (c *CrudController[TEntity]) localizeValidationError(ctx context.Context, validationErr *serrors.ValidationError) string {
+	l, ok := intl.UseLocalizer(ctx)
+	if !ok || validationErr.LocaleKey == "" {
+		return validationErr.Message
+	}
+
+	data := make(map[string]string, len(validationErr.TemplateData))
+	for k, v := range validationErr.TemplateData {
+		data[k] = v
+	}
+	for _, k := range []string{"Field", "Param"} {
+		key := data[k]
+		if key == "" {
+			continue
+		}
+		// Errors of field rules name fields without a localization key by their name
+		if !strings.Contains(key, ".") {
+			key = fmt.Sprintf("%s.Fields.%s", c.schema.Name(), key)
+		}
+		label, err := c.localize(ctx, key, key[strings.LastIndex(key, ".")+1:])
+		if err == nil {
+			data[k] = label
+		}
+	}
+
+	message, err := l.Localize(&i18n.LocalizeConfig{
+		MessageID:    validationErr.LocaleKey,
+		TemplateData: data,
+		DefaultMessage: &i18n.Message{
+			ID:    validationErr.LocaleKey,
+			Other: validationErr.Message,
+		},
+	})
+	if err != nil {
+		return validationErr.Message
+	}
+	return message
+}
+
 // validateEntity validates the entity against schema validators
 func (c *CrudController[TEntity]) validateEntity(ctx context.Context, entity TEntity) error {
 	for _, validator := range c.schema.Validators() {
@@ -2222,7 +2918,14 @@ func (c *CrudController[TEntity]) validateEntity(ctx context.Context, entity TEn
 // handleValidationError handles validation errors by re-rendering the form with errors
 func (c *CrudController[TEntity]) handleValidationError(w http.ResponseWriter, r *http.Request, ctx context.Context, err error, fieldValues []crud.FieldValue, isCreate bool) bool {
 	// First, validate field values against their rules
-	fieldErrors := c.validateFieldValues(fieldValues)
+	fieldErrors := c.validateFieldValues(ctx, fieldValues)
+
+	// Then add the fields rejected by the rules of the schema during the save
+	for name, validationErr := range crud.ValidationErrorsOf(err) {
+		if _, exists := fieldErrors[name]; !exists {
+			fieldErrors[name] = c.localizeValidationError(ctx, validationErr)
+		}
+	}
 
 	// If no field errors but we have an entity validation error, add it as a general error
 	if len(fieldErrors) == 0 && err != nil {
@@ -196,6 +196,51 @@ func (s *decimalService) Delete(ctx context.Context, value crud.FieldValue) (Tes
 	return entity, nil
 }
 
+func (s *decimalService) Restore(ctx context.Context, value crud.FieldValue) (TestEntityWithDecimal, error) {
+	return TestEntityWithDecimal{}, crud.ErrNoTrash
+}
+
+func (s *decimalService) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return 0, crud.ErrNoTrash
+}
+
+func (s *decimalService) Aggregate(ctx context.Context, params *crud.FindParams, query crud.AggregateQuery) ([]crud.AggregateRow, error) {
+	return nil, nil
+}
+
+func (s *decimalService) History(ctx context.Context, key crud.FieldValue) ([]crud.HistoryEntry, error) {
+	return nil, crud.ErrNoHistory
+}
+
+func (s *decimalService) Revert(ctx context.Context, key crud.FieldValue, id int64) (TestEntityWithDecimal, error) {
+	return TestEntityWithDecimal{}, crud.ErrNoHistory
+}
+
+func (s *decimalService) BulkSave(ctx context.Context, entities []TestEntityWithDecimal) (int64, error) {
+	for _, entity := range entities {
+		if _, err := s.Save(ctx, entity); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(entities)), nil
+}
+
+func (s *decimalService) BulkUpsert(ctx context.Context, entities []TestEntityWithDecimal) (int64, error) {
+	return s.BulkSave(ctx, entities)
+}
+
+func (s *decimalService) BulkDelete(ctx context.Context, values []crud.FieldValue) ([]TestEntityWithDecimal, error) {
+	deleted := make([]TestEntityWithDecimal, 0, len(values))
+	for _, value := range values {
+		entity, err := s.Delete(ctx, value)
+		if err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, entity)
+	}
+	return deleted, nil
+}
+
 // decimalTestBuilder implements crud.Builder[TestEntityWithDecimal]
 type decimalTestBuilder struct {
 	schema  crud.Schema[TestEntityWithDecimal]
@@ -254,6 +299,51 @@ func (s *nullableTestService) Delete(ctx context.Context, value crud.FieldValue)
 	return NullableEntity{}, fmt.Errorf("entity not found")
 }
 
+func (s *nullableTestService) Restore(ctx context.Context, value crud.FieldValue) (NullableEntity, error) {
+	return NullableEntity{}, crud.ErrNoTrash
+}
+
+func (s *nullableTestService) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return 0, crud.ErrNoTrash
+}
+
+func (s *nullableTestService) Aggregate(ctx context.Context, params *crud.FindParams, query crud.AggregateQuery) ([]crud.AggregateRow, error) {
+	return nil, nil
+}
+
+func (s *nullableTestService) History(ctx context.Context, key crud.FieldValue) ([]crud.HistoryEntry, error) {
+	return nil, crud.ErrNoHistory
+}
+
+func (s *nullableTestService) Revert(ctx context.Context, key crud.FieldValue, id int64) (NullableEntity, error) {
+	return NullableEntity{}, crud.ErrNoHistory
+}
+
+func (s *nullableTestService) BulkSave(ctx context.Context, entities []NullableEntity) (int64, error) {
+	for _, entity := range entities {
+		if _, err := s.Save(ctx, entity); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(entities)), nil
+}
+
+func (s *nullableTestService) BulkUpsert(ctx context.Context, entities []NullableEntity) (int64, error) {
+	return s.BulkSave(ctx, entities)
+}
+
+func (s *nullableTestService) BulkDelete(ctx context.Context, values []crud.FieldValue) ([]NullableEntity, error) {
+	deleted := make([]NullableEntity, 0, len(values))
+	for _, value := range values {
+		entity, err := s.Delete(ctx, value)
+		if err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, entity)
+	}
+	return deleted, nil
+}
+
 // nullableTestBuilder implements crud.Builder[NullableEntity]
 type nullableTestBuilder struct {
 	schema  crud.Schema[NullableEntity]
@@ -482,6 +572,51 @@ func (s *stringKeyService) Delete(ctx context.Context, value crud.FieldValue) (T
 	return entity, nil
 }
 
+func (s *stringKeyService) Restore(ctx context.Context, value crud.FieldValue) (TestEntityWithStringKey, error) {
+	return TestEntityWithStringKey{}, crud.ErrNoTrash
+}
+
+func (s *stringKeyService) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return 0, crud.ErrNoTrash
+}
+
+func (s *stringKeyService) Aggregate(ctx context.Context, params *crud.FindParams, query crud.AggregateQuery) ([]crud.AggregateRow, error) {
+	return nil, nil
+}
+
+func (s *stringKeyService) History(ctx context.Context, key crud.FieldValue) ([]crud.HistoryEntry, error) {
+	return nil, crud.ErrNoHistory
+}
+
+func (s *stringKeyService) Revert(ctx context.Context, key crud.FieldValue, id int64) (TestEntityWithStringKey, error) {
+	return TestEntityWithStringKey{}, crud.ErrNoHistory
+}
+
+func (s *stringKeyService) BulkSave(ctx context.Context, entities []TestEntityWithStringKey) (int64, error) {
+	for _, entity := range entities {
+		if _, err := s.Save(ctx, entity); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(entities)), nil
+}
+
+func (s *stringKeyService) BulkUpsert(ctx context.Context, entities []TestEntityWithStringKey) (int64, error) {
+	return s.BulkSave(ctx, entities)
+}
+
+func (s *stringKeyService) BulkDelete(ctx context.Context, values []crud.FieldValue) ([]TestEntityWithStringKey, error) {
+	deleted := make([]TestEntityWithStringKey, 0, len(values))
+	for _, value := range values {
+		entity, err := s.Delete(ctx, value)
+		if err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, entity)
+	}
+	return deleted, nil
+}
+
 // stringKeyTestBuilder implements crud.Builder[TestEntityWithStringKey]
 type stringKeyTestBuilder struct {
 	schema  crud.Schema[TestEntityWithStringKey]
@@ -153,6 +153,51 @@ func (s *testService) Delete(ctx context.Context, value crud.FieldValue) (TestEn
 	return entity, nil
 }
 
+func (s *testService) Restore(ctx context.Context, value crud.FieldValue) (TestEntity, error) {
+	return TestEntity{}, crud.ErrNoTrash
+}
+
+func (s *testService) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return 0, crud.ErrNoTrash
+}
+
+func (s *testService) Aggregate(ctx context.Context, params *crud.FindParams, query crud.AggregateQuery) ([]crud.AggregateRow, error) {
+	return nil, nil
+}
+
+func (s *testService) History(ctx context.Context, key crud.FieldValue) ([]crud.HistoryEntry, error) {
+	return nil, crud.ErrNoHistory
+}
+
+func (s *testService) Revert(ctx context.Context, key crud.FieldValue, id int64) (TestEntity, error) {
+	return TestEntity{}, crud.ErrNoHistory
+}
+
+func (s *testService) BulkSave(ctx context.Context, entities []TestEntity) (int64, error) {
+	for _, entity := range entities {
+		if _, err := s.Save(ctx, entity); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(entities)), nil
+}
+
+func (s *testService) BulkUpsert(ctx context.Context, entities []TestEntity) (int64, error) {
+	return s.BulkSave(ctx, entities)
+}
+
+func (s *testService) BulkDelete(ctx context.Context, values []crud.FieldValue) ([]TestEntity, error) {
+	deleted := make([]TestEntity, 0, len(values))
+	for _, value := range values {
+		entity, err := s.Delete(ctx, value)
+		if err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, entity)
+	}
+	return deleted, nil
+}
+
 // testMapper implements crud.Mapper[TestEntity]
 type testMapper struct {
 	fields crud.Fields
@@ -323,7 +368,7 @@ func TestCrudController_List_Success(t *testing.T) {
 
 	// Assertions
 	assert.Equal(t, 1, service.calls["List"])
-	assert.Equal(t, 1, service.calls["Count"])
+	assert.Equal(t, 0, service.calls["Count"], "list pages by cursor without counting")
 
 	// Check table headers
 	headerElements := doc.Elements("//thead/tr/th")
@@ -1241,6 +1286,51 @@ func (s *complexTestService) Delete(ctx context.Context, value crud.FieldValue)
 	return ComplexEntity{}, fmt.Errorf("entity not found")
 }
 
+func (s *complexTestService) Restore(ctx context.Context, value crud.FieldValue) (ComplexEntity, error) {
+	return ComplexEntity{}, crud.ErrNoTrash
+}
+
+func (s *complexTestService) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return 0, crud.ErrNoTrash
+}
+
+func (s *complexTestService) Aggregate(ctx context.Context, params *crud.FindParams, query crud.AggregateQuery) ([]crud.AggregateRow, error) {
+	return nil, nil
+}
+
+func (s *complexTestService) History(ctx context.Context, key crud.FieldValue) ([]crud.HistoryEntry, error) {
+	return nil, crud.ErrNoHistory
+}
+
+func (s *complexTestService) Revert(ctx context.Context, key crud.FieldValue, id int64) (ComplexEntity, error) {
+	return ComplexEntity{}, crud.ErrNoHistory
+}
+
+func (s *complexTestService) BulkSave(ctx context.Context, entities []ComplexEntity) (int64, error) {
+	for _, entity := range entities {
+		if _, err := s.Save(ctx, entity); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(entities)), nil
+}
+
+func (s *complexTestService) BulkUpsert(ctx context.Context, entities []ComplexEntity) (int64, error) {
+	return s.BulkSave(ctx, entities)
+}
+
+func (s *complexTestService) BulkDelete(ctx context.Context, values []crud.FieldValue) ([]ComplexEntity, error) {
+	deleted := make([]ComplexEntity, 0, len(values))
+	for _, value := range values {
+		entity, err := s.Delete(ctx, value)
+		if err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, entity)
+	}
+	return deleted, nil
+}
+
 // complexTestBuilder implements crud.Builder[ComplexEntity]
 type complexTestBuilder struct {
 	schema  crud.Schema[ComplexEntity]
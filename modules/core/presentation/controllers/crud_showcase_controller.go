@@ -466,9 +466,11 @@ func NewCrudShowcaseController(
 		mapper,
 	)
 
+	crud.RegisterEvents(app.EventRegistry(), schema)
 	builder := crud.NewBuilder[ShowcaseEntity](
 		schema,
 		app.EventPublisher(),
+		crud.WithServiceOptions[ShowcaseEntity](crud.WithOutbox(app.Outbox())),
 	)
 
 	// Merge the MultiLang renderer option with custom actions and user-provided options
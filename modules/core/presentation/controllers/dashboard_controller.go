@@ -23,10 +23,11 @@ func NewDashboardController(app application.Application) application.Controller
 	// Setup PostgreSQL data source for lens
 	config := configuration.Use()
 	pgConfig := postgres.Config{
-		ConnectionString: config.Database.ConnectionString(),
-		MaxConnections:   5,
-		MinConnections:   1,
-		QueryTimeout:     30 * time.Second,
+		ConnectionString:         config.Database.ConnectionString(),
+		ReplicaConnectionStrings: config.Database.ReplicaConnectionStrings(),
+		MaxConnections:           5,
+		MinConnections:           1,
+		QueryTimeout:             30 * time.Second,
 	}
 
 	pgDataSource, err := postgres.NewPostgreSQLDataSource(pgConfig)
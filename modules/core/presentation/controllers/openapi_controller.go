@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+)
+
+// OpenAPIController serves the OpenAPI document describing the generated APIs of a
+// module, e.g. the CrudAPIControllers it registers:
+//
+//	products := controllers.NewCrudAPIController[Product]("/api/v1/products", app, builder)
+//	app.RegisterControllers(
+//		products,
+//		controllers.NewOpenAPIController("/api/v1/warehouse/openapi.json", "Warehouse API", "1.0.0", products),
+//	)
+type OpenAPIController struct {
+	path      string
+	title     string
+	version   string
+	resources []crud.OpenAPIResource
+}
+
+func NewOpenAPIController(path, title, version string, resources ...crud.OpenAPIResource) application.Controller {
+	return &OpenAPIController{
+		path:      path,
+		title:     title,
+		version:   version,
+		resources: resources,
+	}
+}
+
+func (c *OpenAPIController) Key() string {
+	return c.path
+}
+
+func (c *OpenAPIController) Register(r *mux.Router) {
+	r.HandleFunc(c.path, c.Document).Methods(http.MethodGet)
+}
+
+func (c *OpenAPIController) Document(w http.ResponseWriter, r *http.Request) {
+	doc := crud.NewOpenAPIDocument(c.title, c.version)
+	for _, resource := range c.resources {
+		resource.OpenAPI(doc)
+	}
+	writeAPIJSON(w, http.StatusOK, doc)
+}
@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+
+	trashtemplates "github.com/iota-uz/iota-sdk/modules/core/presentation/templates/pages/trash"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/htmx"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/trash"
+)
+
+// TrashController shows the soft-deleted entities of the given bins and restores them.
+// Bins the user isn't allowed to see are left out of the page.
+type TrashController struct {
+	app      application.Application
+	basePath string
+	bins     []trash.Bin
+}
+
+func NewTrashController(app application.Application, basePath string, bins ...trash.Bin) application.Controller {
+	return &TrashController{
+		app:      app,
+		basePath: basePath,
+		bins:     bins,
+	}
+}
+
+func (c *TrashController) Key() string {
+	return c.basePath
+}
+
+func (c *TrashController) Register(r *mux.Router) {
+	router := r.PathPrefix(c.basePath).Subrouter()
+	router.Use(
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		middleware.ProvideDynamicLogo(c.app),
+		middleware.ProvideLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	)
+	router.HandleFunc("", c.List).Methods(http.MethodGet)
+	router.HandleFunc("/{bin}/{id}/restore", c.Restore).Methods(http.MethodPost)
+}
+
+func (c *TrashController) List(w http.ResponseWriter, r *http.Request) {
+	logger := composables.UseLogger(r.Context())
+
+	props := &trashtemplates.IndexPageProps{BasePath: c.basePath}
+	for _, bin := range c.bins {
+		items, err := bin.List(r.Context())
+		if errors.Is(err, composables.ErrForbidden) {
+			continue
+		}
+		if err != nil {
+			logger.WithError(err).Errorf("failed to list %s trash", bin.Name())
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		props.Bins = append(props.Bins, trashtemplates.BinProps{Name: bin.Name(), Items: items})
+	}
+	templ.Handler(trashtemplates.Index(props)).ServeHTTP(w, r)
+}
+
+func (c *TrashController) Restore(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bin := c.bin(vars["bin"])
+	if bin == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	err := bin.Restore(r.Context(), vars["id"])
+	switch {
+	case errors.Is(err, composables.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	case errors.Is(err, trash.ErrNotFound):
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		composables.UseLogger(r.Context()).WithError(err).Errorf("failed to restore %s %s", bin.Name(), vars["id"])
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// htmx swaps the restored row with the empty response
+	if htmx.IsHxRequest(r) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	http.Redirect(w, r, c.basePath, http.StatusFound)
+}
+
+func (c *TrashController) bin(name string) trash.Bin {
+	for _, bin := range c.bins {
+		if bin.Name() == name {
+			return bin
+		}
+	}
+	return nil
+}
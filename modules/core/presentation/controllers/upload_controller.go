@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"log"
 	"mime/multipart"
 	"net/http"
@@ -17,21 +18,24 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/core/services"
 	"github.com/iota-uz/iota-sdk/pkg/application"
 	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/jobs"
 	"github.com/iota-uz/iota-sdk/pkg/mapping"
 	"github.com/iota-uz/iota-sdk/pkg/middleware"
 )
 
 type UploadController struct {
-	app           application.Application
-	uploadService *services.UploadService
-	basePath      string
+	app                application.Application
+	uploadService      *services.UploadService
+	excelExportService *services.ExcelExportService
+	basePath           string
 }
 
 func NewUploadController(app application.Application) application.Controller {
 	return &UploadController{
-		app:           app,
-		uploadService: app.Service(services.UploadService{}).(*services.UploadService),
-		basePath:      "/uploads",
+		app:                app,
+		uploadService:      app.Service(services.UploadService{}).(*services.UploadService),
+		excelExportService: app.Service(services.ExcelExportService{}).(*services.ExcelExportService),
+		basePath:           "/uploads",
 	}
 }
 
@@ -46,6 +50,7 @@ func (c *UploadController) Register(r *mux.Router) {
 	router.Use(middleware.ProvideLocalizer(c.app.Bundle()))
 	router.Use(middleware.WithTransaction())
 	router.HandleFunc("", c.Create).Methods(http.MethodPost)
+	router.HandleFunc("/exports/{slug:[0-9a-zA-Z]+}", c.Export).Methods(http.MethodGet)
 
 	workDir, err := os.Getwd()
 	if err != nil {
@@ -129,3 +134,28 @@ func (c *UploadController) Create(w http.ResponseWriter, r *http.Request) {
 
 	templ.Handler(components.UploadTarget(props), templ.WithStreaming()).ServeHTTP(w, r)
 }
+
+// Export redirects to the file of a background export once it is ready and
+// asks the browser to check again while its job is pending or running.
+func (c *UploadController) Export(w http.ResponseWriter, r *http.Request) {
+	up, status, err := c.excelExportService.ExportStatus(r.Context(), mux.Vars(r)["slug"])
+	if errors.Is(err, jobs.ErrJobNotFound) {
+		http.Error(w, "Export not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	switch status {
+	case jobs.StatusCompleted:
+		http.Redirect(w, r, up.URL().String(), http.StatusSeeOther)
+	case jobs.StatusFailed:
+		http.Error(w, "Export failed", http.StatusInternalServerError)
+	default:
+		w.Header().Set("Refresh", "2")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("Export is being prepared..."))
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/configuration"
 	"github.com/iota-uz/iota-sdk/pkg/di"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
 	"github.com/iota-uz/iota-sdk/pkg/htmx"
 	"github.com/iota-uz/iota-sdk/pkg/mapping"
 	"github.com/iota-uz/iota-sdk/pkg/middleware"
@@ -51,12 +52,12 @@ func NewUserRealtimeUpdates(app application.Application, userService *services.U
 }
 
 func (ru *UserRealtimeUpdates) Register() {
-	ru.app.EventPublisher().Subscribe(ru.onUserCreated)
-	ru.app.EventPublisher().Subscribe(ru.onUserUpdated)
-	ru.app.EventPublisher().Subscribe(ru.onUserDeleted)
+	eventbus.Subscribe(ru.app.EventPublisher(), ru.onUserCreated)
+	eventbus.Subscribe(ru.app.EventPublisher(), ru.onUserUpdated)
+	eventbus.Subscribe(ru.app.EventPublisher(), ru.onUserDeleted)
 }
 
-func (ru *UserRealtimeUpdates) onUserCreated(event *user.CreatedEvent) {
+func (ru *UserRealtimeUpdates) onUserCreated(_ context.Context, event *user.CreatedEvent) error {
 	logger := configuration.Use().Logger()
 
 	component := users.UserCreatedEvent(mappers.UserToViewModel(event.Result), &base.TableRowProps{
@@ -75,12 +76,12 @@ func (ru *UserRealtimeUpdates) onUserCreated(event *user.CreatedEvent) {
 		}
 		return nil
 	}); err != nil {
-		logger.WithError(err).Error("failed to broadcast user created event to websocket")
-		return
+		return fmt.Errorf("failed to broadcast user created event to websocket: %w", err)
 	}
+	return nil
 }
 
-func (ru *UserRealtimeUpdates) onUserDeleted(event *user.DeletedEvent) {
+func (ru *UserRealtimeUpdates) onUserDeleted(_ context.Context, event *user.DeletedEvent) error {
 	logger := configuration.Use().Logger()
 
 	component := users.UserRow(mappers.UserToViewModel(event.Result), &base.TableRowProps{
@@ -89,7 +90,7 @@ func (ru *UserRealtimeUpdates) onUserDeleted(event *user.DeletedEvent) {
 		},
 	})
 
-	err := ru.app.Websocket().ForEach(application.ChannelAuthenticated, func(connCtx context.Context, conn application.Connection) error {
+	if err := ru.app.Websocket().ForEach(application.ChannelAuthenticated, func(connCtx context.Context, conn application.Connection) error {
 		var buf bytes.Buffer
 		if err := component.Render(connCtx, &buf); err != nil {
 			logger.WithError(err).Error("failed to render user deleted event for websocket")
@@ -100,14 +101,13 @@ func (ru *UserRealtimeUpdates) onUserDeleted(event *user.DeletedEvent) {
 			return nil // Continue processing other connections
 		}
 		return nil
-	})
-	if err != nil {
-		logger.WithError(err).Error("failed to broadcast user deleted event to websocket")
-		return
+	}); err != nil {
+		return fmt.Errorf("failed to broadcast user deleted event to websocket: %w", err)
 	}
+	return nil
 }
 
-func (ru *UserRealtimeUpdates) onUserUpdated(event *user.UpdatedEvent) {
+func (ru *UserRealtimeUpdates) onUserUpdated(_ context.Context, event *user.UpdatedEvent) error {
 	logger := configuration.Use().Logger()
 
 	component := users.UserRow(mappers.UserToViewModel(event.Result), &base.TableRowProps{
@@ -126,9 +126,9 @@ func (ru *UserRealtimeUpdates) onUserUpdated(event *user.UpdatedEvent) {
 		}
 		return nil
 	}); err != nil {
-		logger.WithError(err).Error("failed to broadcast user updated event to websocket")
-		return
+		return fmt.Errorf("failed to broadcast user updated event to websocket: %w", err)
 	}
+	return nil
 }
 
 type UsersController struct {
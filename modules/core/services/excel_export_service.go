@@ -3,31 +3,130 @@ package services
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/exportconfig"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
+	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/excel"
+	"github.com/iota-uz/iota-sdk/pkg/jobs"
 )
 
+// ExcelExportArgs is the job exporting the results of a query in the background.
+// Args are stored as JSON, so they must survive a JSON round trip: pass UUIDs,
+// dates and decimals as strings and let Postgres cast them.
+type ExcelExportArgs struct {
+	SQL      string        `json:"sql"`
+	Args     []interface{} `json:"args"`
+	Filename string        `json:"filename"`
+	// Slug of the upload the export is saved as
+	Slug string `json:"slug"`
+}
+
+func (ExcelExportArgs) Kind() string { return "core.excel_export" }
+
 // ExcelExportService handles Excel export operations
 type ExcelExportService struct {
 	db            *pgxpool.Pool
 	uploadService *UploadService
+	jobs          jobs.Client
 }
 
 // NewExcelExportService creates a new Excel export service
-func NewExcelExportService(db *pgxpool.Pool, uploadService *UploadService) *ExcelExportService {
+func NewExcelExportService(db *pgxpool.Pool, uploadService *UploadService, jobClient jobs.Client) *ExcelExportService {
 	return &ExcelExportService{
 		db:            db,
 		uploadService: uploadService,
+		jobs:          jobClient,
 	}
 }
 
 // ExportFromQuery exports SQL query results to Excel and saves as upload
 func (s *ExcelExportService) ExportFromQuery(ctx context.Context, query exportconfig.Query, config exportconfig.ExportConfig) (upload.Upload, error) {
+	return s.exportFromQuery(ctx, query, config, "")
+}
+
+// EnqueueExportFromQuery schedules the export of query on a worker and returns the slug
+// of the upload it will be saved as. Use ExportStatus to follow it.
+func (s *ExcelExportService) EnqueueExportFromQuery(ctx context.Context, query exportconfig.Query, filename string) (string, error) {
+	slug := strings.ReplaceAll(uuid.NewString(), "-", "")
+	args := ExcelExportArgs{
+		SQL:      query.SQL(),
+		Args:     query.Args(),
+		Filename: filename,
+		Slug:     slug,
+	}
+	if _, err := s.jobs.Enqueue(ctx, args, jobs.WithUniqueKey(slug)); err != nil {
+		return "", fmt.Errorf("failed to enqueue Excel export: %w", err)
+	}
+	return slug, nil
+}
+
+// RunExport is the handler of ExcelExportArgs jobs
+func (s *ExcelExportService) RunExport(ctx context.Context, args ExcelExportArgs) error {
+	// A previous attempt may have saved the upload and failed afterwards
+	if _, err := s.uploadService.GetBySlug(ctx, args.Slug); err == nil {
+		return nil
+	} else if !errors.Is(err, persistence.ErrUploadNotFound) {
+		return err
+	}
+	_, err := s.exportFromQuery(
+		ctx,
+		exportconfig.NewQuery(args.SQL, args.Args...),
+		exportconfig.New(exportconfig.WithFilename(args.Filename)),
+		args.Slug,
+	)
+	return err
+}
+
+// ExportStatus returns the upload of an export enqueued with EnqueueExportFromQuery,
+// or nil and the status of its job while the export is not ready.
+// It returns jobs.ErrJobNotFound when the current tenant has no such export.
+func (s *ExcelExportService) ExportStatus(ctx context.Context, slug string) (upload.Upload, jobs.Status, error) {
+	up, err := s.uploadService.GetBySlug(ctx, slug)
+	if err == nil {
+		return up, jobs.StatusCompleted, nil
+	}
+	if !errors.Is(err, persistence.ErrUploadNotFound) {
+		return nil, "", err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	found, err := jobs.NewPgStore(s.db).List(ctx, &jobs.FindParams{
+		Limit:     1,
+		Kind:      ExcelExportArgs{}.Kind(),
+		UniqueKey: slug,
+		TenantID:  tenantID,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if len(found) == 0 {
+		return nil, "", jobs.ErrJobNotFound
+	}
+	if found[0].Status != jobs.StatusCompleted {
+		return nil, found[0].Status, nil
+	}
+	// The job completed after the upload was looked up, or the upload was deleted since
+	up, err = s.uploadService.GetBySlug(ctx, slug)
+	if errors.Is(err, persistence.ErrUploadNotFound) {
+		return nil, "", jobs.ErrJobNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return up, jobs.StatusCompleted, nil
+}
+
+func (s *ExcelExportService) exportFromQuery(ctx context.Context, query exportconfig.Query, config exportconfig.ExportConfig, slug string) (upload.Upload, error) {
 	// Create pgx data source
 	datasource := excel.NewPgxDataSource(s.db, query.SQL(), query.Args()...)
 	if config.Filename() != "" {
@@ -53,6 +152,7 @@ func (s *ExcelExportService) ExportFromQuery(ctx context.Context, query exportco
 		File: bytes.NewReader(data),
 		Name: config.Filename(),
 		Size: len(data),
+		Slug: slug,
 	}
 
 	// Save to upload service
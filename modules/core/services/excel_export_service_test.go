@@ -216,7 +216,7 @@ func TestExcelExportService_ExportFromDataSource(t *testing.T) {
 	uploadService := services.NewUploadService(mockRepo, mockStorage, mockEventBus)
 
 	// Create Excel export service (with nil DB since we're using custom datasource)
-	excelService := services.NewExcelExportService(nil, uploadService)
+	excelService := services.NewExcelExportService(nil, uploadService, nil)
 
 	// Create mock datasource
 	headers := []string{"id", "name", "email"}
@@ -292,7 +292,7 @@ func TestExcelExportService_ExportFromDataSourceWithOptions(t *testing.T) {
 
 	// Create services
 	uploadService := services.NewUploadService(mockRepo, mockStorage, mockEventBus)
-	excelService := services.NewExcelExportService(nil, uploadService)
+	excelService := services.NewExcelExportService(nil, uploadService, nil)
 
 	// Create mock datasource
 	headers := []string{"id", "name", "score"}
@@ -353,7 +353,7 @@ func TestExcelExportService_ExportFromDataSource_EmptyFilename(t *testing.T) {
 
 	// Create services
 	uploadService := services.NewUploadService(mockRepo, mockStorage, mockEventBus)
-	excelService := services.NewExcelExportService(nil, uploadService)
+	excelService := services.NewExcelExportService(nil, uploadService, nil)
 
 	// Create mock datasource
 	datasource := &mockDataSource{
@@ -403,7 +403,7 @@ func TestExcelExportService_ExportError(t *testing.T) {
 
 	// Create services
 	uploadService := services.NewUploadService(mockRepo, mockStorage, mockEventBus)
-	excelService := services.NewExcelExportService(nil, uploadService)
+	excelService := services.NewExcelExportService(nil, uploadService, nil)
 
 	// Create a datasource that returns error
 	datasource := &errorDataSource{}
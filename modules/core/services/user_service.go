@@ -89,9 +89,9 @@ func (s *UserService) Create(ctx context.Context, data user.User) (user.User, er
 	}
 	createdEvent.Result = createdUser
 
-	s.publisher.Publish(createdEvent)
+	eventbus.Publish(ctx, s.publisher, createdEvent)
 	for _, e := range data.Events() {
-		s.publisher.Publish(e)
+		eventbus.Publish(ctx, s.publisher, e)
 	}
 
 	return createdUser, nil
@@ -138,9 +138,9 @@ func (s *UserService) Update(ctx context.Context, data user.User) (user.User, er
 
 	updatedEvent.Result = updatedUser
 
-	s.publisher.Publish(updatedEvent)
+	eventbus.Publish(ctx, s.publisher, updatedEvent)
 	for _, e := range data.Events() {
-		s.publisher.Publish(e)
+		eventbus.Publish(ctx, s.publisher, e)
 	}
 
 	return updatedUser, nil
@@ -206,7 +206,7 @@ func (s *UserService) Delete(ctx context.Context, id uint) (user.User, error) {
 	}
 	deletedEvent.Result = deletedUser
 
-	s.publisher.Publish(deletedEvent)
+	eventbus.Publish(ctx, s.publisher, deletedEvent)
 
 	return deletedUser, nil
 }
@@ -0,0 +1,83 @@
+package crm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// chatEventPayload is the wire format of chat events relayed between nodes.
+// Only identifiers are sent; the chat is reloaded on the receiving side.
+type chatEventPayload struct {
+	TenantID uuid.UUID `json:"tenant_id"`
+	ChatID   uint      `json:"chat_id"`
+}
+
+// chatEventCodec encodes chat events by id. Decoded events carry no User.
+type chatEventCodec struct {
+	pool     *pgxpool.Pool
+	chatRepo chat.Repository
+	wrap     func(result chat.Chat) interface{}
+	unwrap   func(event interface{}) (chat.Chat, error)
+}
+
+func (c *chatEventCodec) Encode(event interface{}) ([]byte, error) {
+	result, err := c.unwrap(event)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(chatEventPayload{
+		TenantID: result.TenantID(),
+		ChatID:   result.ID(),
+	})
+}
+
+func (c *chatEventCodec) Decode(payload []byte) (interface{}, error) {
+	var p chatEventPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, err
+	}
+	ctx := composables.WithPool(composables.WithTenantID(context.Background(), p.TenantID), c.pool)
+	result, err := c.chatRepo.GetByID(ctx, p.ChatID)
+	if err != nil {
+		return nil, err
+	}
+	return c.wrap(result), nil
+}
+
+func registerEventCodecs(registry *eventbus.Registry, pool *pgxpool.Pool, chatRepo chat.Repository) {
+	registry.RegisterCodec("crm.chat.created", &chat.CreatedEvent{}, &chatEventCodec{
+		pool:     pool,
+		chatRepo: chatRepo,
+		wrap: func(result chat.Chat) interface{} {
+			return &chat.CreatedEvent{Result: result}
+		},
+		unwrap: func(event interface{}) (chat.Chat, error) {
+			e, ok := event.(*chat.CreatedEvent)
+			if !ok || e.Result == nil {
+				return nil, fmt.Errorf("unexpected event %T", event)
+			}
+			return e.Result, nil
+		},
+	})
+	registry.RegisterCodec("crm.chat.message_added", &chat.MessagedAddedEvent{}, &chatEventCodec{
+		pool:     pool,
+		chatRepo: chatRepo,
+		wrap: func(result chat.Chat) interface{} {
+			return &chat.MessagedAddedEvent{Result: result}
+		},
+		unwrap: func(event interface{}) (chat.Chat, error) {
+			e, ok := event.(*chat.MessagedAddedEvent)
+			if !ok || e.Result == nil {
+				return nil, fmt.Errorf("unexpected event %T", event)
+			}
+			return e.Result, nil
+		},
+	})
+}
@@ -356,17 +356,18 @@ func (g *ChatRepository) GetPaginated(
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get tenant from context")
 	}
-	where, args, joins := []string{"c.tenant_id = $1"}, []interface{}{tenantID}, []string{}
+	// Chats of clients in the trash are hidden along with them
+	where, args := []string{"c.tenant_id = $1", repo.NotDeleted("cl")}, []interface{}{tenantID}
+	joins := []string{"JOIN clients cl ON c.client_id = cl.id"}
 	if params.Search != "" {
 		where = append(
 			where,
 			fmt.Sprintf(
-				"cl.first_name ILIKE $%d OR cl.last_name ILIKE $%d OR cl.middle_name ILIKE $%d OR cl.phone_number ILIKE $%d",
+				"(cl.first_name ILIKE $%d OR cl.last_name ILIKE $%d OR cl.middle_name ILIKE $%d OR cl.phone_number ILIKE $%d)",
 				len(args)+1, len(args)+1, len(args)+1, len(args)+1,
 			),
 		)
 		args = append(args, "%"+params.Search+"%")
-		joins = append(joins, "JOIN clients cl ON c.client_id = cl.id")
 	}
 
 	query := repo.Join(
@@ -391,7 +392,7 @@ func (g *ChatRepository) Count(ctx context.Context) (int64, error) {
 	}
 
 	var count int64
-	if err := pool.QueryRow(ctx, countChatQuery+" WHERE tenant_id = $1", tenantID).Scan(&count); err != nil {
+	if err := pool.QueryRow(ctx, countChatQuery+" WHERE tenant_id = $1 AND client_id IN (SELECT id FROM clients WHERE deleted_at IS NULL)", tenantID).Scan(&count); err != nil {
 		return 0, errors.Wrap(err, "failed to count chats")
 	}
 	return count, nil
@@ -13,6 +13,7 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence/models"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/repo"
+	"github.com/iota-uz/iota-sdk/pkg/trash"
 )
 
 var (
@@ -39,10 +40,10 @@ const (
 			c.updated_at
 		FROM clients c
 	`
-	countClientQuery        = `SELECT COUNT(*) as count FROM clients c`
-	deleteChatMessagesQuery = `DELETE FROM messages WHERE chat_id IN (SELECT id FROM chats WHERE client_id = $1)`
-	deleteClientChatsQuery  = `DELETE FROM chats WHERE client_id = $1`
-	deleteClientQuery       = `DELETE FROM clients WHERE id = $1`
+	countClientQuery          = `SELECT COUNT(*) as count FROM clients c`
+	deleteChatMessagesQuery   = `DELETE FROM messages WHERE chat_id IN (SELECT id FROM chats WHERE client_id = $1)`
+	deleteClientChatsQuery    = `DELETE FROM chats WHERE client_id = $1`
+	deleteClientPassportQuery = `DELETE FROM passports WHERE id = (SELECT passport_id FROM clients WHERE id = $1)`
 
 	selectClientContactsQuery = `SELECT id, contact_type, contact_value, created_at, updated_at FROM client_contacts WHERE client_id = $1`
 	insertClientContactQuery  = `INSERT INTO client_contacts (client_id, contact_type, contact_value, created_at, updated_at) VALUES ($1, $2, $3, $4, $5)`
@@ -57,11 +58,9 @@ const (
 	selectClientByContactQuery = `
 		SELECT c.id FROM clients c
 		JOIN client_contacts cc ON c.id = cc.client_id
-		WHERE cc.contact_type = $1 AND cc.contact_value = $2
+		WHERE cc.contact_type = $1 AND cc.contact_value = $2 AND c.deleted_at IS NULL
 		LIMIT 1
 	`
-
-	selectClientPassportQuery = `SELECT passport_id FROM clients WHERE id = $1`
 )
 
 type ClientRepository struct {
@@ -235,7 +234,7 @@ func (g *ClientRepository) GetPaginated(
 	args := make([]interface{}, 0)
 
 	// Add tenant filter first
-	where = append(where, fmt.Sprintf("c.tenant_id = $%d", len(args)+1))
+	where = append(where, fmt.Sprintf("c.tenant_id = $%d", len(args)+1), repo.NotDeleted("c"))
 	args = append(args, tenantID)
 
 	// Apply filters
@@ -250,7 +249,7 @@ func (g *ClientRepository) GetPaginated(
 
 	if params.Search != "" {
 		searchPlaceholder := fmt.Sprintf("$%d", len(args)+1)
-		where = append(where, fmt.Sprintf("c.first_name ILIKE %s OR c.last_name ILIKE %s OR c.middle_name ILIKE %s OR c.phone_number ILIKE %s", searchPlaceholder, searchPlaceholder, searchPlaceholder, searchPlaceholder))
+		where = append(where, fmt.Sprintf("(c.first_name ILIKE %s OR c.last_name ILIKE %s OR c.middle_name ILIKE %s OR c.phone_number ILIKE %s)", searchPlaceholder, searchPlaceholder, searchPlaceholder, searchPlaceholder))
 		args = append(args, "%"+params.Search+"%")
 	}
 
@@ -283,7 +282,7 @@ func (g *ClientRepository) Count(ctx context.Context, params *client.FindParams)
 	args := make([]interface{}, 0)
 
 	// Add tenant filter first
-	where = append(where, fmt.Sprintf("c.tenant_id = $%d", len(args)+1))
+	where = append(where, fmt.Sprintf("c.tenant_id = $%d", len(args)+1), repo.NotDeleted("c"))
 	args = append(args, tenantID)
 
 	// Apply filters
@@ -298,7 +297,7 @@ func (g *ClientRepository) Count(ctx context.Context, params *client.FindParams)
 
 	if params.Search != "" {
 		searchPlaceholder := fmt.Sprintf("$%d", len(args)+1)
-		where = append(where, fmt.Sprintf("c.first_name ILIKE %s OR c.last_name ILIKE %s OR c.middle_name ILIKE %s OR c.phone_number ILIKE %s", searchPlaceholder, searchPlaceholder, searchPlaceholder, searchPlaceholder))
+		where = append(where, fmt.Sprintf("(c.first_name ILIKE %s OR c.last_name ILIKE %s OR c.middle_name ILIKE %s OR c.phone_number ILIKE %s)", searchPlaceholder, searchPlaceholder, searchPlaceholder, searchPlaceholder))
 		args = append(args, "%"+params.Search+"%")
 	}
 
@@ -317,7 +316,7 @@ func (g *ClientRepository) GetByID(ctx context.Context, id uint) (client.Client,
 		return nil, err
 	}
 
-	clients, err := g.queryClients(ctx, selectClientQuery+" WHERE c.id = $1 AND c.tenant_id = $2", id, tenantID)
+	clients, err := g.queryClients(ctx, selectClientQuery+" WHERE c.id = $1 AND c.tenant_id = $2 AND c.deleted_at IS NULL", id, tenantID)
 	if err != nil {
 		return nil, err
 	}
@@ -329,7 +328,7 @@ func (g *ClientRepository) GetByID(ctx context.Context, id uint) (client.Client,
 
 // getByID is an internal helper method for getting a client by ID without tenant filtering
 func (g *ClientRepository) getByID(ctx context.Context, id uint) (client.Client, error) {
-	clients, err := g.queryClients(ctx, selectClientQuery+" WHERE c.id = $1", id)
+	clients, err := g.queryClients(ctx, selectClientQuery+" WHERE c.id = $1 AND c.deleted_at IS NULL", id)
 	if err != nil {
 		return nil, err
 	}
@@ -340,7 +339,7 @@ func (g *ClientRepository) getByID(ctx context.Context, id uint) (client.Client,
 }
 
 func (g *ClientRepository) GetByPhone(ctx context.Context, phoneNumber string) (client.Client, error) {
-	clients, err := g.queryClients(ctx, selectClientQuery+" WHERE c.phone_number = $1", phoneNumber)
+	clients, err := g.queryClients(ctx, selectClientQuery+" WHERE c.phone_number = $1 AND c.deleted_at IS NULL", phoneNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -589,6 +588,8 @@ func (g *ClientRepository) Save(ctx context.Context, data client.Client) (client
 	return g.create(ctx, data)
 }
 
+// Delete moves the client to the trash, its chats, contacts and passport are
+// removed once it is purged, see NewClientTrashBin.
 func (g *ClientRepository) Delete(ctx context.Context, id uint) error {
 	tenantID, err := composables.UseTenantID(ctx)
 	if err != nil {
@@ -600,36 +601,21 @@ func (g *ClientRepository) Delete(ctx context.Context, id uint) error {
 		return err
 	}
 
-	var passportID sql.NullString
-	err = tx.QueryRow(ctx, selectClientPassportQuery, id).Scan(&passportID)
-	if err != nil && err != sql.ErrNoRows {
-		return err
-	}
-
-	if _, err := tx.Exec(ctx, deleteChatMessagesQuery, id); err != nil {
-		return err
-	}
-
-	if _, err := tx.Exec(ctx, deleteClientChatsQuery, id); err != nil {
-		return err
-	}
-
-	if _, err := tx.Exec(ctx, repo.Join(deleteClientContactQuery, "WHERE client_id = $1"), id); err != nil {
-		return err
-	}
-
-	if _, err := tx.Exec(ctx, deleteClientQuery+" AND tenant_id = $2", id, tenantID); err != nil {
-		return err
-	}
-
-	if passportID.Valid {
-		err = g.passportRepo.Delete(ctx, uuid.MustParse(passportID.String))
-		if err != nil {
-			return fmt.Errorf("failed to delete passport: %w", err)
-		}
-	}
+	_, err = tx.Exec(ctx, repo.SoftDelete("clients", "id = $1", "tenant_id = $2"), id, tenantID)
+	return err
+}
 
-	return nil
+// NewClientTrashBin creates the trash bin of clients.
+func NewClientTrashBin(opts ...trash.TableBinOption) trash.Bin {
+	return trash.NewTableBin(
+		"Clients",
+		"clients",
+		"concat_ws(' ', first_name, last_name)",
+		append(
+			[]trash.TableBinOption{trash.WithPurgeFirst(deleteChatMessagesQuery, deleteClientChatsQuery, deleteClientPassportQuery)},
+			opts...,
+		)...,
+	)
 }
 
 func (g *ClientRepository) getByContactValue(
@@ -670,7 +656,7 @@ func (g *ClientRepository) GetByContactValue(
 	}
 
 	if contactType == client.ContactTypeEmail {
-		clients, err := g.queryClients(ctx, repo.Join(selectClientQuery, "WHERE c.email = $1"), value)
+		clients, err := g.queryClients(ctx, repo.Join(selectClientQuery, "WHERE c.email = $1 AND c.deleted_at IS NULL"), value)
 		if err != nil {
 			return nil, err
 		}
@@ -13,9 +13,12 @@ CREATE TABLE clients (
     pin varchar(128), -- Personal Identification Number
     comments text,
     created_at timestamp with time zone DEFAULT now(),
-    updated_at timestamp with time zone DEFAULT now()
+    updated_at timestamp with time zone DEFAULT now(),
+    deleted_at timestamp with time zone
 );
 
+CREATE INDEX clients_trash_idx ON clients (tenant_id, deleted_at) WHERE deleted_at IS NOT NULL;
+
 CREATE TABLE client_contacts (
     id serial PRIMARY KEY,
     client_id int NOT NULL REFERENCES clients (id) ON DELETE CASCADE ON UPDATE CASCADE,
@@ -94,3 +97,8 @@ CREATE INDEX idx_chats_tenant_id ON chats (tenant_id);
 
 CREATE INDEX idx_message_templates_tenant_id ON message_templates (tenant_id);
 
+-- Row-level security: rows are only visible to the tenant in app.tenant_id
+SELECT enable_tenant_isolation('clients');
+SELECT enable_tenant_isolation('chats');
+SELECT enable_tenant_isolation('chat_members');
+SELECT enable_tenant_isolation('message_templates');
@@ -4,15 +4,19 @@ import (
 	"embed"
 
 	corepersistence "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	corecontrollers "github.com/iota-uz/iota-sdk/modules/core/presentation/controllers"
 	"github.com/iota-uz/iota-sdk/modules/crm/domain/aggregates/chat"
 	"github.com/iota-uz/iota-sdk/modules/crm/handlers"
 	cpassproviders "github.com/iota-uz/iota-sdk/modules/crm/infrastructure/cpass-providers"
 	"github.com/iota-uz/iota-sdk/modules/crm/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/crm/permissions"
 	"github.com/iota-uz/iota-sdk/modules/crm/presentation/controllers"
 	"github.com/iota-uz/iota-sdk/modules/crm/services"
 	"github.com/iota-uz/iota-sdk/pkg/application"
 	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/scheduler"
 	"github.com/iota-uz/iota-sdk/pkg/spotlight"
+	"github.com/iota-uz/iota-sdk/pkg/trash"
 	"github.com/twilio/twilio-go"
 )
 
@@ -57,6 +61,7 @@ func (m *Module) Register(app application.Application) error {
 		[]chat.Provider{twilioProvider},
 		app.EventPublisher(),
 	)
+	registerEventCodecs(app.EventRegistry(), app.DB(), chatRepo)
 	app.RegisterServices(
 		chatsService,
 		clientService,
@@ -73,6 +78,9 @@ func (m *Module) Register(app application.Application) error {
 
 	// Configure client controller with explicit tabs
 	basePath := "/crm/clients"
+	trashBins := []trash.Bin{
+		persistence.NewClientTrashBin(trash.WithPermission(permissions.ClientDelete)),
+	}
 	app.RegisterControllers(
 		controllers.NewClientController(app, controllers.ClientControllerConfig{
 			BasePath: basePath,
@@ -85,6 +93,12 @@ func (m *Module) Register(app application.Application) error {
 		controllers.NewChatController(app, "/crm/chats"),
 		controllers.NewMessageTemplateController(app, "/crm/instant-messages"),
 		controllers.NewTwilioController(app, twilioProvider),
+		corecontrollers.NewTrashController(app, "/crm/trash", trashBins...),
+	)
+	app.SchedulerRegistry().Register(
+		"crm.purge_trash", "@daily",
+		trash.PurgeTask(conf.TrashRetention, trashBins...),
+		scheduler.ForEachTenant(),
 	)
 
 	handlers.RegisterClientHandler(app)
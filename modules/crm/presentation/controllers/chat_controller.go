@@ -26,6 +26,7 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/crm/services"
 	"github.com/iota-uz/iota-sdk/pkg/application"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
 	"github.com/iota-uz/iota-sdk/pkg/mapping"
 	"github.com/iota-uz/iota-sdk/pkg/middleware"
 	"github.com/iota-uz/iota-sdk/pkg/shared"
@@ -84,8 +85,9 @@ func (c *ChatController) Register(r *mux.Router) {
 	router.HandleFunc("", c.Create).Methods(http.MethodPost)
 	router.HandleFunc("/{id:[0-9]+}/messages", c.SendMessage).Methods(http.MethodPost)
 
-	c.app.EventPublisher().Subscribe(c.onMessageAdded)
-	c.app.EventPublisher().Subscribe(c.onChatCreated)
+	// Websocket clients may be connected to any replica, so these run for events from every node
+	eventbus.SubscribeBroadcast(c.app.EventPublisher(), c.onMessageAdded)
+	eventbus.SubscribeBroadcast(c.app.EventPublisher(), c.onChatCreated)
 }
 
 func (c *ChatController) createTenantContext(tenantID uuid.UUID) context.Context {
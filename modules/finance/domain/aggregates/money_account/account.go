@@ -51,6 +51,12 @@ func WithBalance(balance *money.Money) Option {
 	}
 }
 
+func WithVersion(version int) Option {
+	return func(a *account) {
+		a.version = version
+	}
+}
+
 func WithCreatedAt(createdAt time.Time) Option {
 	return func(a *account) {
 		a.createdAt = createdAt
@@ -91,6 +97,7 @@ type account struct {
 	accountNumber string
 	description   string
 	balance       *money.Money
+	version       int
 	createdAt     time.Time
 	updatedAt     time.Time
 }
@@ -153,6 +160,16 @@ func (a *account) UpdateBalance(balance *money.Money) Account {
 	return &result
 }
 
+func (a *account) Version() int {
+	return a.version
+}
+
+func (a *account) UpdateVersion(version int) Account {
+	result := *a
+	result.version = version
+	return &result
+}
+
 func (a *account) CreatedAt() time.Time {
 	return a.createdAt
 }
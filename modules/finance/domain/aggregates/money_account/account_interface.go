@@ -27,6 +27,11 @@ type Account interface {
 	Balance() *money.Money
 	UpdateBalance(balance *money.Money) Account
 
+	// Version is the optimistic concurrency version the account was read at, 0 for new ones.
+	// Repository.Update requires it and fails with a *serrors.ConflictError when it is stale.
+	Version() int
+	UpdateVersion(version int) Account
+
 	CreatedAt() time.Time
 	UpdatedAt() time.Time
 
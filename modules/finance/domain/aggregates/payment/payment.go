@@ -38,6 +38,12 @@ type Payment interface {
 
 	Account() moneyaccount.Account
 	User() user.User
+
+	// Version is the optimistic concurrency version the payment was read at, 0 for new ones.
+	// Repository.Update requires it and fails with a *serrors.ConflictError when it is stale.
+	Version() int
+	UpdateVersion(version int) Payment
+
 	CreatedAt() time.Time
 	UpdatedAt() time.Time
 
@@ -68,6 +68,12 @@ func WithAccountingPeriod(period time.Time) Option {
 	}
 }
 
+func WithVersion(version int) Option {
+	return func(p *payment) {
+		p.version = version
+	}
+}
+
 func WithCreatedAt(createdAt time.Time) Option {
 	return func(p *payment) {
 		p.createdAt = createdAt
@@ -125,6 +131,7 @@ type payment struct {
 	comment          string
 	account          moneyaccount.Account
 	user             user.User
+	version          int
 	createdAt        time.Time
 	updatedAt        time.Time
 	attachments      []uint
@@ -216,6 +223,16 @@ func (p *payment) User() user.User {
 	return p.user
 }
 
+func (p *payment) Version() int {
+	return p.version
+}
+
+func (p *payment) UpdateVersion(version int) Payment {
+	result := *p
+	result.version = version
+	return &result
+}
+
 func (p *payment) CreatedAt() time.Time {
 	return p.createdAt
 }
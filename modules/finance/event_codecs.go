@@ -0,0 +1,127 @@
+package finance
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/debt"
+	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence/models"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+// Debt events hold interfaces (Debt, User), so the debts are stored as
+// snapshots built with the persistence mappers. Decoded events carry no
+// Sender or Session.
+
+type debtEventPayload struct {
+	Data   *models.Debt `json:"data,omitempty"`
+	Result *models.Debt `json:"result,omitempty"`
+}
+
+type debtEventCodec struct {
+	wrap   func(data, result debt.Debt) interface{}
+	unwrap func(event interface{}) (data, result debt.Debt, err error)
+}
+
+func (c debtEventCodec) Encode(event interface{}) ([]byte, error) {
+	data, result, err := c.unwrap(event)
+	if err != nil {
+		return nil, err
+	}
+	var p debtEventPayload
+	if data != nil {
+		p.Data = persistence.ToDBDebt(data)
+	}
+	if result != nil {
+		p.Result = persistence.ToDBDebt(result)
+	}
+	return json.Marshal(p)
+}
+
+func (c debtEventCodec) Decode(payload []byte) (interface{}, error) {
+	var p debtEventPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, err
+	}
+	var data, result debt.Debt
+	var err error
+	if p.Data != nil {
+		if data, err = persistence.ToDomainDebt(p.Data); err != nil {
+			return nil, err
+		}
+	}
+	if p.Result != nil {
+		if result, err = persistence.ToDomainDebt(p.Result); err != nil {
+			return nil, err
+		}
+	}
+	return c.wrap(data, result), nil
+}
+
+func unexpectedDebtEvent(event interface{}) (debt.Debt, debt.Debt, error) {
+	return nil, nil, fmt.Errorf("unexpected event %T", event)
+}
+
+func registerEventCodecs(registry *eventbus.Registry) {
+	registry.RegisterCodec("finance.debt.created", &debt.Created{}, debtEventCodec{
+		wrap: func(data, result debt.Debt) interface{} {
+			return &debt.Created{Data: data, Result: result}
+		},
+		unwrap: func(event interface{}) (debt.Debt, debt.Debt, error) {
+			e, ok := event.(*debt.Created)
+			if !ok {
+				return unexpectedDebtEvent(event)
+			}
+			return e.Data, e.Result, nil
+		},
+	})
+	registry.RegisterCodec("finance.debt.updated", &debt.Updated{}, debtEventCodec{
+		wrap: func(data, result debt.Debt) interface{} {
+			return &debt.Updated{Data: data, Result: result}
+		},
+		unwrap: func(event interface{}) (debt.Debt, debt.Debt, error) {
+			e, ok := event.(*debt.Updated)
+			if !ok {
+				return unexpectedDebtEvent(event)
+			}
+			return e.Data, e.Result, nil
+		},
+	})
+	registry.RegisterCodec("finance.debt.deleted", &debt.Deleted{}, debtEventCodec{
+		wrap: func(_, result debt.Debt) interface{} {
+			return &debt.Deleted{Result: result}
+		},
+		unwrap: func(event interface{}) (debt.Debt, debt.Debt, error) {
+			e, ok := event.(*debt.Deleted)
+			if !ok {
+				return unexpectedDebtEvent(event)
+			}
+			return nil, e.Result, nil
+		},
+	})
+	registry.RegisterCodec("finance.debt.settled", &debt.Settled{}, debtEventCodec{
+		wrap: func(_, result debt.Debt) interface{} {
+			return &debt.Settled{Result: result}
+		},
+		unwrap: func(event interface{}) (debt.Debt, debt.Debt, error) {
+			e, ok := event.(*debt.Settled)
+			if !ok {
+				return unexpectedDebtEvent(event)
+			}
+			return nil, e.Result, nil
+		},
+	})
+	registry.RegisterCodec("finance.debt.written_off", &debt.WrittenOff{}, debtEventCodec{
+		wrap: func(_, result debt.Debt) interface{} {
+			return &debt.WrittenOff{Result: result}
+		},
+		unwrap: func(event interface{}) (debt.Debt, debt.Debt, error) {
+			e, ok := event.(*debt.WrittenOff)
+			if !ok {
+				return unexpectedDebtEvent(event)
+			}
+			return nil, e.Result, nil
+		},
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/mapping"
 	"github.com/iota-uz/iota-sdk/pkg/repo"
+	"github.com/iota-uz/iota-sdk/pkg/trash"
 )
 
 var (
@@ -46,7 +47,6 @@ const (
 		UPDATE counterparty
 		SET name = $1, tin = $2, type = $3, legal_type = $4, legal_address = $5, updated_at = $6
 		WHERE id = $7`
-	deleteCounterpartyQuery = `DELETE FROM counterparty WHERE id = $1`
 )
 
 type GormCounterpartyRepository struct {
@@ -69,7 +69,7 @@ func NewCounterpartyRepository() counterparty.Repository {
 }
 
 func (g *GormCounterpartyRepository) buildCounterpartyFilters(params *counterparty.FindParams) ([]string, []interface{}, error) {
-	where := []string{"1 = 1"}
+	where := []string{repo.NotDeleted("cp")}
 	args := []interface{}{}
 
 	for _, filter := range params.Filters {
@@ -154,11 +154,11 @@ func (g *GormCounterpartyRepository) Count(ctx context.Context, params *counterp
 }
 
 func (g *GormCounterpartyRepository) GetAll(ctx context.Context) ([]counterparty.Counterparty, error) {
-	return g.queryCounterparties(ctx, findCounterpartyQuery)
+	return g.queryCounterparties(ctx, repo.Join(findCounterpartyQuery, repo.JoinWhere(repo.NotDeleted("cp"))))
 }
 
 func (g *GormCounterpartyRepository) GetByID(ctx context.Context, id uuid.UUID) (counterparty.Counterparty, error) {
-	counterparties, err := g.queryCounterparties(ctx, repo.Join(findCounterpartyQuery, "WHERE id = $1"), id)
+	counterparties, err := g.queryCounterparties(ctx, repo.Join(findCounterpartyQuery, "WHERE cp.id = $1 AND cp.deleted_at IS NULL"), id)
 	if err != nil {
 		return nil, err
 	}
@@ -221,8 +221,18 @@ func (g *GormCounterpartyRepository) Update(ctx context.Context, data counterpar
 	return g.GetByID(ctx, data.ID())
 }
 
+// Delete moves the counterparty to the trash.
 func (g *GormCounterpartyRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return g.execQuery(ctx, deleteCounterpartyQuery, id)
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get tenant from context")
+	}
+	return g.execQuery(ctx, repo.SoftDelete("counterparty", "id = $1", "tenant_id = $2"), id, tenantID)
+}
+
+// NewCounterpartyTrashBin creates the trash bin of counterparties.
+func NewCounterpartyTrashBin(opts ...trash.TableBinOption) trash.Bin {
+	return trash.NewTableBin("Counterparties", "counterparty", "name", opts...)
 }
 
 func (g *GormCounterpartyRepository) queryCounterparties(ctx context.Context, query string, args ...interface{}) ([]counterparty.Counterparty, error) {
@@ -113,6 +113,7 @@ func ToDBPayment(entity payment.Payment) (*models.Payment, *models.Transaction)
 		TransactionID:     entity.TransactionID().String(),
 		CounterpartyID:    entity.CounterpartyID().String(),
 		PaymentCategoryID: mapping.UUIDToSQLNullString(categoryID),
+		Version:           entity.Version(),
 		CreatedAt:         entity.CreatedAt(),
 		UpdatedAt:         entity.UpdatedAt(),
 	}
@@ -161,6 +162,7 @@ func ToDomainPayment(dbPayment *models.Payment, dbTransaction *models.Transactio
 		)),
 		payment.WithTransactionDate(t.TransactionDate()),
 		payment.WithAccountingPeriod(t.AccountingPeriod()),
+		payment.WithVersion(dbPayment.Version),
 		payment.WithCreatedAt(dbPayment.CreatedAt),
 		payment.WithUpdatedAt(dbPayment.UpdatedAt),
 	}
@@ -257,6 +259,7 @@ func ToDomainMoneyAccount(dbAccount *models.MoneyAccount) (moneyaccount.Account,
 		moneyaccount.WithID(uuid.MustParse(dbAccount.ID)),
 		moneyaccount.WithTenantID(tenantID),
 		moneyaccount.WithAccountNumber(dbAccount.AccountNumber),
+		moneyaccount.WithVersion(dbAccount.Version),
 		moneyaccount.WithCreatedAt(dbAccount.CreatedAt),
 		moneyaccount.WithUpdatedAt(dbAccount.UpdatedAt),
 	}
@@ -282,6 +285,7 @@ func ToDBMoneyAccount(entity moneyaccount.Account) *models.MoneyAccount {
 		Balance:           balance.Amount(),
 		BalanceCurrencyID: balance.Currency().Code,
 		Description:       mapping.ValueToSQLNullString(entity.Description()),
+		Version:           entity.Version(),
 		CreatedAt:         entity.CreatedAt(),
 		UpdatedAt:         entity.UpdatedAt(),
 	}
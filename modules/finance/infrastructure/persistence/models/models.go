@@ -22,6 +22,7 @@ type MoneyAccount struct {
 	Description       sql.NullString
 	Balance           int64
 	BalanceCurrencyID string
+	Version           int
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 }
@@ -56,6 +57,7 @@ type Payment struct {
 	CounterpartyID    string
 	PaymentCategoryID sql.NullString
 	TenantID          string
+	Version           int
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 }
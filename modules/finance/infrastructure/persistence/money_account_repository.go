@@ -27,6 +27,7 @@ const (
 			ma.description,
 			ma.balance,
 			ma.balance_currency_id,
+			ma.version,
 			ma.created_at,
 			ma.updated_at
 		FROM money_accounts ma
@@ -64,8 +65,10 @@ const (
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
 	updateQuery = `
 		UPDATE money_accounts
-		SET name = $1, account_number = $2, description = $3, balance = $4, balance_currency_id = $5, updated_at = $6
-		WHERE id = $7 AND tenant_id = $8`
+		SET name = $1, account_number = $2, description = $3, balance = $4, balance_currency_id = $5, updated_at = $6,
+			version = version + 1
+		WHERE id = $7 AND tenant_id = $8 AND version = $9`
+	existsQuery        = `SELECT EXISTS (SELECT 1 FROM money_accounts WHERE id = $1 AND tenant_id = $2)`
 	deleteRelatedQuery = `DELETE FROM transactions WHERE origin_account_id = $1 OR destination_account_id = $1 AND tenant_id = $2;`
 	deleteQuery        = `DELETE FROM money_accounts WHERE id = $1 AND tenant_id = $2;`
 )
@@ -241,6 +244,9 @@ func (g *GormMoneyAccountRepository) Update(ctx context.Context, data moneyaccou
 
 	data = data.UpdateTenantID(tenantID)
 	dbAccount := ToDBMoneyAccount(data)
+	if err := repo.RequireVersion("money account", dbAccount.Version); err != nil {
+		return nil, err
+	}
 	args := []interface{}{
 		dbAccount.Name,
 		dbAccount.AccountNumber,
@@ -250,8 +256,20 @@ func (g *GormMoneyAccountRepository) Update(ctx context.Context, data moneyaccou
 		dbAccount.UpdatedAt,
 		dbAccount.ID,
 		dbAccount.TenantID,
+		dbAccount.Version,
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := tx.Exec(ctx, updateQuery, args...)
+	if err != nil {
+		return nil, err
 	}
-	if err := g.execQuery(ctx, updateQuery, args...); err != nil {
+	if err := repo.CheckVersion(
+		ctx, tx, tag, "money account", data.ID(), dbAccount.Version, ErrAccountNotFound,
+		existsQuery, dbAccount.ID, dbAccount.TenantID,
+	); err != nil {
 		return nil, err
 	}
 	return g.GetByID(ctx, data.ID())
@@ -290,6 +308,7 @@ func (g *GormMoneyAccountRepository) queryAccounts(ctx context.Context, query st
 			&r.Description,
 			&r.Balance,
 			&r.BalanceCurrencyID,
+			&r.Version,
 			&r.CreatedAt,
 			&r.UpdatedAt,
 		); err != nil {
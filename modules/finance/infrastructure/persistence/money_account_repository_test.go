@@ -1,13 +1,16 @@
 package persistence_test
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
 	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
 	moneyaccount "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/money_account"
 	financepersistence "github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/pkg/money"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
 )
 
 func TestGormMoneyAccountRepository_CRUD(t *testing.T) {
@@ -95,6 +98,7 @@ func TestGormMoneyAccountRepository_CRUD(t *testing.T) {
 				money.New(20000, "USD"),
 				moneyaccount.WithID(createdAccount.ID()),
 				moneyaccount.WithAccountNumber("123"),
+				moneyaccount.WithVersion(createdAccount.Version()),
 			)
 			if _, err := accountRepository.Update(f.Ctx, updatedAccount); err != nil {
 				t.Fatal(err)
@@ -111,4 +115,33 @@ func TestGormMoneyAccountRepository_CRUD(t *testing.T) {
 			}
 		},
 	)
+	t.Run(
+		"UpdateStaleVersion", func(t *testing.T) {
+			staleAccount := moneyaccount.New(
+				"stale",
+				money.New(30000, "USD"),
+				moneyaccount.WithID(createdAccount.ID()),
+				moneyaccount.WithVersion(createdAccount.Version()),
+			)
+			_, err := accountRepository.Update(f.Ctx, staleAccount)
+			if !serrors.IsConflict(err) {
+				t.Errorf("expected a conflict, got %v", err)
+			}
+		},
+	)
+
+	t.Run(
+		"UpdateMissing", func(t *testing.T) {
+			missingAccount := moneyaccount.New(
+				"missing",
+				money.New(30000, "USD"),
+				moneyaccount.WithID(uuid.New()),
+				moneyaccount.WithVersion(1),
+			)
+			_, err := accountRepository.Update(f.Ctx, missingAccount)
+			if !errors.Is(err, financepersistence.ErrAccountNotFound) {
+				t.Errorf("expected %v, got %v", financepersistence.ErrAccountNotFound, err)
+			}
+		},
+	)
 }
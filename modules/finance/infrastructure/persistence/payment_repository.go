@@ -22,6 +22,7 @@ const (
 		SELECT p.id,
 		p.counterparty_id,
 		p.payment_category_id,
+		p.version,
 		p.created_at,
 		p.updated_at,
 		t.id,
@@ -46,7 +47,8 @@ const (
 		updated_at
 	)
 	VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
-	paymentUpdateQuery        = `UPDATE payments SET counterparty_id = $1, updated_at = $2 WHERE id = $3`
+	paymentUpdateQuery        = `UPDATE payments SET counterparty_id = $1, updated_at = $2, version = version + 1 WHERE id = $3 AND version = $4`
+	paymentExistsQuery        = `SELECT EXISTS (SELECT 1 FROM payments WHERE id = $1)`
 	paymentDeleteRelatedQuery = `DELETE FROM transactions WHERE id = $1 AND tenant_id = $2`
 	paymentDeleteQuery        = `DELETE FROM payments WHERE id = $1`
 
@@ -195,12 +197,27 @@ func (g *GormPaymentRepository) Update(ctx context.Context, data payment.Payment
 	data = data.UpdateTenantID(tenantID)
 
 	dbPayment, dbTransaction := ToDBPayment(data)
-	if err := g.execQuery(
+	if err := repo.RequireVersion("payment", dbPayment.Version); err != nil {
+		return nil, err
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := tx.Exec(
 		ctx,
 		paymentUpdateQuery,
 		dbPayment.CounterpartyID,
 		dbPayment.UpdatedAt,
 		dbPayment.ID,
+		dbPayment.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.CheckVersion(
+		ctx, tx, tag, "payment", data.ID(), dbPayment.Version, ErrPaymentNotFound,
+		paymentExistsQuery, dbPayment.ID,
 	); err != nil {
 		return nil, err
 	}
@@ -260,6 +277,7 @@ func (g *GormPaymentRepository) queryPaymentsWithAttachments(ctx context.Context
 			&paymentRow.ID,
 			&paymentRow.CounterpartyID,
 			&paymentRow.PaymentCategoryID,
+			&paymentRow.Version,
 			&paymentRow.CreatedAt,
 			&paymentRow.UpdatedAt,
 			&transactionRow.ID,
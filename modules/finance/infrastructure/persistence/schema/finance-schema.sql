@@ -8,9 +8,13 @@ CREATE TABLE counterparty (
     legal_address varchar(255),
     created_at timestamp with time zone DEFAULT now(),
     updated_at timestamp with time zone DEFAULT now(),
-    UNIQUE (tenant_id, tin)
+    deleted_at timestamp with time zone
 );
 
+CREATE UNIQUE INDEX counterparty_tenant_id_tin_key ON counterparty (tenant_id, tin) WHERE deleted_at IS NULL;
+
+CREATE INDEX counterparty_trash_idx ON counterparty (tenant_id, deleted_at) WHERE deleted_at IS NOT NULL;
+
 CREATE TABLE counterparty_contacts (
     id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
     counterparty_id uuid NOT NULL REFERENCES counterparty (id) ON DELETE CASCADE,
@@ -54,6 +58,7 @@ CREATE TABLE money_accounts (
     description text,
     balance bigint NOT NULL,
     balance_currency_id varchar(3) NOT NULL REFERENCES currencies (code) ON DELETE CASCADE,
+    version int NOT NULL DEFAULT 1,
     created_at timestamp with time zone DEFAULT now(),
     updated_at timestamp with time zone DEFAULT now(),
     UNIQUE (tenant_id, account_number)
@@ -100,6 +105,7 @@ CREATE TABLE payments (
     transaction_id uuid NOT NULL REFERENCES transactions (id) ON DELETE RESTRICT,
     counterparty_id uuid NOT NULL REFERENCES counterparty (id) ON DELETE RESTRICT,
     payment_category_id uuid REFERENCES payment_categories (id) ON DELETE SET NULL,
+    version int NOT NULL DEFAULT 1,
     created_at timestamp with time zone DEFAULT now(),
     updated_at timestamp with time zone DEFAULT now()
 );
@@ -171,3 +177,13 @@ CREATE INDEX debts_original_amount_currency_id_idx ON debts (original_amount_cur
 
 CREATE INDEX debts_outstanding_currency_id_idx ON debts (outstanding_currency_id);
 
+-- Row-level security: rows are only visible to the tenant in app.tenant_id
+SELECT enable_tenant_isolation('counterparty');
+SELECT enable_tenant_isolation('inventory');
+SELECT enable_tenant_isolation('expense_categories');
+SELECT enable_tenant_isolation('money_accounts');
+SELECT enable_tenant_isolation('transactions');
+SELECT enable_tenant_isolation('expenses');
+SELECT enable_tenant_isolation('payment_categories');
+SELECT enable_tenant_isolation('payments');
+SELECT enable_tenant_isolation('debts');
@@ -5,12 +5,16 @@ import (
 
 	icons "github.com/iota-uz/icons/phosphor"
 	corepersistence "github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
+	corecontrollers "github.com/iota-uz/iota-sdk/modules/core/presentation/controllers"
 	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/finance/infrastructure/query"
 	"github.com/iota-uz/iota-sdk/modules/finance/presentation/controllers"
 	"github.com/iota-uz/iota-sdk/modules/finance/services"
 	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/scheduler"
 	"github.com/iota-uz/iota-sdk/pkg/spotlight"
+	"github.com/iota-uz/iota-sdk/pkg/trash"
 )
 
 //go:embed presentation/locales/*.json
@@ -30,6 +34,7 @@ func (m *Module) Register(app application.Application) error {
 	// Create upload repository for attachment functionality
 	uploadRepo := corepersistence.NewUploadRepository()
 
+	registerEventCodecs(app.EventRegistry())
 	moneyAccountService := services.NewMoneyAccountService(
 		persistence.NewMoneyAccountRepository(),
 		persistence.NewTransactionRepository(),
@@ -68,6 +73,7 @@ func (m *Module) Register(app application.Application) error {
 		services.NewDebtService(
 			persistence.NewDebtRepository(),
 			app.EventPublisher(),
+			services.WithDebtOutbox(app.Outbox()),
 		),
 		services.NewFinancialReportService(
 			query.NewPgFinancialReportsQueryRepository(),
@@ -75,6 +81,9 @@ func (m *Module) Register(app application.Application) error {
 		),
 	)
 
+	trashBins := []trash.Bin{
+		persistence.NewCounterpartyTrashBin(),
+	}
 	app.RegisterControllers(
 		controllers.NewFinancialOverviewController(app),
 		controllers.NewMoneyAccountController(app),
@@ -86,6 +95,12 @@ func (m *Module) Register(app application.Application) error {
 		controllers.NewDebtAggregateController(app),
 		controllers.NewFinancialReportController(app),
 		controllers.NewCashflowController(app),
+		corecontrollers.NewTrashController(app, "/finance/trash", trashBins...),
+	)
+	app.SchedulerRegistry().Register(
+		"finance.purge_trash", "@daily",
+		trash.PurgeTask(configuration.Use().TrashRetention, trashBins...),
+		scheduler.ForEachTenant(),
 	)
 	app.QuickLinks().Add(
 		spotlight.NewQuickLink(nil, ExpenseCategoriesItem.Name, ExpenseCategoriesItem.Href),
@@ -28,6 +28,7 @@ type MoneyAccountUpdateDTO struct {
 	AccountNumber string
 	CurrencyCode  string `validate:"len=3"`
 	Description   string
+	Version       int
 }
 
 func (p *MoneyAccountCreateDTO) Ok(ctx context.Context) (map[string]string, bool) {
@@ -102,6 +103,7 @@ func (p *MoneyAccountUpdateDTO) ToEntity(id uuid.UUID, tenantID uuid.UUID) (mone
 		moneyaccount.WithTenantID(tenantID),
 		moneyaccount.WithAccountNumber(p.AccountNumber),
 		moneyaccount.WithDescription(p.Description),
+		moneyaccount.WithVersion(p.Version),
 	), nil
 }
 
@@ -122,7 +124,8 @@ func (p *MoneyAccountUpdateDTO) Apply(existing moneyaccount.Account) (moneyaccou
 		UpdateName(p.Name).
 		UpdateBalance(balance).
 		UpdateAccountNumber(p.AccountNumber).
-		UpdateDescription(p.Description)
+		UpdateDescription(p.Description).
+		UpdateVersion(p.Version)
 
 	return existing, nil
 }
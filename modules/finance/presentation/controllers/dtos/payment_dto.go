@@ -93,6 +93,7 @@ type PaymentUpdateDTO struct {
 	Comment           string
 	UserID            uint
 	Attachments       []uint // Upload IDs to attach
+	Version           int
 }
 
 func (p *PaymentCreateDTO) Ok(ctx context.Context) (map[string]string, bool) {
@@ -189,6 +190,7 @@ func (p *PaymentUpdateDTO) ToEntity(id uuid.UUID, tenantID uuid.UUID, category p
 		)),
 		payment.WithTransactionDate(time.Time(p.TransactionDate)),
 		payment.WithAccountingPeriod(time.Time(p.AccountingPeriod)),
+		payment.WithVersion(p.Version),
 		payment.WithCreatedAt(time.Now()),
 		payment.WithUpdatedAt(time.Now()),
 	)
@@ -222,7 +224,8 @@ func (p *PaymentUpdateDTO) Apply(existing payment.Payment, category paymentcateg
 		UpdateCounterpartyID(counterpartyID).
 		UpdateComment(p.Comment).
 		UpdateTransactionDate(time.Time(p.TransactionDate)).
-		UpdateAccountingPeriod(time.Time(p.AccountingPeriod))
+		UpdateAccountingPeriod(time.Time(p.AccountingPeriod)).
+		UpdateVersion(p.Version)
 
 	return existing, nil
 }
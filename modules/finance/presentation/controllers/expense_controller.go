@@ -203,28 +203,25 @@ func (c *ExpenseController) Export(
 		WHERE ex.tenant_id = $1
 		ORDER BY ex.created_at DESC`
 
-	args := []interface{}{tenantID}
+	// The export runs on a worker, so args must survive a JSON round trip
+	args := []interface{}{tenantID.String()}
 
 	// For now, only handle Excel export (as per the example)
 	// TODO: Extend the exporter service to support other formats
 	switch format {
 	case export.ExportFormatExcel:
 		queryObj := exportconfig.NewQuery(query, args...)
-		config := exportconfig.New(exportconfig.WithFilename("expenses_export"))
-		upload, err := excelService.ExportFromQuery(
-			ctx,
-			queryObj,
-			config,
-		)
+		slug, err := excelService.EnqueueExportFromQuery(ctx, queryObj, "expenses_export")
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
+		exportURL := "/uploads/exports/" + slug
 		if htmx.IsHxRequest(r) {
-			htmx.Redirect(w, upload.URL().String())
+			htmx.Redirect(w, exportURL)
 		} else {
-			http.Redirect(w, r, upload.URL().String(), http.StatusSeeOther)
+			http.Redirect(w, r, exportURL, http.StatusSeeOther)
 		}
 	case export.ExportFormatCSV:
 		http.Error(w, "CSV export not yet implemented", http.StatusNotImplemented)
@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/iota-uz/iota-sdk/modules/core"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/currency"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
 	"github.com/iota-uz/iota-sdk/modules/finance"
 	expenseAggregate "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense"
 	expenseCategoryEntity "github.com/iota-uz/iota-sdk/modules/finance/domain/aggregates/expense_category"
@@ -19,6 +21,7 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/finance/presentation/controllers"
 	"github.com/iota-uz/iota-sdk/modules/finance/services"
 	"github.com/iota-uz/iota-sdk/pkg/itf"
+	"github.com/iota-uz/iota-sdk/pkg/jobs"
 	"github.com/iota-uz/iota-sdk/pkg/money"
 	"github.com/iota-uz/iota-sdk/pkg/rbac"
 	"github.com/iota-uz/iota-sdk/pkg/shared"
@@ -753,8 +756,26 @@ func TestExpenseController_Export_Excel_Success(t *testing.T) {
 	require.True(t, statusCode == 302 || statusCode == 303, "Expected 302 or 303, got %d", statusCode)
 
 	redirectLocation := response.Header("Location")
-	require.NotEmpty(t, redirectLocation)
-	require.Contains(t, redirectLocation, ".xlsx") // Check for Excel file extension
+	require.True(t, strings.HasPrefix(redirectLocation, "/uploads/exports/"), "unexpected redirect %q", redirectLocation)
+	slug := strings.TrimPrefix(redirectLocation, "/uploads/exports/")
+
+	// Workers cannot see the uncommitted test transaction, so run the enqueued job through the registry
+	job := &jobs.Job{TenantID: env.Tenant.ID}
+	err = env.Tx.QueryRow(
+		env.Ctx,
+		"SELECT id, kind, payload FROM jobs WHERE unique_key = $1 AND status = 'pending'",
+		slug,
+	).Scan(&job.ID, &job.Kind, &job.Payload)
+	require.NoError(t, err)
+	require.Equal(t, coreservices.ExcelExportArgs{}.Kind(), job.Kind)
+	require.NoError(t, env.App.JobRegistry().Run(env.Ctx, job))
+
+	excelService := env.App.Service(coreservices.ExcelExportService{}).(*coreservices.ExcelExportService)
+	exported, status, err := excelService.ExportStatus(env.Ctx, slug)
+	require.NoError(t, err)
+	require.Equal(t, jobs.StatusCompleted, status)
+	require.Equal(t, slug, exported.Slug())
+	require.Contains(t, exported.URL().String(), ".xlsx") // Check for Excel file extension
 }
 
 func TestExpenseController_Export_InvalidFormat(t *testing.T) {
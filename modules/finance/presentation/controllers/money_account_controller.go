@@ -324,6 +324,9 @@ func (c *MoneyAccountController) Update(w http.ResponseWriter, r *http.Request)
 			return
 		}
 		if _, err := c.moneyAccountService.Update(r.Context(), entity); err != nil {
+			if shared.RenderConflict(w, r, err) {
+				return
+			}
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
@@ -344,6 +347,10 @@ func (c *MoneyAccountController) Update(w http.ResponseWriter, r *http.Request)
 		}
 
 		if isDrawer {
+			// Keep the version the user started editing at, so resubmitting still detects conflicts
+			if dto.Version > 0 {
+				entity = entity.UpdateVersion(dto.Version)
+			}
 			props := &moneyaccounts.DrawerEditProps{
 				Account:    mappers.MoneyAccountToViewModel(entity),
 				UpdateData: mappers.MoneyAccountToViewUpdateModel(entity),
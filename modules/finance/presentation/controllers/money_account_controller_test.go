@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
 	"testing"
 
 	"github.com/google/uuid"
@@ -334,6 +335,7 @@ func TestMoneyAccountController_Update_Success(t *testing.T) {
 	formData.Set("CurrencyCode", "EUR")
 	formData.Set("AccountNumber", "UPD001")
 	formData.Set("Description", "Updated description")
+	formData.Set("Version", strconv.Itoa(createdAccount.Version()))
 
 	suite.POST(fmt.Sprintf("%s/%s", MoneyAccountBasePath, createdAccount.ID().String())).
 		Form(formData).
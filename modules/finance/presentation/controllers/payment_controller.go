@@ -293,6 +293,10 @@ func (c *PaymentsController) Update(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		// Keep the version the user started editing at, so resubmitting still detects conflicts
+		if dto.Version > 0 {
+			paymentViewModel.Version = strconv.Itoa(dto.Version)
+		}
 		accounts, err := c.viewModelAccounts(r)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -348,6 +352,9 @@ func (c *PaymentsController) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if _, err := c.paymentService.Update(r.Context(), entity); err != nil {
+		if shared.RenderConflict(w, r, err) {
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
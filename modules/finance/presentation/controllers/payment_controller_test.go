@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
 	"testing"
 	"time"
 
@@ -554,6 +555,7 @@ func TestPaymentController_Update_Success(t *testing.T) {
 	formData.Set("PaymentCategoryID", createdCategory.ID().String())
 	formData.Set("CounterpartyID", createdCounterparty.ID().String())
 	formData.Set("Comment", "Updated payment comment")
+	formData.Set("Version", strconv.Itoa(createdPayment1.Version()))
 	formData.Set("TransactionDate", time.Time(shared.DateOnly(now)).Format(time.DateOnly))
 	formData.Set("AccountingPeriod", time.Time(shared.DateOnly(now)).Format(time.DateOnly))
 
@@ -2,6 +2,7 @@ package mappers
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
@@ -71,6 +72,7 @@ func MoneyAccountToViewUpdateModel(entity moneyaccount.Account) *viewmodels.Mone
 		AccountNumber: entity.AccountNumber(),
 		Balance:       fmt.Sprintf("%.2f", balance.AsMajorUnits()),
 		CurrencyCode:  balance.Currency().Code,
+		Version:       strconv.Itoa(entity.Version()),
 	}
 }
 
@@ -88,6 +90,7 @@ func PaymentToViewModel(entity payment.Payment) *viewmodels.Payment {
 		TransactionDate:    entity.TransactionDate().Format(time.DateOnly),
 		AccountingPeriod:   entity.AccountingPeriod().Format(time.DateOnly),
 		Comment:            entity.Comment(),
+		Version:            strconv.Itoa(entity.Version()),
 		CreatedAt:          entity.CreatedAt().Format(time.RFC3339),
 		UpdatedAt:          entity.UpdatedAt().Format(time.RFC3339),
 	}
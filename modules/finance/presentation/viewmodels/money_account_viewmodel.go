@@ -15,6 +15,7 @@ type MoneyAccountUpdateDTO struct {
 	AccountNumber string
 	Balance       string
 	CurrencyCode  string
+	Version       string
 }
 
 type MoneyAccount struct {
@@ -16,6 +16,7 @@ type Payment struct {
 	TransactionDate    string
 	AccountingPeriod   string
 	Comment            string
+	Version            string
 	CreatedAt          string
 	UpdatedAt          string
 	Attachments        []*viewmodels.Upload // File attachments
@@ -15,16 +15,48 @@ import (
 type DebtService struct {
 	repo      debt.Repository
 	publisher eventbus.EventBus
+	outbox    eventbus.Outbox
+}
+
+type DebtServiceOption func(s *DebtService)
+
+// WithDebtOutbox enqueues debt events in the transactional outbox, in the same
+// database transaction as the change that produced them, instead of publishing
+// them after it commits. The outbox dispatcher delivers them.
+func WithDebtOutbox(outbox eventbus.Outbox) DebtServiceOption {
+	return func(s *DebtService) {
+		s.outbox = outbox
+	}
 }
 
 func NewDebtService(
 	repo debt.Repository,
 	publisher eventbus.EventBus,
+	opts ...DebtServiceOption,
 ) *DebtService {
-	return &DebtService{
+	s := &DebtService{
 		repo:      repo,
 		publisher: publisher,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// enqueue records event in the outbox when one is configured. ctx must carry the transaction.
+func (s *DebtService) enqueue(ctx context.Context, event interface{}) error {
+	if s.outbox == nil {
+		return nil
+	}
+	return s.outbox.Enqueue(ctx, event)
+}
+
+// publish delivers event of a committed change, unless it went through the outbox
+func (s *DebtService) publish(event interface{}) {
+	if s.outbox == nil {
+		s.publisher.Publish(event)
+	}
 }
 
 func (s *DebtService) GetByID(ctx context.Context, id uuid.UUID) (debt.Debt, error) {
@@ -78,14 +110,17 @@ func (s *DebtService) Create(ctx context.Context, entity debt.Debt) (debt.Debt,
 	err = composables.InTx(ctx, func(txCtx context.Context) error {
 		var err error
 		createdEntity, err = s.repo.Create(txCtx, entity)
-		return err
+		if err != nil {
+			return err
+		}
+		createdEvent.Result = createdEntity
+		return s.enqueue(txCtx, createdEvent)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	createdEvent.Result = createdEntity
-	s.publisher.Publish(createdEvent)
+	s.publish(createdEvent)
 	return createdEntity, nil
 }
 
@@ -103,14 +138,17 @@ func (s *DebtService) Update(ctx context.Context, entity debt.Debt) (debt.Debt,
 	err = composables.InTx(ctx, func(txCtx context.Context) error {
 		var err error
 		updatedEntity, err = s.repo.Update(txCtx, entity)
-		return err
+		if err != nil {
+			return err
+		}
+		updatedEvent.Result = updatedEntity
+		return s.enqueue(txCtx, updatedEvent)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	updatedEvent.Result = updatedEntity
-	s.publisher.Publish(updatedEvent)
+	s.publish(updatedEvent)
 	return updatedEntity, nil
 }
 
@@ -151,21 +189,26 @@ func (s *DebtService) Settle(ctx context.Context, debtID uuid.UUID, settlementAm
 		settledDebt = settledDebt.UpdateSettlementTransactionID(transactionID)
 	}
 
-	var updatedEntity debt.Debt
+	var (
+		updatedEntity debt.Debt
+		settledEvent  *debt.Settled
+	)
 	err = composables.InTx(ctx, func(txCtx context.Context) error {
 		updatedEntity, err = s.repo.Update(txCtx, settledDebt)
-		return err
+		if err != nil {
+			return err
+		}
+		settledEvent, err = debt.NewDebtSettledEvent(ctx, updatedEntity)
+		if err != nil {
+			return err
+		}
+		return s.enqueue(txCtx, settledEvent)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Publish debt settled event
-	settledEvent, err := debt.NewDebtSettledEvent(ctx, updatedEntity)
-	if err != nil {
-		return nil, err
-	}
-	s.publisher.Publish(settledEvent)
+	s.publish(settledEvent)
 	return updatedEntity, nil
 }
 
@@ -185,21 +228,26 @@ func (s *DebtService) WriteOff(ctx context.Context, debtID uuid.UUID) (debt.Debt
 		UpdateStatus(debt.DebtStatusWrittenOff).
 		UpdateOutstandingAmount(money.New(0, zeroCurrency))
 
-	var updatedEntity debt.Debt
+	var (
+		updatedEntity   debt.Debt
+		writtenOffEvent *debt.WrittenOff
+	)
 	err = composables.InTx(ctx, func(txCtx context.Context) error {
 		updatedEntity, err = s.repo.Update(txCtx, writtenOffDebt)
-		return err
+		if err != nil {
+			return err
+		}
+		writtenOffEvent, err = debt.NewDebtWrittenOffEvent(ctx, updatedEntity)
+		if err != nil {
+			return err
+		}
+		return s.enqueue(txCtx, writtenOffEvent)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Publish debt written off event
-	writtenOffEvent, err := debt.NewDebtWrittenOffEvent(ctx, updatedEntity)
-	if err != nil {
-		return nil, err
-	}
-	s.publisher.Publish(writtenOffEvent)
+	s.publish(writtenOffEvent)
 	return updatedEntity, nil
 }
 
@@ -219,13 +267,16 @@ func (s *DebtService) Delete(ctx context.Context, id uuid.UUID) (debt.Debt, erro
 	}
 
 	err = composables.InTx(ctx, func(txCtx context.Context) error {
-		return s.repo.Delete(txCtx, id)
+		if err := s.repo.Delete(txCtx, id); err != nil {
+			return err
+		}
+		return s.enqueue(txCtx, deletedEvent)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	s.publisher.Publish(deletedEvent)
+	s.publish(deletedEvent)
 	return entity, nil
 }
 
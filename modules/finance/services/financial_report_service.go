@@ -32,6 +32,9 @@ func NewFinancialReportService(
 
 // GenerateIncomeStatement generates an income statement for a specific period
 func (s *FinancialReportService) GenerateIncomeStatement(ctx context.Context, startDate, endDate time.Time) (*value_objects.IncomeStatement, error) {
+	// Reports only read, so they can be served by a replica
+	ctx = composables.WithReadOnly(ctx)
+
 	tenantID, err := composables.UseTenantID(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get tenant ID")
@@ -138,6 +141,8 @@ func (s *FinancialReportService) determinePrimaryCurrency(data *query.IncomeStat
 
 // GenerateCashflowStatement generates a cashflow statement for a specific account and period
 func (s *FinancialReportService) GenerateCashflowStatement(ctx context.Context, accountID uuid.UUID, startDate, endDate time.Time) (*value_objects.CashflowStatement, error) {
+	ctx = composables.WithReadOnly(ctx)
+
 	// Get cashflow data from query repository
 	data, err := s.queryRepo.GetCashflowData(ctx, accountID, startDate, endDate)
 	if err != nil {
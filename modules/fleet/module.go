@@ -41,7 +41,6 @@ func (m *Module) Register(app application.Application) error {
 	fuelService := services.NewFuelService(fuelEntryRepo, app.EventPublisher())
 	analyticsService := services.NewAnalyticsService(vehicleRepo, driverRepo, tripRepo, maintenanceRepo, fuelEntryRepo)
 	notificationService := services.NewNotificationService(vehicleService, driverService, maintenanceService, fuelService, app.EventPublisher(), logger)
-	schedulerService := services.NewSchedulerService(notificationService, logger)
 
 	app.RegisterServices(
 		vehicleService,
@@ -51,8 +50,8 @@ func (m *Module) Register(app application.Application) error {
 		fuelService,
 		analyticsService,
 		notificationService,
-		schedulerService,
 	)
+	registerScheduledTasks(app.SchedulerRegistry(), notificationService)
 
 	app.RegisterControllers(
 		controllers.NewVehicleController(app),
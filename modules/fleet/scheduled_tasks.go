@@ -0,0 +1,38 @@
+package fleet
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/modules/fleet/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/scheduler"
+)
+
+const expiryNoticeDays = 30
+
+func registerScheduledTasks(registry *scheduler.Registry, notificationService *services.NotificationService) {
+	registry.Register("fleet.check_expiring_licenses", "@daily", forTenant(func(ctx context.Context, tenantID uuid.UUID) error {
+		return notificationService.CheckExpiringLicenses(ctx, tenantID, expiryNoticeDays)
+	}), scheduler.ForEachTenant())
+
+	registry.Register("fleet.check_expiring_registrations", "@daily", forTenant(func(ctx context.Context, tenantID uuid.UUID) error {
+		return notificationService.CheckExpiringRegistrations(ctx, tenantID, expiryNoticeDays)
+	}), scheduler.ForEachTenant())
+
+	registry.Register("fleet.check_expiring_insurance", "@daily", forTenant(func(ctx context.Context, tenantID uuid.UUID) error {
+		return notificationService.CheckExpiringInsurance(ctx, tenantID, expiryNoticeDays)
+	}), scheduler.ForEachTenant())
+
+	registry.Register("fleet.check_due_maintenance", "@daily", forTenant(notificationService.CheckDueMaintenance), scheduler.ForEachTenant())
+}
+
+func forTenant(fn func(ctx context.Context, tenantID uuid.UUID) error) scheduler.TaskFunc {
+	return func(ctx context.Context) error {
+		tenantID, err := composables.UseTenantID(ctx)
+		if err != nil {
+			return err
+		}
+		return fn(ctx, tenantID)
+	}
+}
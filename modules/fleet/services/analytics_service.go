@@ -78,6 +78,7 @@ func NewAnalyticsService(
 }
 
 func (s *AnalyticsService) GetDashboardStats(ctx context.Context, tenantID uuid.UUID) (*DashboardStats, error) {
+	ctx = composables.WithReadOnly(ctx)
 	if err := composables.CanUser(ctx, permissions.VehicleRead); err != nil {
 		return nil, fmt.Errorf("permission check failed: %w", err)
 	}
@@ -184,6 +185,7 @@ func (s *AnalyticsService) GetDashboardStats(ctx context.Context, tenantID uuid.
 }
 
 func (s *AnalyticsService) GetUtilizationReport(ctx context.Context, tenantID uuid.UUID, startDate, endDate time.Time) ([]UtilizationReport, error) {
+	ctx = composables.WithReadOnly(ctx)
 	if err := composables.CanUser(ctx, permissions.VehicleRead); err != nil {
 		return nil, err
 	}
@@ -248,6 +250,7 @@ func (s *AnalyticsService) GetUtilizationReport(ctx context.Context, tenantID uu
 }
 
 func (s *AnalyticsService) GetCostAnalysis(ctx context.Context, tenantID uuid.UUID, startDate, endDate time.Time) ([]CostAnalysis, error) {
+	ctx = composables.WithReadOnly(ctx)
 	if err := composables.CanUser(ctx, permissions.VehicleRead); err != nil {
 		return nil, err
 	}
@@ -333,6 +336,7 @@ func (s *AnalyticsService) GetCostAnalysis(ctx context.Context, tenantID uuid.UU
 }
 
 func (s *AnalyticsService) GetTrendData(ctx context.Context, tenantID uuid.UUID, startDate, endDate time.Time) ([]TrendData, error) {
+	ctx = composables.WithReadOnly(ctx)
 	if err := composables.CanUser(ctx, permissions.VehicleRead); err != nil {
 		return nil, err
 	}
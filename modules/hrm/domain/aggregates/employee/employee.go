@@ -62,6 +62,12 @@ func WithNotes(notes string) Option {
 	}
 }
 
+func WithVersion(version int) Option {
+	return func(e *employee) {
+		e.version = version
+	}
+}
+
 func WithCreatedAt(createdAt time.Time) Option {
 	return func(e *employee) {
 		e.createdAt = createdAt
@@ -97,6 +103,10 @@ type Employee interface {
 	UpdateName(firstName, lastName, middleName string) Employee
 	MarkAsResigned(date time.Time) Employee
 
+	// Version is the optimistic concurrency version the employee was read at, 0 for new ones.
+	// Repository.Update requires it and fails with a *serrors.ConflictError when it is stale.
+	Version() int
+
 	// Timestamps
 	CreatedAt() time.Time
 	UpdatedAt() time.Time
@@ -194,6 +204,7 @@ type employee struct {
 	hireDate        time.Time
 	resignationDate *time.Time
 	notes           string
+	version         int
 	createdAt       time.Time
 	updatedAt       time.Time
 }
@@ -282,6 +293,10 @@ func (e *employee) Notes() string {
 	return e.notes
 }
 
+func (e *employee) Version() int {
+	return e.version
+}
+
 func (e *employee) CreatedAt() time.Time {
 	return e.createdAt
 }
@@ -33,6 +33,7 @@ type UpdateDTO struct {
 	SecondaryLanguage string
 	AvatarID          uint
 	Notes             string
+	Version           int
 }
 
 func (d *UpdateDTO) Ok(ctx context.Context) (map[string]string, bool) {
@@ -132,7 +133,7 @@ func (d *UpdateDTO) ToEntity(id uint) (Employee, error) {
 	if !time.Time(d.BirthDate).IsZero() {
 		opts = append(opts, WithBirthDate(time.Time(d.BirthDate)))
 	}
-	opts = append(opts, WithUpdatedAt(time.Now()))
+	opts = append(opts, WithVersion(d.Version), WithUpdatedAt(time.Now()))
 
 	return NewWithID(
 		id,
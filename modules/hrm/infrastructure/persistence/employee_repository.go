@@ -30,6 +30,7 @@ const (
 		       e.hourly_rate,
 		       e.coefficient,
 		       e.avatar_id,
+		       e.version,
 		       e.created_at,
 		       e.updated_at,
 		       em.primary_language,
@@ -64,8 +65,9 @@ const (
 		UPDATE employees
 		   SET first_name = $1, last_name = $2, middle_name = $3, email = $4, phone = $5,
 		       salary = $6, salary_currency_id = $7, hourly_rate = $8, coefficient = $9,
-		       avatar_id = $10, updated_at = $11
-		WHERE id = $12 AND tenant_id = $13`
+		       avatar_id = $10, updated_at = $11, version = version + 1
+		WHERE id = $12 AND tenant_id = $13 AND version = $14`
+	employeeExistsQuery = `SELECT EXISTS (SELECT 1 FROM employees WHERE id = $1 AND tenant_id = $2)`
 
 	employeeUpdateMetaQuery = `
 		UPDATE employee_meta
@@ -203,7 +205,14 @@ func (g *GormEmployeeRepository) Update(ctx context.Context, data employee.Emplo
 	}
 
 	dbEmployee, dbMeta := toDBEmployee(data)
-	if err := g.execQuery(
+	if err := repo.RequireVersion("employee", dbEmployee.Version); err != nil {
+		return err
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tag, err := tx.Exec(
 		ctx,
 		employeeUpdateQuery,
 		dbEmployee.FirstName,
@@ -219,6 +228,14 @@ func (g *GormEmployeeRepository) Update(ctx context.Context, data employee.Emplo
 		dbEmployee.UpdatedAt,
 		dbEmployee.ID,
 		tenantID,
+		dbEmployee.Version,
+	)
+	if err != nil {
+		return err
+	}
+	if err := repo.CheckVersion(
+		ctx, tx, tag, "employee", dbEmployee.ID, dbEmployee.Version, ErrEmployeeNotFound,
+		employeeExistsQuery, dbEmployee.ID, tenantID,
 	); err != nil {
 		return err
 	}
@@ -275,6 +292,7 @@ func (g *GormEmployeeRepository) queryEmployees(ctx context.Context, query strin
 			&employeeRow.HourlyRate,
 			&employeeRow.Coefficient,
 			&employeeRow.AvatarID,
+			&employeeRow.Version,
 			&employeeRow.CreatedAt,
 			&employeeRow.UpdatedAt,
 			&metaRow.PrimaryLanguage,
@@ -55,6 +55,7 @@ func toDomainEmployee(dbEmployee *models.Employee, dbMeta *models.EmployeeMeta)
 		employee.WithBirthDate(dbMeta.BirthDate.Time),
 		employee.WithResignationDate(mapping.SQLNullTimeToPointer(dbMeta.ResignationDate)),
 		employee.WithNotes(dbMeta.Notes.String),
+		employee.WithVersion(dbEmployee.Version),
 		employee.WithCreatedAt(dbEmployee.CreatedAt),
 		employee.WithUpdatedAt(dbEmployee.UpdatedAt),
 	), nil
@@ -72,6 +73,7 @@ func toDBEmployee(entity employee.Employee) (*models.Employee, *models.EmployeeM
 		SalaryCurrencyID: mapping.ValueToSQLNullString(salary.Currency().Code),
 		Email:            entity.Email().Value(),
 		Phone:            mapping.ValueToSQLNullString(entity.Phone()),
+		Version:          entity.Version(),
 		CreatedAt:        entity.CreatedAt(),
 		UpdatedAt:        entity.UpdatedAt(),
 	}
@@ -27,6 +27,7 @@ type Employee struct {
 	HourlyRate       float64
 	Coefficient      float64
 	AvatarID         *uint
+	Version          int
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
 }
@@ -21,6 +21,7 @@ CREATE TABLE employees (
     hourly_rate numeric(9, 2) NOT NULL,
     coefficient float NOT NULL,
     avatar_id int REFERENCES uploads (id) ON DELETE SET NULL,
+    version int NOT NULL DEFAULT 1,
     created_at timestamp with time zone DEFAULT now(),
     updated_at timestamp with time zone DEFAULT now(),
     UNIQUE (tenant_id, email),
@@ -66,3 +67,6 @@ CREATE INDEX employees_email_idx ON employees (email);
 
 CREATE INDEX employees_phone_idx ON employees (phone);
 
+-- Row-level security: rows are only visible to the tenant in app.tenant_id
+SELECT enable_tenant_isolation('positions');
+SELECT enable_tenant_isolation('employees');
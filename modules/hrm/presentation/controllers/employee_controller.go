@@ -233,6 +233,9 @@ func (c *EmployeeController) Update(w http.ResponseWriter, r *http.Request) {
 	if ok {
 		err := c.employeeService.Update(r.Context(), id, dto)
 		if err != nil {
+			if shared.RenderConflict(w, r, err) {
+				return
+			}
 			l, ok := intl.UseLocalizer(r.Context())
 			if !ok {
 				http.Error(w, fmt.Sprintf("%+v", err), http.StatusInternalServerError)
@@ -282,8 +285,13 @@ func (c *EmployeeController) Update(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Error retrieving employee", http.StatusInternalServerError)
 			return
 		}
+		vm := mappers.EmployeeToViewModel(entity)
+		// Keep the version the user started editing at, so resubmitting still detects conflicts
+		if dto.Version > 0 {
+			vm.Version = strconv.Itoa(dto.Version)
+		}
 		props := &employees.EditPageProps{
-			Employee:  mappers.EmployeeToViewModel(entity),
+			Employee:  vm,
 			Errors:    errorsMap,
 			SaveURL:   fmt.Sprintf("%s/%d", c.basePath, id),
 			DeleteURL: fmt.Sprintf("%s/%d", c.basePath, id),
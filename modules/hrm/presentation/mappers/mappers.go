@@ -34,6 +34,7 @@ func EmployeeToViewModel(entity employee.Employee) *viewmodels.Employee {
 		HireDate:        entity.HireDate().Format(time.DateOnly),
 		ResignationDate: entity.BirthDate().Format(time.DateOnly),
 		Notes:           entity.Notes(),
+		Version:         strconv.Itoa(entity.Version()),
 		UpdatedAt:       entity.UpdatedAt().Format(time.RFC3339),
 		CreatedAt:       entity.CreatedAt().Format(time.RFC3339),
 	}
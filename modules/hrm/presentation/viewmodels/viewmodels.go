@@ -14,6 +14,7 @@ type Employee struct {
 	HireDate        string
 	ResignationDate string
 	Notes           string
+	Version         string
 	CreatedAt       string
 	UpdatedAt       string
 }
@@ -30,3 +30,6 @@ CREATE INDEX authentication_logs_user_id_idx ON authentication_logs (user_id);
 
 CREATE INDEX authentication_logs_created_at_idx ON authentication_logs (created_at);
 
+-- Row-level security: rows are only visible to the tenant in app.tenant_id
+SELECT enable_tenant_isolation('authentication_logs');
+SELECT enable_tenant_isolation('action_logs');
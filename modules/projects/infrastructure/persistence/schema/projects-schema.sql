@@ -52,3 +52,5 @@ CREATE INDEX project_stage_payments_project_stage_id_idx ON project_stage_paymen
 
 CREATE INDEX project_stage_payments_payment_id_idx ON project_stage_payments (payment_id);
 
+-- Row-level security: rows are only visible to the tenant in app.tenant_id
+SELECT enable_tenant_isolation('projects');
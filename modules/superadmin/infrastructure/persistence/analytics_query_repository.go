@@ -182,7 +182,7 @@ func (r *pgAnalyticsQueryRepository) fieldMapping() map[Field]string {
 }
 
 func (r *pgAnalyticsQueryRepository) GetDashboardMetrics(ctx context.Context, startDate, endDate time.Time) (*entities.Analytics, error) {
-	tx, err := composables.UseTx(ctx)
+	tx, err := composables.UseReadTx(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get transaction")
 	}
@@ -204,7 +204,7 @@ func (r *pgAnalyticsQueryRepository) GetDashboardMetrics(ctx context.Context, st
 }
 
 func (r *pgAnalyticsQueryRepository) GetTenantCount(ctx context.Context) (int, error) {
-	tx, err := composables.UseTx(ctx)
+	tx, err := composables.UseReadTx(ctx)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to get transaction")
 	}
@@ -219,7 +219,7 @@ func (r *pgAnalyticsQueryRepository) GetTenantCount(ctx context.Context) (int, e
 }
 
 func (r *pgAnalyticsQueryRepository) GetUserCount(ctx context.Context) (int, error) {
-	tx, err := composables.UseTx(ctx)
+	tx, err := composables.UseReadTx(ctx)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to get transaction")
 	}
@@ -234,7 +234,7 @@ func (r *pgAnalyticsQueryRepository) GetUserCount(ctx context.Context) (int, err
 }
 
 func (r *pgAnalyticsQueryRepository) GetActiveUsersCount(ctx context.Context, since time.Time) (int, error) {
-	tx, err := composables.UseTx(ctx)
+	tx, err := composables.UseReadTx(ctx)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to get transaction")
 	}
@@ -249,7 +249,7 @@ func (r *pgAnalyticsQueryRepository) GetActiveUsersCount(ctx context.Context, si
 }
 
 func (r *pgAnalyticsQueryRepository) ListTenants(ctx context.Context, limit, offset int, sortBy SortBy) ([]*entities.TenantInfo, int, error) {
-	tx, err := composables.UseTx(ctx)
+	tx, err := composables.UseReadTx(ctx)
 	if err != nil {
 		return nil, 0, errors.Wrap(err, "failed to get transaction")
 	}
@@ -306,7 +306,7 @@ func (r *pgAnalyticsQueryRepository) ListTenants(ctx context.Context, limit, off
 }
 
 func (r *pgAnalyticsQueryRepository) SearchTenants(ctx context.Context, search string, limit, offset int, sortBy SortBy) ([]*entities.TenantInfo, int, error) {
-	tx, err := composables.UseTx(ctx)
+	tx, err := composables.UseReadTx(ctx)
 	if err != nil {
 		return nil, 0, errors.Wrap(err, "failed to get transaction")
 	}
@@ -366,7 +366,7 @@ func (r *pgAnalyticsQueryRepository) SearchTenants(ctx context.Context, search s
 }
 
 func (r *pgAnalyticsQueryRepository) FilterTenantsByDateRange(ctx context.Context, startDate, endDate time.Time, limit, offset int, sortBy SortBy) ([]*entities.TenantInfo, int, error) {
-	tx, err := composables.UseTx(ctx)
+	tx, err := composables.UseReadTx(ctx)
 	if err != nil {
 		return nil, 0, errors.Wrap(err, "failed to get transaction")
 	}
@@ -423,7 +423,7 @@ func (r *pgAnalyticsQueryRepository) FilterTenantsByDateRange(ctx context.Contex
 }
 
 func (r *pgAnalyticsQueryRepository) GetTenantDetails(ctx context.Context, tenantID uuid.UUID) (*entities.TenantInfo, error) {
-	tx, err := composables.UseTx(ctx)
+	tx, err := composables.UseReadTx(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get transaction")
 	}
@@ -450,7 +450,7 @@ func (r *pgAnalyticsQueryRepository) GetTenantDetails(ctx context.Context, tenan
 }
 
 func (r *pgAnalyticsQueryRepository) GetUserSignupsTimeSeries(ctx context.Context, startDate, endDate time.Time) ([]entities.TimeSeriesDataPoint, error) {
-	tx, err := composables.UseTx(ctx)
+	tx, err := composables.UseReadTx(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get transaction")
 	}
@@ -479,7 +479,7 @@ func (r *pgAnalyticsQueryRepository) GetUserSignupsTimeSeries(ctx context.Contex
 }
 
 func (r *pgAnalyticsQueryRepository) GetTenantSignupsTimeSeries(ctx context.Context, startDate, endDate time.Time) ([]entities.TimeSeriesDataPoint, error) {
-	tx, err := composables.UseTx(ctx)
+	tx, err := composables.UseReadTx(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get transaction")
 	}
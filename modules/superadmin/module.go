@@ -8,6 +8,9 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/superadmin/presentation/controllers"
 	"github.com/iota-uz/iota-sdk/modules/superadmin/services"
 	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/jobs"
+	"github.com/iota-uz/iota-sdk/pkg/scheduler"
 )
 
 //go:embed presentation/locales/*.toml
@@ -46,12 +49,18 @@ func (m *Module) Register(app application.Application) error {
 		services.NewAnalyticsService(analyticsRepo),
 		services.NewTenantService(analyticsRepo),
 		services.NewTenantUsersService(userRepo),
+		services.NewDeadLetterService(eventbus.NewPgDeadLetterStore(app.DB())),
+		services.NewJobService(jobs.NewPgStore(app.DB())),
+		services.NewScheduledTaskService(scheduler.NewPgHistory(app.DB())),
 	)
 
 	// Register controllers
 	app.RegisterControllers(
 		controllers.NewDashboardController(app),
 		controllers.NewTenantsController(app),
+		controllers.NewDeadLettersController(app),
+		controllers.NewJobsController(app),
+		controllers.NewSchedulerController(app),
 	)
 
 	return nil
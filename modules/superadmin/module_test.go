@@ -65,7 +65,7 @@ func TestNavItems(t *testing.T) {
 		t.Parallel()
 
 		assert.NotEmpty(t, superadmin.NavItems, "navigation items should be defined")
-		assert.Len(t, superadmin.NavItems, 2, "should have 2 navigation items")
+		assert.Len(t, superadmin.NavItems, 5, "should have 5 navigation items")
 	})
 
 	t.Run("DashboardLink", func(t *testing.T) {
@@ -83,4 +83,20 @@ func TestNavItems(t *testing.T) {
 		assert.Equal(t, "/superadmin/tenants", superadmin.TenantsLink.Href)
 		assert.NotNil(t, superadmin.TenantsLink.Icon)
 	})
+
+	t.Run("JobsLink", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "SuperAdmin.NavigationLinks.Jobs", superadmin.JobsLink.Name)
+		assert.Equal(t, "/superadmin/jobs", superadmin.JobsLink.Href)
+		assert.NotNil(t, superadmin.JobsLink.Icon)
+	})
+
+	t.Run("SchedulerLink", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "SuperAdmin.NavigationLinks.Scheduler", superadmin.SchedulerLink.Name)
+		assert.Equal(t, "/superadmin/scheduler", superadmin.SchedulerLink.Href)
+		assert.NotNil(t, superadmin.SchedulerLink.Icon)
+	})
 }
@@ -17,7 +17,28 @@ var TenantsLink = types.NavigationItem{
 	Href: "/superadmin/tenants",
 }
 
+var DeadLettersLink = types.NavigationItem{
+	Name: "SuperAdmin.NavigationLinks.DeadLetters",
+	Icon: icons.WarningCircle(icons.Props{Size: "20"}),
+	Href: "/superadmin/dead-letters",
+}
+
+var JobsLink = types.NavigationItem{
+	Name: "SuperAdmin.NavigationLinks.Jobs",
+	Icon: icons.Queue(icons.Props{Size: "20"}),
+	Href: "/superadmin/jobs",
+}
+
+var SchedulerLink = types.NavigationItem{
+	Name: "SuperAdmin.NavigationLinks.Scheduler",
+	Icon: icons.Clock(icons.Props{Size: "20"}),
+	Href: "/superadmin/scheduler",
+}
+
 var NavItems = []types.NavigationItem{
 	DashboardLink,
 	TenantsLink,
+	DeadLettersLink,
+	JobsLink,
+	SchedulerLink,
 }
@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/iota-uz/iota-sdk/modules/superadmin/presentation/templates/pages/deadletters"
+	"github.com/iota-uz/iota-sdk/modules/superadmin/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/di"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/htmx"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/sirupsen/logrus"
+
+	superadminMiddleware "github.com/iota-uz/iota-sdk/modules/superadmin/middleware"
+)
+
+type DeadLettersController struct {
+	app      application.Application
+	basePath string
+}
+
+func NewDeadLettersController(app application.Application) application.Controller {
+	return &DeadLettersController{
+		app:      app,
+		basePath: "/superadmin/dead-letters",
+	}
+}
+
+func (c *DeadLettersController) Key() string {
+	return c.basePath
+}
+
+func (c *DeadLettersController) Register(r *mux.Router) {
+	router := r.PathPrefix(c.basePath).Subrouter()
+	router.Use(
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		superadminMiddleware.RequireSuperAdmin(),
+		middleware.ProvideDynamicLogo(c.app),
+		middleware.ProvideLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	)
+	router.HandleFunc("", di.H(c.Index)).Methods(http.MethodGet)
+	router.HandleFunc("/{id}", di.H(c.Details)).Methods(http.MethodGet)
+	router.HandleFunc("/{id}/replay", di.H(c.Replay)).Methods(http.MethodPost)
+	router.HandleFunc("/{id}", di.H(c.Discard)).Methods(http.MethodDelete)
+}
+
+// Index renders the dead letters table page and handles HTMX filtering requests
+func (c *DeadLettersController) Index(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	deadLetterService *services.DeadLetterService,
+) {
+	params := composables.UsePaginated(r)
+	status := eventbus.DeadLetterStatus(r.URL.Query().Get("status"))
+
+	deadLetters, total, err := deadLetterService.FindDeadLetters(r.Context(), params.Limit, params.Offset, status)
+	if err != nil {
+		logger.Errorf("Error retrieving dead letters: %v", err)
+		http.Error(w, "Error retrieving dead letters", http.StatusInternalServerError)
+		return
+	}
+
+	props := &deadletters.IndexPageProps{
+		DeadLetters: deadLetters,
+		Total:       total,
+		Status:      string(status),
+	}
+
+	if htmx.IsHxRequest(r) {
+		templ.Handler(deadletters.TableRows(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(deadletters.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+// Details renders a drawer with the failed event payload and error
+func (c *DeadLettersController) Details(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	deadLetterService *services.DeadLetterService,
+) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		logger.Errorf("Invalid dead letter ID: %v", err)
+		http.Error(w, "Invalid dead letter ID", http.StatusBadRequest)
+		return
+	}
+
+	dl, err := deadLetterService.GetByID(r.Context(), id)
+	if err != nil {
+		logger.Errorf("Error retrieving dead letter %s: %v", id, err)
+		http.Error(w, "Dead letter not found", http.StatusNotFound)
+		return
+	}
+
+	templ.Handler(deadletters.Details(dl), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// Replay requests redelivery of a dead letter
+func (c *DeadLettersController) Replay(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	deadLetterService *services.DeadLetterService,
+) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		logger.Errorf("Invalid dead letter ID: %v", err)
+		http.Error(w, "Invalid dead letter ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := deadLetterService.Replay(r.Context(), id); err != nil {
+		logger.Errorf("Error replaying dead letter %s: %v", id, err)
+		http.Error(w, "Error replaying dead letter", http.StatusInternalServerError)
+		return
+	}
+
+	if htmx.IsHxRequest(r) {
+		htmx.Refresh(w)
+	} else {
+		http.Redirect(w, r, c.basePath, http.StatusSeeOther)
+	}
+}
+
+// Discard permanently removes a dead letter
+func (c *DeadLettersController) Discard(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	deadLetterService *services.DeadLetterService,
+) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		logger.Errorf("Invalid dead letter ID: %v", err)
+		http.Error(w, "Invalid dead letter ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := deadLetterService.Discard(r.Context(), id); err != nil {
+		logger.Errorf("Error discarding dead letter %s: %v", id, err)
+		http.Error(w, "Error discarding dead letter", http.StatusInternalServerError)
+		return
+	}
+
+	htmx.Refresh(w)
+}
@@ -0,0 +1,196 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	jobspage "github.com/iota-uz/iota-sdk/modules/superadmin/presentation/templates/pages/jobs"
+	"github.com/iota-uz/iota-sdk/modules/superadmin/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/di"
+	"github.com/iota-uz/iota-sdk/pkg/htmx"
+	"github.com/iota-uz/iota-sdk/pkg/jobs"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/sirupsen/logrus"
+
+	superadminMiddleware "github.com/iota-uz/iota-sdk/modules/superadmin/middleware"
+)
+
+type JobsController struct {
+	app      application.Application
+	basePath string
+}
+
+func NewJobsController(app application.Application) application.Controller {
+	return &JobsController{
+		app:      app,
+		basePath: "/superadmin/jobs",
+	}
+}
+
+func (c *JobsController) Key() string {
+	return c.basePath
+}
+
+func (c *JobsController) Register(r *mux.Router) {
+	router := r.PathPrefix(c.basePath).Subrouter()
+	router.Use(
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		superadminMiddleware.RequireSuperAdmin(),
+		middleware.ProvideDynamicLogo(c.app),
+		middleware.ProvideLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	)
+	router.HandleFunc("", di.H(c.Index)).Methods(http.MethodGet)
+	router.HandleFunc("/{id:[0-9]+}", di.H(c.Details)).Methods(http.MethodGet)
+	router.HandleFunc("/{id:[0-9]+}/retry", di.H(c.Retry)).Methods(http.MethodPost)
+	router.HandleFunc("/{id:[0-9]+}/run", di.H(c.RunNow)).Methods(http.MethodPost)
+}
+
+// Index renders the jobs table page and handles HTMX filtering requests
+func (c *JobsController) Index(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	jobService *services.JobService,
+	tenantService *coreservices.TenantService,
+) {
+	params := composables.UsePaginated(r)
+	query := r.URL.Query()
+	findParams := &jobs.FindParams{
+		Limit:  params.Limit,
+		Offset: params.Offset,
+		Status: jobs.Status(query.Get("status")),
+	}
+	if v := query.Get("tenant_id"); v != "" {
+		tenantID, err := uuid.Parse(v)
+		if err != nil {
+			logger.Errorf("Invalid tenant ID: %v", err)
+			http.Error(w, "Invalid tenant ID", http.StatusBadRequest)
+			return
+		}
+		findParams.TenantID = tenantID
+	}
+
+	list, total, err := jobService.FindJobs(r.Context(), findParams)
+	if err != nil {
+		logger.Errorf("Error retrieving jobs: %v", err)
+		http.Error(w, "Error retrieving jobs", http.StatusInternalServerError)
+		return
+	}
+
+	tenants, err := tenantService.List(r.Context())
+	if err != nil {
+		logger.Errorf("Error retrieving tenants: %v", err)
+		http.Error(w, "Error retrieving tenants", http.StatusInternalServerError)
+		return
+	}
+
+	props := &jobspage.IndexPageProps{
+		Jobs:     list,
+		Total:    total,
+		Tenants:  tenants,
+		Status:   string(findParams.Status),
+		TenantID: query.Get("tenant_id"),
+	}
+
+	if htmx.IsHxRequest(r) {
+		templ.Handler(jobspage.TableRows(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(jobspage.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+// Details renders a drawer with the job payload, attempts and last error
+func (c *JobsController) Details(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	jobService *services.JobService,
+	tenantService *coreservices.TenantService,
+) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		logger.Errorf("Invalid job ID: %v", err)
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	job, err := jobService.GetByID(r.Context(), id)
+	if err != nil {
+		logger.Errorf("Error retrieving job %d: %v", id, err)
+		http.Error(w, "Job not found", http.StatusNotFound)
+		return
+	}
+
+	tenants, err := tenantService.List(r.Context())
+	if err != nil {
+		logger.Errorf("Error retrieving tenants: %v", err)
+		http.Error(w, "Error retrieving tenants", http.StatusInternalServerError)
+		return
+	}
+
+	templ.Handler(jobspage.Details(job, tenants), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// Retry puts a failed job back on its queue
+func (c *JobsController) Retry(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	jobService *services.JobService,
+) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		logger.Errorf("Invalid job ID: %v", err)
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := jobService.Retry(r.Context(), id); err != nil {
+		logger.Errorf("Error retrying job %d: %v", id, err)
+		http.Error(w, "Error retrying job", http.StatusInternalServerError)
+		return
+	}
+
+	if htmx.IsHxRequest(r) {
+		htmx.Refresh(w)
+	} else {
+		http.Redirect(w, r, c.basePath, http.StatusSeeOther)
+	}
+}
+
+// RunNow makes a pending job available to workers immediately
+func (c *JobsController) RunNow(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	jobService *services.JobService,
+) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		logger.Errorf("Invalid job ID: %v", err)
+		http.Error(w, "Invalid job ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := jobService.RunNow(r.Context(), id); err != nil {
+		logger.Errorf("Error running job %d: %v", id, err)
+		http.Error(w, "Error running job", http.StatusInternalServerError)
+		return
+	}
+
+	if htmx.IsHxRequest(r) {
+		htmx.Refresh(w)
+	} else {
+		http.Redirect(w, r, c.basePath, http.StatusSeeOther)
+	}
+}
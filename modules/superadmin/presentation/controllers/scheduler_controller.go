@@ -0,0 +1,249 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	schedulerpage "github.com/iota-uz/iota-sdk/modules/superadmin/presentation/templates/pages/scheduler"
+	"github.com/iota-uz/iota-sdk/modules/superadmin/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/di"
+	"github.com/iota-uz/iota-sdk/pkg/htmx"
+	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/scheduler"
+	"github.com/sirupsen/logrus"
+
+	superadminMiddleware "github.com/iota-uz/iota-sdk/modules/superadmin/middleware"
+)
+
+type SchedulerController struct {
+	app      application.Application
+	basePath string
+}
+
+func NewSchedulerController(app application.Application) application.Controller {
+	return &SchedulerController{
+		app:      app,
+		basePath: "/superadmin/scheduler",
+	}
+}
+
+func (c *SchedulerController) Key() string {
+	return c.basePath
+}
+
+func (c *SchedulerController) Register(r *mux.Router) {
+	router := r.PathPrefix(c.basePath).Subrouter()
+	router.Use(
+		middleware.Authorize(),
+		middleware.RedirectNotAuthenticated(),
+		middleware.ProvideUser(),
+		superadminMiddleware.RequireSuperAdmin(),
+		middleware.ProvideDynamicLogo(c.app),
+		middleware.ProvideLocalizer(c.app.Bundle()),
+		middleware.NavItems(),
+		middleware.WithPageContext(),
+	)
+	router.HandleFunc("", di.H(c.Index)).Methods(http.MethodGet)
+	router.HandleFunc("/tasks/{name}/run", di.H(c.RunNow)).Methods(http.MethodPost)
+	router.HandleFunc("/runs", di.H(c.Runs)).Methods(http.MethodGet)
+	router.HandleFunc("/runs/{id:[0-9]+}", di.H(c.RunDetails)).Methods(http.MethodGet)
+	router.HandleFunc("/runs/{id:[0-9]+}/retry", di.H(c.Retry)).Methods(http.MethodPost)
+}
+
+// Index renders the scheduled tasks table with their next and last runs
+func (c *SchedulerController) Index(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	taskService *services.ScheduledTaskService,
+	tenantService *coreservices.TenantService,
+) {
+	tasks, err := taskService.Tasks(r.Context())
+	if err != nil {
+		logger.Errorf("Error retrieving scheduled tasks: %v", err)
+		http.Error(w, "Error retrieving scheduled tasks", http.StatusInternalServerError)
+		return
+	}
+
+	tenants, err := tenantService.List(r.Context())
+	if err != nil {
+		logger.Errorf("Error retrieving tenants: %v", err)
+		http.Error(w, "Error retrieving tenants", http.StatusInternalServerError)
+		return
+	}
+
+	props := &schedulerpage.IndexPageProps{
+		Tasks:    tasks,
+		Tenants:  tenants,
+		TenantID: r.URL.Query().Get("tenant_id"),
+	}
+
+	if htmx.IsHxRequest(r) {
+		templ.Handler(schedulerpage.TaskRows(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(schedulerpage.Index(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+// RunNow requests an immediate run of a task for the selected tenant, or for
+// every active tenant when none is selected
+func (c *SchedulerController) RunNow(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	taskService *services.ScheduledTaskService,
+) {
+	name := mux.Vars(r)["name"]
+
+	tenantID := uuid.Nil
+	if v := r.FormValue("tenant_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			logger.Errorf("Invalid tenant ID: %v", err)
+			http.Error(w, "Invalid tenant ID", http.StatusBadRequest)
+			return
+		}
+		tenantID = id
+	}
+
+	if _, err := taskService.RunNow(r.Context(), name, tenantID); err != nil {
+		logger.Errorf("Error triggering scheduled task %s: %v", name, err)
+		http.Error(w, "Error triggering scheduled task", http.StatusInternalServerError)
+		return
+	}
+
+	if htmx.IsHxRequest(r) {
+		htmx.Refresh(w)
+	} else {
+		http.Redirect(w, r, c.basePath, http.StatusSeeOther)
+	}
+}
+
+// Runs renders the run history table and handles HTMX filtering requests
+func (c *SchedulerController) Runs(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	taskService *services.ScheduledTaskService,
+	tenantService *coreservices.TenantService,
+) {
+	params := composables.UsePaginated(r)
+	query := r.URL.Query()
+	findParams := &scheduler.RunFindParams{
+		Limit:  params.Limit,
+		Offset: params.Offset,
+		Task:   query.Get("task"),
+		Status: scheduler.RunStatus(query.Get("status")),
+	}
+	if v := query.Get("tenant_id"); v != "" {
+		tenantID, err := uuid.Parse(v)
+		if err != nil {
+			logger.Errorf("Invalid tenant ID: %v", err)
+			http.Error(w, "Invalid tenant ID", http.StatusBadRequest)
+			return
+		}
+		findParams.TenantID = tenantID
+	}
+
+	runs, total, err := taskService.FindRuns(r.Context(), findParams)
+	if err != nil {
+		logger.Errorf("Error retrieving scheduled runs: %v", err)
+		http.Error(w, "Error retrieving scheduled runs", http.StatusInternalServerError)
+		return
+	}
+
+	tasks, err := taskService.Tasks(r.Context())
+	if err != nil {
+		logger.Errorf("Error retrieving scheduled tasks: %v", err)
+		http.Error(w, "Error retrieving scheduled tasks", http.StatusInternalServerError)
+		return
+	}
+
+	tenants, err := tenantService.List(r.Context())
+	if err != nil {
+		logger.Errorf("Error retrieving tenants: %v", err)
+		http.Error(w, "Error retrieving tenants", http.StatusInternalServerError)
+		return
+	}
+
+	props := &schedulerpage.RunsPageProps{
+		Runs:     runs,
+		Total:    total,
+		Tasks:    tasks,
+		Tenants:  tenants,
+		Task:     findParams.Task,
+		Status:   string(findParams.Status),
+		TenantID: query.Get("tenant_id"),
+	}
+
+	if htmx.IsHxRequest(r) {
+		templ.Handler(schedulerpage.RunRows(props), templ.WithStreaming()).ServeHTTP(w, r)
+	} else {
+		templ.Handler(schedulerpage.Runs(props), templ.WithStreaming()).ServeHTTP(w, r)
+	}
+}
+
+// RunDetails renders a drawer with the run timings, error and stack trace
+func (c *SchedulerController) RunDetails(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	taskService *services.ScheduledTaskService,
+	tenantService *coreservices.TenantService,
+) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		logger.Errorf("Invalid run ID: %v", err)
+		http.Error(w, "Invalid run ID", http.StatusBadRequest)
+		return
+	}
+
+	run, err := taskService.GetRun(r.Context(), id)
+	if err != nil {
+		logger.Errorf("Error retrieving scheduled run %d: %v", id, err)
+		http.Error(w, "Run not found", http.StatusNotFound)
+		return
+	}
+
+	tenants, err := tenantService.List(r.Context())
+	if err != nil {
+		logger.Errorf("Error retrieving tenants: %v", err)
+		http.Error(w, "Error retrieving tenants", http.StatusInternalServerError)
+		return
+	}
+
+	templ.Handler(schedulerpage.RunDetails(run, tenants), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// Retry requests a new run of the task and tenant of a finished run
+func (c *SchedulerController) Retry(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	taskService *services.ScheduledTaskService,
+) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		logger.Errorf("Invalid run ID: %v", err)
+		http.Error(w, "Invalid run ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := taskService.Retry(r.Context(), id); err != nil {
+		logger.Errorf("Error retrying scheduled run %d: %v", id, err)
+		http.Error(w, "Error retrying scheduled run", http.StatusInternalServerError)
+		return
+	}
+
+	if htmx.IsHxRequest(r) {
+		htmx.Refresh(w)
+	} else {
+		http.Redirect(w, r, c.basePath+"/runs", http.StatusSeeOther)
+	}
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/pkg/errors"
+)
+
+// DeadLetterService provides inspection and replay of failed event deliveries
+type DeadLetterService struct {
+	store eventbus.DeadLetterStore
+}
+
+// NewDeadLetterService creates a new dead letter service
+func NewDeadLetterService(store eventbus.DeadLetterStore) *DeadLetterService {
+	return &DeadLetterService{
+		store: store,
+	}
+}
+
+// FindDeadLetters returns a paginated list of dead letters, newest first
+func (s *DeadLetterService) FindDeadLetters(ctx context.Context, limit, offset int, status eventbus.DeadLetterStatus) ([]*eventbus.DeadLetter, int, error) {
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	params := &eventbus.DeadLetterFindParams{
+		Limit:  limit,
+		Offset: offset,
+		Status: status,
+	}
+	deadLetters, err := s.store.List(ctx, params)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to list dead letters")
+	}
+	total, err := s.store.Count(ctx, params)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to count dead letters")
+	}
+	return deadLetters, int(total), nil
+}
+
+// GetByID returns a single dead letter
+func (s *DeadLetterService) GetByID(ctx context.Context, id uuid.UUID) (*eventbus.DeadLetter, error) {
+	dl, err := s.store.Get(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get dead letter")
+	}
+	return dl, nil
+}
+
+// Replay asks the application nodes to redeliver a dead letter to its subscriber.
+// Delivery happens asynchronously on whichever node claims the request first.
+func (s *DeadLetterService) Replay(ctx context.Context, id uuid.UUID) error {
+	if err := s.store.RequestReplay(ctx, id); err != nil {
+		return errors.Wrap(err, "failed to request dead letter replay")
+	}
+	return nil
+}
+
+// Discard permanently removes a dead letter
+func (s *DeadLetterService) Discard(ctx context.Context, id uuid.UUID) error {
+	if err := s.store.Delete(ctx, id); err != nil {
+		return errors.Wrap(err, "failed to discard dead letter")
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/pkg/jobs"
+	"github.com/pkg/errors"
+)
+
+// JobService provides inspection of the background job queue
+type JobService struct {
+	store jobs.Store
+}
+
+// NewJobService creates a new job service
+func NewJobService(store jobs.Store) *JobService {
+	return &JobService{
+		store: store,
+	}
+}
+
+// FindJobs returns a paginated list of jobs, most recently updated first
+func (s *JobService) FindJobs(ctx context.Context, params *jobs.FindParams) ([]*jobs.Job, int, error) {
+	if params.Limit <= 0 {
+		params.Limit = DefaultPageSize
+	}
+	if params.Offset < 0 {
+		params.Offset = 0
+	}
+
+	list, err := s.store.List(ctx, params)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to list jobs")
+	}
+	total, err := s.store.Count(ctx, params)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to count jobs")
+	}
+	return list, int(total), nil
+}
+
+// GetByID returns a single job
+func (s *JobService) GetByID(ctx context.Context, id int64) (*jobs.Job, error) {
+	job, err := s.store.Get(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get job")
+	}
+	return job, nil
+}
+
+// Retry puts a failed job back on its queue with a fresh set of attempts
+func (s *JobService) Retry(ctx context.Context, id int64) error {
+	if err := s.store.Retry(ctx, id); err != nil {
+		return errors.Wrap(err, "failed to retry job")
+	}
+	return nil
+}
+
+// RunNow makes a pending job ready for the next worker poll
+func (s *JobService) RunNow(ctx context.Context, id int64) error {
+	if err := s.store.RunNow(ctx, id); err != nil {
+		return errors.Wrap(err, "failed to run job")
+	}
+	return nil
+}
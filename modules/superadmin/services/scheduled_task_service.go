@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/pkg/scheduler"
+	"github.com/pkg/errors"
+)
+
+// ScheduledTaskService provides inspection of scheduled tasks and their runs
+type ScheduledTaskService struct {
+	history scheduler.History
+}
+
+// NewScheduledTaskService creates a new scheduled task service
+func NewScheduledTaskService(history scheduler.History) *ScheduledTaskService {
+	return &ScheduledTaskService{
+		history: history,
+	}
+}
+
+// Tasks returns the tasks registered on the scheduler leader with their next and last runs
+func (s *ScheduledTaskService) Tasks(ctx context.Context) ([]*scheduler.TaskInfo, error) {
+	tasks, err := s.history.Tasks(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list scheduled tasks")
+	}
+	return tasks, nil
+}
+
+// FindRuns returns a paginated list of runs, newest first
+func (s *ScheduledTaskService) FindRuns(ctx context.Context, params *scheduler.RunFindParams) ([]*scheduler.Run, int, error) {
+	if params.Limit <= 0 {
+		params.Limit = DefaultPageSize
+	}
+	if params.Offset < 0 {
+		params.Offset = 0
+	}
+
+	runs, err := s.history.ListRuns(ctx, params)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to list scheduled runs")
+	}
+	total, err := s.history.CountRuns(ctx, params)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to count scheduled runs")
+	}
+	return runs, int(total), nil
+}
+
+// GetRun returns a single run
+func (s *ScheduledTaskService) GetRun(ctx context.Context, id int64) (*scheduler.Run, error) {
+	run, err := s.history.GetRun(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get scheduled run")
+	}
+	return run, nil
+}
+
+// RunNow asks the scheduler leader to run a task for a tenant, or for every
+// active tenant when tenantID is uuid.Nil. It returns the number of requested runs.
+func (s *ScheduledTaskService) RunNow(ctx context.Context, task string, tenantID uuid.UUID) (int, error) {
+	n, err := s.history.Trigger(ctx, task, tenantID)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to trigger scheduled task")
+	}
+	return n, nil
+}
+
+// Retry asks the scheduler leader to run the task of a finished run again for the same tenant
+func (s *ScheduledTaskService) Retry(ctx context.Context, id int64) error {
+	if _, err := s.history.Retry(ctx, id); err != nil {
+		return errors.Wrap(err, "failed to retry scheduled run")
+	}
+	return nil
+}
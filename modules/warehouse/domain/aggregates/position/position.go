@@ -51,6 +51,12 @@ func WithImages(images []upload.Upload) Option {
 	}
 }
 
+func WithVersion(version int) Option {
+	return func(p *position) {
+		p.version = version
+	}
+}
+
 func WithCreatedAt(createdAt time.Time) Option {
 	return func(p *position) {
 		p.createdAt = createdAt
@@ -74,6 +80,9 @@ type Position interface {
 	Unit() *unit.Unit
 	InStock() uint
 	Images() []upload.Upload
+	// Version is the optimistic concurrency version the position was read at, 0 for new ones.
+	// Repository.Update requires it and fails with a *serrors.ConflictError when it is stale.
+	Version() int
 	CreatedAt() time.Time
 	UpdatedAt() time.Time
 
@@ -117,6 +126,7 @@ type position struct {
 	unit      *unit.Unit
 	inStock   uint
 	images    []upload.Upload
+	version   int
 	createdAt time.Time
 	updatedAt time.Time
 	events    []interface{}
@@ -154,6 +164,10 @@ func (p *position) Images() []upload.Upload {
 	return p.images
 }
 
+func (p *position) Version() int {
+	return p.version
+}
+
 func (p *position) CreatedAt() time.Time {
 	return p.createdAt
 }
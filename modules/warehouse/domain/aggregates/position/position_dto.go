@@ -19,6 +19,7 @@ type UpdateDTO struct {
 	Title   string
 	Barcode string
 	UnitID  uint
+	Version int
 }
 
 func (d *CreateDTO) Ok(l ut.Translator) (map[string]string, bool) {
@@ -62,5 +63,6 @@ func (d *UpdateDTO) ToEntity(id uint) (Position, error) {
 		WithUnitID(d.UnitID),
 		WithUnit(&unit.Unit{ID: d.UnitID}), //nolint:exhaustruct
 		WithImages([]upload.Upload{}),
+		WithVersion(d.Version),
 	), nil
 }
@@ -1,6 +1,10 @@
 package position
 
-import "context"
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
 
 type DateRange struct {
 	From string
@@ -16,6 +20,15 @@ type FindParams struct {
 	Fields    []string
 	UnitID    string
 	CreatedAt DateRange
+	// Cursor continues the list after the position it was created for with NextCursor.
+	// When set, Offset is ignored.
+	Cursor string
+}
+
+// NextCursor returns the cursor continuing a paginated list after p.
+// Paginated positions are ordered by ID, ascending.
+func NextCursor(p Position) string {
+	return repo.EncodeCursor(p.ID())
 }
 
 type Repository interface {
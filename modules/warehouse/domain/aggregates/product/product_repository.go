@@ -1,6 +1,10 @@
 package product
 
-import "context"
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
 
 type DateRange struct {
 	From string
@@ -18,6 +22,9 @@ type FindParams struct {
 	CreatedAt  DateRange
 	Rfids      []string
 	OrderID    uint
+	// Cursor continues the list after the product it was created for with NextCursor.
+	// When set, Offset is ignored.
+	Cursor string
 }
 
 type FindByPositionParams struct {
@@ -32,6 +39,12 @@ type CountParams struct {
 	Status     Status
 }
 
+// NextCursor returns the cursor continuing a paginated list after p.
+// Paginated products are ordered by ID, descending.
+func NextCursor(p Product) string {
+	return repo.EncodeCursor(p.ID())
+}
+
 type Repository interface {
 	GetPaginated(context.Context, *FindParams) ([]Product, error)
 	Count(context.Context, *CountParams) (int64, error)
@@ -147,7 +147,7 @@ func (g *GormInventoryRepository) Positions(ctx context.Context) ([]*inventory.P
 	SELECT warehouse_positions.id, warehouse_positions.title, COUNT(warehouse_products.id) quantity, array_agg(warehouse_products.rfid) rfid_tags
 	FROM warehouse_positions
 	JOIN warehouse_products ON warehouse_positions.id = warehouse_products.position_id
-	WHERE warehouse_positions.tenant_id = $1
+	WHERE warehouse_positions.tenant_id = $1 AND warehouse_products.deleted_at IS NULL
 	GROUP BY warehouse_positions.id;
 	`
 	rows, err := tx.Query(ctx, sql, tenantID)
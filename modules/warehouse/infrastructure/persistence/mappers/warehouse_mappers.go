@@ -101,6 +101,7 @@ func ToDomainPosition(dbPosition *models.WarehousePosition, dbUnit *models.Wareh
 		position.WithUnitID(uint(dbPosition.UnitID.Int32)),
 		position.WithUnit(unit),
 		position.WithImages(images),
+		position.WithVersion(dbPosition.Version),
 		position.WithCreatedAt(dbPosition.CreatedAt),
 		position.WithUpdatedAt(dbPosition.UpdatedAt),
 	), nil
@@ -122,6 +123,7 @@ func ToDBPosition(entity position.Position) (*models.WarehousePosition, []*model
 		Title:     entity.Title(),
 		Barcode:   entity.Barcode(),
 		UnitID:    mapping.ValueToSQLNullInt32(int32(entity.UnitID())),
+		Version:   entity.Version(),
 		CreatedAt: entity.CreatedAt(),
 		UpdatedAt: entity.UpdatedAt(),
 	}
@@ -71,6 +71,7 @@ type WarehousePosition struct {
 	Barcode   string
 	UnitID    sql.NullInt32
 	Images    []coremodels.Upload `gorm:"many2many:warehouse_position_images;"`
+	Version   int
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -18,6 +18,10 @@ import (
 
 var (
 	ErrPositionNotFound = errors.New("position not found")
+
+	// positionsSortBy orders paginated positions by id so that cursors stay stable
+	positionsSortBy      = repo.SortBy[string]{Fields: []repo.SortByField[string]{{Field: "id", Ascending: true}}}
+	positionsSortMapping = map[string]string{"id": "wp.id"}
 )
 
 const (
@@ -27,6 +31,7 @@ const (
 		wp.title,
 		wp.barcode,
 		wp.unit_id,
+		wp.version,
 		wp.created_at,
 		wp.updated_at,
 		wp.tenant_id,
@@ -41,7 +46,8 @@ const (
 	countPositionQuery        = `SELECT COUNT(*) FROM warehouse_positions`
 	insertPositionQuery       = `INSERT INTO warehouse_positions (title, barcode, unit_id, created_at, tenant_id) VALUES ($1, $2, $3, $4, $5) RETURNING id`
 	insertPositionImageQuery  = `INSERT INTO warehouse_position_images (warehouse_position_id, upload_id) VALUES`
-	updatePositionQuery       = `UPDATE warehouse_positions SET title = $1, barcode = $2, unit_id = $3 WHERE id = $4 AND tenant_id = $5`
+	updatePositionQuery       = `UPDATE warehouse_positions SET title = $1, barcode = $2, unit_id = $3, version = version + 1 WHERE id = $4 AND tenant_id = $5 AND version = $6`
+	positionExistsQuery       = `SELECT EXISTS (SELECT 1 FROM warehouse_positions WHERE id = $1 AND tenant_id = $2)`
 	deletePositionQuery       = `DELETE FROM warehouse_positions WHERE id = $1 AND tenant_id = $2`
 	deletePositionImagesQuery = `DELETE FROM warehouse_position_images WHERE warehouse_position_id = $1`
 )
@@ -75,6 +81,7 @@ func (g *GormPositionRepository) queryPositions(ctx context.Context, query strin
 			&p.Title,
 			&p.Barcode,
 			&p.UnitID,
+			&p.Version,
 			&p.CreatedAt,
 			&p.UpdatedAt,
 			&p.TenantID,
@@ -129,12 +136,26 @@ func (g *GormPositionRepository) GetPaginated(
 			where = append(where, strings.Join(queries, " OR "))
 		}
 	}
+	offset := params.Offset
+	if params.Cursor != "" {
+		cursor, err := repo.DecodeCursor(params.Cursor)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode cursor")
+		}
+		seek, seekArgs, err := positionsSortBy.Seek(positionsSortMapping, cursor, len(args)+1)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to build cursor condition")
+		}
+		where, args = append(where, seek), append(args, seekArgs...)
+		offset = 0
+	}
 	return g.queryPositions(
 		ctx,
 		repo.Join(
 			selectPositionQuery,
 			repo.JoinWhere(where...),
-			repo.FormatLimitOffset(params.Limit, params.Offset),
+			positionsSortBy.ToSQL(positionsSortMapping),
+			repo.FormatLimitOffset(params.Limit, offset),
 		),
 		args...,
 	)
@@ -322,8 +343,11 @@ func (g *GormPositionRepository) Update(ctx context.Context, data position.Posit
 	positionRow, junctionRows := mappers.ToDBPosition(data)
 	positionRow.TenantID = tenantID.String()
 	// Note: Position is now immutable, TenantID should already be set
+	if err := repo.RequireVersion("position", positionRow.Version); err != nil {
+		return nil, err
+	}
 
-	if _, err := tx.Exec(
+	tag, err := tx.Exec(
 		ctx,
 		updatePositionQuery,
 		positionRow.Title,
@@ -331,6 +355,14 @@ func (g *GormPositionRepository) Update(ctx context.Context, data position.Posit
 		positionRow.UnitID,
 		positionRow.ID,
 		positionRow.TenantID,
+		positionRow.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.CheckVersion(
+		ctx, tx, tag, "position", positionRow.ID, positionRow.Version, ErrPositionNotFound,
+		positionExistsQuery, positionRow.ID, positionRow.TenantID,
 	); err != nil {
 		return nil, err
 	}
@@ -357,6 +389,7 @@ func (g *GormPositionRepository) Update(ctx context.Context, data position.Posit
 		position.WithUnit(data.Unit()),
 		position.WithInStock(data.InStock()),
 		position.WithImages(data.Images()),
+		position.WithVersion(data.Version()+1),
 		position.WithCreatedAt(data.CreatedAt()),
 		position.WithUpdatedAt(time.Now()),
 	), nil
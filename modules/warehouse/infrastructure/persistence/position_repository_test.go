@@ -149,7 +149,8 @@ func TestGormPositionRepository_CRUD(t *testing.T) {
 				position.New("Updated Position 1", "3141592653589",
 					position.WithID(1),
 					position.WithUnitID(1),
-					position.WithImages([]upload.Upload{})),
+					position.WithImages([]upload.Upload{}),
+					position.WithVersion(1)),
 			); err != nil {
 				t.Fatal(err)
 			}
@@ -11,6 +11,8 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/warehouse/domain/aggregates/product"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence/models"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+	"github.com/iota-uz/iota-sdk/pkg/trash"
 )
 
 var (
@@ -61,14 +63,6 @@ const (
 		UPDATE warehouse_products
 		SET status = $1
 		WHERE id = ANY($2) AND tenant_id = $3`
-
-	productDeleteQuery = `
-		DELETE FROM warehouse_products
-		WHERE id = $1 AND tenant_id = $2`
-
-	productBulkDeleteQuery = `
-		DELETE FROM warehouse_products
-		WHERE id = ANY($1) AND tenant_id = $2`
 )
 
 type GormProductRepository struct {
@@ -84,7 +78,7 @@ func (g *GormProductRepository) GetPaginated(ctx context.Context, params *produc
 		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
 	}
 
-	where, args := []string{"wp.tenant_id = $1"}, []interface{}{tenantID}
+	where, args := []string{"wp.tenant_id = $1", repo.NotDeleted("wp")}, []interface{}{tenantID}
 
 	if params.OrderID != 0 {
 		where = append(where, fmt.Sprintf(
@@ -125,6 +119,23 @@ func (g *GormProductRepository) GetPaginated(ctx context.Context, params *produc
 		}
 	}
 
+	offset := params.Offset
+	if params.Cursor != "" {
+		cursor, err := repo.DecodeCursor(params.Cursor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode cursor: %w", err)
+		}
+		// matches ORDER BY wp.id DESC below
+		sortBy := repo.SortBy[string]{Fields: []repo.SortByField[string]{{Field: "id", Ascending: false}}}
+		seek, seekArgs, err := sortBy.Seek(map[string]string{"id": "wp.id"}, cursor, len(args)+1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build cursor condition: %w", err)
+		}
+		where = append(where, seek)
+		args = append(args, seekArgs...)
+		offset = 0
+	}
+
 	query := productFindQuery + "\n" +
 		"WHERE " + strings.Join(where, " AND ") + "\n" +
 		"ORDER BY wp.id DESC"
@@ -132,8 +143,8 @@ func (g *GormProductRepository) GetPaginated(ctx context.Context, params *produc
 	if params.Limit > 0 {
 		query += fmt.Sprintf(" LIMIT %d", params.Limit)
 	}
-	if params.Offset > 0 {
-		query += fmt.Sprintf(" OFFSET %d", params.Offset)
+	if offset > 0 {
+		query += fmt.Sprintf(" OFFSET %d", offset)
 	}
 
 	return g.queryProducts(ctx, query, args...)
@@ -145,7 +156,7 @@ func (g *GormProductRepository) Count(ctx context.Context, opts *product.CountPa
 		return 0, fmt.Errorf("failed to get tenant from context: %w", err)
 	}
 
-	where, args := []string{"tenant_id = $1"}, []interface{}{tenantID}
+	where, args := []string{"tenant_id = $1", repo.NotDeleted("")}, []interface{}{tenantID}
 
 	if opts.PositionID != 0 {
 		where = append(where, fmt.Sprintf("position_id = $%d", len(args)+1))
@@ -184,7 +195,7 @@ func (g *GormProductRepository) GetAll(ctx context.Context) ([]product.Product,
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
 	}
-	return g.queryProducts(ctx, productFindQuery+" WHERE wp.tenant_id = $1", tenantID)
+	return g.queryProducts(ctx, productFindQuery+" WHERE wp.tenant_id = $1 AND wp.deleted_at IS NULL", tenantID)
 }
 
 func (g *GormProductRepository) GetByID(ctx context.Context, id uint) (product.Product, error) {
@@ -193,7 +204,7 @@ func (g *GormProductRepository) GetByID(ctx context.Context, id uint) (product.P
 		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
 	}
 
-	products, err := g.queryProducts(ctx, productFindQuery+" WHERE wp.id = $1 AND wp.tenant_id = $2", id, tenantID)
+	products, err := g.queryProducts(ctx, productFindQuery+" WHERE wp.id = $1 AND wp.tenant_id = $2 AND wp.deleted_at IS NULL", id, tenantID)
 	if err != nil {
 		return nil, err
 	}
@@ -209,7 +220,7 @@ func (g *GormProductRepository) GetByRfid(ctx context.Context, rfid string) (pro
 		return nil, fmt.Errorf("failed to get tenant from context: %w", err)
 	}
 
-	products, err := g.queryProducts(ctx, productFindQuery+" WHERE wp.rfid = $1 AND wp.tenant_id = $2", rfid, tenantID)
+	products, err := g.queryProducts(ctx, productFindQuery+" WHERE wp.rfid = $1 AND wp.tenant_id = $2 AND wp.deleted_at IS NULL", rfid, tenantID)
 	if err != nil {
 		return nil, err
 	}
@@ -319,7 +330,7 @@ func (g *GormProductRepository) Delete(ctx context.Context, id uint) error {
 		return fmt.Errorf("failed to get tenant from context: %w", err)
 	}
 
-	return g.execQuery(ctx, productDeleteQuery, id, tenantID)
+	return g.execQuery(ctx, repo.SoftDelete("warehouse_products", "id = $1", "tenant_id = $2"), id, tenantID)
 }
 
 func (g *GormProductRepository) BulkDelete(ctx context.Context, ids []uint) error {
@@ -328,7 +339,12 @@ func (g *GormProductRepository) BulkDelete(ctx context.Context, ids []uint) erro
 		return fmt.Errorf("failed to get tenant from context: %w", err)
 	}
 
-	return g.execQuery(ctx, productBulkDeleteQuery, ids, tenantID)
+	return g.execQuery(ctx, repo.SoftDelete("warehouse_products", "id = ANY($1)", "tenant_id = $2"), ids, tenantID)
+}
+
+// NewProductTrashBin creates the trash bin of products.
+func NewProductTrashBin(opts ...trash.TableBinOption) trash.Bin {
+	return trash.NewTableBin("Products", "warehouse_products", "COALESCE(rfid, id::text)", opts...)
 }
 
 func (g *GormProductRepository) queryProducts(ctx context.Context, query string, args ...interface{}) ([]product.Product, error) {
@@ -16,6 +16,7 @@ CREATE TABLE warehouse_positions (
     barcode varchar(255) NOT NULL,
     description text,
     unit_id int REFERENCES warehouse_units (id) ON DELETE SET NULL,
+    version int NOT NULL DEFAULT 1,
     created_at timestamp with time zone DEFAULT now(),
     updated_at timestamp with time zone DEFAULT now(),
     UNIQUE (tenant_id, barcode)
@@ -35,9 +36,13 @@ CREATE TABLE warehouse_products (
     status varchar(255) NOT NULL,
     created_at timestamp with time zone DEFAULT now(),
     updated_at timestamp with time zone DEFAULT now(),
-    UNIQUE (tenant_id, rfid)
+    deleted_at timestamp with time zone
 );
 
+CREATE UNIQUE INDEX warehouse_products_tenant_id_rfid_key ON warehouse_products (tenant_id, rfid) WHERE deleted_at IS NULL;
+
+CREATE INDEX warehouse_products_trash_idx ON warehouse_products (tenant_id, deleted_at) WHERE deleted_at IS NOT NULL;
+
 CREATE TABLE warehouse_orders (
     id serial PRIMARY KEY,
     tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
@@ -87,3 +92,10 @@ CREATE INDEX inventory_checks_tenant_id_idx ON inventory_checks (tenant_id);
 
 CREATE INDEX inventory_check_results_tenant_id_idx ON inventory_check_results (tenant_id);
 
+-- Row-level security: rows are only visible to the tenant in app.tenant_id
+SELECT enable_tenant_isolation('warehouse_units');
+SELECT enable_tenant_isolation('warehouse_positions');
+SELECT enable_tenant_isolation('warehouse_products');
+SELECT enable_tenant_isolation('warehouse_orders');
+SELECT enable_tenant_isolation('inventory_checks');
+SELECT enable_tenant_isolation('inventory_check_results');
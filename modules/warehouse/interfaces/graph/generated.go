@@ -78,13 +78,15 @@ type ComplexityRoot struct {
 	}
 
 	PaginatedProducts struct {
-		Data  func(childComplexity int) int
-		Total func(childComplexity int) int
+		Data       func(childComplexity int) int
+		NextCursor func(childComplexity int) int
+		Total      func(childComplexity int) int
 	}
 
 	PaginatedWarehousePositions struct {
-		Data  func(childComplexity int) int
-		Total func(childComplexity int) int
+		Data       func(childComplexity int) int
+		NextCursor func(childComplexity int) int
+		Total      func(childComplexity int) int
 	}
 
 	Product struct {
@@ -105,10 +107,10 @@ type ComplexityRoot struct {
 		Order                  func(childComplexity int, id int64) int
 		Orders                 func(childComplexity int, query model.OrderQuery) int
 		Product                func(childComplexity int, id int64) int
-		Products               func(childComplexity int, offset int, limit int, sortBy []string) int
+		Products               func(childComplexity int, offset int, limit int, sortBy []string, after *string) int
 		ValidateProducts       func(childComplexity int, tags []string) int
 		WarehousePosition      func(childComplexity int, id int64) int
-		WarehousePositions     func(childComplexity int, offset int, limit int, sortBy []string) int
+		WarehousePositions     func(childComplexity int, offset int, limit int, sortBy []string, after *string) int
 	}
 
 	ValidateProductsResult struct {
@@ -135,9 +137,9 @@ type QueryResolver interface {
 	Orders(ctx context.Context, query model.OrderQuery) (*model.PaginatedOrders, error)
 	CompleteOrder(ctx context.Context, id int64) (*model.Order, error)
 	WarehousePosition(ctx context.Context, id int64) (*model.WarehousePosition, error)
-	WarehousePositions(ctx context.Context, offset int, limit int, sortBy []string) (*model.PaginatedWarehousePositions, error)
+	WarehousePositions(ctx context.Context, offset int, limit int, sortBy []string, after *string) (*model.PaginatedWarehousePositions, error)
 	Product(ctx context.Context, id int64) (*model.Product, error)
-	Products(ctx context.Context, offset int, limit int, sortBy []string) (*model.PaginatedProducts, error)
+	Products(ctx context.Context, offset int, limit int, sortBy []string, after *string) (*model.PaginatedProducts, error)
 	CreateProductsFromTags(ctx context.Context, input model.CreateProductsFromTags) ([]*model.Product, error)
 	ValidateProducts(ctx context.Context, tags []string) (*model.ValidateProductsResult, error)
 }
@@ -271,6 +273,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.PaginatedProducts.Data(childComplexity), true
 
+	case "PaginatedProducts.nextCursor":
+		if e.complexity.PaginatedProducts.NextCursor == nil {
+			break
+		}
+
+		return e.complexity.PaginatedProducts.NextCursor(childComplexity), true
+
 	case "PaginatedProducts.total":
 		if e.complexity.PaginatedProducts.Total == nil {
 			break
@@ -285,6 +294,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.PaginatedWarehousePositions.Data(childComplexity), true
 
+	case "PaginatedWarehousePositions.nextCursor":
+		if e.complexity.PaginatedWarehousePositions.NextCursor == nil {
+			break
+		}
+
+		return e.complexity.PaginatedWarehousePositions.NextCursor(childComplexity), true
+
 	case "PaginatedWarehousePositions.total":
 		if e.complexity.PaginatedWarehousePositions.Total == nil {
 			break
@@ -430,7 +446,7 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Query.Products(childComplexity, args["offset"].(int), args["limit"].(int), args["sortBy"].([]string)), true
+		return e.complexity.Query.Products(childComplexity, args["offset"].(int), args["limit"].(int), args["sortBy"].([]string), args["after"].(*string)), true
 
 	case "Query.validateProducts":
 		if e.complexity.Query.ValidateProducts == nil {
@@ -466,7 +482,7 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			return 0, false
 		}
 
-		return e.complexity.Query.WarehousePositions(childComplexity, args["offset"].(int), args["limit"].(int), args["sortBy"].([]string)), true
+		return e.complexity.Query.WarehousePositions(childComplexity, args["offset"].(int), args["limit"].(int), args["sortBy"].([]string), args["after"].(*string)), true
 
 	case "ValidateProductsResult.invalid":
 		if e.complexity.ValidateProductsResult.Invalid == nil {
@@ -922,6 +938,11 @@ func (ec *executionContext) field_Query_products_args(ctx context.Context, rawAr
 		return nil, err
 	}
 	args["sortBy"] = arg2
+	arg3, err := ec.field_Query_products_argsAfter(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["after"] = arg3
 	return args, nil
 }
 func (ec *executionContext) field_Query_products_argsOffset(
@@ -990,6 +1011,28 @@ func (ec *executionContext) field_Query_products_argsSortBy(
 	return zeroVal, nil
 }
 
+func (ec *executionContext) field_Query_products_argsAfter(
+	ctx context.Context,
+	rawArgs map[string]interface{},
+) (*string, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["after"]
+	if !ok {
+		var zeroVal *string
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("after"))
+	if tmp, ok := rawArgs["after"]; ok {
+		return ec.unmarshalOString2ᚖstring(ctx, tmp)
+	}
+
+	var zeroVal *string
+	return zeroVal, nil
+}
+
 func (ec *executionContext) field_Query_validateProducts_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -1072,6 +1115,11 @@ func (ec *executionContext) field_Query_warehousePositions_args(ctx context.Cont
 		return nil, err
 	}
 	args["sortBy"] = arg2
+	arg3, err := ec.field_Query_warehousePositions_argsAfter(ctx, rawArgs)
+	if err != nil {
+		return nil, err
+	}
+	args["after"] = arg3
 	return args, nil
 }
 func (ec *executionContext) field_Query_warehousePositions_argsOffset(
@@ -1140,6 +1188,28 @@ func (ec *executionContext) field_Query_warehousePositions_argsSortBy(
 	return zeroVal, nil
 }
 
+func (ec *executionContext) field_Query_warehousePositions_argsAfter(
+	ctx context.Context,
+	rawArgs map[string]interface{},
+) (*string, error) {
+	// We won't call the directive if the argument is null.
+	// Set call_argument_directives_with_null to true to call directives
+	// even if the argument is null.
+	_, ok := rawArgs["after"]
+	if !ok {
+		var zeroVal *string
+		return zeroVal, nil
+	}
+
+	ctx = graphql.WithPathContext(ctx, graphql.NewPathWithField("after"))
+	if tmp, ok := rawArgs["after"]; ok {
+		return ec.unmarshalOString2ᚖstring(ctx, tmp)
+	}
+
+	var zeroVal *string
+	return zeroVal, nil
+}
+
 func (ec *executionContext) field___Type_enumValues_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -1991,6 +2061,47 @@ func (ec *executionContext) fieldContext_PaginatedProducts_total(_ context.Conte
 	return fc, nil
 }
 
+func (ec *executionContext) _PaginatedProducts_nextCursor(ctx context.Context, field graphql.CollectedField, obj *model.PaginatedProducts) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PaginatedProducts_nextCursor(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.NextCursor, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_PaginatedProducts_nextCursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PaginatedProducts",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _PaginatedWarehousePositions_data(ctx context.Context, field graphql.CollectedField, obj *model.PaginatedWarehousePositions) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_PaginatedWarehousePositions_data(ctx, field)
 	if err != nil {
@@ -2091,6 +2202,47 @@ func (ec *executionContext) fieldContext_PaginatedWarehousePositions_total(_ con
 	return fc, nil
 }
 
+func (ec *executionContext) _PaginatedWarehousePositions_nextCursor(ctx context.Context, field graphql.CollectedField, obj *model.PaginatedWarehousePositions) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_PaginatedWarehousePositions_nextCursor(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.NextCursor, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_PaginatedWarehousePositions_nextCursor(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "PaginatedWarehousePositions",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Product_id(ctx context.Context, field graphql.CollectedField, obj *model.Product) (ret graphql.Marshaler) {
 	fc, err := ec.fieldContext_Product_id(ctx, field)
 	if err != nil {
@@ -2782,7 +2934,7 @@ func (ec *executionContext) _Query_warehousePositions(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().WarehousePositions(rctx, fc.Args["offset"].(int), fc.Args["limit"].(int), fc.Args["sortBy"].([]string))
+		return ec.resolvers.Query().WarehousePositions(rctx, fc.Args["offset"].(int), fc.Args["limit"].(int), fc.Args["sortBy"].([]string), fc.Args["after"].(*string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2811,6 +2963,8 @@ func (ec *executionContext) fieldContext_Query_warehousePositions(ctx context.Co
 				return ec.fieldContext_PaginatedWarehousePositions_data(ctx, field)
 			case "total":
 				return ec.fieldContext_PaginatedWarehousePositions_total(ctx, field)
+			case "nextCursor":
+				return ec.fieldContext_PaginatedWarehousePositions_nextCursor(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type PaginatedWarehousePositions", field.Name)
 		},
@@ -2911,7 +3065,7 @@ func (ec *executionContext) _Query_products(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Products(rctx, fc.Args["offset"].(int), fc.Args["limit"].(int), fc.Args["sortBy"].([]string))
+		return ec.resolvers.Query().Products(rctx, fc.Args["offset"].(int), fc.Args["limit"].(int), fc.Args["sortBy"].([]string), fc.Args["after"].(*string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2940,6 +3094,8 @@ func (ec *executionContext) fieldContext_Query_products(ctx context.Context, fie
 				return ec.fieldContext_PaginatedProducts_data(ctx, field)
 			case "total":
 				return ec.fieldContext_PaginatedProducts_total(ctx, field)
+			case "nextCursor":
+				return ec.fieldContext_PaginatedProducts_nextCursor(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type PaginatedProducts", field.Name)
 		},
@@ -5702,6 +5858,8 @@ func (ec *executionContext) _PaginatedProducts(ctx context.Context, sel ast.Sele
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "nextCursor":
+			out.Values[i] = ec._PaginatedProducts_nextCursor(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -5746,6 +5904,8 @@ func (ec *executionContext) _PaginatedWarehousePositions(ctx context.Context, se
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "nextCursor":
+			out.Values[i] = ec._PaginatedWarehousePositions_nextCursor(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
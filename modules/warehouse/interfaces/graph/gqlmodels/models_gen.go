@@ -53,13 +53,15 @@ type PaginatedOrders struct {
 }
 
 type PaginatedProducts struct {
-	Data  []*Product `json:"data"`
-	Total int64      `json:"total"`
+	Data       []*Product `json:"data"`
+	Total      int64      `json:"total"`
+	NextCursor *string    `json:"nextCursor,omitempty"`
 }
 
 type PaginatedWarehousePositions struct {
-	Data  []*WarehousePosition `json:"data"`
-	Total int64                `json:"total"`
+	Data       []*WarehousePosition `json:"data"`
+	Total      int64                `json:"total"`
+	NextCursor *string              `json:"nextCursor,omitempty"`
 }
 
 type Product struct {
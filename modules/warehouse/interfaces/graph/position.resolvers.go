@@ -31,26 +31,38 @@ func (r *queryResolver) WarehousePosition(ctx context.Context, id int64) (*model
 }
 
 // WarehousePositions is the resolver for the warehousePositions field.
-func (r *queryResolver) WarehousePositions(ctx context.Context, offset int, limit int, sortBy []string) (*model.PaginatedWarehousePositions, error) {
+func (r *queryResolver) WarehousePositions(ctx context.Context, offset int, limit int, sortBy []string, after *string) (*model.PaginatedWarehousePositions, error) {
 	_, err := composables.UseUser(ctx)
 	if err != nil {
 		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
 		return nil, nil
 	}
-	domainPositions, err := r.positionService.GetPaginated(ctx, &position.FindParams{
+	limit = composables.PageLimit(limit)
+	params := &position.FindParams{
 		Offset: offset,
-		Limit:  limit,
+		Limit:  limit + 1,
 		SortBy: sortBy,
-	})
+	}
+	if after != nil {
+		params.Cursor = *after
+	}
+	domainPositions, err := r.positionService.GetPaginated(ctx, params)
 	if err != nil {
 		return nil, err
 	}
+	var nextCursor *string
+	if len(domainPositions) > limit {
+		domainPositions = domainPositions[:limit]
+		cursor := position.NextCursor(domainPositions[len(domainPositions)-1])
+		nextCursor = &cursor
+	}
 	total, err := r.positionService.Count(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return &model.PaginatedWarehousePositions{
-		Data:  mapping.MapViewModels(domainPositions, mappers.PositionToGraphModel),
-		Total: total,
+		Data:       mapping.MapViewModels(domainPositions, mappers.PositionToGraphModel),
+		Total:      total,
+		NextCursor: nextCursor,
 	}, nil
 }
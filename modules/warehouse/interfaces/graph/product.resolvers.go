@@ -30,27 +30,39 @@ func (r *queryResolver) Product(ctx context.Context, id int64) (*model.Product,
 }
 
 // Products is the resolver for the products field.
-func (r *queryResolver) Products(ctx context.Context, offset int, limit int, sortBy []string) (*model.PaginatedProducts, error) {
+func (r *queryResolver) Products(ctx context.Context, offset int, limit int, sortBy []string, after *string) (*model.PaginatedProducts, error) {
 	_, err := composables.UseUser(ctx)
 	if err != nil {
 		graphql.AddError(ctx, serrors.UnauthorizedGQLError(graphql.GetPath(ctx)))
 		return nil, nil
 	}
-	domainProducts, err := r.productService.GetPaginated(ctx, &product.FindParams{
+	limit = composables.PageLimit(limit)
+	params := &product.FindParams{
 		Offset: offset,
-		Limit:  limit,
+		Limit:  limit + 1,
 		SortBy: sortBy,
-	})
+	}
+	if after != nil {
+		params.Cursor = *after
+	}
+	domainProducts, err := r.productService.GetPaginated(ctx, params)
 	if err != nil {
 		return nil, err
 	}
+	var nextCursor *string
+	if len(domainProducts) > limit {
+		domainProducts = domainProducts[:limit]
+		cursor := product.NextCursor(domainProducts[len(domainProducts)-1])
+		nextCursor = &cursor
+	}
 	total, err := r.productService.Count(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 	return &model.PaginatedProducts{
-		Data:  ProductsToGraphModel(domainProducts),
-		Total: total,
+		Data:       ProductsToGraphModel(domainProducts),
+		Total:      total,
+		NextCursor: nextCursor,
 	}, nil
 }
 
@@ -4,8 +4,10 @@ import (
 	"embed"
 
 	icons "github.com/iota-uz/icons/phosphor"
+	corecontrollers "github.com/iota-uz/iota-sdk/modules/core/presentation/controllers"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/interfaces/graph"
+	"github.com/iota-uz/iota-sdk/modules/warehouse/permissions"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/assets"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/presentation/controllers"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/services"
@@ -13,7 +15,10 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/warehouse/services/positionservice"
 	"github.com/iota-uz/iota-sdk/modules/warehouse/services/productservice"
 	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/scheduler"
 	"github.com/iota-uz/iota-sdk/pkg/spotlight"
+	"github.com/iota-uz/iota-sdk/pkg/trash"
 )
 
 //go:generate go run github.com/99designs/gqlgen generate
@@ -60,12 +65,21 @@ func (m *Module) Register(app application.Application) error {
 		services.NewInventoryService(app.EventPublisher()),
 	)
 
+	trashBins := []trash.Bin{
+		persistence.NewProductTrashBin(trash.WithPermission(permissions.ProductDelete)),
+	}
 	app.RegisterControllers(
 		controllers.NewProductsController(app),
 		controllers.NewPositionsController(app),
 		controllers.NewUnitsController(app),
 		controllers.NewOrdersController(app),
 		controllers.NewInventoryController(app),
+		corecontrollers.NewTrashController(app, "/warehouse/trash", trashBins...),
+	)
+	app.SchedulerRegistry().Register(
+		"warehouse.purge_trash", "@daily",
+		trash.PurgeTask(configuration.Use().TrashRetention, trashBins...),
+		scheduler.ForEachTenant(),
 	)
 	app.RegisterLocaleFiles(&localeFiles)
 	app.Migrations().RegisterSchema(&migrationFiles)
@@ -445,8 +445,13 @@ func (c *PositionsController) Update(
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		vm := mappers.PositionToViewModel(entity)
+		// Keep the version the user started editing at, so resubmitting still detects conflicts
+		if dto.Version > 0 {
+			vm.Version = strconv.Itoa(dto.Version)
+		}
 		props := &positions2.EditPageProps{
-			Position:  mappers.PositionToViewModel(entity),
+			Position:  vm,
 			Units:     unitViewModels,
 			Errors:    errorsMap,
 			SaveURL:   fmt.Sprintf("%s/%d", c.basePath, id),
@@ -456,6 +461,9 @@ func (c *PositionsController) Update(
 		return
 	}
 	if err := positionService.Update(r.Context(), id, &dto); err != nil {
+		if shared.RenderConflict(w, r, err) {
+			return
+		}
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -43,6 +43,7 @@ func PositionToViewModel(entity position.Position) *viewmodels.Position {
 		UnitID:    strconv.FormatUint(uint64(entity.UnitID()), 10),
 		Unit:      *UnitToViewModel(entity.Unit()),
 		Images:    images,
+		Version:   strconv.Itoa(entity.Version()),
 		CreatedAt: entity.CreatedAt().Format(time.RFC3339),
 		UpdatedAt: entity.UpdatedAt().Format(time.RFC3339),
 	}
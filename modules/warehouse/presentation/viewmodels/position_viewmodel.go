@@ -11,6 +11,7 @@ type Position struct {
 	UnitID    string
 	Unit      Unit
 	Images    []*viewmodels.Upload
+	Version   string
 	CreatedAt string
 	UpdatedAt string
 }
@@ -136,6 +136,7 @@ func (s *PositionService) LoadFromFilePath(ctx context.Context, path string) err
 				Title:   row.Title,
 				UnitID:  unitID,
 				Barcode: row.Barcode,
+				Version: entity.Version(),
 			}
 			if err := s.Update(ctx, entity.ID(), pos); err != nil {
 				return err
@@ -179,6 +180,7 @@ func (s *PositionService) CreatePositionFromXlsRow(ctx context.Context, xlsRow *
 			Title:   xlsRow.Title,
 			UnitID:  unitEntity.ID,
 			Barcode: xlsRow.Barcode,
+			Version: entity.Version(),
 		}
 		return s.Update(ctx, entity.ID(), pos)
 	}
@@ -17,3 +17,5 @@ CREATE TABLE IF NOT EXISTS ai_chat_configs (
 CREATE UNIQUE INDEX IF NOT EXISTS idx_ai_chat_configs_unique_default ON ai_chat_configs (is_default)
 WHERE (is_default = TRUE);
 
+-- Row-level security: rows are only visible to the tenant in app.tenant_id
+SELECT enable_tenant_isolation('ai_chat_configs');
@@ -19,6 +19,8 @@ import (
 
 	"github.com/iota-uz/iota-sdk/pkg/configuration"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/jobs"
+	"github.com/iota-uz/iota-sdk/pkg/scheduler"
 	"github.com/iota-uz/iota-sdk/pkg/spotlight"
 	"github.com/iota-uz/iota-sdk/pkg/types"
 )
@@ -86,11 +88,12 @@ func (s *seeder) Register(seedFuncs ...SeedFunc) {
 // ---- Application implementation ----
 
 type ApplicationOptions struct {
-	Pool     *pgxpool.Pool
-	EventBus eventbus.EventBus
-	Logger   *logrus.Logger
-	Bundle   *i18n.Bundle
-	Huber    Huber
+	Pool          *pgxpool.Pool
+	EventBus      eventbus.EventBus
+	EventRegistry *eventbus.Registry
+	Logger        *logrus.Logger
+	Bundle        *i18n.Bundle
+	Huber         Huber
 }
 
 func LoadBundle() *i18n.Bundle {
@@ -105,9 +108,21 @@ func New(opts *ApplicationOptions) Application {
 	quickLinks := &spotlight.QuickLinks{}
 	sl.Register(quickLinks)
 
+	eventRegistry := opts.EventRegistry
+	if eventRegistry == nil {
+		eventRegistry = eventbus.NewRegistry()
+	}
+	jobRegistry := jobs.NewRegistry()
+
 	return &application{
 		pool:           opts.Pool,
 		eventPublisher: opts.EventBus,
+		eventRegistry:  eventRegistry,
+		outbox:         eventbus.NewOutbox(eventRegistry),
+		eventStore:     eventbus.NewEventStore(eventRegistry),
+		jobRegistry:    jobRegistry,
+		jobs:           jobs.NewClient(jobRegistry),
+		schedules:      scheduler.NewRegistry(),
 		websocket:      opts.Huber,
 		controllers:    make(map[string]Controller),
 		services:       make(map[reflect.Type]interface{}),
@@ -122,6 +137,12 @@ func New(opts *ApplicationOptions) Application {
 type application struct {
 	pool           *pgxpool.Pool
 	eventPublisher eventbus.EventBus
+	eventRegistry  *eventbus.Registry
+	outbox         eventbus.Outbox
+	eventStore     eventbus.EventStore
+	jobRegistry    *jobs.Registry
+	jobs           jobs.Client
+	schedules      *scheduler.Registry
 	websocket      Huber
 	services       map[reflect.Type]interface{}
 	controllers    map[string]Controller
@@ -168,6 +189,30 @@ func (app *application) EventPublisher() eventbus.EventBus {
 	return app.eventPublisher
 }
 
+func (app *application) EventRegistry() *eventbus.Registry {
+	return app.eventRegistry
+}
+
+func (app *application) Outbox() eventbus.Outbox {
+	return app.outbox
+}
+
+func (app *application) EventStore() eventbus.EventStore {
+	return app.eventStore
+}
+
+func (app *application) JobRegistry() *jobs.Registry {
+	return app.jobRegistry
+}
+
+func (app *application) Jobs() jobs.Client {
+	return app.jobs
+}
+
+func (app *application) SchedulerRegistry() *scheduler.Registry {
+	return app.schedules
+}
+
 func (app *application) Controllers() []Controller {
 	controllers := make([]Controller, 0, len(app.controllers))
 	for _, c := range app.controllers {
@@ -6,6 +6,8 @@ import (
 	"reflect"
 
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/jobs"
+	"github.com/iota-uz/iota-sdk/pkg/scheduler"
 	"github.com/iota-uz/iota-sdk/pkg/spotlight"
 	"github.com/iota-uz/iota-sdk/pkg/types"
 
@@ -27,6 +29,12 @@ type GraphSchema struct {
 type Application interface {
 	DB() *pgxpool.Pool
 	EventPublisher() eventbus.EventBus
+	EventRegistry() *eventbus.Registry
+	Outbox() eventbus.Outbox
+	EventStore() eventbus.EventStore
+	JobRegistry() *jobs.Registry
+	Jobs() jobs.Client
+	SchedulerRegistry() *scheduler.Registry
 	Controllers() []Controller
 	Middleware() []mux.MiddlewareFunc
 	Assets() []*embed.FS
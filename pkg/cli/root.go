@@ -22,6 +22,7 @@ func NewRootCommand() *cobra.Command {
 	rootCmd.AddCommand(commands.NewDocCommand())
 	rootCmd.AddCommand(commands.NewE2ECommand())
 	rootCmd.AddCommand(commands.NewMigrateCommand())
+	rootCmd.AddCommand(commands.NewWorkerCommand())
 
 	return rootCmd
 }
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iota-uz/iota-sdk/modules"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/commands/common"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+	"github.com/iota-uz/iota-sdk/pkg/jobs"
+)
+
+// NewWorkerCommand creates the worker command that processes background jobs
+func NewWorkerCommand() *cobra.Command {
+	conf := configuration.Use()
+	var (
+		queues      []string
+		concurrency int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "worker",
+		Short: "Process background jobs",
+		Long:  `Claims jobs registered by the loaded modules from the jobs table and runs them until interrupted. Any number of workers can run at the same time.`,
+		Example: `  # Process jobs from the default queue
+  command worker
+
+  # Process jobs from dedicated queues with 8 jobs at a time
+  command worker --queues exports,imports --concurrency 8`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunWorker(queues, concurrency, modules.BuiltInModules...)
+		},
+	}
+	cmd.Flags().StringSliceVar(&queues, "queues", conf.Jobs.Queues, "Queues to process")
+	cmd.Flags().IntVar(&concurrency, "concurrency", conf.Jobs.Concurrency, "Number of jobs processed at the same time")
+
+	return cmd
+}
+
+// RunWorker processes jobs from the given queues until SIGINT or SIGTERM is received
+func RunWorker(queues []string, concurrency int, mods ...application.Module) error {
+	conf := configuration.Use()
+	logger := conf.Logger()
+
+	app, pool, err := common.NewApplicationWithDefaults(mods...)
+	if err != nil {
+		return fmt.Errorf("failed to initialize application: %w", err)
+	}
+	defer pool.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	worker := jobs.NewWorker(jobs.WorkerOptions{
+		Pool:        pool,
+		Registry:    app.JobRegistry(),
+		Logger:      logger,
+		Queues:      queues,
+		Concurrency: concurrency,
+		Retention:   conf.Jobs.Retention,
+	})
+	logger.Infof("Worker started, queues: %v, kinds: %v", queues, app.JobRegistry().Kinds())
+	worker.Start(ctx)
+
+	<-ctx.Done()
+	logger.Info("Worker stopping, waiting for running jobs")
+	worker.Stop()
+	return nil
+}
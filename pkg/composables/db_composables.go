@@ -19,9 +19,14 @@ func WithTx(ctx context.Context, tx pgx.Tx) context.Context {
 	return context.WithValue(ctx, constants.TxKey, tx)
 }
 
+// UseTx returns the transaction of ctx. Outside a transaction it returns a
+// read replica for read-only work (see WithReadOnly) or the primary pool.
 func UseTx(ctx context.Context) (repo.Tx, error) {
 	tx := ctx.Value(constants.TxKey)
 	if tx == nil {
+		if replica := useReplica(ctx); replica != nil {
+			return replica, nil
+		}
 		return UsePool(ctx)
 	}
 	return tx.(repo.Tx), nil
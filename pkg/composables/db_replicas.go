@@ -0,0 +1,93 @@
+package composables
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type replicasKey struct{}
+type readOnlyKey struct{}
+type primaryKey struct{}
+
+// ReadReplicas balances read-only queries over the pools of one or more read replicas.
+type ReadReplicas struct {
+	pools []*pgxpool.Pool
+	next  atomic.Uint64
+}
+
+// NewReadReplicas creates a round-robin balancer over pools.
+func NewReadReplicas(pools ...*pgxpool.Pool) *ReadReplicas {
+	return &ReadReplicas{pools: pools}
+}
+
+// Pool returns the next replica pool, or nil when there are no replicas.
+func (r *ReadReplicas) Pool() *pgxpool.Pool {
+	if r == nil || len(r.pools) == 0 {
+		return nil
+	}
+	n := r.next.Add(1) - 1
+	return r.pools[n%uint64(len(r.pools))]
+}
+
+// Close closes every replica pool.
+func (r *ReadReplicas) Close() {
+	for _, p := range r.pools {
+		p.Close()
+	}
+}
+
+func WithReplicas(ctx context.Context, replicas *ReadReplicas) context.Context {
+	return context.WithValue(ctx, replicasKey{}, replicas)
+}
+
+func UseReplicas(ctx context.Context) (*ReadReplicas, bool) {
+	replicas, ok := ctx.Value(replicasKey{}).(*ReadReplicas)
+	return replicas, ok && replicas != nil
+}
+
+// WithReadOnly marks work done with ctx as read-only, so UseTx may route its
+// queries to a read replica. Transactions and BeginTx/InTx always use the primary.
+//
+// Example usage:
+//
+//	func (s *FinancialReportService) GenerateIncomeStatement(ctx context.Context, ...) {
+//		ctx = composables.WithReadOnly(ctx)
+//		data, err := s.queryRepo.GetIncomeStatementData(ctx, startDate, endDate)
+func WithReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyKey{}, true)
+}
+
+// IsReadOnly reports whether ctx was marked with WithReadOnly.
+func IsReadOnly(ctx context.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyKey{}).(bool)
+	return readOnly
+}
+
+// WithPrimary pins work done with ctx to the primary, even when marked read-only.
+// It is used for sessions that wrote recently and have to read their own writes.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// UseReadTx is UseTx for read-only queries, see WithReadOnly.
+func UseReadTx(ctx context.Context) (repo.Tx, error) {
+	return UseTx(WithReadOnly(ctx))
+}
+
+// useReplica returns the replica pool that should serve ctx, or nil when the primary has to.
+func useReplica(ctx context.Context) *pgxpool.Pool {
+	if !IsReadOnly(ctx) {
+		return nil
+	}
+	if primary, _ := ctx.Value(primaryKey{}).(bool); primary {
+		return nil
+	}
+	replicas, ok := UseReplicas(ctx)
+	if !ok {
+		return nil
+	}
+	return replicas.Pool()
+}
@@ -0,0 +1,52 @@
+package composables
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLazyPool creates a pool without connecting, pgxpool only dials on first use
+func newLazyPool(t *testing.T, host string) *pgxpool.Pool {
+	t.Helper()
+	pool, err := pgxpool.New(context.Background(), "host="+host+" user=postgres dbname=test")
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+func TestUseTx_ReadReplicaRouting(t *testing.T) {
+	primary := newLazyPool(t, "primary")
+	replicaA := newLazyPool(t, "replica-a")
+	replicaB := newLazyPool(t, "replica-b")
+	ctx := WithReplicas(WithPool(context.Background(), primary), NewReadReplicas(replicaA, replicaB))
+
+	t.Run("writes use the primary", func(t *testing.T) {
+		tx, err := UseTx(ctx)
+		require.NoError(t, err)
+		assert.Same(t, primary, tx)
+	})
+
+	t.Run("read-only work is balanced over replicas", func(t *testing.T) {
+		first, err := UseReadTx(ctx)
+		require.NoError(t, err)
+		second, err := UseReadTx(ctx)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []any{replicaA, replicaB}, []any{first, second})
+	})
+
+	t.Run("sessions pinned to the primary read from it", func(t *testing.T) {
+		tx, err := UseReadTx(WithPrimary(ctx))
+		require.NoError(t, err)
+		assert.Same(t, primary, tx)
+	})
+
+	t.Run("without replicas reads use the primary", func(t *testing.T) {
+		tx, err := UseReadTx(WithPool(context.Background(), primary))
+		require.NoError(t, err)
+		assert.Same(t, primary, tx)
+	})
+}
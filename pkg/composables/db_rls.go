@@ -0,0 +1,67 @@
+package composables
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TenantSetting is the Postgres setting the tenant_isolation row-level security
+// policies compare tenant_id against. An empty value disables the filtering.
+const TenantSetting = "app.tenant_id"
+
+const setTenantQuery = "SELECT set_config('" + TenantSetting + "', $1, $2)"
+
+// EnableTenantRLS makes every connection acquired from a pool built with config
+// set app.tenant_id to the tenant of the acquiring context, so the database
+// itself hides other tenants' rows. The pool has to connect with a role that is
+// neither a superuser nor BYPASSRLS, otherwise Postgres ignores the policies.
+//
+// The policies fail open: connections acquired with a context without a tenant
+// leave app.tenant_id empty and see every tenant's rows, which migrations, workers
+// and the session middleware rely on. Row-level security is therefore a safety
+// net, repositories still have to filter by tenant_id themselves.
+//
+// Example usage:
+//
+//	config, err := pgxpool.ParseConfig(conf.Database.Opts)
+//	if conf.Database.TenantRLS {
+//		composables.EnableTenantRLS(config)
+//	}
+//	pool, err := pgxpool.NewWithConfig(ctx, config)
+func EnableTenantRLS(config *pgxpool.Config) {
+	beforeAcquire := config.BeforeAcquire
+	config.BeforeAcquire = func(ctx context.Context, conn *pgx.Conn) bool {
+		if beforeAcquire != nil && !beforeAcquire(ctx, conn) {
+			return false
+		}
+		// A failed reset leaves the connection in an unknown state, so it is destroyed
+		_, err := conn.Exec(ctx, setTenantQuery, tenantSetting(ctx), false)
+		return err == nil
+	}
+	afterRelease := config.AfterRelease
+	config.AfterRelease = func(conn *pgx.Conn) bool {
+		if afterRelease != nil && !afterRelease(conn) {
+			return false
+		}
+		_, err := conn.Exec(context.Background(), setTenantQuery, "", false)
+		return err == nil
+	}
+}
+
+// SetTxTenant sets app.tenant_id to the tenant of ctx for the rest of tx.
+// It is only needed for transactions begun on pools without EnableTenantRLS.
+func SetTxTenant(ctx context.Context, tx repo.Tx) error {
+	_, err := tx.Exec(ctx, setTenantQuery, tenantSetting(ctx), true)
+	return err
+}
+
+func tenantSetting(ctx context.Context) string {
+	tenantID, err := UseTenantID(ctx)
+	if err != nil {
+		return ""
+	}
+	return tenantID.String()
+}
@@ -9,22 +9,36 @@ import (
 
 // Query parameter constants to avoid circular import
 const (
-	QueryParamLimit = "limit"
-	QueryParamPage  = "page"
+	QueryParamLimit  = "limit"
+	QueryParamPage   = "page"
+	QueryParamCursor = "cursor"
 )
 
 type PaginationParams struct {
 	Limit  int
 	Offset int
 	Page   int
+	// Cursor is the opaque keyset cursor of the previous page, empty on the first page.
+	Cursor string
 }
 
-func UsePaginated(r *http.Request) PaginationParams {
+// PageLimit replaces limits below 1 with the default page size and caps the
+// others at the maximum page size. Pages are fetched with one extra row to
+// detect the next one, so a limit of 0 or less would slice them out of range.
+func PageLimit(limit int) int {
 	config := configuration.Use()
+	if limit < 1 {
+		return config.PageSize
+	}
+	return min(limit, config.MaxPageSize)
+}
+
+func UsePaginated(r *http.Request) PaginationParams {
 	limit, err := strconv.Atoi(r.URL.Query().Get(QueryParamLimit))
-	if err != nil || limit > config.MaxPageSize {
-		limit = config.PageSize
+	if err != nil {
+		limit = 0
 	}
+	limit = PageLimit(limit)
 
 	page, err := strconv.Atoi(r.URL.Query().Get(QueryParamPage))
 	if err != nil || page < 1 {
@@ -35,5 +49,6 @@ func UsePaginated(r *http.Request) PaginationParams {
 		Limit:  limit,
 		Offset: (page - 1) * limit,
 		Page:   page,
+		Cursor: r.URL.Query().Get(QueryParamCursor),
 	}
 }
@@ -0,0 +1,43 @@
+package composables
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+func TestPageLimit(t *testing.T) {
+	config := configuration.Use()
+
+	tests := []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{"zero falls back to the default", 0, config.PageSize},
+		{"negative falls back to the default", -1, config.PageSize},
+		{"above the maximum is capped", config.MaxPageSize + 1, config.MaxPageSize},
+		{"valid limit is kept", 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, PageLimit(tt.limit))
+		})
+	}
+}
+
+func TestUsePaginated_Limit(t *testing.T) {
+	config := configuration.Use()
+
+	for _, limit := range []string{"0", "-1", "abc", ""} {
+		t.Run("limit="+limit, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/?limit="+limit+"&page=2", nil)
+			params := UsePaginated(r)
+			assert.Equal(t, config.PageSize, params.Limit)
+			assert.Equal(t, config.PageSize, params.Offset)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -56,6 +57,15 @@ type DatabaseOptions struct {
 	Port     string `env:"DB_PORT" envDefault:"5432"`
 	User     string `env:"DB_USER" envDefault:"postgres"`
 	Password string `env:"DB_PASSWORD" envDefault:"postgres"`
+	// ReplicaHosts lists read replicas as host or host:port. Name, user and password are shared with the primary.
+	ReplicaHosts []string `env:"DB_REPLICA_HOSTS" envSeparator:","`
+	// ReplicaStickiness is how long a session keeps reading from the primary after a write,
+	// so it sees its own changes despite replication lag.
+	ReplicaStickiness time.Duration `env:"DB_REPLICA_STICKINESS" envDefault:"5s"`
+	// TenantRLS makes connections set app.tenant_id from the request tenant, so the
+	// row-level security policies on tenant-owned tables hide other tenants' rows.
+	// DB_USER must not be a superuser for the policies to apply.
+	TenantRLS bool `env:"DB_TENANT_RLS" envDefault:"false"`
 }
 
 func (d *DatabaseOptions) ConnectionString() string {
@@ -65,6 +75,26 @@ func (d *DatabaseOptions) ConnectionString() string {
 	)
 }
 
+// ReplicaConnectionStrings returns a connection string for every configured read replica.
+func (d *DatabaseOptions) ReplicaConnectionStrings() []string {
+	opts := make([]string, 0, len(d.ReplicaHosts))
+	for _, h := range d.ReplicaHosts {
+		h = strings.TrimSpace(h)
+		if h == "" {
+			continue
+		}
+		host, port := h, d.Port
+		if i := strings.LastIndex(h, ":"); i != -1 {
+			host, port = h[:i], h[i+1:]
+		}
+		opts = append(opts, fmt.Sprintf(
+			"host=%s port=%s user=%s dbname=%s password=%s sslmode=disable",
+			host, port, d.User, d.Name, d.Password,
+		))
+	}
+	return opts
+}
+
 type GoogleOptions struct {
 	RedirectURL  string `env:"GOOGLE_REDIRECT_URL"`
 	ClientID     string `env:"GOOGLE_CLIENT_ID"`
@@ -90,6 +120,29 @@ type OpenTelemetryOptions struct {
 	ServiceName string `env:"OTEL_SERVICE_NAME" envDefault:"sdk"`
 }
 
+type EventBusOptions struct {
+	// Distributed relays events between server replicas through Postgres LISTEN/NOTIFY
+	Distributed bool   `env:"EVENTBUS_DISTRIBUTED" envDefault:"false"`
+	Channel     string `env:"EVENTBUS_CHANNEL" envDefault:"eventbus"`
+	// Async delivers events on a worker pool with retries, recording failed
+	// deliveries as dead letters that superadmins can replay
+	Async   bool `env:"EVENTBUS_ASYNC" envDefault:"false"`
+	Workers int  `env:"EVENTBUS_WORKERS" envDefault:"4"`
+}
+
+type JobsOptions struct {
+	// Queues served by the worker command, comma separated
+	Queues      []string      `env:"JOBS_QUEUES" envDefault:"default"`
+	Concurrency int           `env:"JOBS_CONCURRENCY" envDefault:"4"`
+	Retention   time.Duration `env:"JOBS_RETENTION" envDefault:"168h"`
+}
+
+type SchedulerOptions struct {
+	// Enabled takes part in leader election; only one replica runs tasks at a time
+	Enabled          bool          `env:"SCHEDULER_ENABLED" envDefault:"true"`
+	HistoryRetention time.Duration `env:"SCHEDULER_HISTORY_RETENTION" envDefault:"720h"`
+}
+
 type ClickOptions struct {
 	URL            string `env:"CLICK_URL" envDefault:"https://my.click.uz"`
 	MerchantID     int64  `env:"CLICK_MERCHANT_ID"`
@@ -147,6 +200,9 @@ type Configuration struct {
 	Twilio        TwilioOptions
 	Loki          LokiOptions
 	OpenTelemetry OpenTelemetryOptions
+	EventBus      EventBusOptions
+	Jobs          JobsOptions
+	Scheduler     SchedulerOptions
 	Click         ClickOptions
 	Payme         PaymeOptions
 	Octo          OctoOptions
@@ -157,6 +213,7 @@ type Configuration struct {
 	MigrationsDir    string        `env:"MIGRATIONS_DIR" envDefault:"migrations"`
 	ServerPort       int           `env:"PORT" envDefault:"3200"`
 	SessionDuration  time.Duration `env:"SESSION_DURATION" envDefault:"720h"`
+	TrashRetention   time.Duration `env:"TRASH_RETENTION" envDefault:"720h"`
 	GoAppEnvironment string        `env:"GO_APP_ENV" envDefault:"development"`
 	SocketAddress    string        `env:"-"`
 	OpenAIKey        string        `env:"OPENAI_KEY"`
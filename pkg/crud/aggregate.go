@@ -0,0 +1,250 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-faster/errors"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+// AggregateFunc is a function Aggregate computes over the values of a field
+type AggregateFunc string
+
+const (
+	AggregateCount AggregateFunc = "count"
+	AggregateSum   AggregateFunc = "sum"
+	AggregateAvg   AggregateFunc = "avg"
+	AggregateMin   AggregateFunc = "min"
+	AggregateMax   AggregateFunc = "max"
+)
+
+// DateBucket is the unit the values of a time field are truncated to when
+// grouping by it, e.g. BucketMonth groups by the first day of the month.
+type DateBucket string
+
+const (
+	BucketHour    DateBucket = "hour"
+	BucketDay     DateBucket = "day"
+	BucketWeek    DateBucket = "week"
+	BucketMonth   DateBucket = "month"
+	BucketQuarter DateBucket = "quarter"
+	BucketYear    DateBucket = "year"
+)
+
+// ErrInvalidAggregation is returned by Aggregate for aggregations and groups it
+// can't compute, e.g. the sum of a string field or a bucket of an int field.
+var ErrInvalidAggregation = errors.New("invalid aggregation")
+
+// Aggregation is a function computed over the values of Field. AggregateCount
+// without a field counts the rows.
+type Aggregation struct {
+	Func  AggregateFunc
+	Field string
+}
+
+// Name is the key of the result of the aggregation in AggregateRow.Values,
+// e.g. sum_price, or count for counting rows.
+func (a Aggregation) Name() string {
+	if a.Field == "" {
+		return string(a.Func)
+	}
+	return string(a.Func) + "_" + a.Field
+}
+
+// GroupBy groups the rows by the values of Field, truncated to Bucket for
+// date, date-time and timestamp fields when it's set.
+type GroupBy struct {
+	Field  string
+	Bucket DateBucket
+}
+
+// AggregateQuery describes what Aggregate computes over the rows matching the
+// filters, search query and trash flag of FindParams. Without GroupBy it
+// returns a single row over all of them.
+type AggregateQuery struct {
+	Aggregations []Aggregation
+	GroupBy      []GroupBy
+}
+
+// AggregateRow is a group of rows and the aggregations computed over them
+type AggregateRow struct {
+	// Groups holds the values of the GroupBy fields in order. Bucketed time
+	// fields hold the start of the bucket.
+	Groups []FieldValue
+	// Values maps the Name of every aggregation to its result. Counts are int64.
+	// Sums are int64 for int fields and float64 for float fields, averages are
+	// float64, and both are decimal strings for decimal fields and int64 minor
+	// units for money fields. Minimums and maximums have the type of the field's
+	// values. Results over no values are nil.
+	Values map[string]any
+}
+
+// aggregateExpr returns the SQL computing fn over the column of f
+func aggregateExpr(f Field, fn AggregateFunc) (string, error) {
+	if fn == AggregateCount {
+		if f == nil {
+			return "COUNT(*)", nil
+		}
+		return fmt.Sprintf("COUNT(%s)", f.Name()), nil
+	}
+	if f == nil {
+		return "", errors.Wrapf(ErrInvalidAggregation, "%s requires a field", fn)
+	}
+
+	column := f.Name()
+	switch fn {
+	case AggregateSum, AggregateAvg:
+		switch f.Type() {
+		case MoneyFieldType:
+			// Amounts stay in minor units, averages rounded to the nearest one
+			if fn == AggregateSum {
+				return fmt.Sprintf("SUM(%s)::bigint", column), nil
+			}
+			return fmt.Sprintf("ROUND(AVG(%s))::bigint", column), nil
+		case IntFieldType:
+			if fn == AggregateSum {
+				return fmt.Sprintf("SUM(%s)::bigint", column), nil
+			}
+			return fmt.Sprintf("AVG(%s)::float8", column), nil
+		case FloatFieldType:
+			return fmt.Sprintf("%s(%s)::float8", strings.ToUpper(string(fn)), column), nil
+		case DecimalFieldType:
+			return fmt.Sprintf("%s(%s)::text", strings.ToUpper(string(fn)), column), nil
+		}
+	case AggregateMin, AggregateMax:
+		switch f.Type() {
+		case DecimalFieldType:
+			return fmt.Sprintf("%s(%s)::text", strings.ToUpper(string(fn)), column), nil
+		case IntFieldType, FloatFieldType, StringFieldType, MoneyFieldType, EnumFieldType,
+			DateFieldType, DateTimeFieldType, TimestampFieldType:
+			return fmt.Sprintf("%s(%s)", strings.ToUpper(string(fn)), column), nil
+		}
+	default:
+		return "", errors.Wrapf(ErrInvalidAggregation, "unknown function %q", fn)
+	}
+	return "", errors.Wrapf(ErrInvalidAggregation, "can't compute %s of %s field %q", fn, f.Type(), f.Name())
+}
+
+// groupExpr returns the SQL of the values of f truncated to bucket
+func groupExpr(f Field, bucket DateBucket) (string, error) {
+	if bucket == "" {
+		return f.Name(), nil
+	}
+	switch bucket {
+	case BucketHour, BucketDay, BucketWeek, BucketMonth, BucketQuarter, BucketYear:
+	default:
+		return "", errors.Wrapf(ErrInvalidAggregation, "unknown bucket %q", bucket)
+	}
+	switch f.Type() {
+	case DateFieldType:
+		return fmt.Sprintf("date_trunc('%s', %s)::date", bucket, f.Name()), nil
+	case DateTimeFieldType, TimestampFieldType:
+		return fmt.Sprintf("date_trunc('%s', %s)", bucket, f.Name()), nil
+	}
+	return "", errors.Wrapf(ErrInvalidAggregation, "can't group %s field %q by %s", f.Type(), f.Name(), bucket)
+}
+
+func (r *repository[TEntity]) Aggregate(ctx context.Context, params *FindParams, query AggregateQuery) ([]AggregateRow, error) {
+	if len(query.Aggregations) == 0 {
+		return nil, errors.Wrap(ErrInvalidAggregation, "no aggregations")
+	}
+	if params == nil {
+		params = &FindParams{}
+	}
+
+	selects := make([]string, 0, len(query.GroupBy)+len(query.Aggregations))
+	groups := make([]Field, len(query.GroupBy))
+	for i, g := range query.GroupBy {
+		f, err := r.columnField(g.Field)
+		if err != nil {
+			return nil, err
+		}
+		expr, err := groupExpr(f, g.Bucket)
+		if err != nil {
+			return nil, err
+		}
+		groups[i] = f
+		selects = append(selects, expr)
+	}
+	fields := make([]Field, len(query.Aggregations))
+	for i, a := range query.Aggregations {
+		if a.Field != "" {
+			f, err := r.columnField(a.Field)
+			if err != nil {
+				return nil, err
+			}
+			fields[i] = f
+		}
+		expr, err := aggregateExpr(fields[i], a.Func)
+		if err != nil {
+			return nil, err
+		}
+		selects = append(selects, expr)
+	}
+
+	whereClauses, args, err := r.buildFilters(ctx, params)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build filters for aggregate")
+	}
+
+	sql := fmt.Sprintf("SELECT %s FROM %s", strings.Join(selects, ", "), r.schema.Name())
+	if len(whereClauses) > 0 {
+		sql = repo.Join(sql, repo.JoinWhere(whereClauses...))
+	}
+	if len(groups) > 0 {
+		positions := make([]string, len(groups))
+		for i := range groups {
+			positions[i] = fmt.Sprint(i + 1)
+		}
+		sql = repo.Join(sql, "GROUP BY", strings.Join(positions, ", "), "ORDER BY", strings.Join(positions, ", "))
+	}
+
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to aggregate entities")
+	}
+	defer rows.Close()
+
+	var result []AggregateRow
+	for rows.Next() {
+		raw, err := rows.Values()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan aggregate row")
+		}
+		row := AggregateRow{
+			Groups: make([]FieldValue, len(groups)),
+			Values: make(map[string]any, len(query.Aggregations)),
+		}
+		for i, f := range groups {
+			row.Groups[i] = f.Value(raw[i])
+		}
+		for i, a := range query.Aggregations {
+			value := raw[len(groups)+i]
+			if (a.Func == AggregateMin || a.Func == AggregateMax) && value != nil {
+				value = fields[i].Value(value).Value()
+			}
+			row.Values[a.Name()] = value
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "aggregate row iteration error")
+	}
+	return result, nil
+}
+
+// columnField returns the field of the schema with a column named name
+func (r *repository[TEntity]) columnField(name string) (Field, error) {
+	if _, ok := r.fieldMap[name]; !ok {
+		return nil, errors.Wrapf(ErrInvalidAggregation, "unknown field %q", name)
+	}
+	return r.schema.Fields().Field(name)
+}
@@ -0,0 +1,93 @@
+package crud_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+func TestRepository_Aggregate(t *testing.T) {
+	fixture := setupTest(t)
+	ctx := fixture.ctx
+	rep := crud.DefaultRepository[Report](fixture.schema)
+
+	for _, report := range []Report{
+		NewReport(CreateMultiLangTitle("Aggregate 1"), WithAuthor("Ann"), WithSummary("aggregate")),
+		NewReport(CreateMultiLangTitle("Aggregate 2"), WithAuthor("Ann"), WithSummary("aggregate")),
+		NewReport(CreateMultiLangTitle("Aggregate 3"), WithAuthor("Bob"), WithSummary("aggregate")),
+	} {
+		fields, err := fixture.schema.Mapper().ToFieldValues(ctx, report)
+		require.NoError(t, err)
+		_, err = rep.Create(ctx, fields)
+		require.NoError(t, err)
+	}
+	params := &crud.FindParams{
+		Filters: []crud.Filter{{Column: "summary", Filter: repo.Eq("aggregate")}},
+	}
+
+	t.Run("totals", func(t *testing.T) {
+		rows, err := rep.Aggregate(ctx, params, crud.AggregateQuery{
+			Aggregations: []crud.Aggregation{
+				{Func: crud.AggregateCount},
+				{Func: crud.AggregateMin, Field: "author"},
+				{Func: crud.AggregateAvg, Field: "id"},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Empty(t, rows[0].Groups)
+		assert.Equal(t, int64(3), rows[0].Values["count"])
+		assert.Equal(t, "Ann", rows[0].Values["min_author"])
+		assert.IsType(t, float64(0), rows[0].Values["avg_id"])
+	})
+
+	t.Run("grouped", func(t *testing.T) {
+		rows, err := rep.Aggregate(ctx, params, crud.AggregateQuery{
+			Aggregations: []crud.Aggregation{{Func: crud.AggregateCount}},
+			GroupBy:      []crud.GroupBy{{Field: "author"}},
+		})
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, "Ann", rows[0].Groups[0].Value())
+		assert.Equal(t, int64(2), rows[0].Values["count"])
+		assert.Equal(t, "Bob", rows[1].Groups[0].Value())
+		assert.Equal(t, int64(1), rows[1].Values["count"])
+	})
+}
+
+func TestRepository_AggregateInvalid(t *testing.T) {
+	rep := crud.DefaultRepository[Report](buildReportSchema())
+
+	tests := map[string]crud.AggregateQuery{
+		"no aggregations": {},
+		"sum of string": {
+			Aggregations: []crud.Aggregation{{Func: crud.AggregateSum, Field: "author"}},
+		},
+		"unknown function": {
+			Aggregations: []crud.Aggregation{{Func: "median", Field: "id"}},
+		},
+		"unknown field": {
+			Aggregations: []crud.Aggregation{{Func: crud.AggregateMax, Field: "pages"}},
+		},
+		"bucket of int": {
+			Aggregations: []crud.Aggregation{{Func: crud.AggregateCount}},
+			GroupBy:      []crud.GroupBy{{Field: "id", Bucket: crud.BucketMonth}},
+		},
+	}
+	for name, query := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := rep.Aggregate(context.Background(), nil, query)
+			require.ErrorIs(t, err, crud.ErrInvalidAggregation)
+		})
+	}
+}
+
+func TestAggregation_Name(t *testing.T) {
+	assert.Equal(t, "count", crud.Aggregation{Func: crud.AggregateCount}.Name())
+	assert.Equal(t, "sum_amount", crud.Aggregation{Func: crud.AggregateSum, Field: "amount"}.Name())
+}
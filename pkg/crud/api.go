@@ -0,0 +1,381 @@
+package crud
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+)
+
+// ErrInvalidAPIValue is returned by ParseAPIValue for values that don't match the field type.
+var ErrInvalidAPIValue = errors.New("invalid value")
+
+// APIRelatedRecord is the JSON representation of a related record in generated APIs
+type APIRelatedRecord struct {
+	Key   any    `json:"key"`
+	Label string `json:"label"`
+}
+
+// APIGeoPoint is the JSON representation of geopoint fields in generated APIs
+type APIGeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// APIFields returns the fields generated APIs expose: the visible ones, the key
+// and the version, which clients send back for optimistic concurrency checks.
+func APIFields(fields Fields) []Field {
+	result := make([]Field, 0, len(fields.Fields()))
+	for _, f := range fields.Fields() {
+		if !f.Hidden() || f.Key() || IsVersionField(f) {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// IsAPIWritable reports whether generated APIs accept values of field in request bodies
+func IsAPIWritable(field Field) bool {
+	if IsVersionField(field) {
+		return true
+	}
+	return !field.Readonly() && !field.Hidden()
+}
+
+// APIValue converts the value of fv to its JSON representation in generated APIs:
+// dates as YYYY-MM-DD, times as HH:MM:SS, decimals as strings, JSON fields as
+// embedded documents, related records as APIRelatedRecord, money as amounts in
+// minor units with the currency and geopoints as APIGeoPoint.
+func APIValue(fv FieldValue) any {
+	value := fv.Value()
+	if value == nil {
+		return nil
+	}
+
+	switch fv.Field().Type() {
+	case DateFieldType:
+		if t, ok := value.(time.Time); ok {
+			return t.Format(time.DateOnly)
+		}
+	case TimeFieldType:
+		if t, ok := value.(time.Time); ok {
+			return t.Format(time.TimeOnly)
+		}
+	case UUIDFieldType:
+		if b, ok := value.([16]uint8); ok {
+			return uuid.UUID(b)
+		}
+	case DecimalFieldType:
+		if s, err := fv.AsDecimal(); err == nil {
+			return s
+		}
+	case JSONFieldType:
+		if s, ok := value.(string); ok && json.Valid([]byte(s)) {
+			return json.RawMessage(s)
+		}
+	case RelationFieldType:
+		records, err := fv.AsRelated()
+		if err != nil {
+			return nil
+		}
+		result := make([]APIRelatedRecord, len(records))
+		for i, record := range records {
+			result[i] = APIRelatedRecord{Key: record.Key, Label: record.Label}
+		}
+		return result
+	case MoneyFieldType:
+		if m, err := fv.AsMoney(); err == nil {
+			return m
+		}
+	case GeoPointFieldType:
+		if p, err := fv.AsGeoPoint(); err == nil {
+			return APIGeoPoint{Lat: p.Lat(), Lng: p.Lng()}
+		}
+	case StringFieldType, IntFieldType, BoolFieldType, FloatFieldType, DateTimeFieldType, TimestampFieldType,
+		EnumFieldType, FileFieldType:
+	}
+	return value
+}
+
+// ParseAPIValue converts a value decoded from a JSON request body or taken from a
+// query string to a value of field. JSON numbers are expected as json.Number, i.e.
+// decoded with json.Decoder.UseNumber. Virtual relations take an array of target keys.
+// Money fields also take amounts in major units, e.g. "12.50", and geopoint fields
+// coordinates as "lat,lng".
+func ParseAPIValue(field Field, value any) (FieldValue, error) {
+	if value == nil {
+		return field.Value(nil), nil
+	}
+
+	parsed, err := parseAPIValue(field, value)
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidAPIValue, "field %q: %v", field.Name(), err)
+	}
+	return field.Value(parsed), nil
+}
+
+// FormValue converts the value of fv to text ParseAPIValue parses back, e.g. for
+// form inputs: money in major units and geopoints as "lat,lng".
+func FormValue(fv FieldValue) string {
+	value := fv.Value()
+	if value == nil {
+		return ""
+	}
+	switch fv.Field().Type() {
+	case MoneyFieldType:
+		if mf, ok := fv.Field().(MoneyField); ok {
+			if amount, ok := value.(int64); ok {
+				return formatMajorUnits(amount, mf.Fraction())
+			}
+		}
+	case GeoPointFieldType:
+		if p, err := fv.AsGeoPoint(); err == nil {
+			return strconv.FormatFloat(p.Lat(), 'f', -1, 64) + "," + strconv.FormatFloat(p.Lng(), 'f', -1, 64)
+		}
+	}
+	return fmt.Sprint(value)
+}
+
+func parseAPIValue(field Field, value any) (any, error) {
+	switch field.Type() {
+	case StringFieldType:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+	case IntFieldType:
+		var n int64
+		var err error
+		switch v := value.(type) {
+		case json.Number:
+			n, err = v.Int64()
+		case string:
+			n, err = strconv.ParseInt(v, 10, 64)
+		default:
+			return nil, fmt.Errorf("expected integer, got %T", value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if n >= math.MinInt32 && n <= math.MaxInt32 {
+			return int(n), nil
+		}
+		return n, nil
+	case FloatFieldType:
+		switch v := value.(type) {
+		case json.Number:
+			return v.Float64()
+		case string:
+			return strconv.ParseFloat(v, 64)
+		}
+	case DecimalFieldType:
+		var s string
+		switch v := value.(type) {
+		case json.Number:
+			s = v.String()
+		case string:
+			s = v
+		default:
+			return nil, fmt.Errorf("expected decimal, got %T", value)
+		}
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return nil, err
+		}
+		return s, nil
+	case BoolFieldType:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return strconv.ParseBool(v)
+		}
+	case DateFieldType:
+		return parseAPITime(value, time.DateOnly, time.RFC3339)
+	case TimeFieldType:
+		return parseAPITime(value, time.TimeOnly, "15:04")
+	case DateTimeFieldType, TimestampFieldType:
+		return parseAPITime(value, time.RFC3339)
+	case UUIDFieldType:
+		if s, ok := value.(string); ok {
+			return uuid.Parse(s)
+		}
+	case JSONFieldType:
+		if s, ok := value.(string); ok && json.Valid([]byte(s)) {
+			return s, nil
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case MoneyFieldType:
+		mf, ok := field.(MoneyField)
+		if !ok {
+			break
+		}
+		switch v := value.(type) {
+		case map[string]any:
+			if currency, _ := v["currency"].(string); currency != "" && !strings.EqualFold(currency, mf.Currency()) {
+				return nil, fmt.Errorf("expected amount in %s, got %s", mf.Currency(), currency)
+			}
+			amount, ok := v["amount"].(json.Number)
+			if !ok {
+				return nil, fmt.Errorf("expected amount in minor units, got %T", v["amount"])
+			}
+			return amount.Int64()
+		case json.Number:
+			return parseMajorUnits(v.String(), mf.Fraction())
+		case string:
+			return parseMajorUnits(v, mf.Fraction())
+		}
+	case EnumFieldType:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+	case FileFieldType:
+		switch v := value.(type) {
+		case json.Number:
+			return v.Int64()
+		case string:
+			return strconv.ParseInt(v, 10, 64)
+		}
+	case GeoPointFieldType:
+		switch v := value.(type) {
+		case map[string]any:
+			lat, latOK := v["lat"].(json.Number)
+			lng, lngOK := v["lng"].(json.Number)
+			if !latOK || !lngOK {
+				return nil, errors.New("expected lat and lng numbers")
+			}
+			return parseGeoPoint(lat.String() + "," + lng.String())
+		case string:
+			return parseGeoPoint(v)
+		}
+	case RelationFieldType:
+		rf, ok := field.(RelationField)
+		if !ok {
+			break
+		}
+		keys, ok := value.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expected array of keys, got %T", value)
+		}
+		keyField := rf.Config().Target.Fields().KeyField()
+		records := make([]RelatedRecord, 0, len(keys))
+		for _, key := range keys {
+			parsed, err := parseAPIValue(keyField, key)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, RelatedRecord{Key: relationKey(parsed)})
+		}
+		return records, nil
+	}
+	return nil, fmt.Errorf("expected %s, got %T", field.Type(), value)
+}
+
+// APIFilter creates a filter on field for one of APIFilterOperators or "eq". The
+// values are parsed with ParseAPIValue, except for like, which matches a substring.
+// The in operator takes any number of values, the others exactly one.
+func APIFilter(field Field, op string, values ...any) (repo.Filter, error) {
+	if op != "in" && len(values) != 1 {
+		return nil, errors.Errorf("%s filter takes one value, got %d", op, len(values))
+	}
+
+	switch op {
+	case "like":
+		s, ok := values[0].(string)
+		if !ok {
+			return nil, errors.Wrapf(ErrInvalidAPIValue, "field %q: expected string, got %T", field.Name(), values[0])
+		}
+		return repo.ILike("%" + s + "%"), nil
+	case "in":
+		parsed := make([]any, 0, len(values))
+		for _, v := range values {
+			fv, err := ParseAPIValue(field, v)
+			if err != nil {
+				return nil, err
+			}
+			parsed = append(parsed, fv.Value())
+		}
+		return repo.In(parsed), nil
+	}
+
+	fv, err := ParseAPIValue(field, values[0])
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case "eq":
+		return repo.Eq(fv.Value()), nil
+	case "ne":
+		return repo.NotEq(fv.Value()), nil
+	case "gt":
+		return repo.Gt(fv.Value()), nil
+	case "gte":
+		return repo.Gte(fv.Value()), nil
+	case "lt":
+		return repo.Lt(fv.Value()), nil
+	case "lte":
+		return repo.Lte(fv.Value()), nil
+	}
+	return nil, errors.Errorf("unknown filter operator %q", op)
+}
+
+func parseAPITime(value any, layouts ...string) (time.Time, error) {
+	s, ok := value.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("expected time string, got %T", value)
+	}
+	var err error
+	for _, layout := range layouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// ValidateFieldValues runs the rules of the fields on fieldValues and returns the
+// first failure of every field, keyed by field name.
+func ValidateFieldValues(fieldValues []FieldValue) serrors.ValidationErrors {
+	errs := make(serrors.ValidationErrors)
+	for _, fv := range fieldValues {
+		field := fv.Field()
+		for _, rule := range field.Rules() {
+			if err := rule(fv); err != nil {
+				errs[field.Name()] = NewFieldValidationError(field, err)
+				break
+			}
+		}
+	}
+	return errs
+}
+
+// NewFieldValidationError wraps an error of a field rule or ParseAPIValue into a
+// validation error localized with ValidationErrors.custom.
+func NewFieldValidationError(field Field, err error) *serrors.ValidationError {
+	fieldName := field.LocalizationKey()
+	if fieldName == "" {
+		fieldName = field.Name()
+	}
+	validationErr := serrors.NewValidationError(
+		field.Name(),
+		"VALIDATION_FIELD",
+		err.Error(),
+		"ValidationErrors.custom",
+	)
+	validationErr.TemplateData = map[string]string{
+		"Field": fieldName,
+		"Error": err.Error(),
+	}
+	return validationErr
+}
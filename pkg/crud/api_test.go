@@ -0,0 +1,146 @@
+package crud_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAPIValue(t *testing.T) {
+	t.Run("converts JSON values to field types", func(t *testing.T) {
+		id := uuid.New()
+		tests := []struct {
+			field crud.Field
+			value any
+			want  any
+		}{
+			{crud.NewStringField("title"), "Report", "Report"},
+			{crud.NewIntField("count"), json.Number("42"), 42},
+			{crud.NewIntField("count"), "42", 42},
+			{crud.NewIntField("count"), json.Number("5000000000"), int64(5000000000)},
+			{crud.NewFloatField("rate"), json.Number("1.5"), 1.5},
+			{crud.NewDecimalField("amount"), json.Number("10.25"), "10.25"},
+			{crud.NewBoolField("active"), true, true},
+			{crud.NewBoolField("active"), "false", false},
+			{crud.NewUUIDField("ref"), id.String(), id},
+			{crud.NewDateField("day"), "2024-03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+			{crud.NewTimestampField("at"), "2024-03-01T10:00:00Z", time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)},
+		}
+
+		for _, tt := range tests {
+			fv, err := crud.ParseAPIValue(tt.field, tt.value)
+			require.NoError(t, err, tt.field.Name())
+			assert.Equal(t, tt.want, fv.Value(), tt.field.Name())
+		}
+	})
+
+	t.Run("null clears the value", func(t *testing.T) {
+		fv, err := crud.ParseAPIValue(crud.NewStringField("title"), nil)
+		require.NoError(t, err)
+		assert.Nil(t, fv.Value())
+	})
+
+	t.Run("rejects values of other types", func(t *testing.T) {
+		_, err := crud.ParseAPIValue(crud.NewIntField("count"), "many")
+		require.ErrorIs(t, err, crud.ErrInvalidAPIValue)
+
+		_, err = crud.ParseAPIValue(crud.NewStringField("title"), json.Number("1"))
+		require.ErrorIs(t, err, crud.ErrInvalidAPIValue)
+
+		_, err = crud.ParseAPIValue(crud.NewDateField("day"), "yesterday")
+		require.ErrorIs(t, err, crud.ErrInvalidAPIValue)
+	})
+
+	t.Run("many-to-many takes target keys", func(t *testing.T) {
+		field := crud.NewManyToManyField("authors", crud.RelationConfig{
+			Target:     buildAuthorSchema(),
+			JoinTable:  "report_authors",
+			ForeignKey: "report_id",
+			JoinKey:    "author_id",
+		})
+
+		fv, err := crud.ParseAPIValue(field, []any{json.Number("1"), json.Number("2")})
+		require.NoError(t, err)
+		records, err := fv.AsRelated()
+		require.NoError(t, err)
+		assert.Equal(t, []any{int64(1), int64(2)}, crud.RelatedKeys(records))
+	})
+}
+
+func TestAPIValue(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "2024-03-01", crud.APIValue(crud.NewDateField("day").Value(day)))
+	assert.Equal(t, "10.25", crud.APIValue(crud.NewDecimalField("amount").Value("10.25")))
+	assert.Nil(t, crud.APIValue(crud.NewStringField("title").Value(nil)))
+
+	authors := crud.NewManyToManyField("authors", crud.RelationConfig{
+		Target:     buildAuthorSchema(),
+		JoinTable:  "report_authors",
+		ForeignKey: "report_id",
+		JoinKey:    "author_id",
+	})
+	value := crud.APIValue(authors.Value([]crud.RelatedRecord{{Key: int64(1), Label: "Ann"}}))
+	assert.Equal(t, []crud.APIRelatedRecord{{Key: int64(1), Label: "Ann"}}, value)
+}
+
+func TestValidateFieldValues(t *testing.T) {
+	title := crud.NewStringField("title", crud.WithRequired(), crud.WithLocalizationKey("Reports.Fields.Title"))
+	summary := crud.NewStringField("summary")
+
+	errs := crud.ValidateFieldValues([]crud.FieldValue{title.Value(""), summary.Value("")})
+
+	require.Len(t, errs, 1)
+	require.Contains(t, errs, "title")
+	assert.Equal(t, "title", errs["title"].Field)
+	assert.Equal(t, "ValidationErrors.custom", errs["title"].LocaleKey)
+	assert.Equal(t, "Reports.Fields.Title", errs["title"].TemplateData["Field"])
+}
+
+func TestAPIFields(t *testing.T) {
+	fields := crud.NewFields([]crud.Field{
+		crud.NewIntField("id", crud.WithKey(), crud.WithHidden()),
+		crud.NewIntField("version", crud.WithVersion(), crud.WithHidden()),
+		crud.NewStringField("title"),
+		crud.NewStringField("tenant_id", crud.WithHidden()),
+		crud.NewTimestampField("created_at", crud.WithReadonly()),
+	})
+
+	var names []string
+	for _, f := range crud.APIFields(fields) {
+		names = append(names, f.Name())
+	}
+	assert.Equal(t, []string{"id", "version", "title", "created_at"}, names)
+
+	version, err := fields.Field("version")
+	require.NoError(t, err)
+	assert.True(t, crud.IsAPIWritable(version))
+	createdAt, err := fields.Field("created_at")
+	require.NoError(t, err)
+	assert.False(t, crud.IsAPIWritable(createdAt))
+}
+
+func TestAPIFilter(t *testing.T) {
+	count := crud.NewIntField("count")
+
+	filter, err := crud.APIFilter(count, "in", "1", json.Number("2"))
+	require.NoError(t, err)
+	assert.Equal(t, "count IN ($1, $2)", filter.String("count", 1))
+	assert.Equal(t, []any{1, 2}, filter.Value())
+
+	filter, err = crud.APIFilter(count, "gte", "10")
+	require.NoError(t, err)
+	assert.Equal(t, "count >= $1", filter.String("count", 1))
+	assert.Equal(t, []any{10}, filter.Value())
+
+	_, err = crud.APIFilter(count, "gte", "1", "2")
+	require.Error(t, err)
+
+	_, err = crud.APIFilter(count, "between", "1")
+	require.Error(t, err)
+}
@@ -22,6 +22,14 @@ func WithService[TEntity any](service Service[TEntity]) BuilderOption[TEntity] {
 	}
 }
 
+// WithServiceOptions configures the default service, e.g. WithOutbox. It has no
+// effect together with WithService.
+func WithServiceOptions[TEntity any](opts ...ServiceOption) BuilderOption[TEntity] {
+	return func(b *builder[TEntity]) {
+		b.serviceOpts = append(b.serviceOpts, opts...)
+	}
+}
+
 func NewBuilder[TEntity any](
 	schema Schema[TEntity],
 	publisher eventbus.EventBus,
@@ -40,17 +48,18 @@ func NewBuilder[TEntity any](
 		b.repository = DefaultRepository[TEntity](schema)
 	}
 	if b.service == nil {
-		b.service = DefaultService[TEntity](schema, b.repository, b.publisher)
+		b.service = DefaultService[TEntity](schema, b.repository, b.publisher, b.serviceOpts...)
 	}
 
 	return b
 }
 
 type builder[TEntity any] struct {
-	schema     Schema[TEntity]
-	repository Repository[TEntity]
-	service    Service[TEntity]
-	publisher  eventbus.EventBus
+	schema      Schema[TEntity]
+	repository  Repository[TEntity]
+	service     Service[TEntity]
+	serviceOpts []ServiceOption
+	publisher   eventbus.EventBus
 }
 
 func (b *builder[TEntity]) Schema() Schema[TEntity] {
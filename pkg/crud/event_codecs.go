@@ -0,0 +1,143 @@
+package crud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/go-faster/errors"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+// RegisterEvents registers the events of services of schema in registry as
+// "crud.<schema>.created", "crud.<schema>.updated", ..., so that services built
+// WithOutbox can enqueue them. Entities are stored as their field values in the
+// form of the API; virtual fields are left out.
+func RegisterEvents[TEntity any](registry *eventbus.Registry, schema Schema[TEntity]) {
+	prefix := "crud." + schema.Name() + "."
+	register := func(name string, prototype any, wrap func(map[string][]TEntity) any, unwrap func(event any) map[string][]TEntity) {
+		registry.RegisterCodec(prefix+name, prototype, &eventCodec[TEntity]{schema: schema, wrap: wrap, unwrap: unwrap})
+	}
+
+	register("created", &CreatedEvent[TEntity]{}, func(m map[string][]TEntity) any {
+		return &CreatedEvent[TEntity]{Data: firstEntity(m["data"]), Result: firstEntity(m["result"])}
+	}, func(event any) map[string][]TEntity {
+		e := event.(*CreatedEvent[TEntity])
+		return map[string][]TEntity{"data": {e.Data}, "result": {e.Result}}
+	})
+	register("updated", &UpdatedEvent[TEntity]{}, func(m map[string][]TEntity) any {
+		return &UpdatedEvent[TEntity]{Data: firstEntity(m["data"]), Result: firstEntity(m["result"])}
+	}, func(event any) map[string][]TEntity {
+		e := event.(*UpdatedEvent[TEntity])
+		return map[string][]TEntity{"data": {e.Data}, "result": {e.Result}}
+	})
+	register("deleted", &DeletedEvent[TEntity]{}, func(m map[string][]TEntity) any {
+		return &DeletedEvent[TEntity]{Data: firstEntity(m["data"])}
+	}, func(event any) map[string][]TEntity {
+		return map[string][]TEntity{"data": {event.(*DeletedEvent[TEntity]).Data}}
+	})
+	register("restored", &RestoredEvent[TEntity]{}, func(m map[string][]TEntity) any {
+		return &RestoredEvent[TEntity]{Data: firstEntity(m["data"])}
+	}, func(event any) map[string][]TEntity {
+		return map[string][]TEntity{"data": {event.(*RestoredEvent[TEntity]).Data}}
+	})
+	register("bulk_saved", &BulkSavedEvent[TEntity]{}, func(m map[string][]TEntity) any {
+		return &BulkSavedEvent[TEntity]{Created: m["created"], Updated: m["updated"]}
+	}, func(event any) map[string][]TEntity {
+		e := event.(*BulkSavedEvent[TEntity])
+		return map[string][]TEntity{"created": e.Created, "updated": e.Updated}
+	})
+	register("bulk_upserted", &BulkUpsertedEvent[TEntity]{}, func(m map[string][]TEntity) any {
+		return &BulkUpsertedEvent[TEntity]{Data: m["data"]}
+	}, func(event any) map[string][]TEntity {
+		return map[string][]TEntity{"data": event.(*BulkUpsertedEvent[TEntity]).Data}
+	})
+	register("bulk_deleted", &BulkDeletedEvent[TEntity]{}, func(m map[string][]TEntity) any {
+		return &BulkDeletedEvent[TEntity]{Data: m["data"]}
+	}, func(event any) map[string][]TEntity {
+		return map[string][]TEntity{"data": event.(*BulkDeletedEvent[TEntity]).Data}
+	})
+}
+
+func firstEntity[TEntity any](entities []TEntity) TEntity {
+	var zero TEntity
+	if len(entities) == 0 {
+		return zero
+	}
+	return entities[0]
+}
+
+// eventCodec encodes the entities of an event, grouped by their role in it
+// (data, result, ...), with the mapper of the schema
+type eventCodec[TEntity any] struct {
+	schema Schema[TEntity]
+	wrap   func(map[string][]TEntity) any
+	unwrap func(event any) map[string][]TEntity
+}
+
+func (c *eventCodec[TEntity]) Encode(event any) ([]byte, error) {
+	ctx := context.Background()
+	payload := make(map[string][]map[string]any)
+	for role, entities := range c.unwrap(event) {
+		var set []TEntity
+		for _, e := range entities {
+			// Events leave entities they have no use for unset
+			if !reflect.ValueOf(&e).Elem().IsZero() {
+				set = append(set, e)
+			}
+		}
+		if len(set) == 0 {
+			continue
+		}
+		valuesList, err := c.schema.Mapper().ToFieldValuesList(ctx, set...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to map %s of %s event", role, c.schema.Name())
+		}
+		records := make([]map[string]any, len(valuesList))
+		for i, values := range valuesList {
+			records[i] = make(map[string]any, len(values))
+			for _, fv := range values {
+				if !IsVirtualField(fv.Field()) {
+					records[i][fv.Field().Name()] = APIValue(fv)
+				}
+			}
+		}
+		payload[role] = records
+	}
+	return json.Marshal(payload)
+}
+
+func (c *eventCodec[TEntity]) Decode(data []byte) (any, error) {
+	var payload map[string][]map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	entities := make(map[string][]TEntity, len(payload))
+	for role, records := range payload {
+		valuesList := make([][]FieldValue, len(records))
+		for i, record := range records {
+			for _, f := range c.schema.Fields().Fields() {
+				v, ok := record[f.Name()]
+				if !ok || IsVirtualField(f) {
+					continue
+				}
+				fv, err := HistoryValue(f, v)
+				if err != nil {
+					return nil, err
+				}
+				valuesList[i] = append(valuesList[i], fv)
+			}
+		}
+		mapped, err := c.schema.Mapper().ToEntities(ctx, valuesList...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to map %s of %s event", role, c.schema.Name())
+		}
+		entities[role] = mapped
+	}
+	return c.wrap(entities), nil
+}
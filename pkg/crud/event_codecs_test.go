@@ -0,0 +1,42 @@
+package crud_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+func TestRegisterEvents_RoundTrip(t *testing.T) {
+	schema := buildReportSchema()
+	registry := eventbus.NewRegistry()
+	crud.RegisterEvents(registry, schema)
+
+	data := NewReport(CreateMultiLangTitle("Draft"), WithAuthor("Alice"))
+	result := data.SetID(7)
+
+	name, payload, err := registry.Encode(&crud.CreatedEvent[Report]{Data: data, Result: result})
+	require.NoError(t, err)
+	assert.Equal(t, "crud.reports.created", name)
+
+	decoded, err := registry.Decode(name, payload)
+	require.NoError(t, err)
+	event, ok := decoded.(*crud.CreatedEvent[Report])
+	require.True(t, ok)
+	assert.Equal(t, 0, event.Data.ID())
+	assert.Equal(t, 7, event.Result.ID())
+	assert.Equal(t, "Alice", event.Result.Author())
+	assert.Equal(t, data.Title(), event.Result.Title())
+
+	t.Run("UnsetEntities", func(t *testing.T) {
+		name, payload, err := registry.Encode(&crud.BulkDeletedEvent[Report]{})
+		require.NoError(t, err)
+
+		decoded, err := registry.Decode(name, payload)
+		require.NoError(t, err)
+		assert.Empty(t, decoded.(*crud.BulkDeletedEvent[Report]).Data)
+	})
+}
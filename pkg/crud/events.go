@@ -22,6 +22,10 @@ func NewDeletedEvent[TEntity any](_ context.Context) (*DeletedEvent[TEntity], er
 	return &DeletedEvent[TEntity]{}, nil
 }
 
+func NewRestoredEvent[TEntity any](_ context.Context) (*RestoredEvent[TEntity], error) {
+	return &RestoredEvent[TEntity]{}, nil
+}
+
 type CreatedEvent[TEntity any] struct {
 	Data   TEntity
 	Result TEntity
@@ -43,3 +47,41 @@ func (e *UpdatedEvent[TEntity]) SetResult(result TEntity) {
 type DeletedEvent[TEntity any] struct {
 	Data TEntity
 }
+
+type RestoredEvent[TEntity any] struct {
+	Data TEntity
+}
+
+func NewBulkSavedEvent[TEntity any](_ context.Context, created, updated []TEntity) (*BulkSavedEvent[TEntity], error) {
+	return &BulkSavedEvent[TEntity]{
+		Created: created,
+		Updated: updated,
+	}, nil
+}
+
+func NewBulkUpsertedEvent[TEntity any](_ context.Context, data []TEntity) (*BulkUpsertedEvent[TEntity], error) {
+	return &BulkUpsertedEvent[TEntity]{
+		Data: data,
+	}, nil
+}
+
+func NewBulkDeletedEvent[TEntity any](_ context.Context) (*BulkDeletedEvent[TEntity], error) {
+	return &BulkDeletedEvent[TEntity]{}, nil
+}
+
+// BulkSavedEvent is published once per Service.BulkSave. Created entities are the
+// ones that were passed in, so they don't have keys generated by the database.
+type BulkSavedEvent[TEntity any] struct {
+	Created []TEntity
+	Updated []TEntity
+}
+
+// BulkUpsertedEvent is published once per Service.BulkUpsert.
+type BulkUpsertedEvent[TEntity any] struct {
+	Data []TEntity
+}
+
+// BulkDeletedEvent is published once per Service.BulkDelete.
+type BulkDeletedEvent[TEntity any] struct {
+	Data []TEntity
+}
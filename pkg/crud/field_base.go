@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 var (
@@ -36,6 +38,10 @@ const (
 	TimestampFieldType FieldType = "timestamp"
 	UUIDFieldType      FieldType = "uuid"
 	JSONFieldType      FieldType = "json"
+	MoneyFieldType     FieldType = "money"
+	EnumFieldType      FieldType = "enum"
+	FileFieldType      FieldType = "file"
+	GeoPointFieldType  FieldType = "geopoint"
 )
 
 const (
@@ -61,6 +67,26 @@ const (
 	DefaultValue string = "defaultValue"
 	TrueLabel    string = "trueLabel"
 	FalseLabel   string = "falseLabel"
+	Search       string = "search"
+	SearchConfig string = "searchConfig"
+	Versioned    string = "versioned"
+
+	ReadPermission  string = "readPermission"
+	WritePermission string = "writePermission"
+)
+
+// SearchMode selects how FindParams.Query is matched against a searchable field.
+type SearchMode string
+
+const (
+	// SearchModeLike matches fields containing the query, ignoring case. This is the default.
+	SearchModeLike SearchMode = "like"
+	// SearchModeFullText matches words of the query with Postgres full-text search.
+	// Set FindParams.RankSearch to order results by rank ahead of FindParams.SortBy.
+	SearchModeFullText SearchMode = "fulltext"
+	// SearchModeTrigram matches fields with a word similar to the query, tolerating typos.
+	// Requires the pg_trgm extension.
+	SearchModeTrigram SearchMode = "trigram"
 )
 
 type Field interface {
@@ -145,6 +171,14 @@ func newField(
 	if f.searchable && f.type_ != StringFieldType && f.type_ != JSONFieldType {
 		panic(fmt.Sprintf("field %q: searchable allowed only for type %q, got %q", name, StringFieldType, f.type_))
 	}
+	if f.attrs[Versioned] == true && f.type_ != IntFieldType {
+		panic(fmt.Sprintf("field %q: version allowed only for type %q, got %q", name, IntFieldType, f.type_))
+	}
+	if config, ok := f.attrs[SearchConfig].(string); ok {
+		if err := repo.ValidateTextSearchConfig(config); err != nil {
+			panic(fmt.Sprintf("field %q: %v", name, err))
+		}
+	}
 
 	return f
 }
@@ -298,6 +332,26 @@ func isValidType(fieldType FieldType, value any) bool {
 	case JSONFieldType:
 		return true
 
+	case MoneyFieldType, FileFieldType:
+		switch value.(type) {
+		case int, int32, int64:
+			return true
+		default:
+			return false
+		}
+
+	case EnumFieldType:
+		_, ok := value.(string)
+		return ok
+
+	case GeoPointFieldType:
+		_, ok := value.(pgtype.Point)
+		return ok
+
+	case RelationFieldType:
+		_, ok := value.([]RelatedRecord)
+		return ok
+
 	default:
 		return false
 	}
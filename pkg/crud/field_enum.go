@@ -0,0 +1,103 @@
+package crud
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// EnumField holds one of a fixed set of values, stored in a text column. The
+// labels of the values are translated with the keys returned by LabelKey.
+type EnumField interface {
+	Field
+
+	Values() []string
+	// LabelKey returns the localization key of the label of value
+	LabelKey(value string) string
+}
+
+// EnumFieldConfig holds configuration for enum field
+type EnumFieldConfig struct {
+	Values []string
+	// LabelKeyPrefix is prepended to values to build the localization keys of
+	// their labels, e.g. "Orders.Statuses" translates "paid" with
+	// "Orders.Statuses.paid". Defaults to "Enums.<field name>".
+	LabelKeyPrefix string
+}
+
+// EnumValues converts the constants of a string enumeration to EnumFieldConfig.Values
+func EnumValues[T ~string](values ...T) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = string(v)
+	}
+	return result
+}
+
+// NewEnumField creates a new enum field, which only accepts the configured values
+func NewEnumField(
+	name string,
+	config EnumFieldConfig,
+	opts ...FieldOption,
+) EnumField {
+	if len(config.Values) == 0 {
+		panic(fmt.Sprintf("field %q: enum requires values", name))
+	}
+	if config.LabelKeyPrefix == "" {
+		config.LabelKeyPrefix = "Enums." + name
+	}
+
+	f := newField(
+		name,
+		EnumFieldType,
+		opts...,
+	).(*field)
+	if f.rendererType == "" {
+		f.rendererType = string(EnumFieldType)
+	}
+	f.rules = append([]FieldRule{enumRule(config.Values)}, f.rules...)
+
+	return &enumField{field: f, config: config}
+}
+
+type enumField struct {
+	*field
+	config EnumFieldConfig
+}
+
+func (e *enumField) Values() []string {
+	return e.config.Values
+}
+
+func (e *enumField) LabelKey(value string) string {
+	return e.config.LabelKeyPrefix + "." + value
+}
+
+// Value takes strings and values of string enumerations, e.g. order.Status
+func (e *enumField) Value(value any) FieldValue {
+	if value == nil {
+		return &fieldValue{field: e, value: nil}
+	}
+	if s, ok := value.(string); ok {
+		return &fieldValue{field: e, value: s}
+	}
+	if v := reflect.ValueOf(value); v.Kind() == reflect.String {
+		return &fieldValue{field: e, value: v.String()}
+	}
+	panic(fmt.Sprintf(
+		"invalid type for enum field %q: expected string, got %T",
+		e.name, value,
+	))
+}
+
+// enumRule rejects values other than values, leaving empty ones to RequiredRule
+func enumRule(values []string) FieldRule {
+	return func(fv FieldValue) error {
+		val, ok := fv.Value().(string)
+		if !ok || val == "" || slices.Contains(values, val) {
+			return nil
+		}
+		return fmt.Errorf("field %q must be one of %s", fv.Field().Name(), strings.Join(values, ", "))
+	}
+}
@@ -0,0 +1,69 @@
+package crud_test
+
+import (
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type orderStatus string
+
+const (
+	orderStatusNew  orderStatus = "new"
+	orderStatusPaid orderStatus = "paid"
+)
+
+func TestEnumField(t *testing.T) {
+	t.Run("creates enum field correctly", func(t *testing.T) {
+		field := crud.NewEnumField("status", crud.EnumFieldConfig{
+			Values: crud.EnumValues(orderStatusNew, orderStatusPaid),
+		})
+
+		assert.Equal(t, crud.EnumFieldType, field.Type())
+		assert.Equal(t, []string{"new", "paid"}, field.Values())
+		assert.Equal(t, "Enums.status.paid", field.LabelKey("paid"))
+		assert.Equal(t, string(crud.EnumFieldType), field.RendererType())
+	})
+
+	t.Run("uses label key prefix", func(t *testing.T) {
+		field := crud.NewEnumField("status", crud.EnumFieldConfig{
+			Values:         []string{"new"},
+			LabelKeyPrefix: "Orders.Statuses",
+		})
+
+		assert.Equal(t, "Orders.Statuses.new", field.LabelKey("new"))
+	})
+
+	t.Run("requires values", func(t *testing.T) {
+		assert.Panics(t, func() {
+			crud.NewEnumField("status", crud.EnumFieldConfig{})
+		})
+	})
+
+	t.Run("takes string enumerations", func(t *testing.T) {
+		field := crud.NewEnumField("status", crud.EnumFieldConfig{Values: []string{"new", "paid"}})
+
+		s, err := field.Value(orderStatusPaid).AsString()
+		require.NoError(t, err)
+		assert.Equal(t, "paid", s)
+	})
+
+	t.Run("validates values", func(t *testing.T) {
+		field := crud.NewEnumField("status", crud.EnumFieldConfig{Values: []string{"new", "paid"}})
+
+		require.NoError(t, validateValue(field, "paid"))
+		require.NoError(t, validateValue(field, ""))
+		require.Error(t, validateValue(field, "shipped"))
+	})
+
+	t.Run("required rule still rejects empty values", func(t *testing.T) {
+		field := crud.NewEnumField("status",
+			crud.EnumFieldConfig{Values: []string{"new"}},
+			crud.WithRule(crud.RequiredRule()),
+		)
+
+		require.Error(t, validateValue(field, ""))
+	})
+}
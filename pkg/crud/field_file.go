@@ -0,0 +1,125 @@
+package crud
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
+)
+
+// FileField references an upload.Upload, stored in a bigint column referencing
+// uploads. Field values hold the ID of the upload as int64 and Value also takes
+// the upload itself.
+type FileField interface {
+	Field
+
+	// Accept lists the MIME types of the uploads the field takes, e.g.
+	// image/* or application/pdf. Any type is taken when empty.
+	Accept() []string
+	// MaxSize is the largest upload in bytes the field takes, 0 for any size
+	MaxSize() int
+	// Check returns an error when u isn't of an accepted type or is too large
+	Check(u upload.Upload) error
+}
+
+// FileFieldConfig holds configuration for file field
+type FileFieldConfig struct {
+	Accept  []string
+	MaxSize int
+}
+
+func NewFileField(
+	name string,
+	config FileFieldConfig,
+	opts ...FieldOption,
+) FileField {
+	f := newField(
+		name,
+		FileFieldType,
+		opts...,
+	).(*field)
+	if f.rendererType == "" {
+		f.rendererType = string(FileFieldType)
+	}
+	f.rules = append([]FieldRule{fileRule()}, f.rules...)
+
+	return &fileField{field: f, config: config}
+}
+
+type fileField struct {
+	*field
+	config FileFieldConfig
+}
+
+func (f *fileField) Accept() []string {
+	return f.config.Accept
+}
+
+func (f *fileField) MaxSize() int {
+	return f.config.MaxSize
+}
+
+func (f *fileField) Check(u upload.Upload) error {
+	if len(f.config.Accept) > 0 {
+		accepted := false
+		for _, pattern := range f.config.Accept {
+			if acceptsMimetype(pattern, u) {
+				accepted = true
+				break
+			}
+		}
+		if !accepted {
+			return fmt.Errorf("field %q takes %s files only", f.name, strings.Join(f.config.Accept, ", "))
+		}
+	}
+	if f.config.MaxSize > 0 && u.Size() != nil && u.Size().Bytes() > f.config.MaxSize {
+		return fmt.Errorf("field %q takes files up to %d bytes", f.name, f.config.MaxSize)
+	}
+	return nil
+}
+
+func (f *fileField) Value(value any) FieldValue {
+	switch v := value.(type) {
+	case nil:
+		return &fieldValue{field: f, value: nil}
+	case int:
+		return &fieldValue{field: f, value: int64(v)}
+	case int32:
+		return &fieldValue{field: f, value: int64(v)}
+	case int64:
+		return &fieldValue{field: f, value: v}
+	case uint:
+		return &fieldValue{field: f, value: int64(v)}
+	case upload.Upload:
+		if v == nil {
+			return &fieldValue{field: f, value: nil}
+		}
+		return &fieldValue{field: f, value: int64(v.ID())}
+	}
+	panic(fmt.Sprintf(
+		"invalid type for file field %q: expected upload.Upload or int64 ID, got %T",
+		f.name, value,
+	))
+}
+
+// acceptsMimetype matches the MIME type of u against pattern, which may end with a wildcard subtype
+func acceptsMimetype(pattern string, u upload.Upload) bool {
+	mime := u.Mimetype()
+	if mime == nil {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+		return strings.HasPrefix(mime.String(), prefix+"/")
+	}
+	return mime.Is(pattern)
+}
+
+// fileRule rejects references to uploads that can't exist
+func fileRule() FieldRule {
+	return func(fv FieldValue) error {
+		if id, ok := fv.Value().(int64); ok && id <= 0 {
+			return fmt.Errorf("field %q must reference an upload", fv.Field().Name())
+		}
+		return nil
+	}
+}
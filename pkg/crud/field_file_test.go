@@ -0,0 +1,62 @@
+package crud_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/upload"
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileField(t *testing.T) {
+	t.Run("creates file field correctly", func(t *testing.T) {
+		field := crud.NewFileField("avatar", crud.FileFieldConfig{
+			Accept:  []string{"image/*"},
+			MaxSize: 1024,
+		})
+
+		assert.Equal(t, crud.FileFieldType, field.Type())
+		assert.Equal(t, []string{"image/*"}, field.Accept())
+		assert.Equal(t, 1024, field.MaxSize())
+		assert.Equal(t, string(crud.FileFieldType), field.RendererType())
+	})
+
+	t.Run("stores upload IDs", func(t *testing.T) {
+		field := crud.NewFileField("avatar", crud.FileFieldConfig{})
+		u := upload.New("hash", "path", "avatar.png", "", 10, mimetype.Lookup("image/png"))
+		u.SetID(7)
+
+		fv := field.Value(u)
+		assert.Equal(t, int64(7), fv.Value())
+
+		id, err := fv.AsUploadID()
+		require.NoError(t, err)
+		assert.Equal(t, uint(7), id)
+
+		fv, err = crud.ParseAPIValue(field, json.Number("9"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(9), fv.Value())
+	})
+
+	t.Run("rejects invalid upload IDs", func(t *testing.T) {
+		field := crud.NewFileField("avatar", crud.FileFieldConfig{})
+
+		require.NoError(t, validateValue(field, int64(1)))
+		require.Error(t, validateValue(field, int64(0)))
+	})
+
+	t.Run("checks mimetype and size", func(t *testing.T) {
+		field := crud.NewFileField("avatar", crud.FileFieldConfig{
+			Accept:  []string{"image/*", "application/pdf"},
+			MaxSize: 100,
+		})
+
+		require.NoError(t, field.Check(upload.New("h", "p", "a.png", "", 10, mimetype.Lookup("image/png"))))
+		require.NoError(t, field.Check(upload.New("h", "p", "a.pdf", "", 10, mimetype.Lookup("application/pdf"))))
+		require.Error(t, field.Check(upload.New("h", "p", "a.zip", "", 10, mimetype.Lookup("application/zip"))))
+		require.Error(t, field.Check(upload.New("h", "p", "a.png", "", 101, mimetype.Lookup("image/png"))))
+	})
+}
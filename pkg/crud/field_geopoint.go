@@ -0,0 +1,98 @@
+package crud
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/geopoint"
+)
+
+// GeoPointField holds geopoint.GeoPoint coordinates, stored in a point column
+// with the longitude as x and the latitude as y. Field values hold pgtype.Point
+// and FieldValue.AsGeoPoint converts them back.
+type GeoPointField interface {
+	Field
+}
+
+func NewGeoPointField(
+	name string,
+	opts ...FieldOption,
+) GeoPointField {
+	f := newField(
+		name,
+		GeoPointFieldType,
+		opts...,
+	).(*field)
+	if f.rendererType == "" {
+		f.rendererType = string(GeoPointFieldType)
+	}
+	f.rules = append([]FieldRule{geoPointRule()}, f.rules...)
+
+	return &geoPointField{field: f}
+}
+
+type geoPointField struct {
+	*field
+}
+
+func (g *geoPointField) Value(value any) FieldValue {
+	switch v := value.(type) {
+	case nil:
+		return &fieldValue{field: g, value: nil}
+	case pgtype.Point:
+		// NULL columns are scanned as invalid points
+		if !v.Valid {
+			return &fieldValue{field: g, value: nil}
+		}
+		return &fieldValue{field: g, value: v}
+	case geopoint.GeoPoint:
+		if v == nil {
+			return &fieldValue{field: g, value: nil}
+		}
+		return &fieldValue{field: g, value: pgtype.Point{
+			P:     pgtype.Vec2{X: v.Lng(), Y: v.Lat()},
+			Valid: true,
+		}}
+	}
+	panic(fmt.Sprintf(
+		"invalid type for geopoint field %q: expected geopoint.GeoPoint or pgtype.Point, got %T",
+		g.name, value,
+	))
+}
+
+// parseGeoPoint parses coordinates in the "lat,lng" format of geopoint.GeoPoint.String
+func parseGeoPoint(s string) (geopoint.GeoPoint, error) {
+	latStr, lngStr, ok := strings.Cut(s, ",")
+	if !ok {
+		return nil, fmt.Errorf("expected coordinates as lat,lng, got %q", s)
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latStr), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid latitude %q", latStr)
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(lngStr), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid longitude %q", lngStr)
+	}
+	return geopoint.New(lat, lng), nil
+}
+
+// geoPointRule rejects coordinates outside of the globe
+func geoPointRule() FieldRule {
+	return func(fv FieldValue) error {
+		p, ok := fv.Value().(pgtype.Point)
+		if !ok {
+			return nil
+		}
+		if p.P.Y < -90 || p.P.Y > 90 {
+			return fmt.Errorf("field %q must have a latitude between -90 and 90", fv.Field().Name())
+		}
+		if p.P.X < -180 || p.P.X > 180 {
+			return fmt.Errorf("field %q must have a longitude between -180 and 180", fv.Field().Name())
+		}
+		return nil
+	}
+}
@@ -0,0 +1,58 @@
+package crud_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/geopoint"
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeoPointField(t *testing.T) {
+	t.Run("stores points with longitude as x", func(t *testing.T) {
+		field := crud.NewGeoPointField("location")
+
+		fv := field.Value(geopoint.New(41.31, 69.24))
+		assert.Equal(t, pgtype.Point{P: pgtype.Vec2{X: 69.24, Y: 41.31}, Valid: true}, fv.Value())
+
+		p, err := fv.AsGeoPoint()
+		require.NoError(t, err)
+		assert.InDelta(t, 41.31, p.Lat(), 1e-9)
+		assert.InDelta(t, 69.24, p.Lng(), 1e-9)
+	})
+
+	t.Run("maps NULL points to nil", func(t *testing.T) {
+		field := crud.NewGeoPointField("location")
+
+		assert.Nil(t, field.Value(pgtype.Point{}).Value())
+	})
+
+	t.Run("parses coordinates", func(t *testing.T) {
+		field := crud.NewGeoPointField("location")
+		want := field.Value(geopoint.New(41.31, 69.24)).Value()
+
+		fv, err := crud.ParseAPIValue(field, "41.31, 69.24")
+		require.NoError(t, err)
+		assert.Equal(t, want, fv.Value())
+
+		fv, err = crud.ParseAPIValue(field, map[string]any{"lat": json.Number("41.31"), "lng": json.Number("69.24")})
+		require.NoError(t, err)
+		assert.Equal(t, want, fv.Value())
+
+		assert.Equal(t, "41.31,69.24", crud.FormValue(fv))
+
+		_, err = crud.ParseAPIValue(field, "41.31")
+		require.ErrorIs(t, err, crud.ErrInvalidAPIValue)
+	})
+
+	t.Run("validates coordinates", func(t *testing.T) {
+		field := crud.NewGeoPointField("location")
+
+		require.NoError(t, validateValue(field, geopoint.New(90, 180)))
+		require.Error(t, validateValue(field, geopoint.New(91, 0)))
+		require.Error(t, validateValue(field, geopoint.New(0, -181)))
+	})
+}
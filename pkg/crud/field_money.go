@@ -0,0 +1,129 @@
+package crud
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/iota-uz/iota-sdk/pkg/money"
+)
+
+// MoneyField holds amounts of money in a fixed currency. Amounts are stored in a
+// bigint column in minor units, e.g. cents, and held in field values as int64.
+// Value also takes money.Money in the currency of the field and
+// FieldValue.AsMoney converts amounts back to it.
+type MoneyField interface {
+	Field
+
+	// Currency is the ISO 4217 code of the currency of the amounts
+	Currency() string
+	// Fraction is the number of digits of the minor units of the currency
+	Fraction() int
+}
+
+func NewMoneyField(
+	name string,
+	currency string,
+	opts ...FieldOption,
+) MoneyField {
+	f := newField(
+		name,
+		MoneyFieldType,
+		opts...,
+	).(*field)
+	// Money, enum, file and geopoint fields render with the renderer registered
+	// under the name of their type unless WithRenderer overrides it
+	if f.rendererType == "" {
+		f.rendererType = string(MoneyFieldType)
+	}
+
+	return &moneyField{field: f, currency: strings.ToUpper(currency)}
+}
+
+type moneyField struct {
+	*field
+	currency string
+}
+
+func (m *moneyField) Currency() string {
+	return m.currency
+}
+
+func (m *moneyField) Fraction() int {
+	if c := money.GetCurrency(m.currency); c != nil {
+		return c.Fraction
+	}
+	return 2
+}
+
+func (m *moneyField) Value(value any) FieldValue {
+	var amount int64
+	switch v := value.(type) {
+	case nil:
+		return &fieldValue{field: m, value: nil}
+	case int:
+		amount = int64(v)
+	case int32:
+		amount = int64(v)
+	case int64:
+		amount = v
+	case money.Money:
+		amount = m.amountOf(&v)
+	case *money.Money:
+		if v == nil {
+			return &fieldValue{field: m, value: nil}
+		}
+		amount = m.amountOf(v)
+	default:
+		panic(fmt.Sprintf(
+			"invalid type for money field %q: expected money.Money or int64 minor units, got %T",
+			m.name, value,
+		))
+	}
+	return &fieldValue{field: m, value: amount}
+}
+
+// amountOf returns the amount of v, which must be in the currency of the field
+func (m *moneyField) amountOf(v *money.Money) int64 {
+	if c := v.Currency(); c != nil && c.Code != "" && c.Code != m.currency {
+		panic(fmt.Sprintf(
+			"invalid currency for money field %q: expected %s, got %s",
+			m.name, m.currency, c.Code,
+		))
+	}
+	return v.Amount()
+}
+
+// parseMajorUnits converts an amount in major units, e.g. "12.50", to minor
+// units without going through floats, which would lose cents.
+func parseMajorUnits(s string, fraction int) (int64, error) {
+	s = strings.TrimSpace(s)
+	whole, frac, _ := strings.Cut(s, ".")
+	if len(frac) > fraction {
+		return 0, fmt.Errorf("expected at most %d decimal places, got %q", fraction, s)
+	}
+	negative := strings.HasPrefix(whole, "-")
+	digits := strings.TrimPrefix(whole, "-") + frac + strings.Repeat("0", fraction-len(frac))
+	amount, err := strconv.ParseUint(digits, 10, 63)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	if negative {
+		return -int64(amount), nil
+	}
+	return int64(amount), nil
+}
+
+// formatMajorUnits is the inverse of parseMajorUnits, e.g. 1250 cents to "12.50"
+func formatMajorUnits(amount int64, fraction int) string {
+	if fraction == 0 {
+		return strconv.FormatInt(amount, 10)
+	}
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	digits := fmt.Sprintf("%0*d", fraction+1, amount)
+	return sign + digits[:len(digits)-fraction] + "." + digits[len(digits)-fraction:]
+}
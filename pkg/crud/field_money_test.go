@@ -0,0 +1,123 @@
+package crud_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+	"github.com/iota-uz/iota-sdk/pkg/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoneyField(t *testing.T) {
+	t.Run("creates money field correctly", func(t *testing.T) {
+		field := crud.NewMoneyField("price", "usd")
+
+		assert.Equal(t, "price", field.Name())
+		assert.Equal(t, crud.MoneyFieldType, field.Type())
+		assert.Equal(t, "USD", field.Currency())
+		assert.Equal(t, 2, field.Fraction())
+		assert.Equal(t, string(crud.MoneyFieldType), field.RendererType())
+	})
+
+	t.Run("keeps explicit renderer", func(t *testing.T) {
+		field := crud.NewMoneyField("price", "USD", crud.WithRenderer("custom"))
+
+		assert.Equal(t, "custom", field.RendererType())
+	})
+
+	t.Run("stores minor units", func(t *testing.T) {
+		field := crud.NewMoneyField("price", "USD")
+
+		assert.Equal(t, int64(1250), field.Value(1250).Value())
+		assert.Equal(t, int64(1250), field.Value(money.New(1250, "USD")).Value())
+		assert.Equal(t, int64(1250), field.Value(*money.New(1250, "USD")).Value())
+		assert.Nil(t, field.Value(nil).Value())
+	})
+
+	t.Run("rejects other currencies", func(t *testing.T) {
+		field := crud.NewMoneyField("price", "USD")
+
+		assert.Panics(t, func() {
+			field.Value(money.New(1250, "EUR"))
+		})
+	})
+
+	t.Run("converts back to money", func(t *testing.T) {
+		field := crud.NewMoneyField("price", "USD")
+
+		m, err := field.Value(int64(1250)).AsMoney()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1250), m.Amount())
+		assert.Equal(t, "USD", m.Currency().Code)
+
+		_, err = crud.NewIntField("count").Value(1).AsMoney()
+		require.Error(t, err)
+	})
+
+	t.Run("parses amounts in major and minor units", func(t *testing.T) {
+		field := crud.NewMoneyField("price", "USD")
+		tests := []struct {
+			value any
+			want  int64
+		}{
+			{"12.50", 1250},
+			{"12.5", 1250},
+			{"12", 1200},
+			{"-0.05", -5},
+			{json.Number("7.99"), 799},
+			{map[string]any{"amount": json.Number("1250"), "currency": "USD"}, 1250},
+			{map[string]any{"amount": json.Number("1250")}, 1250},
+		}
+
+		for _, tt := range tests {
+			fv, err := crud.ParseAPIValue(field, tt.value)
+			require.NoError(t, err, tt.value)
+			assert.Equal(t, tt.want, fv.Value(), tt.value)
+		}
+	})
+
+	t.Run("rejects invalid amounts", func(t *testing.T) {
+		field := crud.NewMoneyField("price", "USD")
+
+		for _, value := range []any{
+			"12.505",
+			"twelve",
+			map[string]any{"amount": json.Number("1250"), "currency": "EUR"},
+			true,
+		} {
+			_, err := crud.ParseAPIValue(field, value)
+			require.ErrorIs(t, err, crud.ErrInvalidAPIValue, value)
+		}
+	})
+
+	t.Run("formats amounts in major units", func(t *testing.T) {
+		field := crud.NewMoneyField("price", "USD")
+
+		assert.Equal(t, "12.50", crud.FormValue(field.Value(1250)))
+		assert.Equal(t, "-0.05", crud.FormValue(field.Value(-5)))
+
+		fv, err := crud.ParseAPIValue(field, crud.FormValue(field.Value(123456)))
+		require.NoError(t, err)
+		assert.Equal(t, int64(123456), fv.Value())
+	})
+
+	t.Run("applies value rules in major units", func(t *testing.T) {
+		field := crud.NewMoneyField("price", "USD", crud.WithRule(crud.MaxValueRule(100)))
+
+		require.NoError(t, validateValue(field, int64(10000)))
+		require.Error(t, validateValue(field, int64(10001)))
+	})
+}
+
+// validateValue runs the rules of field against value, returning the first error
+func validateValue(field crud.Field, value any) error {
+	fv := field.Value(value)
+	for _, rule := range field.Rules() {
+		if err := rule(fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -29,6 +29,35 @@ func WithSearchable() FieldOption {
 	}
 }
 
+// WithSearchMode makes the field searchable using the given mode.
+func WithSearchMode(mode SearchMode) FieldOption {
+	return func(field *field) {
+		field.searchable = true
+		field.attrs[Search] = mode
+	}
+}
+
+// WithFullTextSearch makes the field searchable with full-text search
+// using a Postgres text search config such as "english", or "simple" when empty.
+func WithFullTextSearch(config string) FieldOption {
+	return func(field *field) {
+		field.searchable = true
+		field.attrs[Search] = SearchModeFullText
+		field.attrs[SearchConfig] = config
+	}
+}
+
+// WithVersion makes the int field the optimistic concurrency version of the entity.
+// Updates only succeed while the stored version still equals the submitted one and
+// increment it, otherwise they fail with a *serrors.ConflictError. Updates without
+// a version fail with repo.ErrVersionRequired. The column should default to 1, see
+// repo.VersionColumn.
+func WithVersion() FieldOption {
+	return func(field *field) {
+		field.attrs[Versioned] = true
+	}
+}
+
 func WithSortable() FieldOption {
 	return func(field *field) {
 		field.sortable = true
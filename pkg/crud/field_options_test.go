@@ -36,6 +36,20 @@ func TestFieldOptions(t *testing.T) {
 		assert.False(t, field2.Hidden())
 	})
 
+	t.Run("WithVersion", func(t *testing.T) {
+		field := crud.NewIntField("version", crud.WithVersion())
+		assert.True(t, crud.IsVersionField(field))
+		assert.False(t, crud.IsVersionField(crud.NewIntField("count")))
+
+		fields := crud.NewFields([]crud.Field{crud.NewIntField("id", crud.WithKey()), field})
+		assert.Equal(t, field, fields.VersionField())
+		assert.Nil(t, crud.NewFields([]crud.Field{crud.NewIntField("id", crud.WithKey())}).VersionField())
+
+		assert.Panics(t, func() {
+			crud.NewStringField("version", crud.WithVersion())
+		})
+	})
+
 	t.Run("WithSearchable", func(t *testing.T) {
 		field := crud.NewStringField("test", crud.WithSearchable())
 		assert.True(t, field.Searchable())
@@ -50,6 +64,23 @@ func TestFieldOptions(t *testing.T) {
 		})
 	})
 
+	t.Run("WithSearchMode", func(t *testing.T) {
+		field := crud.NewStringField("test", crud.WithSearchMode(crud.SearchModeTrigram))
+		assert.True(t, field.Searchable())
+		assert.Equal(t, crud.SearchModeTrigram, field.Attrs()[crud.Search])
+	})
+
+	t.Run("WithFullTextSearch", func(t *testing.T) {
+		field := crud.NewStringField("test", crud.WithFullTextSearch("english"))
+		assert.True(t, field.Searchable())
+		assert.Equal(t, crud.SearchModeFullText, field.Attrs()[crud.Search])
+		assert.Equal(t, "english", field.Attrs()[crud.SearchConfig])
+
+		assert.Panics(t, func() {
+			crud.NewStringField("test", crud.WithFullTextSearch("english'"))
+		})
+	})
+
 	t.Run("WithInitialValue", func(t *testing.T) {
 		field := crud.NewStringField("test", crud.WithInitialValue(func(ctx context.Context) any {
 			return "initial"
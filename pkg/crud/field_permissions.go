@@ -0,0 +1,57 @@
+package crud
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
+)
+
+// WithReadPermission makes reading the field require all of perms. Service
+// clears the field in the entities it returns to users without them, and the
+// scaffold controller and generated APIs leave it out.
+func WithReadPermission(perms ...rbac.Permission) FieldOption {
+	return func(field *field) {
+		field.attrs[ReadPermission] = perms
+	}
+}
+
+// WithWritePermission makes changing the field require all of perms. Service
+// rejects saves that change the field of users without them with
+// composables.ErrForbidden, and forms show the field as readonly.
+func WithWritePermission(perms ...rbac.Permission) FieldOption {
+	return func(field *field) {
+		field.attrs[WritePermission] = perms
+	}
+}
+
+// CanReadField reports whether the user in ctx may read field. Key fields are
+// always readable, and every field is readable without a user in ctx.
+func CanReadField(ctx context.Context, field Field) bool {
+	if field.Key() {
+		return true
+	}
+	perms, _ := field.Attrs()[ReadPermission].([]rbac.Permission)
+	return composables.CanUserAll(ctx, perms...) == nil
+}
+
+// CanWriteField reports whether the user in ctx may change field, which
+// requires reading it as well.
+func CanWriteField(ctx context.Context, field Field) bool {
+	if !CanReadField(ctx, field) {
+		return false
+	}
+	perms, _ := field.Attrs()[WritePermission].([]rbac.Permission)
+	return composables.CanUserAll(ctx, perms...) == nil
+}
+
+// ReadableFields returns the fields of fields the user in ctx may read
+func ReadableFields(ctx context.Context, fields []Field) []Field {
+	result := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		if CanReadField(ctx, f) {
+			result = append(result, f)
+		}
+	}
+	return result
+}
@@ -0,0 +1,151 @@
+package crud_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/internet"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+	"github.com/iota-uz/iota-sdk/pkg/crud/models"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+type allowPermission bool
+
+func (p allowPermission) Can(user.User) bool {
+	return bool(p)
+}
+
+func userContext() context.Context {
+	email, _ := internet.NewEmail("john.doe@example.com")
+	return composables.WithUser(context.Background(), user.New("John", "Doe", email, "en"))
+}
+
+// memoryReports is a repository of reports kept in memory, implementing the
+// methods the field permission tests need
+type memoryReports struct {
+	crud.Repository[Report]
+	reports []Report
+}
+
+func (r *memoryReports) Get(_ context.Context, value crud.FieldValue) (Report, error) {
+	for _, report := range r.reports {
+		if report.ID() == value.Value() {
+			return report, nil
+		}
+	}
+	return nil, crud.ErrNotFound
+}
+
+func (r *memoryReports) Exists(ctx context.Context, value crud.FieldValue) (bool, error) {
+	_, err := r.Get(ctx, value)
+	return err == nil, nil
+}
+
+func (r *memoryReports) List(context.Context, *crud.FindParams) ([]Report, error) {
+	return r.reports, nil
+}
+
+func TestFieldPermissions(t *testing.T) {
+	ctx := userContext()
+
+	t.Run("no permissions", func(t *testing.T) {
+		field := crud.NewStringField("name")
+		assert.True(t, crud.CanReadField(ctx, field))
+		assert.True(t, crud.CanWriteField(ctx, field))
+	})
+
+	t.Run("WithReadPermission", func(t *testing.T) {
+		field := crud.NewStringField("salary", crud.WithReadPermission(allowPermission(true), allowPermission(false)))
+		assert.False(t, crud.CanReadField(ctx, field))
+		assert.False(t, crud.CanWriteField(ctx, field))
+		assert.True(t, crud.CanReadField(context.Background(), field))
+
+		key := crud.NewIntField("id", crud.WithKey(), crud.WithReadPermission(allowPermission(false)))
+		assert.True(t, crud.CanReadField(ctx, key))
+	})
+
+	t.Run("WithWritePermission", func(t *testing.T) {
+		field := crud.NewStringField("salary", crud.WithWritePermission(allowPermission(false)))
+		assert.True(t, crud.CanReadField(ctx, field))
+		assert.False(t, crud.CanWriteField(ctx, field))
+		assert.True(t, crud.CanWriteField(context.Background(), field))
+	})
+
+	t.Run("ReadableFields", func(t *testing.T) {
+		fields := []crud.Field{
+			crud.NewStringField("name"),
+			crud.NewStringField("salary", crud.WithReadPermission(allowPermission(false))),
+		}
+		readable := crud.ReadableFields(ctx, fields)
+		require.Len(t, readable, 1)
+		assert.Equal(t, "name", readable[0].Name())
+	})
+}
+
+func TestService_FieldPermissions(t *testing.T) {
+	fields := crud.NewFields([]crud.Field{
+		crud.NewIntField("id", crud.WithKey()),
+		crud.NewJSONField[models.MultiLang]("title", crud.JSONFieldConfig[models.MultiLang]{}),
+		crud.NewStringField("author", crud.WithReadPermission(allowPermission(false))),
+		crud.NewStringField("summary", crud.WithWritePermission(allowPermission(false))),
+	})
+	schema := crud.NewSchema("reports", fields, NewReportMapper(fields))
+	repository := &memoryReports{reports: []Report{
+		NewReport(CreateMultiLangTitle("Q1"), WithID(1), WithAuthor("Jane"), WithSummary("Quarterly")),
+	}}
+	service := crud.DefaultService[Report](schema, repository, nil)
+	key := fields.KeyField().Value(1)
+
+	t.Run("clears unreadable fields", func(t *testing.T) {
+		report, err := service.Get(userContext(), key)
+		require.NoError(t, err)
+		assert.Empty(t, report.Author())
+		assert.Equal(t, "Quarterly", report.Summary())
+
+		reports, err := service.List(userContext(), &crud.FindParams{})
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Empty(t, reports[0].Author())
+
+		report, err = service.Get(context.Background(), key)
+		require.NoError(t, err)
+		assert.Equal(t, "Jane", report.Author())
+	})
+
+	t.Run("rejects filters and sorts on unreadable fields", func(t *testing.T) {
+		_, err := service.List(userContext(), &crud.FindParams{
+			Filters: []crud.Filter{{Column: "author", Filter: repo.Eq("Jane")}},
+		})
+		require.ErrorIs(t, err, composables.ErrForbidden)
+
+		_, err = service.Count(userContext(), &crud.FindParams{
+			SortBy: crud.SortBy{Fields: []repo.SortByField[string]{{Field: "author"}}},
+		})
+		require.ErrorIs(t, err, composables.ErrForbidden)
+
+		_, err = service.List(userContext(), &crud.FindParams{
+			Filters: []crud.Filter{{Column: "summary", Filter: repo.Eq("Quarterly")}},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects writes to forbidden fields", func(t *testing.T) {
+		report, err := service.Get(userContext(), key)
+		require.NoError(t, err)
+
+		_, err = service.Save(userContext(), report.SetSummary("Annual"))
+		require.ErrorIs(t, err, composables.ErrForbidden)
+
+		_, err = service.Save(userContext(), report.SetAuthor("John"))
+		require.ErrorIs(t, err, composables.ErrForbidden)
+
+		_, err = service.Save(userContext(), NewReport(CreateMultiLangTitle("Q2"), WithSummary("Annual")))
+		require.ErrorIs(t, err, composables.ErrForbidden)
+	})
+}
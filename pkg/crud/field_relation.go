@@ -0,0 +1,437 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-faster/errors"
+	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+// RelationFieldType is the type of has-many and many-to-many fields, whose values
+// are the related records rather than a column of the table.
+const RelationFieldType FieldType = "relation"
+
+// ErrMissingRelated is returned when saving an entity that references records
+// of a related schema that don't exist.
+var ErrMissingRelated = errors.New("related record not found")
+
+// RelationType defines how a relation field links two schemas
+type RelationType string
+
+const (
+	// BelongsTo stores the key of one target record in the field's column
+	BelongsTo RelationType = "belongsTo"
+	// HasMany links the target records whose foreign key column holds the entity key
+	HasMany RelationType = "hasMany"
+	// ManyToMany links target records through a join table holding both keys
+	ManyToMany RelationType = "manyToMany"
+)
+
+// RelatedSchema is the part of a Schema that relations need to query the
+// related table. Any Schema[TEntity] satisfies it.
+type RelatedSchema interface {
+	Name() string
+	Fields() Fields
+	SoftDelete() bool
+}
+
+// RelatedRecord is a record of the target schema loaded through a relation
+type RelatedRecord struct {
+	Key    any
+	Label  string
+	Values map[string]any
+}
+
+// RelatedKeys returns the keys of records
+func RelatedKeys(records []RelatedRecord) []any {
+	keys := make([]any, len(records))
+	for i, record := range records {
+		keys[i] = record.Key
+	}
+	return keys
+}
+
+// RelatedRecords maps the keys records were loaded for to the records
+type RelatedRecords map[any][]RelatedRecord
+
+// For returns the records loaded for key, which may be of any integer or UUID
+// representation of the stored key.
+func (r RelatedRecords) For(key any) []RelatedRecord {
+	return r[relationKey(key)]
+}
+
+// RelationConfig describes the target of a relation field
+type RelationConfig struct {
+	// Target is the schema of the related records
+	Target RelatedSchema
+	// LabelField names the target field shown for related records,
+	// the key field of the target when empty
+	LabelField string
+	// ForeignKey is the column referencing the entity: of the target table
+	// for HasMany and of the join table for ManyToMany
+	ForeignKey string
+	// JoinTable links entities and target records for ManyToMany
+	JoinTable string
+	// JoinKey is the column of the join table referencing the target for ManyToMany
+	JoinKey string
+	// Endpoint renders the field as a searchable select fetching options from it
+	// instead of listing all target records
+	Endpoint string
+}
+
+// RelationField links the entities of a schema to the records of another schema.
+// Belongs-to fields are columns holding the target key, so their values are keys.
+// Has-many and many-to-many fields are virtual: they have no column, their values
+// are []RelatedRecord and they are loaded by Repository.Get and for the fields
+// listed in FindParams.Include. A nil value means the relation wasn't loaded and
+// leaves the stored links of a many-to-many relation untouched when saving.
+type RelationField interface {
+	Field
+
+	Relation() RelationType
+	Config() RelationConfig
+	// Virtual reports whether the field has no column in the table of the schema
+	Virtual() bool
+
+	// Options lists all live target records as select options
+	Options(ctx context.Context) ([]SelectOption, error)
+	// Load fetches the related records of many entities in a single query.
+	// For BelongsTo keys are the field values, otherwise the entity keys.
+	Load(ctx context.Context, keys []any) (RelatedRecords, error)
+	// Missing returns the keys that have no live target record
+	Missing(ctx context.Context, keys []any) ([]any, error)
+	// Sync links the entity with the given key to exactly the target records
+	// with keys for ManyToMany. It does nothing for other relations.
+	Sync(ctx context.Context, key any, keys []any) error
+}
+
+// NewBelongsToField creates a field holding the key of a record of config.Target.
+// It's rendered as a select of the target records, or a searchable select if
+// config.Endpoint is set, and its value is checked to exist when saving.
+func NewBelongsToField(name string, config RelationConfig, opts ...FieldOption) RelationField {
+	return newRelationField(name, BelongsTo, config, opts...)
+}
+
+// NewHasManyField creates a readonly virtual field listing the records of
+// config.Target whose config.ForeignKey column holds the entity key.
+func NewHasManyField(name string, config RelationConfig, opts ...FieldOption) RelationField {
+	if config.ForeignKey == "" {
+		panic(fmt.Sprintf("field %q: has-many relation requires a foreign key", name))
+	}
+	return newRelationField(name, HasMany, config, append([]FieldOption{WithReadonly()}, opts...)...)
+}
+
+// NewManyToManyField creates a virtual field linking the entity to records of
+// config.Target through config.JoinTable. Saving an entity with a non-nil value
+// replaces its links with the records in the value.
+func NewManyToManyField(name string, config RelationConfig, opts ...FieldOption) RelationField {
+	if config.JoinTable == "" || config.ForeignKey == "" || config.JoinKey == "" {
+		panic(fmt.Sprintf("field %q: many-to-many relation requires a join table, foreign key and join key", name))
+	}
+	return newRelationField(name, ManyToMany, config, opts...)
+}
+
+func newRelationField(name string, relation RelationType, config RelationConfig, opts ...FieldOption) RelationField {
+	if config.Target == nil {
+		panic(fmt.Sprintf("field %q: relation requires a target schema", name))
+	}
+	targetKey := config.Target.Fields().KeyField()
+	if config.LabelField == "" {
+		config.LabelField = targetKey.Name()
+	} else if _, err := config.Target.Fields().Field(config.LabelField); err != nil {
+		panic(fmt.Sprintf("field %q: label %v", name, err))
+	}
+
+	type_ := RelationFieldType
+	if relation == BelongsTo {
+		type_ = targetKey.Type()
+	}
+	f := newField(name, type_, opts...).(*field)
+	f.attrs["relation"] = relation
+
+	return &relationField{
+		field:    f,
+		relation: relation,
+		config:   config,
+	}
+}
+
+type relationField struct {
+	*field
+	relation RelationType
+	config   RelationConfig
+}
+
+func (f *relationField) Relation() RelationType {
+	return f.relation
+}
+
+func (f *relationField) Config() RelationConfig {
+	return f.config
+}
+
+func (f *relationField) Virtual() bool {
+	return f.relation != BelongsTo
+}
+
+func (f *relationField) Value(value any) FieldValue {
+	if !isValidType(f.Type(), value) {
+		panic(fmt.Sprintf(
+			"invalid type for field %q: expected %s, got %T",
+			f.name, f.Type(), value,
+		))
+	}
+	return &fieldValue{
+		field: f,
+		value: value,
+	}
+}
+
+func (f *relationField) Options(ctx context.Context) ([]SelectOption, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+
+	target := f.config.Target
+	query := fmt.Sprintf(
+		"SELECT %s, %s FROM %s %s ORDER BY %s",
+		target.Fields().KeyField().Name(),
+		f.config.LabelField,
+		target.Name(),
+		repo.JoinWhere(liveTarget(target, "")...),
+		f.config.LabelField,
+	)
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list %s options", target.Name())
+	}
+	defer rows.Close()
+
+	var options []SelectOption
+	for rows.Next() {
+		var key, label any
+		if err := rows.Scan(&key, &label); err != nil {
+			return nil, errors.Wrap(err, "failed to scan option")
+		}
+		options = append(options, SelectOption{Value: relationKey(key), Label: fmt.Sprint(label)})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "row iteration error")
+	}
+	return options, nil
+}
+
+func (f *relationField) Load(ctx context.Context, keys []any) (RelatedRecords, error) {
+	records := make(RelatedRecords, len(keys))
+	if len(keys) == 0 {
+		return records, nil
+	}
+
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+
+	target := f.config.Target
+	targetKey := target.Fields().KeyField().Name()
+	columns := make([]string, 0, len(target.Fields().Fields()))
+	for _, name := range columnNames(target.Fields()) {
+		columns = append(columns, "t."+name)
+	}
+
+	var query string
+	switch f.relation {
+	case BelongsTo:
+		query = fmt.Sprintf(
+			"SELECT t.%s, %s FROM %s t %s",
+			targetKey,
+			strings.Join(columns, ", "),
+			target.Name(),
+			repo.JoinWhere(liveTarget(target, "t", fmt.Sprintf("t.%s = ANY($1)", targetKey))...),
+		)
+	case HasMany:
+		query = fmt.Sprintf(
+			"SELECT t.%s, %s FROM %s t %s ORDER BY t.%s",
+			f.config.ForeignKey,
+			strings.Join(columns, ", "),
+			target.Name(),
+			repo.JoinWhere(liveTarget(target, "t", fmt.Sprintf("t.%s = ANY($1)", f.config.ForeignKey))...),
+			targetKey,
+		)
+	case ManyToMany:
+		query = fmt.Sprintf(
+			"SELECT j.%s, %s FROM %s t JOIN %s j ON j.%s = t.%s %s ORDER BY t.%s",
+			f.config.ForeignKey,
+			strings.Join(columns, ", "),
+			target.Name(),
+			f.config.JoinTable,
+			f.config.JoinKey,
+			targetKey,
+			repo.JoinWhere(liveTarget(target, "t", fmt.Sprintf("j.%s = ANY($1)", f.config.ForeignKey))...),
+			targetKey,
+		)
+	}
+
+	rows, err := tx.Query(ctx, query, keys)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load %s of field %q", target.Name(), f.name)
+	}
+	defer rows.Close()
+
+	descriptions := rows.FieldDescriptions()
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan related record")
+		}
+		record := RelatedRecord{Values: make(map[string]any, len(values)-1)}
+		for i, value := range values[1:] {
+			record.Values[descriptions[i+1].Name] = value
+		}
+		record.Key = relationKey(record.Values[targetKey])
+		record.Label = fmt.Sprint(record.Values[f.config.LabelField])
+		owner := relationKey(values[0])
+		records[owner] = append(records[owner], record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "row iteration error")
+	}
+	return records, nil
+}
+
+func (f *relationField) Missing(ctx context.Context, keys []any) ([]any, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+
+	target := f.config.Target
+	targetKey := target.Fields().KeyField().Name()
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s %s",
+		targetKey,
+		target.Name(),
+		repo.JoinWhere(liveTarget(target, "", targetKey+" = ANY($1)")...),
+	)
+	rows, err := tx.Query(ctx, query, keys)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to look up %s", target.Name())
+	}
+	defer rows.Close()
+
+	found := make(map[any]bool, len(keys))
+	for rows.Next() {
+		var key any
+		if err := rows.Scan(&key); err != nil {
+			return nil, errors.Wrap(err, "failed to scan key")
+		}
+		found[relationKey(key)] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "row iteration error")
+	}
+
+	var missing []any
+	for _, key := range keys {
+		if !found[relationKey(key)] {
+			missing = append(missing, key)
+		}
+	}
+	return missing, nil
+}
+
+func (f *relationField) Sync(ctx context.Context, key any, keys []any) error {
+	if f.relation != ManyToMany {
+		return nil
+	}
+	if keys == nil {
+		keys = []any{}
+	}
+
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get transaction")
+	}
+
+	unlink := fmt.Sprintf(
+		"DELETE FROM %s WHERE %s = $1 AND NOT (%s = ANY($2))",
+		f.config.JoinTable,
+		f.config.ForeignKey,
+		f.config.JoinKey,
+	)
+	if _, err := tx.Exec(ctx, unlink, key, keys); err != nil {
+		return errors.Wrapf(err, "failed to unlink %s of field %q", f.config.Target.Name(), f.name)
+	}
+
+	targetKey := f.config.Target.Fields().KeyField().Name()
+	link := fmt.Sprintf(
+		"INSERT INTO %s (%s, %s) SELECT $1, t.%s FROM %s t WHERE t.%s = ANY($2) AND NOT EXISTS (SELECT 1 FROM %s j WHERE j.%s = $1 AND j.%s = t.%s)",
+		f.config.JoinTable,
+		f.config.ForeignKey,
+		f.config.JoinKey,
+		targetKey,
+		f.config.Target.Name(),
+		targetKey,
+		f.config.JoinTable,
+		f.config.ForeignKey,
+		f.config.JoinKey,
+		targetKey,
+	)
+	if _, err := tx.Exec(ctx, link, key, keys); err != nil {
+		return errors.Wrapf(err, "failed to link %s of field %q", f.config.Target.Name(), f.name)
+	}
+	return nil
+}
+
+// IsVirtualField reports whether field has no column in the table of its schema
+func IsVirtualField(field Field) bool {
+	rf, ok := field.(RelationField)
+	return ok && rf.Virtual()
+}
+
+// columnNames lists the names of the fields stored in columns of the table
+func columnNames(fields Fields) []string {
+	names := make([]string, 0, len(fields.Fields()))
+	for _, f := range fields.Fields() {
+		if !IsVirtualField(f) {
+			names = append(names, f.Name())
+		}
+	}
+	return names
+}
+
+// liveTarget adds the condition leaving out target records in the trash to where.
+func liveTarget(target RelatedSchema, alias string, where ...string) []string {
+	if target.SoftDelete() {
+		where = append(where, repo.NotDeleted(alias))
+	}
+	return where
+}
+
+// relationKey normalizes integer and UUID keys so that keys read from
+// different columns and entities can be compared and used as map keys.
+func relationKey(key any) any {
+	switch k := key.(type) {
+	case int:
+		return int64(k)
+	case int16:
+		return int64(k)
+	case int32:
+		return int64(k)
+	case uint32:
+		return int64(k)
+	case [16]uint8:
+		return uuid.UUID(k)
+	default:
+		return key
+	}
+}
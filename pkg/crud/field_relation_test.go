@@ -0,0 +1,132 @@
+package crud_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildAuthorSchema() crud.Schema[Report] {
+	fields := crud.NewFields([]crud.Field{
+		crud.NewIntField("id", crud.WithKey()),
+		crud.NewStringField("name"),
+	})
+	return crud.NewSchema("authors", fields, NewReportMapper(fields))
+}
+
+func TestRelationField_Creation(t *testing.T) {
+	authors := buildAuthorSchema()
+
+	t.Run("belongs-to takes the type of the target key", func(t *testing.T) {
+		field := crud.NewBelongsToField("author_id", crud.RelationConfig{Target: authors, LabelField: "name"})
+
+		assert.Equal(t, crud.BelongsTo, field.Relation())
+		assert.Equal(t, crud.IntFieldType, field.Type())
+		assert.False(t, field.Virtual())
+		assert.False(t, crud.IsVirtualField(field))
+		assert.Equal(t, "name", field.Config().LabelField)
+	})
+
+	t.Run("label defaults to the target key", func(t *testing.T) {
+		field := crud.NewBelongsToField("author_id", crud.RelationConfig{Target: authors})
+
+		assert.Equal(t, "id", field.Config().LabelField)
+	})
+
+	t.Run("has-many is virtual and readonly", func(t *testing.T) {
+		field := crud.NewHasManyField("reports", crud.RelationConfig{Target: authors, ForeignKey: "author_id"})
+
+		assert.Equal(t, crud.HasMany, field.Relation())
+		assert.Equal(t, crud.RelationFieldType, field.Type())
+		assert.True(t, field.Readonly())
+		assert.True(t, crud.IsVirtualField(field))
+	})
+
+	t.Run("many-to-many is virtual", func(t *testing.T) {
+		field := crud.NewManyToManyField("authors", crud.RelationConfig{
+			Target:     authors,
+			JoinTable:  "report_authors",
+			ForeignKey: "report_id",
+			JoinKey:    "author_id",
+		})
+
+		assert.Equal(t, crud.ManyToMany, field.Relation())
+		assert.True(t, crud.IsVirtualField(field))
+		assert.False(t, field.Readonly())
+	})
+
+	t.Run("panics on invalid config", func(t *testing.T) {
+		assert.Panics(t, func() {
+			crud.NewBelongsToField("author_id", crud.RelationConfig{})
+		})
+		assert.Panics(t, func() {
+			crud.NewBelongsToField("author_id", crud.RelationConfig{Target: authors, LabelField: "missing"})
+		})
+		assert.Panics(t, func() {
+			crud.NewHasManyField("reports", crud.RelationConfig{Target: authors})
+		})
+		assert.Panics(t, func() {
+			crud.NewManyToManyField("authors", crud.RelationConfig{Target: authors, JoinTable: "report_authors"})
+		})
+	})
+}
+
+func TestRelationField_Value(t *testing.T) {
+	authors := buildAuthorSchema()
+
+	t.Run("belongs-to holds keys", func(t *testing.T) {
+		field := crud.NewBelongsToField("author_id", crud.RelationConfig{Target: authors})
+
+		fv := field.Value(5)
+		assert.Equal(t, field, fv.Field())
+		assert.Panics(t, func() {
+			field.Value("5")
+		})
+	})
+
+	t.Run("virtual relations hold related records", func(t *testing.T) {
+		field := crud.NewManyToManyField("authors", crud.RelationConfig{
+			Target:     authors,
+			JoinTable:  "report_authors",
+			ForeignKey: "report_id",
+			JoinKey:    "author_id",
+		})
+
+		records := []crud.RelatedRecord{{Key: 1, Label: "Ann"}, {Key: 2, Label: "Bob"}}
+		fv := field.Value(records)
+		related, err := fv.AsRelated()
+		require.NoError(t, err)
+		assert.Equal(t, records, related)
+		assert.Equal(t, []any{1, 2}, crud.RelatedKeys(related))
+
+		related, err = field.Value(nil).AsRelated()
+		require.NoError(t, err)
+		assert.Nil(t, related)
+
+		assert.Panics(t, func() {
+			field.Value([]int{1, 2})
+		})
+	})
+
+	t.Run("AsRelated fails for other fields", func(t *testing.T) {
+		_, err := crud.NewIntField("id").Value(1).AsRelated()
+		require.Error(t, err)
+	})
+}
+
+func TestRelatedRecords_For(t *testing.T) {
+	id := uuid.New()
+	records := crud.RelatedRecords{
+		int64(1): {{Key: int64(10)}},
+		id:       {{Key: int64(20)}},
+	}
+
+	assert.Len(t, records.For(1), 1)
+	assert.Len(t, records.For(int32(1)), 1)
+	assert.Len(t, records.For(int64(1)), 1)
+	assert.Len(t, records.For([16]uint8(id)), 1)
+	assert.Nil(t, records.For(2))
+}
@@ -94,6 +94,15 @@ func MinValueRule(minValue float64) FieldRule {
 	return func(fv FieldValue) error {
 		fieldType := fv.Field().Type()
 		switch fieldType {
+		case MoneyFieldType:
+			// Limits of money fields are in major units, e.g. dollars
+			m, err := fv.AsMoney()
+			if err != nil || m == nil {
+				return nil
+			}
+			if m.AsMajorUnits() < minValue {
+				return fmt.Errorf("field %q must be at least %g", fv.Field().Name(), minValue)
+			}
 		case IntFieldType:
 			val := fv.Value()
 			var intVal int64
@@ -127,7 +136,8 @@ func MinValueRule(minValue float64) FieldRule {
 		case DecimalFieldType:
 			// DecimalFieldType validation would be added here when implemented
 			return nil
-		case StringFieldType, BoolFieldType, DateFieldType, TimeFieldType, DateTimeFieldType, TimestampFieldType, UUIDFieldType, JSONFieldType:
+		case StringFieldType, BoolFieldType, DateFieldType, TimeFieldType, DateTimeFieldType, TimestampFieldType, UUIDFieldType, JSONFieldType, RelationFieldType,
+			EnumFieldType, FileFieldType, GeoPointFieldType:
 			return fmt.Errorf("min value rule only applies to int and float fields")
 		}
 		return nil
@@ -138,6 +148,15 @@ func MaxValueRule(maxValue float64) FieldRule {
 	return func(fv FieldValue) error {
 		fieldType := fv.Field().Type()
 		switch fieldType {
+		case MoneyFieldType:
+			// Limits of money fields are in major units, e.g. dollars
+			m, err := fv.AsMoney()
+			if err != nil || m == nil {
+				return nil
+			}
+			if m.AsMajorUnits() > maxValue {
+				return fmt.Errorf("field %q must be at most %g", fv.Field().Name(), maxValue)
+			}
 		case IntFieldType:
 			val := fv.Value()
 			var intVal int64
@@ -171,7 +190,8 @@ func MaxValueRule(maxValue float64) FieldRule {
 		case DecimalFieldType:
 			// DecimalFieldType validation would be added here when implemented
 			return nil
-		case StringFieldType, BoolFieldType, DateFieldType, TimeFieldType, DateTimeFieldType, TimestampFieldType, UUIDFieldType, JSONFieldType:
+		case StringFieldType, BoolFieldType, DateFieldType, TimeFieldType, DateTimeFieldType, TimestampFieldType, UUIDFieldType, JSONFieldType, RelationFieldType,
+			EnumFieldType, FileFieldType, GeoPointFieldType:
 			return fmt.Errorf("max value rule only applies to int and float fields")
 		}
 		return nil
@@ -182,7 +202,7 @@ func PositiveRule() FieldRule {
 	return func(fv FieldValue) error {
 		fieldType := fv.Field().Type()
 		switch fieldType {
-		case IntFieldType:
+		case IntFieldType, MoneyFieldType:
 			val := fv.Value()
 			var intVal int64
 			switch v := val.(type) {
@@ -215,7 +235,8 @@ func PositiveRule() FieldRule {
 		case DecimalFieldType:
 			// DecimalFieldType validation would be added here when implemented
 			return nil
-		case StringFieldType, BoolFieldType, DateFieldType, TimeFieldType, DateTimeFieldType, TimestampFieldType, UUIDFieldType, JSONFieldType:
+		case StringFieldType, BoolFieldType, DateFieldType, TimeFieldType, DateTimeFieldType, TimestampFieldType, UUIDFieldType, JSONFieldType, RelationFieldType,
+			EnumFieldType, FileFieldType, GeoPointFieldType:
 			return fmt.Errorf("positive rule only applies to int and float fields")
 		}
 		return nil
@@ -226,7 +247,7 @@ func NonNegativeRule() FieldRule {
 	return func(fv FieldValue) error {
 		fieldType := fv.Field().Type()
 		switch fieldType {
-		case IntFieldType:
+		case IntFieldType, MoneyFieldType:
 			val := fv.Value()
 			var intVal int64
 			switch v := val.(type) {
@@ -256,7 +277,8 @@ func NonNegativeRule() FieldRule {
 			if floatVal < 0 {
 				return fmt.Errorf("field %q must be non-negative", fv.Field().Name())
 			}
-		case StringFieldType, BoolFieldType, DateFieldType, TimeFieldType, DateTimeFieldType, TimestampFieldType, UUIDFieldType, DecimalFieldType, JSONFieldType:
+		case StringFieldType, BoolFieldType, DateFieldType, TimeFieldType, DateTimeFieldType, TimestampFieldType, UUIDFieldType, DecimalFieldType, JSONFieldType, RelationFieldType,
+			EnumFieldType, FileFieldType, GeoPointFieldType:
 			return fmt.Errorf("non-negative rule only applies to int and float fields")
 		}
 		return nil
@@ -362,7 +384,8 @@ func WeekdayRule() FieldRule {
 				return fmt.Errorf("field %q must be a weekday", fv.Field().Name())
 			}
 			return nil
-		case StringFieldType, IntFieldType, BoolFieldType, FloatFieldType, TimeFieldType, UUIDFieldType, DecimalFieldType, JSONFieldType:
+		case StringFieldType, IntFieldType, BoolFieldType, FloatFieldType, TimeFieldType, UUIDFieldType, DecimalFieldType, JSONFieldType, RelationFieldType,
+			MoneyFieldType, EnumFieldType, FileFieldType, GeoPointFieldType:
 			return fmt.Errorf("weekday rule only applies to date/time fields")
 		}
 		return nil
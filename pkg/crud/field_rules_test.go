@@ -6,7 +6,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/geopoint"
 	"github.com/iota-uz/iota-sdk/pkg/crud"
+	"github.com/iota-uz/iota-sdk/pkg/money"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -1266,6 +1268,21 @@ func (m *mockFieldValue) AsJSON() (string, error) {
 	}
 	return "", fmt.Errorf("value is not a string")
 }
+func (m *mockFieldValue) AsRelated() ([]crud.RelatedRecord, error) {
+	if r, ok := m.value.([]crud.RelatedRecord); ok {
+		return r, nil
+	}
+	return nil, fmt.Errorf("value is not a []crud.RelatedRecord")
+}
+func (m *mockFieldValue) AsMoney() (*money.Money, error) {
+	return nil, fmt.Errorf("value is not a money.Money")
+}
+func (m *mockFieldValue) AsUploadID() (uint, error) {
+	return 0, fmt.Errorf("value is not an upload ID")
+}
+func (m *mockFieldValue) AsGeoPoint() (geopoint.GeoPoint, error) {
+	return nil, fmt.Errorf("value is not a geopoint.GeoPoint")
+}
 func (m *mockFieldValue) AsUUID() (uuid.UUID, error) {
 	if u, ok := m.value.(uuid.UUID); ok {
 		return u, nil
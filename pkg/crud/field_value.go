@@ -8,6 +8,10 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/geopoint"
+	"github.com/iota-uz/iota-sdk/pkg/money"
 )
 
 type FieldValue interface {
@@ -25,6 +29,10 @@ type FieldValue interface {
 	AsTime() (time.Time, error)
 	AsUUID() (uuid.UUID, error)
 	AsJSON() (string, error)
+	AsRelated() ([]RelatedRecord, error)
+	AsMoney() (*money.Money, error)
+	AsUploadID() (uint, error)
+	AsGeoPoint() (geopoint.GeoPoint, error)
 }
 
 type fieldValue struct {
@@ -47,8 +55,9 @@ func (fv *fieldValue) IsZero() bool {
 	return reflect.ValueOf(fv.value).IsZero()
 }
 
+// AsString returns the values of string fields and enum fields
 func (fv *fieldValue) AsString() (string, error) {
-	if fv.Field().Type() != StringFieldType {
+	if ft := fv.Field().Type(); ft != StringFieldType && ft != EnumFieldType {
 		return "", fv.typeMismatch("string")
 	}
 
@@ -274,6 +283,72 @@ func (fv *fieldValue) AsJSON() (string, error) {
 	return jsonStr, nil
 }
 
+func (fv *fieldValue) AsRelated() ([]RelatedRecord, error) {
+	if fv.Field().Type() != RelationFieldType {
+		return nil, fv.typeMismatch("[]RelatedRecord")
+	}
+
+	if fv.value == nil {
+		return nil, nil
+	}
+
+	records, ok := fv.value.([]RelatedRecord)
+	if !ok {
+		return nil, fv.valueCastError("[]RelatedRecord")
+	}
+	return records, nil
+}
+
+// AsMoney returns the amount of a money field in the currency of the field
+func (fv *fieldValue) AsMoney() (*money.Money, error) {
+	mf, ok := fv.Field().(MoneyField)
+	if !ok {
+		return nil, fv.typeMismatch("money.Money")
+	}
+
+	if fv.value == nil {
+		return nil, nil
+	}
+
+	amount, ok := fv.value.(int64)
+	if !ok {
+		return nil, fv.valueCastError("money.Money")
+	}
+	return money.New(amount, mf.Currency()), nil
+}
+
+func (fv *fieldValue) AsUploadID() (uint, error) {
+	if fv.Field().Type() != FileFieldType {
+		return 0, fv.typeMismatch("upload ID")
+	}
+
+	if fv.value == nil {
+		return 0, nil
+	}
+
+	id, ok := fv.value.(int64)
+	if !ok || id < 0 {
+		return 0, fv.valueCastError("upload ID")
+	}
+	return uint(id), nil
+}
+
+func (fv *fieldValue) AsGeoPoint() (geopoint.GeoPoint, error) {
+	if fv.Field().Type() != GeoPointFieldType {
+		return nil, fv.typeMismatch("geopoint.GeoPoint")
+	}
+
+	if fv.value == nil {
+		return nil, nil
+	}
+
+	p, ok := fv.value.(pgtype.Point)
+	if !ok {
+		return nil, fv.valueCastError("geopoint.GeoPoint")
+	}
+	return geopoint.New(p.P.Y, p.P.X), nil
+}
+
 func (fv *fieldValue) typeMismatch(expected string) error {
 	return fmt.Errorf("field '%s' has type '%s', expected '%s'", fv.Field().Name(), fv.Field().Type(), expected)
 }
@@ -7,6 +7,8 @@ type Fields interface {
 	Fields() []Field
 	Searchable() []Field
 	KeyField() Field
+	// VersionField returns the field marked WithVersion, or nil if the entity isn't versioned.
+	VersionField() Field
 	Field(name string) (Field, error)
 	FieldValues(values map[string]any) ([]FieldValue, error)
 }
@@ -14,6 +16,7 @@ type Fields interface {
 func NewFields(value []Field) Fields {
 	dict := make(map[string]Field, len(value))
 	keyIndex := -1
+	var versionField Field
 
 	for i, f := range value {
 		name := f.Name()
@@ -27,6 +30,12 @@ func NewFields(value []Field) Fields {
 				panic("expected exactly one key field")
 			}
 		}
+		if IsVersionField(f) {
+			if versionField != nil {
+				panic("expected at most one version field")
+			}
+			versionField = f
+		}
 		dict[name] = f
 	}
 
@@ -35,16 +44,18 @@ func NewFields(value []Field) Fields {
 	}
 
 	return &fields{
-		dict:     dict,
-		fields:   value,
-		keyField: value[keyIndex],
+		dict:         dict,
+		fields:       value,
+		keyField:     value[keyIndex],
+		versionField: versionField,
 	}
 }
 
 type fields struct {
-	dict     map[string]Field
-	keyField Field
-	fields   []Field
+	dict         map[string]Field
+	keyField     Field
+	versionField Field
+	fields       []Field
 }
 
 func (f *fields) Names() []string {
@@ -74,6 +85,15 @@ func (f *fields) KeyField() Field {
 	return f.keyField
 }
 
+func (f *fields) VersionField() Field {
+	return f.versionField
+}
+
+// IsVersionField reports whether field was created with WithVersion.
+func IsVersionField(field Field) bool {
+	return field.Attrs()[Versioned] == true
+}
+
 func (f *fields) Field(name string) (Field, error) {
 	if field, ok := f.dict[name]; ok {
 		return field, nil
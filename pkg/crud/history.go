@@ -0,0 +1,218 @@
+package crud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+const selectHistoryQuery = `
+	SELECT id, action, changes, user_id, user_name, created_at
+	FROM crud_history
+	WHERE schema_name = $1 AND entity_key = $2 AND tenant_id IS NOT DISTINCT FROM $3
+	ORDER BY id DESC`
+
+// ErrNoHistory is returned by History and Revert on schemas without WithHistory.
+var ErrNoHistory = errors.New("schema doesn't record history")
+
+// HistoryAction is what happened to an entity in a HistoryEntry
+type HistoryAction string
+
+const (
+	HistoryCreated  HistoryAction = "created"
+	HistoryUpdated  HistoryAction = "updated"
+	HistoryDeleted  HistoryAction = "deleted"
+	HistoryRestored HistoryAction = "restored"
+)
+
+// FieldChange is the change of a field recorded in a HistoryEntry. The values
+// are in the JSON representation of APIValue, e.g. dates as YYYY-MM-DD.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// HistoryEntry is a change of an entity recorded for schemas created WithHistory
+type HistoryEntry struct {
+	ID      int64
+	Action  HistoryAction
+	Changes []FieldChange
+	// UserID and UserName identify who made the change. They are empty for
+	// changes made without a user in the context, e.g. by background jobs.
+	UserID    uint
+	UserName  string
+	CreatedAt time.Time
+}
+
+// historyRecord is a HistoryEntry about to be recorded for the entity with key
+type historyRecord struct {
+	key     FieldValue
+	action  HistoryAction
+	changes []FieldChange
+}
+
+// diffFieldValues returns the changes between the field values of an entity
+// before and after it was saved, which is empty when it was created. Keys,
+// versions and has-many relations, which the entity doesn't own, are left out.
+func diffFieldValues(before, after []FieldValue) []FieldChange {
+	old := make(map[string]FieldValue, len(before))
+	for _, fv := range before {
+		old[fv.Field().Name()] = fv
+	}
+
+	var changes []FieldChange
+	for _, fv := range after {
+		f := fv.Field()
+		if f.Key() || IsVersionField(f) {
+			continue
+		}
+		if rf, ok := f.(RelationField); ok && rf.Relation() == HasMany {
+			continue
+		}
+		// Saves leave relations without a value unchanged
+		if IsVirtualField(f) && fv.Value() == nil {
+			continue
+		}
+		oldFv, ok := old[f.Name()]
+		if !ok {
+			if fv.IsZero() {
+				continue
+			}
+			changes = append(changes, FieldChange{Field: f.Name(), New: APIValue(fv)})
+			continue
+		}
+		if !sameValue(oldFv, fv) {
+			changes = append(changes, FieldChange{Field: f.Name(), Old: APIValue(oldFv), New: APIValue(fv)})
+		}
+	}
+	return changes
+}
+
+// historyKey converts the value of a key field to the text it is recorded with
+func historyKey(key FieldValue) string {
+	if b, ok := key.Value().([16]uint8); ok {
+		return uuid.UUID(b).String()
+	}
+	return fmt.Sprint(key.Value())
+}
+
+// recordHistory stores records for the schema named schemaName in the transaction
+// of ctx, attributing them to the user in ctx. Updates without changes are skipped.
+func recordHistory(ctx context.Context, schemaName string, records ...historyRecord) error {
+	var (
+		userID   *int64
+		userName string
+	)
+	if u, err := composables.UseUser(ctx); err == nil && u != nil {
+		id := int64(u.ID())
+		userID = &id
+		userName = u.FirstName() + " " + u.LastName()
+	}
+	tenantID := historyTenantID(ctx)
+
+	insert := repo.NewInsert("crud_history").
+		Columns("tenant_id", "schema_name", "entity_key", "action", "changes", "user_id", "user_name")
+	rows := 0
+	for _, r := range records {
+		if r.action == HistoryUpdated && len(r.changes) == 0 {
+			continue
+		}
+		if r.changes == nil {
+			r.changes = []FieldChange{}
+		}
+		changes, err := json.Marshal(r.changes)
+		if err != nil {
+			return errors.Wrapf(err, "failed to encode changes of %s %s", schemaName, historyKey(r.key))
+		}
+		insert.Values(tenantID, schemaName, historyKey(r.key), string(r.action), changes, userID, userName)
+		rows++
+	}
+	if rows == 0 {
+		return nil
+	}
+
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get transaction")
+	}
+	query, args := insert.Build()
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return errors.Wrap(err, "failed to record history")
+	}
+	return nil
+}
+
+// historyTenantID returns the tenant in ctx that history is recorded for, nil
+// for changes made without one
+func historyTenantID(ctx context.Context) *uuid.UUID {
+	if id, err := composables.UseTenantID(ctx); err == nil {
+		return &id
+	}
+	return nil
+}
+
+// loadHistory returns the history of the entity with key of the schema named
+// schemaName recorded for the tenant in ctx, newest first
+func loadHistory(ctx context.Context, schemaName string, key FieldValue) ([]HistoryEntry, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get transaction")
+	}
+
+	rows, err := tx.Query(ctx, selectHistoryQuery, schemaName, historyKey(key), historyTenantID(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query history")
+	}
+	defer rows.Close()
+
+	var entries []HistoryEntry
+	for rows.Next() {
+		var (
+			entry   HistoryEntry
+			action  string
+			changes []byte
+			userID  *int64
+		)
+		if err := rows.Scan(&entry.ID, &action, &changes, &userID, &entry.UserName, &entry.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "failed to scan history entry")
+		}
+		entry.Action = HistoryAction(action)
+		if userID != nil {
+			entry.UserID = uint(*userID)
+		}
+		decoder := json.NewDecoder(bytes.NewReader(changes))
+		decoder.UseNumber()
+		if err := decoder.Decode(&entry.Changes); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode changes of history entry %d", entry.ID)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read history")
+	}
+	return entries, nil
+}
+
+// HistoryValue converts a value recorded in a FieldChange back to a value of field
+func HistoryValue(field Field, value any) (FieldValue, error) {
+	// Relations are recorded as APIRelatedRecord and parsed from their keys
+	if records, ok := value.([]any); ok && field.Type() == RelationFieldType {
+		keys := make([]any, len(records))
+		for i, record := range records {
+			if m, ok := record.(map[string]any); ok {
+				keys[i] = m["key"]
+			}
+		}
+		value = keys
+	}
+	return ParseAPIValue(field, value)
+}
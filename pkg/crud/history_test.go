@@ -0,0 +1,134 @@
+package crud_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+	"github.com/iota-uz/iota-sdk/pkg/crud/models"
+)
+
+func TestService_History(t *testing.T) {
+	fixture := setupTest(t)
+	ctx := fixture.ctx
+
+	_, err := fixture.pool.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS crud_history (
+			id bigserial PRIMARY KEY,
+			tenant_id uuid,
+			schema_name varchar(255) NOT NULL,
+			entity_key varchar(255) NOT NULL,
+			action varchar(16) NOT NULL,
+			changes jsonb NOT NULL DEFAULT '[]',
+			user_id int,
+			user_name varchar(511) NOT NULL DEFAULT '',
+			created_at timestamp with time zone NOT NULL DEFAULT now()
+		);`)
+	require.NoError(t, err)
+
+	fields := fixture.schema.Fields()
+	schema := crud.NewSchema("reports", fields, NewReportMapper(fields), crud.WithHistory[Report]())
+	service := crud.DefaultService(schema, crud.DefaultRepository[Report](schema), fixture.publisher)
+
+	created, err := service.Save(ctx, NewReport(CreateMultiLangTitle("History"), WithAuthor("Jane"), WithSummary("Draft")))
+	require.NoError(t, err)
+	key := fields.KeyField().Value(created.ID())
+
+	_, err = service.Save(ctx, created.SetSummary("Final"))
+	require.NoError(t, err)
+
+	t.Run("records changes newest first", func(t *testing.T) {
+		entries, err := service.History(ctx, key)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+
+		assert.Equal(t, crud.HistoryUpdated, entries[0].Action)
+		assert.Equal(t, []crud.FieldChange{{Field: "summary", Old: "Draft", New: "Final"}}, entries[0].Changes)
+
+		assert.Equal(t, crud.HistoryCreated, entries[1].Action)
+		changed := make([]string, len(entries[1].Changes))
+		for i, change := range entries[1].Changes {
+			changed[i] = change.Field
+		}
+		assert.ElementsMatch(t, []string{"title", "author", "summary"}, changed)
+	})
+
+	t.Run("skips saves without changes", func(t *testing.T) {
+		report, err := service.Get(ctx, key)
+		require.NoError(t, err)
+		_, err = service.Save(ctx, report)
+		require.NoError(t, err)
+
+		entries, err := service.History(ctx, key)
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+	})
+
+	t.Run("Revert", func(t *testing.T) {
+		entries, err := service.History(ctx, key)
+		require.NoError(t, err)
+
+		reverted, err := service.Revert(ctx, key, entries[1].ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Draft", reverted.Summary())
+
+		entries, err = service.History(ctx, key)
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+		assert.Equal(t, []crud.FieldChange{{Field: "summary", Old: "Final", New: "Draft"}}, entries[0].Changes)
+
+		_, err = service.Revert(ctx, key, -1)
+		require.ErrorIs(t, err, crud.ErrNotFound)
+	})
+}
+
+func TestService_HistoryDisabled(t *testing.T) {
+	fields := crud.NewFields([]crud.Field{
+		crud.NewIntField("id", crud.WithKey()),
+		crud.NewJSONField[models.MultiLang]("title", crud.JSONFieldConfig[models.MultiLang]{}),
+	})
+	schema := crud.NewSchema("reports", fields, NewReportMapper(fields))
+	service := crud.DefaultService[Report](schema, &memoryReports{}, nil)
+	key := fields.KeyField().Value(1)
+
+	_, err := service.History(context.Background(), key)
+	require.ErrorIs(t, err, crud.ErrNoHistory)
+
+	_, err = service.Revert(context.Background(), key, 1)
+	require.ErrorIs(t, err, crud.ErrNoHistory)
+}
+
+func TestHistoryValue(t *testing.T) {
+	t.Run("scalars", func(t *testing.T) {
+		fv, err := crud.HistoryValue(crud.NewIntField("count"), json.Number("42"))
+		require.NoError(t, err)
+		assert.Equal(t, 42, fv.Value())
+
+		fv, err = crud.HistoryValue(crud.NewDateField("day"), "2024-03-01")
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), fv.Value())
+	})
+
+	t.Run("relations are parsed from their keys", func(t *testing.T) {
+		field := crud.NewManyToManyField("authors", crud.RelationConfig{
+			Target:     buildAuthorSchema(),
+			JoinTable:  "report_authors",
+			ForeignKey: "report_id",
+			JoinKey:    "author_id",
+		})
+
+		fv, err := crud.HistoryValue(field, []any{
+			map[string]any{"key": json.Number("1"), "label": "Jane"},
+			map[string]any{"key": json.Number("2"), "label": "John"},
+		})
+		require.NoError(t, err)
+		records, err := fv.AsRelated()
+		require.NoError(t, err)
+		assert.Equal(t, []any{int64(1), int64(2)}, crud.RelatedKeys(records))
+	})
+}
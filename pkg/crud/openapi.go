@@ -0,0 +1,420 @@
+package crud
+
+import (
+	"math"
+	"strings"
+)
+
+// OpenAPIVersion is the version of the OpenAPI specification documents are written in
+const OpenAPIVersion = "3.0.3"
+
+// APIFilterOperators are the operators list endpoints of generated APIs accept as
+// field[operator]=value query parameters besides field=value equality filters
+var APIFilterOperators = []string{"ne", "gt", "gte", "lt", "lte", "in", "like"}
+
+// OpenAPIDocument is an OpenAPI 3 document describing generated REST APIs.
+// Only the parts of the specification the generated APIs use are modelled.
+type OpenAPIDocument struct {
+	OpenAPI    string                     `json:"openapi"`
+	Info       OpenAPIInfo                `json:"info"`
+	Paths      map[string]OpenAPIPathItem `json:"paths"`
+	Components OpenAPIComponents          `json:"components"`
+	Security   []map[string][]string      `json:"security,omitempty"`
+	Tags       []OpenAPITag               `json:"tags,omitempty"`
+}
+
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type OpenAPITag struct {
+	Name string `json:"name"`
+}
+
+// OpenAPIPathItem maps lowercase HTTP methods to the operations of a path
+type OpenAPIPathItem map[string]*OpenAPIOperation
+
+type OpenAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Summary     string                     `json:"summary,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Parameters  []OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]OpenAPIResponse `json:"responses"`
+}
+
+type OpenAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      *OpenAPISchema `json:"schema"`
+}
+
+type OpenAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]OpenAPIMediaType `json:"content"`
+}
+
+type OpenAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
+}
+
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema"`
+}
+
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Description          string                    `json:"description,omitempty"`
+	Enum                 []any                     `json:"enum,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	ReadOnly             bool                      `json:"readOnly,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+	MinLength            *int                      `json:"minLength,omitempty"`
+	MaxLength            *int                      `json:"maxLength,omitempty"`
+	Minimum              *float64                  `json:"minimum,omitempty"`
+	Maximum              *float64                  `json:"maximum,omitempty"`
+	Pattern              string                    `json:"pattern,omitempty"`
+}
+
+type OpenAPIComponents struct {
+	Schemas         map[string]*OpenAPISchema        `json:"schemas"`
+	SecuritySchemes map[string]OpenAPISecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type OpenAPISecurityScheme struct {
+	Type string `json:"type"`
+	In   string `json:"in"`
+	Name string `json:"name"`
+}
+
+// OpenAPIResource is implemented by APIs that describe their operations in an OpenAPI document
+type OpenAPIResource interface {
+	OpenAPI(doc *OpenAPIDocument)
+}
+
+// NewOpenAPIDocument creates a document without paths. Requests are authorized
+// with the session token in the Authorization header.
+func NewOpenAPIDocument(title, version string) *OpenAPIDocument {
+	return &OpenAPIDocument{
+		OpenAPI: OpenAPIVersion,
+		Info:    OpenAPIInfo{Title: title, Version: version},
+		Paths:   make(map[string]OpenAPIPathItem),
+		Components: OpenAPIComponents{
+			Schemas: map[string]*OpenAPISchema{
+				"Error": {
+					Type: "object",
+					Properties: map[string]*OpenAPISchema{
+						"code":    {Type: "string"},
+						"message": {Type: "string"},
+						"errors": {
+							Type:                 "object",
+							AdditionalProperties: openAPIRef("ValidationError"),
+						},
+					},
+					Required: []string{"code", "message"},
+				},
+				"ValidationError": {
+					Type: "object",
+					Properties: map[string]*OpenAPISchema{
+						"field":      {Type: "string"},
+						"code":       {Type: "string"},
+						"message":    {Type: "string"},
+						"locale_key": {Type: "string"},
+					},
+					Required: []string{"field", "code", "message"},
+				},
+				"RelatedRecord": {
+					Type: "object",
+					Properties: map[string]*OpenAPISchema{
+						"key":   {},
+						"label": {Type: "string"},
+					},
+				},
+			},
+			SecuritySchemes: map[string]OpenAPISecurityScheme{
+				"session": {Type: "apiKey", In: "header", Name: "Authorization"},
+			},
+		},
+		Security: []map[string][]string{{"session": {}}},
+	}
+}
+
+// AddResource describes the list, get, create, update and delete operations of the
+// entities of a schema served under path, exposing the fields returned by APIFields.
+func (d *OpenAPIDocument) AddResource(name, path string, fields Fields) {
+	typeName := openAPITypeName(name)
+	d.Tags = append(d.Tags, OpenAPITag{Name: name})
+
+	entity := &OpenAPISchema{Type: "object", Properties: make(map[string]*OpenAPISchema)}
+	input := &OpenAPISchema{Type: "object", Properties: make(map[string]*OpenAPISchema)}
+	var filters, include []string
+	for _, f := range APIFields(fields) {
+		entity.Properties[f.Name()] = FieldOpenAPISchema(f)
+		if rf, ok := f.(RelationField); ok && rf.Virtual() {
+			include = append(include, f.Name())
+		} else {
+			filters = append(filters, f.Name())
+		}
+		if !IsAPIWritable(f) {
+			continue
+		}
+		inputSchema := FieldOpenAPISchema(f)
+		if inputSchema.Type == "array" && inputSchema.Items.Ref != "" {
+			// Relations are written as arrays of target keys
+			inputSchema.Items = openAPIKeySchema(f.(RelationField))
+		}
+		input.Properties[f.Name()] = inputSchema
+	}
+	d.Components.Schemas[typeName] = entity
+	d.Components.Schemas[typeName+"Input"] = input
+	d.Components.Schemas[typeName+"List"] = &OpenAPISchema{
+		Type: "object",
+		Properties: map[string]*OpenAPISchema{
+			"data":        {Type: "array", Items: openAPIRef(typeName)},
+			"next_cursor": {Type: "string", Description: "Passed as cursor to fetch the next chunk, empty on the last one"},
+		},
+		Required: []string{"data"},
+	}
+
+	listParams := []OpenAPIParameter{
+		{Name: "limit", In: "query", Schema: &OpenAPISchema{Type: "integer"}},
+		{Name: "offset", In: "query", Schema: &OpenAPISchema{Type: "integer"}},
+		{Name: "cursor", In: "query", Schema: &OpenAPISchema{Type: "string"}},
+		{Name: "q", In: "query", Description: "Search query over the searchable fields", Schema: &OpenAPISchema{Type: "string"}},
+		{
+			Name:        "sort",
+			In:          "query",
+			Description: "Comma separated fields to sort by, descending when prefixed with -",
+			Schema:      &OpenAPISchema{Type: "string"},
+		},
+	}
+	if len(include) > 0 {
+		listParams = append(listParams, OpenAPIParameter{
+			Name:        "include",
+			In:          "query",
+			Description: "Comma separated relations to load out of " + strings.Join(include, ", "),
+			Schema:      &OpenAPISchema{Type: "string"},
+		})
+	}
+	for _, name := range filters {
+		listParams = append(listParams, OpenAPIParameter{
+			Name:        name,
+			In:          "query",
+			Description: "Equality filter, also accepted as " + name + "[" + strings.Join(APIFilterOperators, "|") + "]",
+			Schema:      &OpenAPISchema{Type: "string"},
+		})
+	}
+
+	keyParam := OpenAPIParameter{Name: "id", In: "path", Required: true, Schema: FieldOpenAPISchema(fields.KeyField())}
+	keyParam.Schema.ReadOnly = false
+	inputBody := &OpenAPIRequestBody{Required: true, Content: openAPIJSON(openAPIRef(typeName + "Input"))}
+	entityResponse := OpenAPIResponse{Description: "The " + name + " entity", Content: openAPIJSON(openAPIRef(typeName))}
+
+	d.Paths[path] = OpenAPIPathItem{
+		"get": {
+			OperationID: "list" + typeName,
+			Summary:     "List " + name,
+			Tags:        []string{name},
+			Parameters:  listParams,
+			Responses: openAPIResponses(map[string]OpenAPIResponse{
+				"200": {Description: "A chunk of " + name, Content: openAPIJSON(openAPIRef(typeName + "List"))},
+				"400": openAPIError("Invalid query parameters"),
+			}),
+		},
+		"post": {
+			OperationID: "create" + typeName,
+			Summary:     "Create " + name,
+			Tags:        []string{name},
+			RequestBody: inputBody,
+			Responses: openAPIResponses(map[string]OpenAPIResponse{
+				"201": entityResponse,
+				"422": openAPIError("Validation failed"),
+			}),
+		},
+	}
+	updateParams := []OpenAPIParameter{keyParam}
+	updateResponses := map[string]OpenAPIResponse{
+		"200": entityResponse,
+		"404": openAPIError("Not found"),
+		"409": openAPIError("Modified concurrently"),
+		"422": openAPIError("Validation failed"),
+	}
+	if versionField := fields.VersionField(); versionField != nil {
+		updateParams = append(updateParams, OpenAPIParameter{
+			Name:        "If-Match",
+			In:          "header",
+			Description: "Version the update is based on, required unless the body has " + versionField.Name(),
+			Schema:      &OpenAPISchema{Type: "string"},
+		})
+		updateResponses["400"] = openAPIError("Version missing")
+	}
+	d.Paths[path+"/{id}"] = OpenAPIPathItem{
+		"get": {
+			OperationID: "get" + typeName,
+			Summary:     "Get " + name,
+			Tags:        []string{name},
+			Parameters:  []OpenAPIParameter{keyParam},
+			Responses: openAPIResponses(map[string]OpenAPIResponse{
+				"200": entityResponse,
+				"404": openAPIError("Not found"),
+			}),
+		},
+		"put": {
+			OperationID: "update" + typeName,
+			Summary:     "Update " + name,
+			Tags:        []string{name},
+			Parameters:  updateParams,
+			RequestBody: inputBody,
+			Responses:   openAPIResponses(updateResponses),
+		},
+		"delete": {
+			OperationID: "delete" + typeName,
+			Summary:     "Delete " + name,
+			Tags:        []string{name},
+			Parameters:  []OpenAPIParameter{keyParam},
+			Responses: openAPIResponses(map[string]OpenAPIResponse{
+				"200": entityResponse,
+				"404": openAPIError("Not found"),
+			}),
+		},
+	}
+}
+
+// FieldOpenAPISchema returns the schema of the JSON representation of field values, see APIValue
+func FieldOpenAPISchema(field Field) *OpenAPISchema {
+	schema := &OpenAPISchema{ReadOnly: field.Readonly(), Nullable: !field.Key()}
+
+	switch field.Type() {
+	case StringFieldType:
+		schema.Type = "string"
+		if sf, err := field.AsStringField(); err == nil {
+			if minLen := sf.MinLen(); minLen > 0 {
+				schema.MinLength = &minLen
+			}
+			if maxLen := sf.MaxLen(); maxLen > 0 && maxLen < math.MaxInt32 {
+				schema.MaxLength = &maxLen
+			}
+			schema.Pattern = sf.Pattern()
+		}
+	case IntFieldType:
+		schema.Type = "integer"
+		schema.Format = "int64"
+		if intField, err := field.AsIntField(); err == nil {
+			if minValue := intField.Min(); minValue != math.MinInt64 {
+				v := float64(minValue)
+				schema.Minimum = &v
+			}
+			if maxValue := intField.Max(); maxValue != math.MaxInt64 {
+				v := float64(maxValue)
+				schema.Maximum = &v
+			}
+		}
+	case FloatFieldType:
+		schema.Type = "number"
+		schema.Format = "double"
+	case DecimalFieldType:
+		schema.Type = "string"
+		schema.Format = "decimal"
+	case BoolFieldType:
+		schema.Type = "boolean"
+	case DateFieldType:
+		schema.Type = "string"
+		schema.Format = "date"
+	case TimeFieldType:
+		schema.Type = "string"
+		schema.Format = "time"
+	case DateTimeFieldType, TimestampFieldType:
+		schema.Type = "string"
+		schema.Format = "date-time"
+	case UUIDFieldType:
+		schema.Type = "string"
+		schema.Format = "uuid"
+	case JSONFieldType:
+		schema.Description = "JSON document"
+	case RelationFieldType:
+		schema.Type = "array"
+		schema.Items = openAPIRef("RelatedRecord")
+		schema.Nullable = false
+	case MoneyFieldType:
+		schema.Type = "object"
+		schema.Description = "Amount in minor units, requests also take amounts in major units as strings"
+		schema.Properties = map[string]*OpenAPISchema{
+			"amount":   {Type: "integer", Format: "int64"},
+			"currency": {Type: "string"},
+		}
+		schema.Required = []string{"amount", "currency"}
+		if mf, ok := field.(MoneyField); ok {
+			schema.Properties["currency"].Enum = []any{mf.Currency()}
+		}
+	case EnumFieldType:
+		schema.Type = "string"
+		if ef, ok := field.(EnumField); ok {
+			for _, v := range ef.Values() {
+				schema.Enum = append(schema.Enum, v)
+			}
+		}
+	case FileFieldType:
+		schema.Type = "integer"
+		schema.Format = "int64"
+		schema.Description = "ID of an upload"
+	case GeoPointFieldType:
+		schema.Type = "object"
+		schema.Properties = map[string]*OpenAPISchema{
+			"lat": {Type: "number", Format: "double"},
+			"lng": {Type: "number", Format: "double"},
+		}
+		schema.Required = []string{"lat", "lng"}
+	}
+
+	if sf, ok := field.(SelectField); ok && sf.SelectType() == SelectTypeStatic {
+		for _, opt := range sf.Options() {
+			schema.Enum = append(schema.Enum, opt.Value)
+		}
+	}
+	return schema
+}
+
+func openAPIKeySchema(field RelationField) *OpenAPISchema {
+	schema := FieldOpenAPISchema(field.Config().Target.Fields().KeyField())
+	schema.ReadOnly = false
+	return schema
+}
+
+func openAPIRef(name string) *OpenAPISchema {
+	return &OpenAPISchema{Ref: "#/components/schemas/" + name}
+}
+
+func openAPIJSON(schema *OpenAPISchema) map[string]OpenAPIMediaType {
+	return map[string]OpenAPIMediaType{"application/json": {Schema: schema}}
+}
+
+func openAPIError(description string) OpenAPIResponse {
+	return OpenAPIResponse{Description: description, Content: openAPIJSON(openAPIRef("Error"))}
+}
+
+// openAPIResponses adds the responses every operation can return to responses
+func openAPIResponses(responses map[string]OpenAPIResponse) map[string]OpenAPIResponse {
+	responses["401"] = openAPIError("Not authenticated")
+	responses["403"] = openAPIError("Not permitted")
+	return responses
+}
+
+// openAPITypeName converts a schema name like order_items to OrderItems
+func openAPITypeName(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-' || r == '.' || r == ' '
+	})
+	for i, part := range parts {
+		parts[i] = strings.ToUpper(part[:1]) + part[1:]
+	}
+	return strings.Join(parts, "")
+}
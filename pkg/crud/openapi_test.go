@@ -0,0 +1,94 @@
+package crud_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPIDocument_AddResource(t *testing.T) {
+	fields := crud.NewFields([]crud.Field{
+		crud.NewIntField("id", crud.WithKey(), crud.WithReadonly()),
+		crud.NewStringField("title", crud.WithMaxLen(100)),
+		crud.NewDateField("published_on"),
+		crud.NewStringField("tenant_id", crud.WithHidden()),
+		crud.NewManyToManyField("authors", crud.RelationConfig{
+			Target:     buildAuthorSchema(),
+			JoinTable:  "report_authors",
+			ForeignKey: "report_id",
+			JoinKey:    "author_id",
+		}),
+	})
+
+	doc := crud.NewOpenAPIDocument("Reports API", "1.0.0")
+	doc.AddResource("report_drafts", "/api/v1/report-drafts", fields)
+
+	t.Run("describes entities and inputs", func(t *testing.T) {
+		entity := doc.Components.Schemas["ReportDrafts"]
+		require.NotNil(t, entity)
+		assert.ElementsMatch(t, []string{"id", "title", "published_on", "authors"}, keys(entity.Properties))
+		assert.True(t, entity.Properties["id"].ReadOnly)
+		assert.Equal(t, "date", entity.Properties["published_on"].Format)
+		assert.Equal(t, 100, *entity.Properties["title"].MaxLength)
+		assert.Equal(t, "#/components/schemas/RelatedRecord", entity.Properties["authors"].Items.Ref)
+
+		input := doc.Components.Schemas["ReportDraftsInput"]
+		require.NotNil(t, input)
+		assert.ElementsMatch(t, []string{"title", "published_on", "authors"}, keys(input.Properties))
+		assert.Equal(t, "integer", input.Properties["authors"].Items.Type)
+	})
+
+	t.Run("describes operations", func(t *testing.T) {
+		collection := doc.Paths["/api/v1/report-drafts"]
+		require.Contains(t, collection, "get")
+		require.Contains(t, collection, "post")
+		assert.Equal(t, "listReportDrafts", collection["get"].OperationID)
+		assert.Contains(t, collection["post"].Responses, "422")
+
+		var params []string
+		for _, p := range collection["get"].Parameters {
+			params = append(params, p.Name)
+		}
+		assert.Subset(t, params, []string{"limit", "cursor", "sort", "include", "title"})
+		assert.NotContains(t, params, "tenant_id")
+
+		item := doc.Paths["/api/v1/report-drafts/{id}"]
+		assert.ElementsMatch(t, []string{"get", "put", "delete"}, keys(item))
+		assert.Equal(t, "integer", item["get"].Parameters[0].Schema.Type)
+	})
+
+	t.Run("serializes", func(t *testing.T) {
+		data, err := json.Marshal(doc)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"openapi":"3.0.3"`)
+		assert.Contains(t, string(data), `"$ref":"#/components/schemas/ReportDrafts"`)
+	})
+}
+
+func keys[V any](m map[string]V) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
+
+func TestOpenAPIDocument_AddResourceVersioned(t *testing.T) {
+	fields := crud.NewFields([]crud.Field{
+		crud.NewIntField("id", crud.WithKey(), crud.WithReadonly()),
+		crud.NewStringField("title"),
+		crud.NewIntField("version", crud.WithVersion(), crud.WithHidden()),
+	})
+
+	doc := crud.NewOpenAPIDocument("Reports API", "1.0.0")
+	doc.AddResource("reports", "/api/v1/reports", fields)
+
+	update := doc.Paths["/api/v1/reports/{id}"]["put"]
+	require.Len(t, update.Parameters, 2)
+	assert.Equal(t, "If-Match", update.Parameters[1].Name)
+	assert.Equal(t, "header", update.Parameters[1].In)
+	assert.Contains(t, update.Responses, "400")
+}
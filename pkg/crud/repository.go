@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-faster/errors"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/repo"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type SortBy = repo.SortBy[string]
@@ -19,8 +23,26 @@ type FindParams struct {
 	Limit   int
 	Offset  int
 	SortBy  SortBy
+	// Cursor continues the list after the row it was created for with NextCursor.
+	// When set, Offset is ignored.
+	Cursor string
+	// RankSearch orders rows by how well they match Query on full-text searchable
+	// fields before SortBy. It can't be combined with Cursor.
+	RankSearch bool
+	// Trashed lists the entities in the trash instead of the live ones.
+	// It requires a schema created WithSoftDelete.
+	Trashed bool
+	// Include names the has-many and many-to-many fields to load for the listed
+	// entities, with a single query per relation.
+	Include []string
 }
 
+// ErrNotFound is returned when getting, updating or deleting an entity that doesn't exist.
+var ErrNotFound = errors.New("entity not found")
+
+// ErrNoTrash is returned by trash operations on schemas without WithSoftDelete.
+var ErrNoTrash = errors.New("schema doesn't support soft delete")
+
 type Repository[TEntity any] interface {
 	GetAll(ctx context.Context) ([]TEntity, error)
 	Get(ctx context.Context, value FieldValue) (TEntity, error)
@@ -30,6 +52,24 @@ type Repository[TEntity any] interface {
 	Create(ctx context.Context, values []FieldValue) (TEntity, error)
 	Update(ctx context.Context, values []FieldValue) (TEntity, error)
 	Delete(ctx context.Context, value FieldValue) (TEntity, error)
+	// Restore brings the entity with the key value back from the trash.
+	Restore(ctx context.Context, value FieldValue) (TEntity, error)
+	// Purge removes entities moved to the trash before the given time for good.
+	// Schemas with a tenant_id field only purge the entities of the tenant of ctx.
+	Purge(ctx context.Context, before time.Time) (int64, error)
+	// GetByKeys returns the entities with the given key values, leaving out the missing ones.
+	GetByKeys(ctx context.Context, values []FieldValue) ([]TEntity, error)
+	// BulkCreate inserts rows with COPY and returns how many it inserted.
+	BulkCreate(ctx context.Context, rows [][]FieldValue) (int64, error)
+	// BulkUpdate sends the updates of rows in a single batch and returns how many it updated.
+	BulkUpdate(ctx context.Context, rows [][]FieldValue) (int64, error)
+	// BulkUpsert inserts rows in a single batch, overwriting the entities with the same key.
+	BulkUpsert(ctx context.Context, rows [][]FieldValue) (int64, error)
+	// BulkDelete deletes the entities with the given values of a field and returns them.
+	BulkDelete(ctx context.Context, values []FieldValue) ([]TEntity, error)
+	// Aggregate computes query over the entities matching the filters, search query
+	// and trash flag of params in a single query, ordered by the groups.
+	Aggregate(ctx context.Context, params *FindParams, query AggregateQuery) ([]AggregateRow, error)
 }
 
 func DefaultRepository[TEntity any](
@@ -37,8 +77,8 @@ func DefaultRepository[TEntity any](
 ) Repository[TEntity] {
 	// Initialize fieldMap correctly for generic repository
 	fieldMap := make(map[string]string)
-	for _, f := range schema.Fields().Fields() {
-		fieldMap[f.Name()] = f.Name() // Map field name to itself for generic columns
+	for _, name := range columnNames(schema.Fields()) {
+		fieldMap[name] = name // Map field name to itself for generic columns
 	}
 	return &repository[TEntity]{
 		schema:   schema,
@@ -52,7 +92,10 @@ type repository[TEntity any] struct {
 }
 
 func (r *repository[TEntity]) GetAll(ctx context.Context) ([]TEntity, error) {
-	query := fmt.Sprintf("SELECT * FROM %s", r.schema.Name())
+	query := fmt.Sprintf("SELECT %s FROM %s", r.columns(), r.schema.Name())
+	if r.schema.SoftDelete() {
+		query = repo.Join(query, repo.JoinWhere(repo.NotDeleted("")))
+	}
 	return r.queryEntities(ctx, query)
 }
 
@@ -60,20 +103,24 @@ func (r *repository[TEntity]) Get(ctx context.Context, value FieldValue) (TEntit
 	var zero TEntity
 
 	query := fmt.Sprintf(
-		"SELECT * FROM %s WHERE %s = $1",
+		"SELECT %s FROM %s %s",
+		r.columns(),
 		r.schema.Name(),
-		value.Field().Name(),
+		repo.JoinWhere(r.live(value.Field().Name()+" = $1")...),
 	)
 
-	entities, err := r.queryEntities(ctx, query, value.Value())
+	rows, err := r.queryValues(ctx, query, value.Value())
 	if err != nil {
 		return zero, errors.Wrap(err, fmt.Sprintf("failed to get entity by %s", value.Field().Name()))
 	}
-	if len(entities) == 0 {
-		return zero, errors.New("entity not found")
+	if len(rows) == 0 {
+		return zero, ErrNotFound
+	}
+	if err := r.loadRelations(ctx, rows[:1], virtualFields(r.schema.Fields())); err != nil {
+		return zero, err
 	}
 
-	return entities[0], nil
+	return r.toEntity(ctx, rows[0])
 }
 
 func (r *repository[TEntity]) Exists(ctx context.Context, value FieldValue) (bool, error) {
@@ -83,9 +130,9 @@ func (r *repository[TEntity]) Exists(ctx context.Context, value FieldValue) (boo
 	}
 
 	base := fmt.Sprintf(
-		"SELECT 1 FROM %s WHERE %s = $1",
+		"SELECT 1 FROM %s %s",
 		r.schema.Name(),
-		value.Field().Name(),
+		repo.JoinWhere(r.live(value.Field().Name()+" = $1")...),
 	)
 	query := repo.Exists(base)
 
@@ -102,7 +149,7 @@ func (r *repository[TEntity]) Count(ctx context.Context, params *FindParams) (in
 		return 0, errors.Wrap(err, "failed to get transaction")
 	}
 
-	whereClauses, args, err := r.buildFilters(params)
+	whereClauses, args, err := r.buildFilters(ctx, params)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to build filters for count")
 	}
@@ -124,66 +171,162 @@ func (r *repository[TEntity]) Count(ctx context.Context, params *FindParams) (in
 }
 
 func (r *repository[TEntity]) List(ctx context.Context, params *FindParams) ([]TEntity, error) {
-	whereClauses, args, err := r.buildFilters(params)
+	whereClauses, args, err := r.buildFilters(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build filters for list")
 	}
+	include, err := r.includedRelations(params.Include)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid include")
+	}
 
-	baseQuery := fmt.Sprintf("SELECT * FROM %s", r.schema.Name())
+	sortBy := keysetSortBy(r.schema, params.SortBy)
+	orderBy := sortBy.Columns(r.fieldMap)
+	if params.RankSearch && params.Query != "" {
+		if params.Cursor != "" {
+			return nil, errors.New("ranked search can't be paged by cursor")
+		}
+		if rank, rankArgs := r.searchRank(ctx, params.Query, len(args)+1); rank != "" {
+			orderBy = append([]string{rank + " DESC"}, orderBy...)
+			args = append(args, rankArgs...)
+		}
+	}
+	offset := params.Offset
+	if params.Cursor != "" {
+		cursor, err := repo.DecodeCursor(params.Cursor)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode cursor")
+		}
+		seek, seekArgs, err := sortBy.Seek(r.fieldMap, cursor, len(args)+1)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to build cursor condition")
+		}
+		whereClauses = append(whereClauses, seek)
+		args = append(args, seekArgs...)
+		offset = 0
+	}
+
+	baseQuery := fmt.Sprintf("SELECT %s FROM %s", r.columns(), r.schema.Name())
 	query := baseQuery
 	if len(whereClauses) > 0 {
 		query = repo.Join(query, repo.JoinWhere(whereClauses...))
 	}
-	query = repo.Join(
-		query,
-		params.SortBy.ToSQL(r.fieldMap),
-		repo.FormatLimitOffset(params.Limit, params.Offset),
-	)
+	if len(orderBy) > 0 {
+		query = repo.Join(query, "ORDER BY", strings.Join(orderBy, ", "))
+	}
+	query = repo.Join(query, repo.FormatLimitOffset(params.Limit, offset))
 
-	entities, err := r.queryEntities(ctx, query, args...)
+	rows, err := r.queryValues(ctx, query, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list entities")
 	}
+	if err := r.loadRelations(ctx, rows, include); err != nil {
+		return nil, err
+	}
 
-	return entities, nil
+	return r.toEntities(ctx, rows)
 }
 
-func (r *repository[TEntity]) Create(ctx context.Context, values []FieldValue) (TEntity, error) {
-	var zero TEntity
+// NextCursor returns the cursor continuing a list sorted by sortBy after entity,
+// typically the last entity of a page returned by Repository.List.
+func NextCursor[TEntity any](ctx context.Context, schema Schema[TEntity], sortBy SortBy, entity TEntity) (string, error) {
+	fvs, err := schema.Mapper().ToFieldValuesList(ctx, entity)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to map entity")
+	}
+	if len(fvs) == 0 {
+		return "", ErrEmptyResult
+	}
+	values := make(map[string]any, len(fvs[0]))
+	for _, fv := range fvs[0] {
+		values[fv.Field().Name()] = fv.Value()
+	}
+	keyset := keysetSortBy(schema, sortBy)
+	return keyset.Cursor(func(field string) any {
+		return values[field]
+	}), nil
+}
 
-	columns := make([]string, 0, len(values))
-	args := make([]any, 0, len(values))
+// keysetSortBy makes the primary key the last sort field so that the order is stable across pages.
+func keysetSortBy[TEntity any](schema Schema[TEntity], sortBy SortBy) SortBy {
+	return sortBy.WithTiebreaker(schema.Fields().KeyField().Name())
+}
 
-	for _, fv := range values {
-		field := fv.Field()
-		value := fv.Value()
-		if field.Key() && fv.IsZero() {
-			continue
-		}
-		columns = append(columns, field.Name())
-		args = append(args, value)
-	}
+func (r *repository[TEntity]) Create(ctx context.Context, values []FieldValue) (TEntity, error) {
+	var zero TEntity
 
+	columns, args := insertValues(values)
 	if len(columns) == 0 {
 		return zero, errors.New("no fields to create for entity")
 	}
 
-	query := repo.Insert(r.schema.Name(), columns, r.schema.Fields().Names()...)
-	entities, err := r.queryEntities(ctx, query, args...)
+	query := repo.Insert(r.schema.Name(), columns, columnNames(r.schema.Fields())...)
+	rows, err := r.queryValues(ctx, query, args...)
 	if err != nil {
 		return zero, errors.Wrap(err, "failed to create entity")
 	}
-	if len(entities) != 1 {
-		return zero, errors.Errorf("unexpected insert result count: %d", len(entities))
+	if len(rows) != 1 {
+		return zero, errors.Errorf("unexpected insert result count: %d", len(rows))
 	}
-	return entities[0], nil
+	related, err := syncRelations(ctx, keyValue(rows[0]), values)
+	if err != nil {
+		return zero, err
+	}
+	return r.toEntity(ctx, append(rows[0], related...))
 }
 
 func (r *repository[TEntity]) Update(ctx context.Context, values []FieldValue) (TEntity, error) {
 	var zero TEntity
 
+	u, err := r.buildUpdate(values)
+	if err != nil {
+		return zero, err
+	}
+	rows, err := r.queryValues(ctx, u.query+" RETURNING "+r.columns(), u.args...)
+	if err != nil {
+		return zero, errors.Wrap(err, "failed to update entity")
+	}
+	if len(rows) == 0 {
+		return zero, r.unmatchedUpdate(ctx, u)
+	}
+	if len(rows) != 1 {
+		return zero, errors.Errorf("unexpected update result count: %d", len(rows))
+	}
+	related, err := syncRelations(ctx, u.key, values)
+	if err != nil {
+		return zero, err
+	}
+	return r.toEntity(ctx, append(rows[0], related...))
+}
+
+var errUnmatchedUpdate = errors.New("update matched no row")
+
+// unmatchedUpdate explains an update that matched no row: ErrNotFound when the
+// entity is gone, a *serrors.ConflictError when it was updated concurrently.
+func (r *repository[TEntity]) unmatchedUpdate(ctx context.Context, u updateQuery) error {
+	if u.version > 0 {
+		exists, err := r.Exists(ctx, u.key)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return serrors.NewConflictError(r.schema.Name(), u.key.Value(), u.version)
+		}
+	}
+	return errors.Wrapf(ErrNotFound, "entity %v", u.key.Value())
+}
+
+type updateQuery struct {
+	query   string
+	args    []any
+	key     FieldValue
+	version int
+}
+
+func (r *repository[TEntity]) buildUpdate(values []FieldValue) (updateQuery, error) {
 	keyField := r.schema.Fields().KeyField()
-	var fieldKeyValue FieldValue
+	versionField := r.schema.Fields().VersionField()
+	var u updateQuery
 
 	updates := make([]string, 0, len(values))
 	args := make([]any, 0, len(values))
@@ -192,29 +335,48 @@ func (r *repository[TEntity]) Update(ctx context.Context, values []FieldValue) (
 		field := fv.Field()
 		val := fv.Value()
 		if field.Key() {
-			fieldKeyValue = fv
+			u.key = fv
+			continue
+		}
+		if IsVirtualField(field) {
 			continue
 		}
-		updates = append(updates, field.Name())
+		if versionField != nil && field.Name() == versionField.Name() {
+			v, err := fv.AsInt()
+			if err != nil {
+				return u, errors.Wrap(err, "invalid version")
+			}
+			u.version = v
+			continue
+		}
+		updates = append(updates, fmt.Sprintf("%s = $%d", field.Name(), len(args)+1))
 		args = append(args, val)
 	}
 
-	if fieldKeyValue == nil || fieldKeyValue.IsZero() {
-		return zero, errors.New("missing primary key or value")
+	if u.key == nil || u.key.IsZero() {
+		return u, errors.New("missing primary key or value")
 	}
 
-	whereClause := fmt.Sprintf("%s = $%d", keyField.Name(), len(args)+1)
-	args = append(args, fieldKeyValue.Value())
+	where := r.live(fmt.Sprintf("%s = $%d", keyField.Name(), len(args)+1))
+	args = append(args, u.key.Value())
 
-	query := repo.Update(r.schema.Name(), updates, whereClause) + " RETURNING *"
-	entities, err := r.queryEntities(ctx, query, args...)
-	if err != nil {
-		return zero, errors.Wrap(err, "failed to update entity")
-	}
-	if len(entities) != 1 {
-		return zero, errors.Errorf("unexpected update result count: %d", len(entities))
+	if versionField != nil {
+		if err := repo.RequireVersion(r.schema.Name(), u.version); err != nil {
+			return u, err
+		}
+		updates = append(updates, fmt.Sprintf("%s = %s + 1", versionField.Name(), versionField.Name()))
+		where = append(where, repo.VersionCondition(versionField.Name(), len(args)+1))
+		args = append(args, u.version)
 	}
-	return entities[0], nil
+
+	u.query = fmt.Sprintf(
+		"UPDATE %s SET %s %s",
+		r.schema.Name(),
+		strings.Join(updates, ", "),
+		repo.JoinWhere(where...),
+	)
+	u.args = args
+	return u, nil
 }
 
 func (r *repository[TEntity]) Delete(ctx context.Context, value FieldValue) (TEntity, error) {
@@ -224,13 +386,16 @@ func (r *repository[TEntity]) Delete(ctx context.Context, value FieldValue) (TEn
 		r.schema.Name(),
 		value.Field().Name(),
 	)
+	if r.schema.SoftDelete() {
+		query = repo.SoftDelete(r.schema.Name(), value.Field().Name()+" = $1") + " RETURNING " + r.columns()
+	}
 
 	entities, err := r.queryEntities(ctx, query, value.Value())
 	if err != nil {
 		return zero, errors.Wrap(err, "failed to delete entity")
 	}
 	if len(entities) == 0 {
-		return zero, errors.New("entity not found")
+		return zero, ErrNotFound
 	}
 	if len(entities) > 1 {
 		return zero, errors.New("multiple entities deleted")
@@ -239,11 +404,413 @@ func (r *repository[TEntity]) Delete(ctx context.Context, value FieldValue) (TEn
 	return entities[0], nil
 }
 
-func (r *repository[TEntity]) buildFilters(params *FindParams) ([]string, []any, error) {
+func (r *repository[TEntity]) Restore(ctx context.Context, value FieldValue) (TEntity, error) {
+	var zero TEntity
+	if !r.schema.SoftDelete() {
+		return zero, ErrNoTrash
+	}
+
+	query := repo.Restore(r.schema.Name(), value.Field().Name()+" = $1") + " RETURNING " + r.columns()
+	entities, err := r.queryEntities(ctx, query, value.Value())
+	if err != nil {
+		return zero, errors.Wrap(err, "failed to restore entity")
+	}
+	if len(entities) != 1 {
+		return zero, errors.New("entity not found in trash")
+	}
+	return entities[0], nil
+}
+
+func (r *repository[TEntity]) Purge(ctx context.Context, before time.Time) (int64, error) {
+	if !r.schema.SoftDelete() {
+		return 0, ErrNoTrash
+	}
+
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get transaction")
+	}
+	query := repo.PurgeDeleted(r.schema.Name())
+	args := []any{before}
+	// Like the trash bins, purges don't reach into other tenants
+	if _, err := r.schema.Fields().Field("tenant_id"); err == nil {
+		tenantID, err := composables.UseTenantID(ctx)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to get tenant ID")
+		}
+		query = repo.PurgeDeleted(r.schema.Name(), "tenant_id = $2")
+		args = append(args, tenantID)
+	}
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to purge entities")
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *repository[TEntity]) GetByKeys(ctx context.Context, values []FieldValue) ([]TEntity, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]any, len(values))
+	for i, fv := range values {
+		keys[i] = fv.Value()
+	}
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s %s",
+		r.columns(),
+		r.schema.Name(),
+		repo.JoinWhere(r.live(r.schema.Fields().KeyField().Name()+" = ANY($1)")...),
+	)
+	entities, err := r.queryEntities(ctx, query, keys)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get entities by keys")
+	}
+	return entities, nil
+}
+
+// BulkCreate copies rows with and without a key separately, as COPY needs the
+// same columns for every row. Many-to-many relations are only linked for rows
+// with a key, as COPY doesn't return the generated ones.
+func (r *repository[TEntity]) BulkCreate(ctx context.Context, rows [][]FieldValue) (int64, error) {
+	type copyGroup struct {
+		columns []string
+		rows    [][]any
+	}
+	var groups []*copyGroup
+	byColumns := make(map[string]*copyGroup)
+	for i, values := range rows {
+		columns, args := insertValues(values)
+		if len(columns) == 0 {
+			return 0, errors.Errorf("no fields to create for entity at row %d", i)
+		}
+		key := strings.Join(columns, ",")
+		group, ok := byColumns[key]
+		if !ok {
+			group = &copyGroup{columns: columns}
+			byColumns[key] = group
+			groups = append(groups, group)
+		}
+		group.rows = append(group.rows, args)
+	}
+	if len(groups) == 0 {
+		return 0, nil
+	}
+
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get transaction")
+	}
+	table := pgx.Identifier(strings.Split(r.schema.Name(), "."))
+	var created int64
+	for _, group := range groups {
+		n, err := tx.CopyFrom(ctx, table, group.columns, pgx.CopyFromRows(group.rows))
+		if err != nil {
+			return created, errors.Wrap(err, "failed to copy entities")
+		}
+		created += n
+	}
+	for _, values := range rows {
+		if key := keyValue(values); key != nil && !key.IsZero() {
+			if _, err := syncRelations(ctx, key, values); err != nil {
+				return created, err
+			}
+		}
+	}
+	return created, nil
+}
+
+func (r *repository[TEntity]) BulkUpdate(ctx context.Context, rows [][]FieldValue) (int64, error) {
+	updates := make([]updateQuery, len(rows))
+	batch := &pgx.Batch{}
+	for i, values := range rows {
+		u, err := r.buildUpdate(values)
+		if err != nil {
+			return 0, errors.Wrapf(err, "invalid update at row %d", i)
+		}
+		updates[i] = u
+		batch.Queue(u.query, u.args...)
+	}
+
+	unmatched := -1
+	updated, err := r.sendBatch(ctx, batch, func(i int, tag pgconn.CommandTag) error {
+		if tag.RowsAffected() == 0 {
+			unmatched = i
+			return errUnmatchedUpdate
+		}
+		return nil
+	})
+	// The batch has to be closed before the transaction can tell why a row was not updated
+	if unmatched >= 0 {
+		return updated, errors.Wrapf(r.unmatchedUpdate(ctx, updates[unmatched]), "batch query %d failed", unmatched)
+	}
+	if err != nil {
+		return updated, err
+	}
+	for i, values := range rows {
+		if _, err := syncRelations(ctx, updates[i].key, values); err != nil {
+			return updated, err
+		}
+	}
+	return updated, nil
+}
+
+// BulkUpsert leaves the version out of the rows, so upserts of versioned
+// entities always win and bump the version. Upserting an entity in the trash
+// restores it.
+func (r *repository[TEntity]) BulkUpsert(ctx context.Context, rows [][]FieldValue) (int64, error) {
+	keyField := r.schema.Fields().KeyField()
+	versionField := r.schema.Fields().VersionField()
+
+	batch := &pgx.Batch{}
+	for i, values := range rows {
+		columns := make([]string, 0, len(values))
+		placeholders := make([]string, 0, len(values))
+		updates := make([]string, 0, len(values))
+		args := make([]any, 0, len(values))
+		for _, fv := range values {
+			field := fv.Field()
+			if IsVersionField(field) || IsVirtualField(field) {
+				continue
+			}
+			if field.Key() && fv.IsZero() {
+				return 0, errors.Errorf("missing primary key at row %d", i)
+			}
+			columns = append(columns, field.Name())
+			args = append(args, fv.Value())
+			placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+			if !field.Key() {
+				updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", field.Name(), field.Name()))
+			}
+		}
+		if versionField != nil {
+			updates = append(updates, fmt.Sprintf("%s = t.%s + 1", versionField.Name(), versionField.Name()))
+		}
+		if r.schema.SoftDelete() {
+			updates = append(updates, repo.DeletedAtColumn+" = NULL")
+		}
+		conflict := "DO NOTHING"
+		if len(updates) > 0 {
+			conflict = "DO UPDATE SET " + strings.Join(updates, ", ")
+		}
+		batch.Queue(fmt.Sprintf(
+			"INSERT INTO %s AS t (%s) VALUES (%s) ON CONFLICT (%s) %s",
+			r.schema.Name(),
+			strings.Join(columns, ", "),
+			strings.Join(placeholders, ", "),
+			keyField.Name(),
+			conflict,
+		), args...)
+	}
+
+	upserted, err := r.sendBatch(ctx, batch, nil)
+	if err != nil {
+		return upserted, err
+	}
+	for _, values := range rows {
+		if _, err := syncRelations(ctx, keyValue(values), values); err != nil {
+			return upserted, err
+		}
+	}
+	return upserted, nil
+}
+
+func (r *repository[TEntity]) BulkDelete(ctx context.Context, values []FieldValue) ([]TEntity, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	field := values[0].Field()
+	keys := make([]any, len(values))
+	for i, fv := range values {
+		if fv.Field().Name() != field.Name() {
+			return nil, errors.Errorf("bulk delete by %s got a value of %s", field.Name(), fv.Field().Name())
+		}
+		keys[i] = fv.Value()
+	}
+
+	query := fmt.Sprintf(
+		"DELETE FROM %s WHERE %s = ANY($1) RETURNING *",
+		r.schema.Name(),
+		field.Name(),
+	)
+	if r.schema.SoftDelete() {
+		query = repo.SoftDelete(r.schema.Name(), field.Name()+" = ANY($1)") + " RETURNING " + r.columns()
+	}
+
+	entities, err := r.queryEntities(ctx, query, keys)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to delete entities")
+	}
+	return entities, nil
+}
+
+// sendBatch runs batch and passes the result of every query to check, which may be nil.
+// It returns the number of affected rows.
+func (r *repository[TEntity]) sendBatch(
+	ctx context.Context,
+	batch *pgx.Batch,
+	check func(i int, tag pgconn.CommandTag) error,
+) (int64, error) {
+	if batch.Len() == 0 {
+		return 0, nil
+	}
+
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get transaction")
+	}
+	results := tx.SendBatch(ctx, batch)
+	var affected int64
+	for i := 0; i < batch.Len(); i++ {
+		tag, err := results.Exec()
+		if err == nil && check != nil {
+			err = check(i, tag)
+		}
+		if err != nil {
+			_ = results.Close()
+			return affected, errors.Wrapf(err, "batch query %d failed", i)
+		}
+		affected += tag.RowsAffected()
+	}
+	if err := results.Close(); err != nil {
+		return affected, errors.Wrap(err, "failed to close batch")
+	}
+	return affected, nil
+}
+
+// insertValues returns the columns and values to insert, leaving out a zero key
+// and version for the database to fill in and relations without a column.
+func insertValues(values []FieldValue) ([]string, []any) {
+	columns := make([]string, 0, len(values))
+	args := make([]any, 0, len(values))
+	for _, fv := range values {
+		field := fv.Field()
+		if (field.Key() || IsVersionField(field)) && fv.IsZero() || IsVirtualField(field) {
+			continue
+		}
+		columns = append(columns, field.Name())
+		args = append(args, fv.Value())
+	}
+	return columns, args
+}
+
+// columns lists the columns to select. Soft-deletable tables have a deleted_at
+// column the schema has no field for, so their fields are listed explicitly.
+func (r *repository[TEntity]) columns() string {
+	if r.schema.SoftDelete() {
+		return strings.Join(columnNames(r.schema.Fields()), ", ")
+	}
+	return "*"
+}
+
+// includedRelations returns the virtual relation fields named in include.
+func (r *repository[TEntity]) includedRelations(include []string) ([]RelationField, error) {
+	fields := make([]RelationField, 0, len(include))
+	for _, name := range include {
+		f, err := r.schema.Fields().Field(name)
+		if err != nil {
+			return nil, err
+		}
+		if !IsVirtualField(f) {
+			return nil, errors.Errorf("field %q is not a has-many or many-to-many relation", name)
+		}
+		fields = append(fields, f.(RelationField))
+	}
+	return fields, nil
+}
+
+// loadRelations appends the values of the relation fields to every row,
+// loading each relation for all rows with a single query.
+func (r *repository[TEntity]) loadRelations(ctx context.Context, rows [][]FieldValue, fields []RelationField) error {
+	if len(rows) == 0 || len(fields) == 0 {
+		return nil
+	}
+
+	keys := make([]any, len(rows))
+	for i, values := range rows {
+		key := keyValue(values)
+		if key == nil {
+			return errors.New("missing primary key to load relations")
+		}
+		keys[i] = key.Value()
+	}
+	for _, f := range fields {
+		records, err := f.Load(ctx, keys)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load relation %q", f.Name())
+		}
+		for i := range rows {
+			related := records.For(keys[i])
+			if related == nil {
+				related = []RelatedRecord{}
+			}
+			rows[i] = append(rows[i], f.Value(related))
+		}
+	}
+	return nil
+}
+
+// virtualFields returns the has-many and many-to-many fields.
+func virtualFields(fields Fields) []RelationField {
+	var relations []RelationField
+	for _, f := range fields.Fields() {
+		if IsVirtualField(f) {
+			relations = append(relations, f.(RelationField))
+		}
+	}
+	return relations
+}
+
+// syncRelations links the entity with key to the records of the loaded
+// many-to-many values and returns the virtual values to map with the entity.
+func syncRelations(ctx context.Context, key FieldValue, values []FieldValue) ([]FieldValue, error) {
+	var related []FieldValue
+	for _, fv := range values {
+		if !IsVirtualField(fv.Field()) {
+			continue
+		}
+		related = append(related, fv)
+		if fv.Value() == nil {
+			continue
+		}
+		if key == nil || key.IsZero() {
+			return nil, errors.New("missing primary key to link relations")
+		}
+		records, err := fv.AsRelated()
+		if err != nil {
+			return nil, err
+		}
+		if err := fv.Field().(RelationField).Sync(ctx, key.Value(), RelatedKeys(records)); err != nil {
+			return nil, err
+		}
+	}
+	return related, nil
+}
+
+// live adds the condition leaving out entities in the trash to where.
+func (r *repository[TEntity]) live(where ...string) []string {
+	if r.schema.SoftDelete() {
+		where = append(where, repo.NotDeleted(""))
+	}
+	return where
+}
+
+func (r *repository[TEntity]) buildFilters(ctx context.Context, params *FindParams) ([]string, []any, error) {
 	where := make([]string, 0)
 	args := make([]any, 0)
 	currentArgIdx := 1
 
+	if params.Trashed {
+		if !r.schema.SoftDelete() {
+			return nil, nil, ErrNoTrash
+		}
+		where = append(where, repo.OnlyDeleted(""))
+	} else {
+		where = r.live(where...)
+	}
+
 	for _, filter := range params.Filters {
 		column, ok := r.fieldMap[filter.Column]
 		if !ok {
@@ -258,27 +825,81 @@ func (r *repository[TEntity]) buildFilters(params *FindParams) ([]string, []any,
 
 	if params.Query != "" {
 		searchClauses := make([]string, 0)
-		for _, sf := range r.schema.Fields().Searchable() {
-			var searchClause string
-			if sf.Type() == JSONFieldType {
-				// Cast JSONB to text before applying LOWER for JSON fields
-				searchClause = fmt.Sprintf("LOWER(%s::text) LIKE $%d", sf.Name(), currentArgIdx)
-			} else {
-				// Use regular LOWER for string fields
-				searchClause = fmt.Sprintf("LOWER(%s) LIKE $%d", sf.Name(), currentArgIdx)
+		for _, sf := range ReadableFields(ctx, r.schema.Fields().Searchable()) {
+			var filter repo.Filter
+			switch searchMode(sf) {
+			case SearchModeFullText:
+				filter = repo.FullText(searchConfig(sf), params.Query)
+			case SearchModeTrigram:
+				filter = repo.WordSimilar(params.Query)
+			default:
+				filter = repo.Like("%" + strings.ToLower(params.Query) + "%")
 			}
-			searchClauses = append(searchClauses, searchClause)
+			searchClauses = append(searchClauses, filter.String(searchColumn(sf), currentArgIdx))
+			filterValues := filter.Value()
+			args = append(args, filterValues...)
+			currentArgIdx += len(filterValues)
 		}
 		if len(searchClauses) > 0 {
 			where = append(where, "("+strings.Join(searchClauses, " OR ")+")")
-			args = append(args, "%"+strings.ToLower(params.Query)+"%")
 		}
 	}
 
 	return where, args, nil
 }
 
+// searchRank sums the full-text rank of query over the full-text searchable fields
+// the user in ctx may read.
+// It returns an empty expression when there are no such fields.
+func (r *repository[TEntity]) searchRank(ctx context.Context, query string, argIdx int) (string, []any) {
+	var ranks []string
+	for _, sf := range ReadableFields(ctx, r.schema.Fields().Searchable()) {
+		if searchMode(sf) != SearchModeFullText {
+			continue
+		}
+		ranks = append(ranks, repo.FullText(searchConfig(sf), query).Rank(searchColumn(sf), argIdx))
+	}
+	if len(ranks) == 0 {
+		return "", nil
+	}
+	return "(" + strings.Join(ranks, " + ") + ")", []any{query}
+}
+
+func searchMode(f Field) SearchMode {
+	if mode, ok := f.Attrs()[Search].(SearchMode); ok {
+		return mode
+	}
+	return SearchModeLike
+}
+
+func searchConfig(f Field) string {
+	config, _ := f.Attrs()[SearchConfig].(string)
+	return config
+}
+
+// searchColumn returns the field column as text, lowercased for LIKE searches.
+func searchColumn(f Field) string {
+	column := f.Name()
+	if f.Type() == JSONFieldType {
+		// Cast JSONB to text before searching JSON fields
+		column += "::text"
+	}
+	if searchMode(f) == SearchModeLike {
+		column = "LOWER(" + column + ")"
+	}
+	return column
+}
+
 func (r *repository[TEntity]) queryEntities(ctx context.Context, query string, args ...any) ([]TEntity, error) {
+	fvs, err := r.queryValues(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return r.toEntities(ctx, fvs)
+}
+
+// queryValues runs query and returns the field values of the returned rows.
+func (r *repository[TEntity]) queryValues(ctx context.Context, query string, args ...any) ([][]FieldValue, error) {
 	tx, err := composables.UseTx(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get transaction")
@@ -322,11 +943,27 @@ func (r *repository[TEntity]) queryEntities(ctx context.Context, query string, a
 		return nil, errors.Wrap(err, "row iteration error")
 	}
 
-	entities, err := r.schema.Mapper().ToEntities(ctx, fvs...)
+	return fvs, nil
+}
 
+func (r *repository[TEntity]) toEntities(ctx context.Context, fvs [][]FieldValue) ([]TEntity, error) {
+	entities, err := r.schema.Mapper().ToEntities(ctx, fvs...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to convert entities")
 	}
 
 	return entities, nil
 }
+
+func (r *repository[TEntity]) toEntity(ctx context.Context, values []FieldValue) (TEntity, error) {
+	var zero TEntity
+
+	entities, err := r.toEntities(ctx, [][]FieldValue{values})
+	if err != nil {
+		return zero, err
+	}
+	if len(entities) != 1 {
+		return zero, ErrEmptyResult
+	}
+	return entities[0], nil
+}
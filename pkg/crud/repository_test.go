@@ -108,6 +108,38 @@ func TestReportRepository_AllMethods(t *testing.T) {
 		assert.Equal(t, "OrderTest", list[0].Author())
 	})
 
+	t.Run("List with cursor", func(t *testing.T) {
+		for _, author := range []string{"Cursor B", "Cursor A", "Cursor C", "Cursor A"} {
+			fields, err := schema.Mapper().ToFieldValues(ctx, NewReport(CreateMultiLangTitle("Paged"), WithAuthor(author)))
+			require.NoError(t, err)
+			_, err = rep.Create(ctx, fields)
+			require.NoError(t, err)
+		}
+
+		params := &crud.FindParams{
+			Filters: []crud.Filter{{Column: "author", Filter: repo.Like("Cursor %")}},
+			SortBy: crud.SortBy{
+				Fields: []repo.SortByField[string]{{Field: "author", Ascending: true}},
+			},
+			Limit: 3,
+		}
+		first, err := rep.List(ctx, params)
+		require.NoError(t, err)
+		require.Len(t, first, 3)
+
+		params.Cursor, err = crud.NextCursor(ctx, schema, params.SortBy, first[len(first)-1])
+		require.NoError(t, err)
+		second, err := rep.List(ctx, params)
+		require.NoError(t, err)
+		require.Len(t, second, 1)
+
+		var authors []string
+		for _, r := range append(first, second...) {
+			authors = append(authors, r.Author())
+		}
+		assert.Equal(t, []string{"Cursor A", "Cursor A", "Cursor B", "Cursor C"}, authors)
+	})
+
 	t.Run("Count with filter", func(t *testing.T) {
 		fields, err := schema.Mapper().ToFieldValues(ctx, NewReport(CreateMultiLangTitle("Count Me"), WithAuthor("Counter")))
 		require.NoError(t, err)
@@ -151,6 +183,13 @@ func TestReportRepository_AllMethods(t *testing.T) {
 
 		_, err = rep.Get(ctx, key)
 		require.Error(t, err)
+
+		updateFields, err := schema.Mapper().ToFieldValues(ctx, deleted.SetSummary("Gone"))
+		require.NoError(t, err)
+		_, err = rep.Update(ctx, updateFields)
+		require.ErrorIs(t, err, crud.ErrNotFound)
+		_, err = rep.BulkUpdate(ctx, [][]crud.FieldValue{updateFields})
+		require.ErrorIs(t, err, crud.ErrNotFound)
 	})
 
 	t.Run("Invalid Filter Column", func(t *testing.T) {
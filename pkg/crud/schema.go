@@ -18,7 +18,13 @@ type Schema[TEntity any] interface {
 	Fields() Fields
 	Mapper() FlatMapper[TEntity]
 	Validators() []Validator[TEntity]
+	// Rules are the SchemaRules saves run in their transaction, see WithSchemaRules.
+	Rules() []SchemaRule
 	Hooks() Hooks[TEntity]
+	// SoftDelete reports whether deleting moves entities to the trash, see WithSoftDelete.
+	SoftDelete() bool
+	// History reports whether changes of entities are recorded, see WithHistory.
+	History() bool
 }
 
 func WithValidators[TEntity any](validators []Validator[TEntity]) SchemaOption[TEntity] {
@@ -33,6 +39,21 @@ func WithValidator[TEntity any](validator Validator[TEntity]) SchemaOption[TEnti
 	}
 }
 
+// WithSchemaRules adds rules validating fields together or against the database,
+// e.g. AfterRule, RequiredIfRule and UniqueRule. Unlike validators, they run in
+// the transaction of saves and report the fields they reject.
+func WithSchemaRules[TEntity any](rules []SchemaRule) SchemaOption[TEntity] {
+	return func(s *schema[TEntity]) {
+		s.rules = append(s.rules, rules...)
+	}
+}
+
+func WithSchemaRule[TEntity any](rule SchemaRule) SchemaOption[TEntity] {
+	return func(s *schema[TEntity]) {
+		s.rules = append(s.rules, rule)
+	}
+}
+
 func WithCreateHook[TEntity any](hook Hook[TEntity]) SchemaOption[TEntity] {
 	return func(s *schema[TEntity]) {
 		s.hooks.createHook = hook
@@ -51,6 +72,24 @@ func WithDeleteHook[TEntity any](hook Hook[TEntity]) SchemaOption[TEntity] {
 	}
 }
 
+// WithSoftDelete makes deleting set the deleted_at column of the table instead of
+// removing the row. Deleted entities are left out of all queries except those with
+// FindParams.Trashed, can be restored and are removed for good by Purge.
+func WithSoftDelete[TEntity any]() SchemaOption[TEntity] {
+	return func(s *schema[TEntity]) {
+		s.softDelete = true
+	}
+}
+
+// WithHistory records the changes of entities saved, deleted and restored through
+// the service with who made them in the crud_history table, in the transaction of
+// the change. Service.History returns them and Service.Revert undoes them.
+func WithHistory[TEntity any]() SchemaOption[TEntity] {
+	return func(s *schema[TEntity]) {
+		s.history = true
+	}
+}
+
 func NewSchema[TEntity any](
 	name string,
 	fields Fields,
@@ -87,7 +126,10 @@ type schema[TEntity any] struct {
 	fields     Fields
 	mapper     FlatMapper[TEntity]
 	validators []Validator[TEntity]
+	rules      []SchemaRule
 	hooks      *hooks[TEntity]
+	softDelete bool
+	history    bool
 }
 
 func (s *schema[TEntity]) Name() string {
@@ -106,10 +148,22 @@ func (s *schema[TEntity]) Validators() []Validator[TEntity] {
 	return s.validators
 }
 
+func (s *schema[TEntity]) Rules() []SchemaRule {
+	return s.rules
+}
+
 func (s *schema[TEntity]) Hooks() Hooks[TEntity] {
 	return s.hooks
 }
 
+func (s *schema[TEntity]) SoftDelete() bool {
+	return s.softDelete
+}
+
+func (s *schema[TEntity]) History() bool {
+	return s.history
+}
+
 type hooks[TEntity any] struct {
 	createHook Hook[TEntity]
 	updateHook Hook[TEntity]
@@ -0,0 +1,246 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+)
+
+// SchemaRule validates the field values of an entity together, e.g. that one
+// field comes after another or that a value is unique. Rules run in the
+// transaction of the save, which ctx carries, so they may query the database.
+// They report invalid fields with EntityValues.FieldError and may join several
+// of those; any other error fails the save as is.
+type SchemaRule func(ctx context.Context, values EntityValues) error
+
+// EntityValues holds the field values of the entity a SchemaRule validates
+type EntityValues struct {
+	schema     string
+	softDelete bool
+	values     []FieldValue
+}
+
+// Schema is the name of the schema of the entity, which is also its table
+func (v EntityValues) Schema() string {
+	return v.schema
+}
+
+// Values returns all field values of the entity
+func (v EntityValues) Values() []FieldValue {
+	return v.values
+}
+
+// Get returns the value of the field with name, nil when the entity has none
+func (v EntityValues) Get(name string) FieldValue {
+	for _, fv := range v.values {
+		if fv.Field().Name() == name {
+			return fv
+		}
+	}
+	return nil
+}
+
+// Key returns the value of the primary key field
+func (v EntityValues) Key() FieldValue {
+	return keyValue(v.values)
+}
+
+// LabelKey returns the localization key of the label of the field with name:
+// its WithLocalizationKey or "<schema>.Fields.<name>" like in scaffold forms.
+func (v EntityValues) LabelKey(name string) string {
+	if fv := v.Get(name); fv != nil && fv.Field().LocalizationKey() != "" {
+		return fv.Field().LocalizationKey()
+	}
+	return v.schema + ".Fields." + name
+}
+
+// FieldError reports the field with name as invalid. The message is localized
+// with localeKey, passing the label key of the field as Field in addition to
+// templateData; message is used where it isn't localized, e.g. in logs.
+func (v EntityValues) FieldError(name, code, localeKey, message string, templateData map[string]string) *serrors.ValidationError {
+	err := serrors.NewValidationError(name, code, message, localeKey).WithFieldName(v.LabelKey(name))
+	for k, val := range templateData {
+		err.TemplateData[k] = val
+	}
+	return err
+}
+
+// ValidationErrorsOf collects the validation errors of fields in err, which
+// may join and wrap them like the errors of saves rejected with ErrValidation.
+// The first error of every field wins.
+func ValidationErrorsOf(err error) serrors.ValidationErrors {
+	errs := make(serrors.ValidationErrors)
+	collectValidationErrors(err, errs)
+	return errs
+}
+
+func collectValidationErrors(err error, errs serrors.ValidationErrors) {
+	switch e := err.(type) {
+	case nil:
+		return
+	case *serrors.ValidationError:
+		if _, ok := errs[e.Field]; !ok {
+			errs[e.Field] = e
+		}
+		return
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			collectValidationErrors(inner, errs)
+		}
+		return
+	}
+	collectValidationErrors(errors.Unwrap(err), errs)
+}
+
+// AfterRule requires the value of the field with name to be after the value of
+// the field other, e.g. an end date after the start date. Numbers must be
+// greater. The rule passes while either field is empty.
+func AfterRule(name, other string) SchemaRule {
+	return func(ctx context.Context, values EntityValues) error {
+		fv, otherFv := values.Get(name), values.Get(other)
+		if fv == nil || otherFv == nil || fv.IsZero() || otherFv.IsZero() {
+			return nil
+		}
+
+		if t, ok := fv.Value().(time.Time); ok {
+			otherT, ok := otherFv.Value().(time.Time)
+			if !ok {
+				return errors.Errorf("fields %q and %q can't be compared", name, other)
+			}
+			if t.After(otherT) {
+				return nil
+			}
+			return values.FieldError(
+				name,
+				"VALIDATION_AFTER",
+				"ValidationErrors.after",
+				fmt.Sprintf("field %q must be after %q", name, other),
+				map[string]string{"Param": values.LabelKey(other)},
+			)
+		}
+
+		n, ok := ruleNumber(fv.Value())
+		otherN, otherOK := ruleNumber(otherFv.Value())
+		if !ok || !otherOK {
+			return errors.Errorf("fields %q and %q can't be compared", name, other)
+		}
+		if n > otherN {
+			return nil
+		}
+		return values.FieldError(
+			name,
+			"VALIDATION_GTFIELD",
+			"ValidationErrors.gtfield",
+			fmt.Sprintf("field %q must be greater than %q", name, other),
+			map[string]string{"Param": values.LabelKey(other)},
+		)
+	}
+}
+
+// RequiredIfRule requires the field with name when the field other holds one of
+// values, e.g. a reason when the status is "rejected", or any value when no
+// values are given. Values are compared in their text form, so constants of
+// string enumerations may be passed.
+func RequiredIfRule(name, other string, values ...any) SchemaRule {
+	return func(ctx context.Context, entity EntityValues) error {
+		otherFv := entity.Get(other)
+		if otherFv == nil || otherFv.IsZero() {
+			return nil
+		}
+		if len(values) > 0 {
+			matches := false
+			for _, v := range values {
+				if fmt.Sprint(v) == fmt.Sprint(otherFv.Value()) {
+					matches = true
+					break
+				}
+			}
+			if !matches {
+				return nil
+			}
+		}
+		if fv := entity.Get(name); fv != nil && !fv.IsZero() {
+			return nil
+		}
+		return serrors.NewFieldRequiredError(name, entity.LabelKey(name))
+	}
+}
+
+// UniqueRule requires the value of the field with name to be unique among the
+// stored entities with the same values of the scope fields, e.g.
+// UniqueRule("email", "tenant_id") for emails unique within a tenant. Entities
+// in the trash of soft-deleting schemas are left out. The rule passes while the
+// field is empty. It only sees stored entities, so duplicates within a single
+// BulkSave are left to unique indexes.
+func UniqueRule(name string, scope ...string) SchemaRule {
+	return func(ctx context.Context, values EntityValues) error {
+		fv := values.Get(name)
+		if fv == nil || fv.IsZero() {
+			return nil
+		}
+
+		where := []string{name + " = $1"}
+		args := []any{fv.Value()}
+		for _, s := range scope {
+			scopeFv := values.Get(s)
+			if scopeFv == nil {
+				return errors.Errorf("unique field %q is scoped to unknown field %q", name, s)
+			}
+			args = append(args, scopeFv.Value())
+			where = append(where, s+" IS NOT DISTINCT FROM $"+strconv.Itoa(len(args)))
+		}
+		if key := values.Key(); key != nil && !key.IsZero() {
+			args = append(args, key.Value())
+			where = append(where, key.Field().Name()+" <> $"+strconv.Itoa(len(args)))
+		}
+		if values.softDelete {
+			where = append(where, repo.NotDeleted(""))
+		}
+
+		tx, err := composables.UseTx(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to get transaction")
+		}
+		query := repo.Exists(fmt.Sprintf("SELECT 1 FROM %s %s", values.schema, repo.JoinWhere(where...)))
+		taken := false
+		if err := tx.QueryRow(ctx, query, args...).Scan(&taken); err != nil {
+			return errors.Wrapf(err, "failed to check if %s is unique", name)
+		}
+		if !taken {
+			return nil
+		}
+		message := fmt.Sprintf("field %q must be unique", name)
+		if len(scope) > 0 {
+			message = fmt.Sprintf("field %q must be unique within %s", name, strings.Join(scope, ", "))
+		}
+		return values.FieldError(name, "VALIDATION_UNIQUE", "ValidationErrors.unique", message, nil)
+	}
+}
+
+// ruleNumber converts the values of numeric fields, including decimal strings
+// and money in minor units, to float64 for comparisons
+func ruleNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
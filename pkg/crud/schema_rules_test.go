@@ -0,0 +1,110 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+)
+
+func newTestEntityValues(values ...FieldValue) EntityValues {
+	return EntityValues{schema: "events", values: values}
+}
+
+func TestAfterRule(t *testing.T) {
+	start := NewDateTimeField("starts_at")
+	end := NewDateTimeField("ends_at", WithLocalizationKey("Events.Fields.End"))
+	now := time.Now()
+	rule := AfterRule("ends_at", "starts_at")
+
+	t.Run("passes when after", func(t *testing.T) {
+		values := newTestEntityValues(start.Value(now), end.Value(now.Add(time.Hour)))
+		require.NoError(t, rule(context.Background(), values))
+	})
+
+	t.Run("passes while a field is empty", func(t *testing.T) {
+		values := newTestEntityValues(start.Value(now), end.Value(time.Time{}))
+		require.NoError(t, rule(context.Background(), values))
+	})
+
+	t.Run("reports the field when not after", func(t *testing.T) {
+		values := newTestEntityValues(start.Value(now), end.Value(now))
+		err := rule(context.Background(), values)
+
+		var validationErr *serrors.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "ends_at", validationErr.Field)
+		assert.Equal(t, "ValidationErrors.after", validationErr.LocaleKey)
+		assert.Equal(t, "Events.Fields.End", validationErr.TemplateData["Field"])
+		assert.Equal(t, "events.Fields.starts_at", validationErr.TemplateData["Param"])
+	})
+
+	t.Run("compares numbers", func(t *testing.T) {
+		lower, upper := NewIntField("lower"), NewDecimalField("upper")
+		numbers := AfterRule("upper", "lower")
+
+		require.NoError(t, numbers(context.Background(), newTestEntityValues(lower.Value(1), upper.Value("1.5"))))
+
+		err := numbers(context.Background(), newTestEntityValues(lower.Value(2), upper.Value("1.5")))
+		var validationErr *serrors.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "ValidationErrors.gtfield", validationErr.LocaleKey)
+	})
+
+	t.Run("fails on fields that can't be compared", func(t *testing.T) {
+		values := newTestEntityValues(NewStringField("starts_at").Value("soon"), end.Value(now))
+		err := rule(context.Background(), values)
+		require.Error(t, err)
+		assert.Empty(t, ValidationErrorsOf(err))
+	})
+}
+
+func TestRequiredIfRule(t *testing.T) {
+	status := NewStringField("status")
+	reason := NewStringField("reason")
+
+	t.Run("requires the field for the given values", func(t *testing.T) {
+		rule := RequiredIfRule("reason", "status", "rejected")
+
+		require.NoError(t, rule(context.Background(), newTestEntityValues(status.Value("approved"), reason.Value(""))))
+		require.NoError(t, rule(context.Background(), newTestEntityValues(status.Value("rejected"), reason.Value("Late"))))
+
+		err := rule(context.Background(), newTestEntityValues(status.Value("rejected"), reason.Value("")))
+		errs := ValidationErrorsOf(err)
+		require.Contains(t, errs, "reason")
+		assert.Equal(t, "VALIDATION_REQUIRED", errs["reason"].Code)
+		assert.Equal(t, "events.Fields.reason", errs["reason"].TemplateData["Field"])
+	})
+
+	t.Run("requires the field for any value without values", func(t *testing.T) {
+		rule := RequiredIfRule("reason", "status")
+
+		require.NoError(t, rule(context.Background(), newTestEntityValues(status.Value(""), reason.Value(""))))
+		require.Error(t, rule(context.Background(), newTestEntityValues(status.Value("approved"), reason.Value(""))))
+	})
+}
+
+func TestValidationErrorsOf(t *testing.T) {
+	values := newTestEntityValues()
+	first := values.FieldError("email", "VALIDATION_UNIQUE", "ValidationErrors.unique", "taken", nil)
+	second := values.FieldError("email", "VALIDATION_EMAIL", "ValidationErrors.email", "invalid", nil)
+	other := values.FieldError("name", "VALIDATION_REQUIRED", "ValidationErrors.required", "required", nil)
+
+	err := fmt.Errorf("%w: %w", ErrValidation, errors.Join(
+		errors.Wrap(first, "schema rule failed"),
+		errors.New("unrelated"),
+		errors.Join(second, other),
+	))
+
+	errs := ValidationErrorsOf(err)
+	require.Len(t, errs, 2)
+	assert.Same(t, first, errs["email"])
+	assert.Same(t, other, errs["name"])
+	assert.Empty(t, ValidationErrorsOf(errors.New("unrelated")))
+}
@@ -3,12 +3,20 @@ package crud
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"slices"
+	"time"
 
 	"github.com/go-faster/errors"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
 )
 
+// ErrValidation is wrapped by the errors of saves rejected by field rules,
+// schema validators and rules or missing related records. ValidationErrorsOf
+// returns the fields they reject.
+var ErrValidation = errors.New("entity validation failed")
+
 type Service[TEntity any] interface {
 	GetAll(ctx context.Context) ([]TEntity, error)
 	Get(ctx context.Context, value FieldValue) (TEntity, error)
@@ -17,17 +25,64 @@ type Service[TEntity any] interface {
 	List(ctx context.Context, params *FindParams) ([]TEntity, error)
 	Save(ctx context.Context, entity TEntity) (TEntity, error)
 	Delete(ctx context.Context, value FieldValue) (TEntity, error)
+	Restore(ctx context.Context, value FieldValue) (TEntity, error)
+	Purge(ctx context.Context, before time.Time) (int64, error)
+	// BulkSave saves entities in one transaction and returns how many it saved. Like
+	// Save, entities whose key is zero or doesn't exist yet are created and the others
+	// updated, but the existing ones are looked up with a single query, new entities are
+	// copied in with COPY and updates are sent in one batch. Hooks and validators run for
+	// all entities before anything is written and a single BulkSavedEvent is published.
+	BulkSave(ctx context.Context, entities []TEntity) (int64, error)
+	// BulkUpsert saves entities with set keys in one transaction, inserting them or
+	// overwriting the entities with the same key, even if those are created concurrently
+	// or changed since they were read. It publishes a single BulkUpsertedEvent.
+	BulkUpsert(ctx context.Context, entities []TEntity) (int64, error)
+	// BulkDelete deletes the entities with the given values of a field in one query
+	// and publishes a single BulkDeletedEvent.
+	BulkDelete(ctx context.Context, values []FieldValue) ([]TEntity, error)
+	// Aggregate computes query over the entities matching params, see
+	// Repository.Aggregate. Aggregating or grouping by fields the user in ctx
+	// may not read is forbidden.
+	Aggregate(ctx context.Context, params *FindParams, query AggregateQuery) ([]AggregateRow, error)
+	// History returns the changes recorded for the entity with key, newest first,
+	// leaving out the fields the user in ctx may not read. It requires a schema
+	// created WithHistory.
+	History(ctx context.Context, key FieldValue) ([]HistoryEntry, error)
+	// Revert saves the entity with key as it was after the history entry with id,
+	// undoing the changes recorded since. The revert is recorded as an update.
+	Revert(ctx context.Context, key FieldValue, id int64) (TEntity, error)
+}
+
+type ServiceOption func(o *serviceOptions)
+
+type serviceOptions struct {
+	outbox eventbus.Outbox
+}
+
+// WithOutbox makes the service record its events in the transactional outbox
+// instead of publishing them after the transaction commits. The events must be
+// registered with RegisterEvents.
+func WithOutbox(outbox eventbus.Outbox) ServiceOption {
+	return func(o *serviceOptions) {
+		o.outbox = outbox
+	}
 }
 
 func DefaultService[TEntity any](
 	schema Schema[TEntity],
 	repository Repository[TEntity],
 	publisher eventbus.EventBus,
+	opts ...ServiceOption,
 ) Service[TEntity] {
+	options := &serviceOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
 	return &service[TEntity]{
 		schema:     schema,
 		repository: repository,
 		publisher:  publisher,
+		outbox:     options.outbox,
 	}
 }
 
@@ -35,6 +90,7 @@ type service[TEntity any] struct {
 	schema     Schema[TEntity]
 	repository Repository[TEntity]
 	publisher  eventbus.EventBus
+	outbox     eventbus.Outbox
 }
 
 func (s *service[TEntity]) GetAll(ctx context.Context) ([]TEntity, error) {
@@ -42,7 +98,7 @@ func (s *service[TEntity]) GetAll(ctx context.Context) ([]TEntity, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "service failed to get all entities")
 	}
-	return entities, nil
+	return s.stripUnreadable(ctx, entities...)
 }
 
 func (s *service[TEntity]) Get(ctx context.Context, value FieldValue) (TEntity, error) {
@@ -50,7 +106,7 @@ func (s *service[TEntity]) Get(ctx context.Context, value FieldValue) (TEntity,
 	if err != nil {
 		return entity, errors.Wrap(err, "service failed to get entity by value")
 	}
-	return entity, nil
+	return s.stripUnreadableOne(ctx, entity)
 }
 
 func (s *service[TEntity]) Exists(ctx context.Context, value FieldValue) (bool, error) {
@@ -62,6 +118,9 @@ func (s *service[TEntity]) Exists(ctx context.Context, value FieldValue) (bool,
 }
 
 func (s *service[TEntity]) Count(ctx context.Context, params *FindParams) (int64, error) {
+	if err := s.authorizeParams(ctx, params); err != nil {
+		return 0, err
+	}
 	count, err := s.repository.Count(ctx, params)
 	if err != nil {
 		return 0, errors.Wrap(err, "service failed to count entities")
@@ -70,11 +129,14 @@ func (s *service[TEntity]) Count(ctx context.Context, params *FindParams) (int64
 }
 
 func (s *service[TEntity]) List(ctx context.Context, params *FindParams) ([]TEntity, error) {
+	if err := s.authorizeParams(ctx, params); err != nil {
+		return nil, err
+	}
 	entities, err := s.repository.List(ctx, params)
 	if err != nil {
 		return nil, errors.Wrap(err, "service failed to list entities")
 	}
-	return entities, nil
+	return s.stripUnreadable(ctx, entities...)
 }
 
 func (s *service[TEntity]) Save(ctx context.Context, entity TEntity) (TEntity, error) {
@@ -113,6 +175,22 @@ func (s *service[TEntity]) Save(ctx context.Context, entity TEntity) (TEntity, e
 		}
 	}
 
+	if !s.canWriteAll(ctx) {
+		var stored []FieldValue
+		if !isCreate {
+			dbEntity, err := s.repository.Get(ctx, keyFieldVal)
+			if err != nil {
+				return zero, errors.Wrap(err, "service failed to get entity for field permission checks")
+			}
+			if stored, err = s.schema.Mapper().ToFieldValues(ctx, dbEntity); err != nil {
+				return zero, errors.Wrap(err, "failed to map stored entity to field values")
+			}
+		}
+		if entity, err = s.authorizeWrites(ctx, fieldValues, stored); err != nil {
+			return zero, err
+		}
+	}
+
 	if isCreate {
 		entity, err = createHook(ctx, entity)
 		if err != nil {
@@ -147,7 +225,13 @@ func (s *service[TEntity]) Save(ctx context.Context, entity TEntity) (TEntity, e
 
 	if err := composables.InTx(ctx, func(txCtx context.Context) error {
 		if err := s.validation(txCtx, entity); err != nil {
-			return errors.Wrap(err, "entity validation failed")
+			return fmt.Errorf("%w: %w", ErrValidation, err)
+		}
+		var before []FieldValue
+		if s.schema.History() && !isCreate {
+			if before, err = s.storedFieldValues(txCtx, keyFieldVal); err != nil {
+				return err
+			}
 		}
 		if isCreate {
 			savedEntity, err = s.repository.Create(txCtx, fieldValues)
@@ -160,15 +244,31 @@ func (s *service[TEntity]) Save(ctx context.Context, entity TEntity) (TEntity, e
 				return errors.Wrap(err, "failed to update entity in repository")
 			}
 		}
+		if s.schema.History() {
+			action := HistoryUpdated
+			if isCreate {
+				action = HistoryCreated
+			}
+			if err := s.recordChanges(txCtx, action, before, savedEntity); err != nil {
+				return err
+			}
+		}
+		event.SetResult(savedEntity)
+		if s.outbox != nil {
+			if err := s.outbox.Enqueue(txCtx, event); err != nil {
+				return errors.Wrap(err, "failed to enqueue event in outbox")
+			}
+		}
 		return nil
 	}); err != nil {
 		return zero, errors.Wrap(err, "transaction failed during save operation")
 	}
 
-	event.SetResult(savedEntity)
-	s.publisher.Publish(event)
+	if s.outbox == nil {
+		eventbus.Publish(ctx, s.publisher, event)
+	}
 
-	return savedEntity, nil
+	return s.stripUnreadableOne(ctx, savedEntity)
 }
 
 func (s *service[TEntity]) Delete(ctx context.Context, value FieldValue) (TEntity, error) {
@@ -190,82 +290,780 @@ func (s *service[TEntity]) Delete(ctx context.Context, value FieldValue) (TEntit
 			if err != nil {
 				return errors.Wrap(err, "failed to delete entity in hook")
 			}
+			if s.schema.History() {
+				if err := s.recordChanges(txCtx, HistoryDeleted, nil, entity); err != nil {
+					return err
+				}
+			}
+		}
+		deletedEvent.Data = deletedEntity
+		if s.outbox != nil {
+			if err := s.outbox.Enqueue(txCtx, deletedEvent); err != nil {
+				return errors.Wrap(err, "failed to enqueue event in outbox")
+			}
 		}
 		return nil
 	}); err != nil {
 		return zero, errors.Wrap(err, "transaction failed during delete operation")
 	}
 
-	deletedEvent.Data = deletedEntity
-	s.publisher.Publish(deletedEvent)
+	if s.outbox == nil {
+		eventbus.Publish(ctx, s.publisher, deletedEvent)
+	}
 
-	return deletedEntity, nil
+	return s.stripUnreadableOne(ctx, deletedEntity)
 }
 
-func (s *service[TEntity]) validation(ctx context.Context, entity TEntity) error {
-	var errs []error
+func (s *service[TEntity]) Restore(ctx context.Context, value FieldValue) (TEntity, error) {
+	var zero TEntity
+
+	restoredEvent, err := NewRestoredEvent[TEntity](ctx)
+	if err != nil {
+		return zero, errors.Wrap(err, "failed to create 'restored' event")
+	}
+
+	if err := composables.InTx(ctx, func(txCtx context.Context) error {
+		entity, err := s.repository.Restore(txCtx, value)
+		if err != nil {
+			return errors.Wrap(err, "failed to restore entity")
+		}
+		if s.schema.History() {
+			if err := s.recordChanges(txCtx, HistoryRestored, nil, entity); err != nil {
+				return err
+			}
+		}
+		restoredEvent.Data = entity
+		if s.outbox != nil {
+			if err := s.outbox.Enqueue(txCtx, restoredEvent); err != nil {
+				return errors.Wrap(err, "failed to enqueue event in outbox")
+			}
+		}
+		return nil
+	}); err != nil {
+		return zero, errors.Wrap(err, "transaction failed during restore operation")
+	}
+
+	if s.outbox == nil {
+		eventbus.Publish(ctx, s.publisher, restoredEvent)
+	}
+
+	return s.stripUnreadableOne(ctx, restoredEvent.Data)
+}
+
+func (s *service[TEntity]) Purge(ctx context.Context, before time.Time) (int64, error) {
+	purged, err := s.repository.Purge(ctx, before)
+	if err != nil {
+		return 0, errors.Wrap(err, "service failed to purge entities")
+	}
+	return purged, nil
+}
+
+func (s *service[TEntity]) BulkSave(ctx context.Context, entities []TEntity) (int64, error) {
+	if len(entities) == 0 {
+		return 0, nil
+	}
+
+	keys, existing, err := s.existing(ctx, entities)
+	if err != nil {
+		return 0, err
+	}
+	if entities, err = s.bulkAuthorizeWrites(ctx, entities, keys, existing); err != nil {
+		return 0, err
+	}
+
+	createHook := s.schema.Hooks().OnCreate()
+	updateHook := s.schema.Hooks().OnUpdate()
+
+	var created, updated []TEntity
+	for i, entity := range entities {
+		if _, ok := existing[keys[i].Value()]; ok {
+			entity, err = updateHook(ctx, entity)
+			if err != nil {
+				return 0, errors.Wrap(err, "service failed to update hook")
+			}
+			updated = append(updated, entity)
+		} else {
+			entity, err = createHook(ctx, entity)
+			if err != nil {
+				return 0, errors.Wrap(err, "service failed to create hook")
+			}
+			created = append(created, entity)
+		}
+	}
+
+	event, err := NewBulkSavedEvent(ctx, created, updated)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create 'bulk saved' event")
+	}
+
+	var saved int64
+	if err := composables.InTx(ctx, func(txCtx context.Context) error {
+		if err := s.bulkValidation(txCtx, append(created[:len(created):len(created)], updated...), existing); err != nil {
+			return fmt.Errorf("%w: %w", ErrValidation, err)
+		}
+		createRows, err := s.schema.Mapper().ToFieldValuesList(txCtx, created...)
+		if err != nil {
+			return errors.Wrap(err, "failed to map entities to field values for saving")
+		}
+		updateRows, err := s.schema.Mapper().ToFieldValuesList(txCtx, updated...)
+		if err != nil {
+			return errors.Wrap(err, "failed to map entities to field values for saving")
+		}
+		createdCount, err := s.repository.BulkCreate(txCtx, createRows)
+		if err != nil {
+			return errors.Wrap(err, "failed to create entities in repository")
+		}
+		updatedCount, err := s.repository.BulkUpdate(txCtx, updateRows)
+		if err != nil {
+			return errors.Wrap(err, "failed to update entities in repository")
+		}
+		saved = createdCount + updatedCount
+		if s.schema.History() {
+			if err := s.recordBulkChanges(txCtx, append(createRows[:len(createRows):len(createRows)], updateRows...), existing); err != nil {
+				return err
+			}
+		}
+		if s.outbox != nil {
+			if err := s.outbox.Enqueue(txCtx, event); err != nil {
+				return errors.Wrap(err, "failed to enqueue event in outbox")
+			}
+		}
+		return nil
+	}); err != nil {
+		return 0, errors.Wrap(err, "transaction failed during bulk save operation")
+	}
+
+	if s.outbox == nil {
+		eventbus.Publish(ctx, s.publisher, event)
+	}
+
+	return saved, nil
+}
+
+func (s *service[TEntity]) BulkUpsert(ctx context.Context, entities []TEntity) (int64, error) {
+	if len(entities) == 0 {
+		return 0, nil
+	}
+
+	keys, existing, err := s.existing(ctx, entities)
+	if err != nil {
+		return 0, err
+	}
+	if entities, err = s.bulkAuthorizeWrites(ctx, entities, keys, existing); err != nil {
+		return 0, err
+	}
+
+	createHook := s.schema.Hooks().OnCreate()
+	updateHook := s.schema.Hooks().OnUpdate()
+
+	upserted := make([]TEntity, len(entities))
+	for i, entity := range entities {
+		if keys[i].IsZero() {
+			return 0, errors.New("missing primary key in entity for upsert operation")
+		}
+		if _, ok := existing[keys[i].Value()]; ok {
+			entity, err = updateHook(ctx, entity)
+			if err != nil {
+				return 0, errors.Wrap(err, "service failed to update hook")
+			}
+		} else {
+			entity, err = createHook(ctx, entity)
+			if err != nil {
+				return 0, errors.Wrap(err, "service failed to create hook")
+			}
+		}
+		upserted[i] = entity
+	}
+
+	event, err := NewBulkUpsertedEvent(ctx, upserted)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create 'bulk upserted' event")
+	}
+
+	var saved int64
+	if err := composables.InTx(ctx, func(txCtx context.Context) error {
+		if err := s.bulkValidation(txCtx, upserted, existing); err != nil {
+			return fmt.Errorf("%w: %w", ErrValidation, err)
+		}
+		rows, err := s.schema.Mapper().ToFieldValuesList(txCtx, upserted...)
+		if err != nil {
+			return errors.Wrap(err, "failed to map entities to field values for saving")
+		}
+		saved, err = s.repository.BulkUpsert(txCtx, rows)
+		if err != nil {
+			return errors.Wrap(err, "failed to upsert entities in repository")
+		}
+		if s.schema.History() {
+			if err := s.recordBulkChanges(txCtx, rows, existing); err != nil {
+				return err
+			}
+		}
+		if s.outbox != nil {
+			if err := s.outbox.Enqueue(txCtx, event); err != nil {
+				return errors.Wrap(err, "failed to enqueue event in outbox")
+			}
+		}
+		return nil
+	}); err != nil {
+		return 0, errors.Wrap(err, "transaction failed during bulk upsert operation")
+	}
+
+	if s.outbox == nil {
+		eventbus.Publish(ctx, s.publisher, event)
+	}
+
+	return saved, nil
+}
+
+func (s *service[TEntity]) BulkDelete(ctx context.Context, values []FieldValue) ([]TEntity, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	deletedEvent, err := NewBulkDeletedEvent[TEntity](ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create 'bulk deleted' event")
+	}
+
+	deletedHook := s.schema.Hooks().OnDelete()
+
+	if err := composables.InTx(ctx, func(txCtx context.Context) error {
+		entities, err := s.repository.BulkDelete(txCtx, values)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete entities")
+		}
+		if s.schema.History() {
+			if err := s.recordDeletes(txCtx, entities); err != nil {
+				return err
+			}
+		}
+		for _, entity := range entities {
+			entity, err = deletedHook(ctx, entity)
+			if err != nil {
+				return errors.Wrap(err, "failed to delete entity in hook")
+			}
+			deletedEvent.Data = append(deletedEvent.Data, entity)
+		}
+		if s.outbox != nil {
+			if err := s.outbox.Enqueue(txCtx, deletedEvent); err != nil {
+				return errors.Wrap(err, "failed to enqueue event in outbox")
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "transaction failed during bulk delete operation")
+	}
+
+	if s.outbox == nil {
+		eventbus.Publish(ctx, s.publisher, deletedEvent)
+	}
+
+	return s.stripUnreadable(ctx, deletedEvent.Data...)
+}
+
+func (s *service[TEntity]) Aggregate(ctx context.Context, params *FindParams, query AggregateQuery) ([]AggregateRow, error) {
+	if err := s.authorizeParams(ctx, params); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(query.Aggregations)+len(query.GroupBy))
+	for _, a := range query.Aggregations {
+		if a.Field != "" {
+			names = append(names, a.Field)
+		}
+	}
+	for _, g := range query.GroupBy {
+		names = append(names, g.Field)
+	}
+	for _, name := range names {
+		if f, err := s.schema.Fields().Field(name); err == nil && !CanReadField(ctx, f) {
+			return nil, errors.Wrapf(composables.ErrForbidden, "can't aggregate field %q", name)
+		}
+	}
+
+	rows, err := s.repository.Aggregate(ctx, params, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "service failed to aggregate entities")
+	}
+	return rows, nil
+}
+
+func (s *service[TEntity]) History(ctx context.Context, key FieldValue) ([]HistoryEntry, error) {
+	if !s.schema.History() {
+		return nil, ErrNoHistory
+	}
+
+	entries, err := loadHistory(ctx, s.schema.Name(), key)
+	if err != nil {
+		return nil, errors.Wrap(err, "service failed to load history")
+	}
+
+	// Leave out the changes of unreadable fields and the updates left without changes
+	result := make([]HistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		changes := make([]FieldChange, 0, len(entry.Changes))
+		for _, change := range entry.Changes {
+			if f, err := s.schema.Fields().Field(change.Field); err == nil && CanReadField(ctx, f) {
+				changes = append(changes, change)
+			}
+		}
+		if entry.Action == HistoryUpdated && len(changes) == 0 {
+			continue
+		}
+		entry.Changes = changes
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+func (s *service[TEntity]) Revert(ctx context.Context, key FieldValue, id int64) (TEntity, error) {
+	var zero TEntity
+	if !s.schema.History() {
+		return zero, ErrNoHistory
+	}
 
+	entries, err := loadHistory(ctx, s.schema.Name(), key)
+	if err != nil {
+		return zero, errors.Wrap(err, "service failed to load history")
+	}
+	i := slices.IndexFunc(entries, func(e HistoryEntry) bool { return e.ID == id })
+	if i < 0 {
+		return zero, errors.Wrapf(ErrNotFound, "history entry %d of %s %s", id, s.schema.Name(), historyKey(key))
+	}
+
+	fieldValues, err := s.storedFieldValues(ctx, key)
+	if err != nil {
+		return zero, err
+	}
+	index := make(map[string]int, len(fieldValues))
+	for j, fv := range fieldValues {
+		index[fv.Field().Name()] = j
+	}
+	// Undo the newer entries from the newest on, keeping the readonly fields, which
+	// are maintained by hooks or the database, and the fields the user may not change
+	for _, entry := range entries[:i] {
+		for _, change := range entry.Changes {
+			j, ok := index[change.Field]
+			if !ok || fieldValues[j].Field().Readonly() || !CanWriteField(ctx, fieldValues[j].Field()) {
+				continue
+			}
+			fv, err := HistoryValue(fieldValues[j].Field(), change.Old)
+			if err != nil {
+				return zero, errors.Wrapf(err, "failed to revert field %q", change.Field)
+			}
+			fieldValues[j] = fv
+		}
+	}
+
+	entity, err := s.schema.Mapper().ToEntity(ctx, fieldValues)
+	if err != nil {
+		return zero, errors.Wrap(err, "failed to map reverted field values to entity")
+	}
+	return s.Save(ctx, entity)
+}
+
+// storedFieldValues returns the field values of the stored entity with key
+func (s *service[TEntity]) storedFieldValues(ctx context.Context, key FieldValue) ([]FieldValue, error) {
+	entity, err := s.repository.Get(ctx, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get stored entity")
+	}
 	fieldValues, err := s.schema.Mapper().ToFieldValues(ctx, entity)
 	if err != nil {
-		errs = append(errs, errors.Wrap(err, "failed to map entity to field values for validation"))
-		return errors.Join(errs...)
+		return nil, errors.Wrap(err, "failed to map stored entity to field values")
 	}
+	return fieldValues, nil
+}
 
-	var keyFieldVal FieldValue
-	readonlyFieldValues := make([]FieldValue, 0)
-	for _, fv := range fieldValues {
-		if fv.Field().Key() {
-			keyFieldVal = fv
+// recordChanges records the action on entity in its history, with the changes
+// from before, the field values of the entity before an update
+func (s *service[TEntity]) recordChanges(ctx context.Context, action HistoryAction, before []FieldValue, entity TEntity) error {
+	after, err := s.schema.Mapper().ToFieldValues(ctx, entity)
+	if err != nil {
+		return errors.Wrap(err, "failed to map entity to field values for history")
+	}
+	var changes []FieldChange
+	if action == HistoryCreated || action == HistoryUpdated {
+		changes = diffFieldValues(before, after)
+	}
+	return recordHistory(ctx, s.schema.Name(), historyRecord{key: keyValue(after), action: action, changes: changes})
+}
+
+// recordBulkChanges records the rows saved in bulk in their history, diffing them
+// with the stored entities in existing. Rows created without a key are skipped
+// since the key the database generated for them isn't known.
+func (s *service[TEntity]) recordBulkChanges(ctx context.Context, rows [][]FieldValue, existing map[any]TEntity) error {
+	records := make([]historyRecord, 0, len(rows))
+	for _, after := range rows {
+		key := keyValue(after)
+		if key == nil || key.IsZero() {
+			continue
 		}
+		record := historyRecord{key: key, action: HistoryCreated}
+		var before []FieldValue
+		if dbEntity, ok := existing[key.Value()]; ok {
+			var err error
+			if before, err = s.schema.Mapper().ToFieldValues(ctx, dbEntity); err != nil {
+				return errors.Wrap(err, "failed to map stored entity to field values for history")
+			}
+			record.action = HistoryUpdated
+		}
+		record.changes = diffFieldValues(before, after)
+		records = append(records, record)
+	}
+	return recordHistory(ctx, s.schema.Name(), records...)
+}
 
-		if fv.Field().Readonly() {
-			readonlyFieldValues = append(readonlyFieldValues, fv)
+// recordDeletes records the deletion of entities in their history
+func (s *service[TEntity]) recordDeletes(ctx context.Context, entities []TEntity) error {
+	rows, err := s.schema.Mapper().ToFieldValuesList(ctx, entities...)
+	if err != nil {
+		return errors.Wrap(err, "failed to map entities to field values for history")
+	}
+	records := make([]historyRecord, len(rows))
+	for i, fieldValues := range rows {
+		records[i] = historyRecord{key: keyValue(fieldValues), action: HistoryDeleted}
+	}
+	return recordHistory(ctx, s.schema.Name(), records...)
+}
+
+// existing returns the key values of entities and looks up the stored entities
+// with non-zero keys in a single query, mapping them by key value.
+func (s *service[TEntity]) existing(ctx context.Context, entities []TEntity) ([]FieldValue, map[any]TEntity, error) {
+	rows, err := s.schema.Mapper().ToFieldValuesList(ctx, entities...)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to map entities to field values for saving")
+	}
+	keys := make([]FieldValue, len(rows))
+	lookup := make([]FieldValue, 0, len(rows))
+	for i, fieldValues := range rows {
+		keys[i] = keyValue(fieldValues)
+		if keys[i] == nil {
+			return nil, nil, errors.New("missing primary key in entity for save operation")
 		}
-		for _, rule := range fv.Field().Rules() {
-			if ruleErr := rule(fv); ruleErr != nil {
-				errs = append(errs, errors.Wrap(ruleErr, fmt.Sprintf("validation rule failed for field %q", fv.Field().Name())))
+		if !keys[i].IsZero() {
+			lookup = append(lookup, keys[i])
+		}
+	}
+
+	stored, err := s.repository.GetByKeys(ctx, lookup)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "service failed to look up existing entities")
+	}
+	storedRows, err := s.schema.Mapper().ToFieldValuesList(ctx, stored...)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to map existing entities to field values")
+	}
+	existing := make(map[any]TEntity, len(stored))
+	for i, fieldValues := range storedRows {
+		if key := keyValue(fieldValues); key != nil {
+			existing[key.Value()] = stored[i]
+		}
+	}
+	return keys, existing, nil
+}
+
+// canWriteAll reports whether the user in ctx may write every field of the schema
+func (s *service[TEntity]) canWriteAll(ctx context.Context) bool {
+	for _, f := range s.schema.Fields().Fields() {
+		if !CanWriteField(ctx, f) {
+			return false
+		}
+	}
+	return true
+}
+
+// authorizeParams rejects filtering and sorting by fields the user in ctx may not read
+func (s *service[TEntity]) authorizeParams(ctx context.Context, params *FindParams) error {
+	if params == nil {
+		return nil
+	}
+	names := make([]string, 0, len(params.Filters)+len(params.SortBy.Fields))
+	for _, filter := range params.Filters {
+		names = append(names, filter.Column)
+	}
+	for _, sf := range params.SortBy.Fields {
+		names = append(names, sf.Field)
+	}
+	for _, name := range names {
+		if f, err := s.schema.Fields().Field(name); err == nil && !CanReadField(ctx, f) {
+			return errors.Wrapf(composables.ErrForbidden, "can't filter or sort by field %q", name)
+		}
+	}
+	return nil
+}
+
+// stripUnreadable clears the fields of entities the user in ctx may not read
+func (s *service[TEntity]) stripUnreadable(ctx context.Context, entities ...TEntity) ([]TEntity, error) {
+	unreadable := false
+	for _, f := range s.schema.Fields().Fields() {
+		if !CanReadField(ctx, f) {
+			unreadable = true
+			break
+		}
+	}
+	if !unreadable || len(entities) == 0 {
+		return entities, nil
+	}
+
+	rows, err := s.schema.Mapper().ToFieldValuesList(ctx, entities...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to map entities to field values for field permission checks")
+	}
+	for _, fieldValues := range rows {
+		for i, fv := range fieldValues {
+			if !CanReadField(ctx, fv.Field()) {
+				fieldValues[i] = fv.Field().Value(nil)
 			}
 		}
 	}
-	if keyFieldVal == nil {
-		errs = append(errs, errors.New("missing primary key for validation"))
-		return errors.Join(errs...)
+	stripped, err := s.schema.Mapper().ToEntities(ctx, rows...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to map field values to entities for field permission checks")
 	}
+	return stripped, nil
+}
+
+func (s *service[TEntity]) stripUnreadableOne(ctx context.Context, entity TEntity) (TEntity, error) {
+	stripped, err := s.stripUnreadable(ctx, entity)
+	if err != nil {
+		var zero TEntity
+		return zero, err
+	}
+	return stripped[0], nil
+}
 
-	if !keyFieldVal.IsZero() && len(readonlyFieldValues) > 0 {
+// authorizeWrites rejects fieldValues with composables.ErrForbidden when they change
+// a field the user in ctx may not write, and returns their entity otherwise. Stored
+// holds the field values of the entity being updated and is empty on creates, which
+// may only leave such fields zero or at their initial value. Fields the user may not
+// read come back cleared from the service, so cleared values keep the stored ones.
+func (s *service[TEntity]) authorizeWrites(ctx context.Context, fieldValues, stored []FieldValue) (TEntity, error) {
+	var zero TEntity
+
+	storedMap := make(map[string]FieldValue, len(stored))
+	for _, fv := range stored {
+		storedMap[fv.Field().Name()] = fv
+	}
+
+	values := make([]FieldValue, len(fieldValues))
+	for i, fv := range fieldValues {
+		values[i] = fv
+		f := fv.Field()
+		if CanWriteField(ctx, f) {
+			continue
+		}
+		if dbFv, ok := storedMap[f.Name()]; ok {
+			if sameValue(fv, dbFv) {
+				continue
+			}
+			if fv.IsZero() && !CanReadField(ctx, f) {
+				values[i] = dbFv
+				continue
+			}
+		} else if len(stored) == 0 && (fv.IsZero() || reflect.DeepEqual(fv.Value(), f.InitialValue(ctx))) {
+			continue
+		}
+		return zero, errors.Wrapf(composables.ErrForbidden, "can't write field %q", f.Name())
+	}
+
+	entity, err := s.schema.Mapper().ToEntity(ctx, values)
+	if err != nil {
+		return zero, errors.Wrap(err, "failed to map field values to entity for field permission checks")
+	}
+	return entity, nil
+}
+
+// bulkAuthorizeWrites runs authorizeWrites on entities, taking the stored entities
+// from existing by the values of keys.
+func (s *service[TEntity]) bulkAuthorizeWrites(ctx context.Context, entities []TEntity, keys []FieldValue, existing map[any]TEntity) ([]TEntity, error) {
+	if s.canWriteAll(ctx) {
+		return entities, nil
+	}
+
+	rows, err := s.schema.Mapper().ToFieldValuesList(ctx, entities...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to map entities to field values for field permission checks")
+	}
+	authorized := make([]TEntity, len(entities))
+	for i, fieldValues := range rows {
+		var stored []FieldValue
+		if dbEntity, ok := existing[keys[i].Value()]; ok {
+			if stored, err = s.schema.Mapper().ToFieldValues(ctx, dbEntity); err != nil {
+				return nil, errors.Wrapf(err, "failed to map stored entity %d to field values", i)
+			}
+		}
+		if authorized[i], err = s.authorizeWrites(ctx, fieldValues, stored); err != nil {
+			return nil, errors.Wrapf(err, "entity %d", i)
+		}
+	}
+	return authorized, nil
+}
+
+func (s *service[TEntity]) validation(ctx context.Context, entity TEntity) error {
+	fieldValues, err := s.schema.Mapper().ToFieldValues(ctx, entity)
+	if err != nil {
+		return errors.Join(errors.Wrap(err, "failed to map entity to field values for validation"))
+	}
+
+	var dbFieldValues []FieldValue
+	if keyFieldVal := keyValue(fieldValues); keyFieldVal != nil && !keyFieldVal.IsZero() && hasReadonly(fieldValues) {
 		// Check if entity actually exists before validating readonly fields
 		exists, err := s.repository.Exists(ctx, keyFieldVal)
 		if err != nil {
-			errs = append(errs, errors.Wrap(err, "failed to check if entity exists for readonly field validation"))
-			return errors.Join(errs...)
+			return errors.Join(errors.Wrap(err, "failed to check if entity exists for readonly field validation"))
 		}
 
 		// Only validate readonly fields if entity exists (update operation)
 		if exists {
 			dbEntity, err := s.repository.Get(ctx, keyFieldVal)
 			if err != nil {
-				errs = append(errs, errors.Wrap(err, "failed to retrieve existing entity for readonly field validation"))
-				return errors.Join(errs...)
+				return errors.Join(errors.Wrap(err, "failed to retrieve existing entity for readonly field validation"))
 			}
 
-			dbFieldValues, err := s.schema.Mapper().ToFieldValues(ctx, dbEntity)
+			dbFieldValues, err = s.schema.Mapper().ToFieldValues(ctx, dbEntity)
 			if err != nil {
-				errs = append(errs, errors.Wrap(err, "failed to map database entity to field values for readonly validation"))
-				return errors.Join(errs...)
+				return errors.Join(errors.Wrap(err, "failed to map database entity to field values for readonly validation"))
 			}
+		}
+	}
+
+	return errors.Join(
+		s.validate(entity, fieldValues, dbFieldValues),
+		s.validateRelations(ctx, [][]FieldValue{fieldValues}),
+		s.validateRules(ctx, fieldValues),
+	)
+}
 
-			dbReadonlyMap := make(map[string]FieldValue, len(readonlyFieldValues))
-			for _, dbFv := range dbFieldValues {
-				if dbFv.Field().Readonly() {
-					dbReadonlyMap[dbFv.Field().Name()] = dbFv
+// bulkValidation validates entities like validation, taking the stored entities
+// for the readonly checks from existing instead of querying them one by one.
+func (s *service[TEntity]) bulkValidation(ctx context.Context, entities []TEntity, existing map[any]TEntity) error {
+	var errs []error
+	rows := make([][]FieldValue, 0, len(entities))
+	for i, entity := range entities {
+		fieldValues, err := s.schema.Mapper().ToFieldValues(ctx, entity)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to map entity %d to field values for validation", i))
+			continue
+		}
+		rows = append(rows, fieldValues)
+
+		var dbFieldValues []FieldValue
+		if keyFieldVal := keyValue(fieldValues); keyFieldVal != nil && !keyFieldVal.IsZero() && hasReadonly(fieldValues) {
+			if dbEntity, ok := existing[keyFieldVal.Value()]; ok {
+				dbFieldValues, err = s.schema.Mapper().ToFieldValues(ctx, dbEntity)
+				if err != nil {
+					errs = append(errs, errors.Wrapf(err, "failed to map database entity %d to field values for readonly validation", i))
+					continue
 				}
 			}
+		}
+
+		if err := errors.Join(
+			s.validate(entity, fieldValues, dbFieldValues),
+			s.validateRules(ctx, fieldValues),
+		); err != nil {
+			errs = append(errs, errors.Wrapf(err, "validation failed for entity %d", i))
+		}
+	}
+	if err := s.validateRelations(ctx, rows); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// validateRelations checks that the belongs-to and many-to-many values of rows
+// reference existing records, looking up the keys of each relation in a single query.
+func (s *service[TEntity]) validateRelations(ctx context.Context, rows [][]FieldValue) error {
+	var errs []error
+	for _, f := range s.schema.Fields().Fields() {
+		rf, ok := f.(RelationField)
+		if !ok || rf.Relation() == HasMany {
+			continue
+		}
+
+		var keys []any
+		for _, fieldValues := range rows {
+			for _, fv := range fieldValues {
+				if fv.Field().Name() != rf.Name() || fv.IsZero() {
+					continue
+				}
+				if !rf.Virtual() {
+					keys = append(keys, fv.Value())
+					continue
+				}
+				records, err := fv.AsRelated()
+				if err != nil {
+					return err
+				}
+				keys = append(keys, RelatedKeys(records)...)
+			}
+		}
+
+		missing, err := rf.Missing(ctx, keys)
+		if err != nil {
+			return errors.Wrapf(err, "failed to check references of field %q", rf.Name())
+		}
+		if len(missing) > 0 {
+			errs = append(errs, errors.Wrapf(ErrMissingRelated, "field %q references missing %s %v", rf.Name(), rf.Config().Target.Name(), missing))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateRules runs the rules of the schema on the field values of an entity.
+// Rules reporting invalid fields don't stop the others from running.
+func (s *service[TEntity]) validateRules(ctx context.Context, fieldValues []FieldValue) error {
+	values := EntityValues{
+		schema:     s.schema.Name(),
+		softDelete: s.schema.SoftDelete(),
+		values:     fieldValues,
+	}
+	var errs []error
+	for _, rule := range s.schema.Rules() {
+		if err := rule(ctx, values); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validate runs the field rules and schema validators on entity and checks that
+// its readonly fields match dbFieldValues, the field values of the stored entity
+// when it is being updated.
+func (s *service[TEntity]) validate(entity TEntity, fieldValues, dbFieldValues []FieldValue) error {
+	var errs []error
+
+	var keyFieldVal FieldValue
+	for _, fv := range fieldValues {
+		if fv.Field().Key() {
+			keyFieldVal = fv
+		}
+		for _, rule := range fv.Field().Rules() {
+			if ruleErr := rule(fv); ruleErr != nil {
+				errs = append(errs, errors.Wrap(NewFieldValidationError(fv.Field(), ruleErr), fmt.Sprintf("validation rule failed for field %q", fv.Field().Name())))
+			}
+		}
+	}
+	if keyFieldVal == nil {
+		errs = append(errs, errors.New("missing primary key for validation"))
+		return errors.Join(errs...)
+	}
+
+	if len(dbFieldValues) > 0 {
+		dbReadonlyMap := make(map[string]FieldValue, len(dbFieldValues))
+		for _, dbFv := range dbFieldValues {
+			if dbFv.Field().Readonly() {
+				dbReadonlyMap[dbFv.Field().Name()] = dbFv
+			}
+		}
 
-			for _, readonlyFv := range readonlyFieldValues {
-				if dbFv, ok := dbReadonlyMap[readonlyFv.Field().Name()]; ok {
-					if readonlyFv.Value() != dbFv.Value() {
-						errs = append(errs, errors.Errorf("readonly field %q has been modified", readonlyFv.Field().Name()))
-					}
+		for _, readonlyFv := range fieldValues {
+			if !readonlyFv.Field().Readonly() {
+				continue
+			}
+			if dbFv, ok := dbReadonlyMap[readonlyFv.Field().Name()]; ok {
+				if !sameValue(readonlyFv, dbFv) {
+					errs = append(errs, errors.Errorf("readonly field %q has been modified", readonlyFv.Field().Name()))
 				}
 			}
 		}
@@ -283,3 +1081,38 @@ func (s *service[TEntity]) validation(ctx context.Context, entity TEntity) error
 
 	return errors.Join(errs...)
 }
+
+func keyValue(fieldValues []FieldValue) FieldValue {
+	for _, fv := range fieldValues {
+		if fv.Field().Key() {
+			return fv
+		}
+	}
+	return nil
+}
+
+// sameValue reports whether a and b hold the same value. Times are compared as
+// instants and related records by their keys.
+func sameValue(a, b FieldValue) bool {
+	if a.IsZero() && b.IsZero() {
+		return true
+	}
+	switch av := a.Value().(type) {
+	case time.Time:
+		bv, ok := b.Value().(time.Time)
+		return ok && av.Equal(bv)
+	case []RelatedRecord:
+		bv, ok := b.Value().([]RelatedRecord)
+		return ok && reflect.DeepEqual(RelatedKeys(av), RelatedKeys(bv))
+	}
+	return reflect.DeepEqual(a.Value(), b.Value())
+}
+
+func hasReadonly(fieldValues []FieldValue) bool {
+	for _, fv := range fieldValues {
+		if fv.Field().Readonly() {
+			return true
+		}
+	}
+	return false
+}
@@ -108,4 +108,101 @@ func TestReportService_CRUD(t *testing.T) {
 		_, err = service.Get(ctx, key)
 		require.Error(t, err)
 	})
+	t.Run("BulkSave", func(t *testing.T) {
+		existing, err := service.Save(ctx, NewReport(CreateMultiLangTitle("Bulk Existing"), WithAuthor("BulkSaver")))
+		require.NoError(t, err)
+
+		saved, err := service.BulkSave(ctx, []Report{
+			existing.SetSummary("Bulk Updated"),
+			NewReport(CreateMultiLangTitle("Bulk 1"), WithAuthor("BulkSaver")),
+			NewReport(CreateMultiLangTitle("Bulk 2"), WithAuthor("BulkSaver")),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), saved)
+
+		list, err := service.List(ctx, &crud.FindParams{
+			Filters: []crud.Filter{{Column: "author", Filter: repo.Eq("BulkSaver")}},
+		})
+		require.NoError(t, err)
+		require.Len(t, list, 3)
+
+		got, err := service.Get(ctx, fixture.schema.Fields().KeyField().Value(existing.ID()))
+		require.NoError(t, err)
+		assert.Equal(t, "Bulk Updated", got.Summary())
+	})
+
+	t.Run("BulkUpsert", func(t *testing.T) {
+		existing, err := service.Save(ctx, NewReport(CreateMultiLangTitle("Upsert"), WithAuthor("Upserter")))
+		require.NoError(t, err)
+
+		upserted, err := service.BulkUpsert(ctx, []Report{existing.SetSummary("Upserted")})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), upserted)
+
+		got, err := service.Get(ctx, fixture.schema.Fields().KeyField().Value(existing.ID()))
+		require.NoError(t, err)
+		assert.Equal(t, "Upserted", got.Summary())
+	})
+
+	t.Run("BulkDelete", func(t *testing.T) {
+		first, err := service.Save(ctx, NewReport(CreateMultiLangTitle("Bulk Delete 1"), WithAuthor("BulkDel")))
+		require.NoError(t, err)
+		second, err := service.Save(ctx, NewReport(CreateMultiLangTitle("Bulk Delete 2"), WithAuthor("BulkDel")))
+		require.NoError(t, err)
+
+		keyField := fixture.schema.Fields().KeyField()
+		deleted, err := service.BulkDelete(ctx, []crud.FieldValue{keyField.Value(first.ID()), keyField.Value(second.ID())})
+		require.NoError(t, err)
+		assert.Len(t, deleted, 2)
+
+		count, err := service.Count(ctx, &crud.FindParams{
+			Filters: []crud.Filter{{Column: "author", Filter: repo.Eq("BulkDel")}},
+		})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
+
+func TestService_SchemaRules(t *testing.T) {
+	fixture := setupTest(t)
+	ctx := fixture.ctx
+
+	fields := fixture.schema.Fields()
+	schema := crud.NewSchema("reports", fields, NewReportMapper(fields),
+		crud.WithSchemaRule[Report](crud.UniqueRule("summary", "author")),
+		crud.WithSchemaRule[Report](crud.RequiredIfRule("summary", "author", "Reviewer")),
+	)
+	service := crud.DefaultService(schema, crud.DefaultRepository[Report](schema), fixture.publisher)
+
+	created, err := service.Save(ctx, NewReport(CreateMultiLangTitle("Unique"), WithAuthor("RulesAuthor"), WithSummary("Taken")))
+	require.NoError(t, err)
+
+	t.Run("rejects duplicates within the scope", func(t *testing.T) {
+		_, err := service.Save(ctx, NewReport(CreateMultiLangTitle("Duplicate"), WithAuthor("RulesAuthor"), WithSummary("Taken")))
+		require.ErrorIs(t, err, crud.ErrValidation)
+
+		errs := crud.ValidationErrorsOf(err)
+		require.Contains(t, errs, "summary")
+		assert.Equal(t, "VALIDATION_UNIQUE", errs["summary"].Code)
+		assert.Equal(t, "reports.Fields.summary", errs["summary"].TemplateData["Field"])
+	})
+
+	t.Run("allows duplicates in other scopes", func(t *testing.T) {
+		_, err := service.Save(ctx, NewReport(CreateMultiLangTitle("Other"), WithAuthor("OtherRulesAuthor"), WithSummary("Taken")))
+		require.NoError(t, err)
+	})
+
+	t.Run("ignores the entity itself on update", func(t *testing.T) {
+		_, err := service.Save(ctx, created.SetSummary("Taken"))
+		require.NoError(t, err)
+	})
+
+	t.Run("reports conditionally required fields", func(t *testing.T) {
+		_, err := service.Save(ctx, NewReport(CreateMultiLangTitle("Review"), WithAuthor("Reviewer")))
+		require.ErrorIs(t, err, crud.ErrValidation)
+
+		errs := crud.ValidationErrorsOf(err)
+		require.Contains(t, errs, "summary")
+		assert.Equal(t, "VALIDATION_REQUIRED", errs["summary"].Code)
+	})
 }
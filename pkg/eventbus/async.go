@@ -0,0 +1,433 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+)
+
+var ErrEventBusClosed = errors.New("eventbus: event bus is closed")
+
+// RetryPolicy controls how an asynchronous delivery is retried after a handler fails.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of delivery attempts, including the first one.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+		Multiplier:     2,
+	}
+}
+
+// Backoff returns the delay before the given retry attempt (1-based).
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		backoff *= p.Multiplier
+		if p.MaxBackoff > 0 && backoff >= float64(p.MaxBackoff) {
+			return p.MaxBackoff
+		}
+	}
+	return time.Duration(backoff)
+}
+
+type SubscribeOption func(s *Subscriber)
+
+// WithRetryPolicy overrides the default retry policy of a subscription.
+func WithRetryPolicy(policy RetryPolicy) SubscribeOption {
+	return func(s *Subscriber) {
+		s.Retry = policy
+	}
+}
+
+// WithSubscriberName sets a stable subscriber name used to route dead letter replays.
+// Replays are matched by name, so it must be the same on every node.
+func WithSubscriberName(name string) SubscribeOption {
+	return func(s *Subscriber) {
+		s.Name = name
+	}
+}
+
+// AsyncEventBus delivers events on a bounded worker pool, retrying failed
+// handlers and recording deliveries that exhaust their retries as dead letters.
+type AsyncEventBus interface {
+	TypedEventBus
+	SubscribeWithOptions(handler interface{}, opts ...SubscribeOption)
+	// Replay redelivers a dead letter to the subscriber that failed it.
+	Replay(ctx context.Context, id uuid.UUID) error
+	// Shutdown stops accepting events and waits for queued deliveries to finish.
+	Shutdown(ctx context.Context) error
+}
+
+type AsyncOptions struct {
+	Logger *logrus.Logger
+	// Workers is the number of concurrent deliveries. Defaults to 4.
+	Workers int
+	// QueueSize bounds pending deliveries; Publish blocks when the queue is full. Defaults to 1024.
+	QueueSize int
+	// Retry is the policy for subscriptions that do not set their own.
+	Retry RetryPolicy
+	// DeadLetters and Registry are optional; without them failed deliveries are only logged.
+	DeadLetters DeadLetterStore
+	Registry    *Registry
+	// ReplayInterval is how often replay requests from the dead letter store are polled. Defaults to 5 seconds.
+	ReplayInterval time.Duration
+}
+
+type delivery struct {
+	ctx          context.Context
+	subscriber   Subscriber
+	args         []interface{}
+	attempt      int
+	deadLetterID uuid.UUID
+}
+
+type asyncPublisher struct {
+	opts        AsyncOptions
+	mu          sync.RWMutex
+	subscribers []Subscriber
+	typed       map[reflect.Type][]Subscriber
+	queue       chan *delivery
+	pending     sync.WaitGroup
+	workers     sync.WaitGroup
+	closeMu     sync.RWMutex
+	closing     bool
+	closed      chan struct{}
+}
+
+func NewAsyncEventPublisher(opts AsyncOptions) AsyncEventBus {
+	if opts.Workers <= 0 {
+		opts.Workers = 4
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 1024
+	}
+	if opts.Retry.MaxAttempts <= 0 {
+		opts.Retry = DefaultRetryPolicy()
+	}
+	if opts.ReplayInterval <= 0 {
+		opts.ReplayInterval = 5 * time.Second
+	}
+	p := &asyncPublisher{
+		opts:   opts,
+		queue:  make(chan *delivery, opts.QueueSize),
+		closed: make(chan struct{}),
+	}
+	for i := 0; i < opts.Workers; i++ {
+		p.workers.Add(1)
+		go p.work()
+	}
+	if opts.DeadLetters != nil && opts.Registry != nil {
+		p.workers.Add(1)
+		go p.pollReplays()
+	}
+	return p
+}
+
+// detach keeps the values of a publisher's context (tenant, user, ...) for a
+// delivery that outlives it. The transaction is dropped since it is finished by
+// the time the handler runs, so handlers fall back to the pool.
+func detach(ctx context.Context) context.Context {
+	return context.WithValue(context.WithoutCancel(ctx), constants.TxKey, nil)
+}
+
+func (p *asyncPublisher) Publish(args ...interface{}) {
+	p.publish(context.Background(), args)
+}
+
+func (p *asyncPublisher) PublishContext(ctx context.Context, event interface{}) {
+	p.publish(detach(ctx), []interface{}{event})
+}
+
+func (p *asyncPublisher) publish(ctx context.Context, args []interface{}) {
+	p.mu.RLock()
+	var subscribers []Subscriber
+	if len(args) == 1 && args[0] != nil {
+		subscribers = append(subscribers, p.typed[reflect.TypeOf(args[0])]...)
+	}
+	for _, s := range p.subscribers {
+		if MatchSignature(s.Handler, args) {
+			subscribers = append(subscribers, s)
+		}
+	}
+	p.mu.RUnlock()
+
+	if len(subscribers) == 0 {
+		p.opts.Logger.Warnf("eventbus.Publish: no matching subscribers for event with args: %v", args)
+		return
+	}
+	for _, s := range subscribers {
+		if err := p.enqueue(&delivery{ctx: ctx, subscriber: s, args: args, attempt: 1}); err != nil {
+			p.opts.Logger.Errorf("eventbus: dropped event for %s: %v", s.Name, err)
+		}
+	}
+}
+
+func (p *asyncPublisher) Subscribe(handler interface{}) {
+	p.SubscribeWithOptions(handler)
+}
+
+func (p *asyncPublisher) SubscribeWithOptions(handler interface{}, opts ...SubscribeOption) {
+	if reflect.TypeOf(handler).Kind() != reflect.Func {
+		panic("handler must be a function")
+	}
+	s := Subscriber{
+		Handler: handler,
+		Name:    HandlerName(handler),
+		Retry:   p.opts.Retry,
+	}
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.subscribers = append(p.subscribers, s)
+}
+
+func (p *asyncPublisher) SubscribeType(eventType reflect.Type, handler HandlerFunc, opts ...SubscribeOption) func() {
+	s := newTypedSubscriber(handler, p.opts.Retry, opts)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.typed == nil {
+		p.typed = make(map[reflect.Type][]Subscriber)
+	}
+	p.typed[eventType] = insertByPriority(p.typed[eventType], s)
+	return func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.typed[eventType] = removeSubscriber(p.typed[eventType], s.id)
+	}
+}
+
+func (p *asyncPublisher) Unsubscribe(handler interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, s := range p.subscribers {
+		if reflect.ValueOf(s.Handler).Pointer() == reflect.ValueOf(handler).Pointer() {
+			p.subscribers = append(p.subscribers[:i], p.subscribers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (p *asyncPublisher) Clear() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.subscribers = []Subscriber{}
+	p.typed = nil
+}
+
+func (p *asyncPublisher) SubscribersCount() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	count := len(p.subscribers)
+	for _, subscribers := range p.typed {
+		count += len(subscribers)
+	}
+	return count
+}
+
+func (p *asyncPublisher) Replay(ctx context.Context, id uuid.UUID) error {
+	if p.opts.DeadLetters == nil || p.opts.Registry == nil {
+		return errors.New("eventbus: dead letter store is not configured")
+	}
+	dl, err := p.opts.DeadLetters.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	return p.replay(dl)
+}
+
+func (p *asyncPublisher) Shutdown(ctx context.Context) error {
+	p.closeMu.Lock()
+	if !p.closing {
+		p.closing = true
+		close(p.closed)
+	}
+	p.closeMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.pending.Wait()
+		close(p.queue)
+		p.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *asyncPublisher) enqueue(d *delivery) error {
+	p.closeMu.RLock()
+	if p.closing {
+		p.closeMu.RUnlock()
+		return ErrEventBusClosed
+	}
+	p.pending.Add(1)
+	p.closeMu.RUnlock()
+
+	select {
+	case p.queue <- d:
+		return nil
+	case <-p.closed:
+		p.pending.Done()
+		return ErrEventBusClosed
+	}
+}
+
+func (p *asyncPublisher) work() {
+	defer p.workers.Done()
+	for d := range p.queue {
+		p.deliver(d)
+		p.pending.Done()
+	}
+}
+
+func (p *asyncPublisher) deliver(d *delivery) {
+	err := d.subscriber.call(d.ctx, d.args)
+	if err == nil {
+		if d.deadLetterID != uuid.Nil {
+			if err := p.opts.DeadLetters.Delete(context.Background(), d.deadLetterID); err != nil {
+				p.opts.Logger.WithError(err).Errorf("eventbus: failed to remove replayed dead letter %s", d.deadLetterID)
+			}
+		}
+		return
+	}
+
+	logger := p.opts.Logger.WithError(err).WithFields(logrus.Fields{
+		"subscriber": d.subscriber.Name,
+		"attempt":    d.attempt,
+	})
+	if d.attempt < d.subscriber.Retry.MaxAttempts {
+		logger.Warn("eventbus: handler failed, retrying")
+		next := &delivery{
+			ctx:          d.ctx,
+			subscriber:   d.subscriber,
+			args:         d.args,
+			attempt:      d.attempt + 1,
+			deadLetterID: d.deadLetterID,
+		}
+		// The retry is counted as pending while it waits, so Shutdown lets it run
+		// even though new events are no longer accepted
+		p.pending.Add(1)
+		time.AfterFunc(d.subscriber.Retry.Backoff(d.attempt), func() {
+			p.queue <- next
+		})
+		return
+	}
+
+	logger.Error("eventbus: handler failed, giving up")
+	p.deadLetter(d, err)
+}
+
+func (p *asyncPublisher) deadLetter(d *delivery, cause error) {
+	if p.opts.DeadLetters == nil || p.opts.Registry == nil {
+		return
+	}
+	encoded, err := encodeArgs(p.opts.Registry, d.args)
+	if err != nil {
+		p.opts.Logger.WithError(err).Errorf("eventbus: cannot record dead letter for %s", d.subscriber.Name)
+		return
+	}
+	dl := &DeadLetter{
+		ID:         d.deadLetterID,
+		Subscriber: d.subscriber.Name,
+		Args:       encoded,
+		Error:      cause.Error(),
+		Attempts:   d.attempt,
+		Status:     DeadLetterFailed,
+	}
+	if err := p.opts.DeadLetters.Save(context.Background(), dl); err != nil {
+		p.opts.Logger.WithError(err).Errorf("eventbus: failed to save dead letter for %s", d.subscriber.Name)
+	}
+}
+
+func (p *asyncPublisher) replay(dl *DeadLetter) error {
+	var (
+		subscriber Subscriber
+		found      bool
+	)
+	p.mu.RLock()
+	for _, s := range p.subscribers {
+		if s.Name == dl.Subscriber {
+			subscriber, found = s, true
+			break
+		}
+	}
+	for _, subscribers := range p.typed {
+		for _, s := range subscribers {
+			if !found && s.Name == dl.Subscriber {
+				subscriber, found = s, true
+			}
+		}
+	}
+	p.mu.RUnlock()
+	if !found {
+		return fmt.Errorf("eventbus: subscriber %q is not registered", dl.Subscriber)
+	}
+
+	args, err := decodeArgs(p.opts.Registry, dl.Args)
+	if err != nil {
+		return err
+	}
+	return p.enqueue(&delivery{
+		ctx:          context.Background(),
+		subscriber:   subscriber,
+		args:         args,
+		attempt:      1,
+		deadLetterID: dl.ID,
+	})
+}
+
+func (p *asyncPublisher) pollReplays() {
+	defer p.workers.Done()
+
+	ticker := time.NewTicker(p.opts.ReplayInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.closed:
+			return
+		case <-ticker.C:
+		}
+
+		deadLetters, err := p.opts.DeadLetters.ClaimReplays(context.Background(), p.opts.Workers)
+		if err != nil {
+			p.opts.Logger.WithError(err).Error("eventbus: failed to claim dead letter replays")
+			continue
+		}
+		for _, dl := range deadLetters {
+			if err := p.replay(dl); err != nil {
+				dl.Status = DeadLetterFailed
+				dl.Error = err.Error()
+				dl.Attempts = 0
+				if err := p.opts.DeadLetters.Save(context.Background(), dl); err != nil {
+					p.opts.Logger.WithError(err).Errorf("eventbus: failed to release dead letter %s", dl.ID)
+				}
+			}
+		}
+	}
+}
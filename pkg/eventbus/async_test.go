@@ -0,0 +1,182 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/pkg/logging"
+)
+
+type memoryDeadLetterStore struct {
+	mu    sync.Mutex
+	items map[uuid.UUID]*DeadLetter
+}
+
+func newMemoryDeadLetterStore() *memoryDeadLetterStore {
+	return &memoryDeadLetterStore{items: make(map[uuid.UUID]*DeadLetter)}
+}
+
+func (s *memoryDeadLetterStore) Save(_ context.Context, dl *DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if dl.ID == uuid.Nil {
+		dl.ID = uuid.New()
+	}
+	s.items[dl.ID] = dl
+	return nil
+}
+
+func (s *memoryDeadLetterStore) Get(_ context.Context, id uuid.UUID) (*DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dl, ok := s.items[id]
+	if !ok {
+		return nil, ErrDeadLetterNotFound
+	}
+	return dl, nil
+}
+
+func (s *memoryDeadLetterStore) List(_ context.Context, _ *DeadLetterFindParams) ([]*DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := make([]*DeadLetter, 0, len(s.items))
+	for _, dl := range s.items {
+		result = append(result, dl)
+	}
+	return result, nil
+}
+
+func (s *memoryDeadLetterStore) Count(_ context.Context, _ *DeadLetterFindParams) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return int64(len(s.items)), nil
+}
+
+func (s *memoryDeadLetterStore) Delete(_ context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.items, id)
+	return nil
+}
+
+func (s *memoryDeadLetterStore) RequestReplay(_ context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items[id].Status = DeadLetterReplayRequested
+	return nil
+}
+
+func (s *memoryDeadLetterStore) ClaimReplays(_ context.Context, _ int) ([]*DeadLetter, error) {
+	return nil, nil
+}
+
+func fastRetry(attempts int) RetryPolicy {
+	return RetryPolicy{MaxAttempts: attempts, InitialBackoff: time.Millisecond, Multiplier: 1}
+}
+
+func TestAsyncPublisher_DeliversEvents(t *testing.T) {
+	publisher := NewAsyncEventPublisher(AsyncOptions{Logger: logging.ConsoleLogger(logrus.WarnLevel)})
+	var calls atomic.Int32
+	publisher.Subscribe(func(e *args) {
+		calls.Add(1)
+	})
+
+	for i := 0; i < 10; i++ {
+		publisher.Publish(&args{data: i})
+	}
+	require.NoError(t, publisher.Shutdown(context.Background()))
+	assert.Equal(t, int32(10), calls.Load())
+}
+
+func TestAsyncPublisher_RetriesFailedHandler(t *testing.T) {
+	publisher := NewAsyncEventPublisher(AsyncOptions{
+		Logger: logging.ConsoleLogger(logrus.FatalLevel),
+		Retry:  fastRetry(3),
+	})
+	var calls atomic.Int32
+	publisher.Subscribe(func(e *args) error {
+		if calls.Add(1) < 3 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+
+	publisher.Publish(&args{data: "test"})
+	require.NoError(t, publisher.Shutdown(context.Background()))
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestAsyncPublisher_DeadLetterAndReplay(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("test.registry_event", &registryTestEvent{})
+	store := newMemoryDeadLetterStore()
+	publisher := NewAsyncEventPublisher(AsyncOptions{
+		Logger:      logging.ConsoleLogger(logrus.FatalLevel),
+		Retry:       fastRetry(2),
+		DeadLetters: store,
+		Registry:    registry,
+	})
+
+	var (
+		calls   atomic.Int32
+		healthy atomic.Bool
+	)
+	publisher.SubscribeWithOptions(func(e *registryTestEvent) {
+		calls.Add(1)
+		if !healthy.Load() {
+			panic("handler is broken")
+		}
+	}, WithSubscriberName("test.subscriber"))
+
+	publisher.Publish(&registryTestEvent{ID: 1, Name: "test"})
+	require.Eventually(t, func() bool {
+		count, _ := store.Count(context.Background(), nil)
+		return count == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(2), calls.Load())
+
+	deadLetters, err := store.List(context.Background(), nil)
+	require.NoError(t, err)
+	dl := deadLetters[0]
+	assert.Equal(t, "test.subscriber", dl.Subscriber)
+	assert.Equal(t, 2, dl.Attempts)
+	assert.Contains(t, dl.Error, "handler is broken")
+
+	healthy.Store(true)
+	require.NoError(t, publisher.Replay(context.Background(), dl.ID))
+	require.NoError(t, publisher.Shutdown(context.Background()))
+
+	assert.Equal(t, int32(3), calls.Load())
+	count, err := store.Count(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestAsyncPublisher_PublishAfterShutdown(t *testing.T) {
+	publisher := NewAsyncEventPublisher(AsyncOptions{Logger: logging.ConsoleLogger(logrus.FatalLevel)})
+	var calls atomic.Int32
+	publisher.Subscribe(func(e *args) {
+		calls.Add(1)
+	})
+	require.NoError(t, publisher.Shutdown(context.Background()))
+
+	publisher.Publish(&args{data: "test"})
+	assert.Equal(t, int32(0), calls.Load())
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Multiplier: 2}
+	assert.Equal(t, time.Second, policy.Backoff(1))
+	assert.Equal(t, 2*time.Second, policy.Backoff(2))
+	assert.Equal(t, 4*time.Second, policy.Backoff(3))
+	assert.Equal(t, 5*time.Second, policy.Backoff(4))
+}
@@ -0,0 +1,24 @@
+package eventbus
+
+import (
+	"context"
+
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+// InvalidateCache evicts the cache entries tagged with tags(event) whenever an event of type T is published.
+// Failed evictions are returned to the bus, so they are retried like any failed subscriber.
+//
+// Example usage:
+//
+//	eventbus.InvalidateCache(app.EventPublisher(), cache, func(e *user.UpdatedEvent) []string {
+//		return []string{repo.CacheTag("user", e.Result.ID()), repo.CacheTag("tenant", e.Result.TenantID())}
+//	})
+func InvalidateCache[T any](bus EventBus, cache repo.TaggedCache, tags func(event T) []string, opts ...SubscribeOption) (unsubscribe func()) {
+	return Subscribe(bus, func(_ context.Context, event T) error {
+		if t := tags(event); len(t) > 0 {
+			return cache.InvalidateTags(t...)
+		}
+		return nil
+	}, opts...)
+}
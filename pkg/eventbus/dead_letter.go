@@ -0,0 +1,86 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+type DeadLetterStatus string
+
+const (
+	// DeadLetterFailed marks a delivery that exhausted its retries.
+	DeadLetterFailed DeadLetterStatus = "failed"
+	// DeadLetterReplayRequested marks a delivery an admin asked to retry.
+	DeadLetterReplayRequested DeadLetterStatus = "replay_requested"
+	// DeadLetterReplaying marks a delivery claimed by a node for replay.
+	DeadLetterReplaying DeadLetterStatus = "replaying"
+)
+
+// EncodedEvent is a single serialized Publish argument.
+type EncodedEvent struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// DeadLetter is an event delivery that failed for a single subscriber.
+type DeadLetter struct {
+	ID         uuid.UUID
+	Subscriber string
+	Args       []EncodedEvent
+	Error      string
+	Attempts   int
+	Status     DeadLetterStatus
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type DeadLetterFindParams struct {
+	Limit      int
+	Offset     int
+	Subscriber string
+	Status     DeadLetterStatus
+}
+
+// DeadLetterStore persists failed deliveries so they can be inspected and replayed.
+type DeadLetterStore interface {
+	// Save inserts a dead letter or updates the existing one with the same ID.
+	Save(ctx context.Context, dl *DeadLetter) error
+	Get(ctx context.Context, id uuid.UUID) (*DeadLetter, error)
+	List(ctx context.Context, params *DeadLetterFindParams) ([]*DeadLetter, error)
+	Count(ctx context.Context, params *DeadLetterFindParams) (int64, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// RequestReplay flags a dead letter to be redelivered by any running node.
+	RequestReplay(ctx context.Context, id uuid.UUID) error
+	// ClaimReplays atomically moves up to limit replay requests to DeadLetterReplaying and returns them.
+	ClaimReplays(ctx context.Context, limit int) ([]*DeadLetter, error)
+}
+
+func encodeArgs(registry *Registry, args []interface{}) ([]EncodedEvent, error) {
+	encoded := make([]EncodedEvent, len(args))
+	for i, arg := range args {
+		name, payload, err := registry.Encode(arg)
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = EncodedEvent{Type: name, Payload: payload}
+	}
+	return encoded, nil
+}
+
+func decodeArgs(registry *Registry, encoded []EncodedEvent) ([]interface{}, error) {
+	args := make([]interface{}, len(encoded))
+	for i, e := range encoded {
+		arg, err := registry.Decode(e.Type, e.Payload)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = arg
+	}
+	return args, nil
+}
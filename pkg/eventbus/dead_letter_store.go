@@ -0,0 +1,178 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+const (
+	selectDeadLettersQuery = `
+		SELECT id, subscriber, args, error, attempts, status, created_at, updated_at
+		FROM eventbus_dead_letters`
+
+	countDeadLettersQuery = `SELECT COUNT(*) FROM eventbus_dead_letters`
+
+	upsertDeadLetterQuery = `
+		INSERT INTO eventbus_dead_letters (id, subscriber, args, error, attempts, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO UPDATE
+		SET error = EXCLUDED.error,
+			attempts = eventbus_dead_letters.attempts + EXCLUDED.attempts,
+			status = EXCLUDED.status,
+			updated_at = NOW()`
+
+	deleteDeadLetterQuery = `DELETE FROM eventbus_dead_letters WHERE id = $1`
+
+	requestDeadLetterReplayQuery = `
+		UPDATE eventbus_dead_letters
+		SET status = $2, updated_at = NOW()
+		WHERE id = $1 AND status = $3`
+
+	claimDeadLetterReplaysQuery = `
+		UPDATE eventbus_dead_letters
+		SET status = $1, updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM eventbus_dead_letters
+			WHERE status = $2
+			ORDER BY updated_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, subscriber, args, error, attempts, status, created_at, updated_at`
+)
+
+func NewPgDeadLetterStore(pool *pgxpool.Pool) DeadLetterStore {
+	return &pgDeadLetterStore{pool: pool}
+}
+
+type pgDeadLetterStore struct {
+	pool *pgxpool.Pool
+}
+
+func (s *pgDeadLetterStore) Save(ctx context.Context, dl *DeadLetter) error {
+	if dl.ID == uuid.Nil {
+		dl.ID = uuid.New()
+	}
+	if dl.Status == "" {
+		dl.Status = DeadLetterFailed
+	}
+	args, err := json.Marshal(dl.Args)
+	if err != nil {
+		return fmt.Errorf("eventbus: failed to encode dead letter args: %w", err)
+	}
+	_, err = s.pool.Exec(ctx, upsertDeadLetterQuery, dl.ID, dl.Subscriber, args, dl.Error, dl.Attempts, string(dl.Status))
+	return err
+}
+
+func (s *pgDeadLetterStore) Get(ctx context.Context, id uuid.UUID) (*DeadLetter, error) {
+	rows, err := s.pool.Query(ctx, repo.Join(selectDeadLettersQuery, "WHERE id = $1"), id)
+	if err != nil {
+		return nil, err
+	}
+	deadLetters, err := collectDeadLetters(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(deadLetters) == 0 {
+		return nil, ErrDeadLetterNotFound
+	}
+	return deadLetters[0], nil
+}
+
+func (s *pgDeadLetterStore) List(ctx context.Context, params *DeadLetterFindParams) ([]*DeadLetter, error) {
+	where, args := buildDeadLetterFilters(params)
+	query := repo.Join(
+		selectDeadLettersQuery,
+		where,
+		"ORDER BY created_at DESC",
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	)
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return collectDeadLetters(rows)
+}
+
+func (s *pgDeadLetterStore) Count(ctx context.Context, params *DeadLetterFindParams) (int64, error) {
+	where, args := buildDeadLetterFilters(params)
+	var count int64
+	if err := s.pool.QueryRow(ctx, repo.Join(countDeadLettersQuery, where), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *pgDeadLetterStore) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := s.pool.Exec(ctx, deleteDeadLetterQuery, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrDeadLetterNotFound
+	}
+	return nil
+}
+
+func (s *pgDeadLetterStore) RequestReplay(ctx context.Context, id uuid.UUID) error {
+	tag, err := s.pool.Exec(ctx, requestDeadLetterReplayQuery, id, string(DeadLetterReplayRequested), string(DeadLetterFailed))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrDeadLetterNotFound
+	}
+	return nil
+}
+
+func (s *pgDeadLetterStore) ClaimReplays(ctx context.Context, limit int) ([]*DeadLetter, error) {
+	rows, err := s.pool.Query(ctx, claimDeadLetterReplaysQuery, string(DeadLetterReplaying), string(DeadLetterReplayRequested), limit)
+	if err != nil {
+		return nil, err
+	}
+	return collectDeadLetters(rows)
+}
+
+func buildDeadLetterFilters(params *DeadLetterFindParams) (string, []interface{}) {
+	var (
+		where []string
+		args  []interface{}
+	)
+	if params.Subscriber != "" {
+		args = append(args, params.Subscriber)
+		where = append(where, fmt.Sprintf("subscriber = $%d", len(args)))
+	}
+	if params.Status != "" {
+		args = append(args, string(params.Status))
+		where = append(where, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if len(where) == 0 {
+		return "", nil
+	}
+	return repo.JoinWhere(where...), args
+}
+
+func collectDeadLetters(rows pgx.Rows) ([]*DeadLetter, error) {
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*DeadLetter, error) {
+		var (
+			dl     DeadLetter
+			args   []byte
+			status string
+		)
+		if err := row.Scan(&dl.ID, &dl.Subscriber, &args, &dl.Error, &dl.Attempts, &status, &dl.CreatedAt, &dl.UpdatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(args, &dl.Args); err != nil {
+			return nil, fmt.Errorf("eventbus: failed to decode dead letter args: %w", err)
+		}
+		dl.Status = DeadLetterStatus(status)
+		return &dl, nil
+	})
+}
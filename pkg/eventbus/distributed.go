@@ -0,0 +1,209 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+// maxNotifyPayload is the largest payload Postgres accepts in NOTIFY (8000 bytes by default).
+const maxNotifyPayload = 7999
+
+// Broadcaster is implemented by event buses that can deliver events published on other nodes.
+type Broadcaster interface {
+	// SubscribeBroadcast registers a handler that runs on every node for each
+	// matching event, regardless of which node published it. Use it for
+	// node-local effects such as pushing updates to websocket clients.
+	SubscribeBroadcast(handler interface{})
+}
+
+// SubscribeBroadcast subscribes handler with bus.SubscribeBroadcast when the bus
+// supports cross-node delivery and falls back to a regular subscription otherwise.
+func SubscribeBroadcast(bus EventBus, handler interface{}) {
+	if b, ok := bus.(Broadcaster); ok {
+		b.SubscribeBroadcast(handler)
+		return
+	}
+	bus.Subscribe(handler)
+}
+
+type DistributedOptions struct {
+	Pool *pgxpool.Pool
+	// Local receives events published on this node. Regular subscriptions are delegated to it.
+	Local    EventBus
+	Registry *Registry
+	Logger   *logrus.Logger
+	// Channel is the Postgres notification channel. Defaults to "eventbus".
+	Channel string
+}
+
+type envelope struct {
+	Node string         `json:"node"`
+	Args []EncodedEvent `json:"args"`
+}
+
+// DistributedEventBus relays registered events to other nodes through Postgres LISTEN/NOTIFY.
+//
+// Regular subscribers only see events published on their own node, so side
+// effects (emails, external calls) are not repeated per replica. Broadcast
+// subscribers see events from every node. Events whose types are not in the
+// registry stay local.
+type DistributedEventBus struct {
+	EventBus
+	opts      DistributedOptions
+	node      string
+	broadcast *publisherImpl
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+func NewDistributedEventPublisher(opts DistributedOptions) *DistributedEventBus {
+	if opts.Channel == "" {
+		opts.Channel = "eventbus"
+	}
+	return &DistributedEventBus{
+		EventBus:  opts.Local,
+		opts:      opts,
+		node:      uuid.NewString(),
+		broadcast: &publisherImpl{log: opts.Logger, quiet: true},
+	}
+}
+
+func (b *DistributedEventBus) SubscribeBroadcast(handler interface{}) {
+	b.broadcast.Subscribe(handler)
+}
+
+func (b *DistributedEventBus) Publish(args ...interface{}) {
+	b.EventBus.Publish(args...)
+	b.broadcast.Publish(args...)
+	b.relay(args)
+}
+
+func (b *DistributedEventBus) SubscribeType(eventType reflect.Type, handler HandlerFunc, opts ...SubscribeOption) func() {
+	return subscribeType(b.EventBus, eventType, handler, opts...)
+}
+
+func (b *DistributedEventBus) PublishContext(ctx context.Context, event interface{}) {
+	publishContext(ctx, b.EventBus, event)
+	b.broadcast.PublishContext(ctx, event)
+	b.relay([]interface{}{event})
+}
+
+func (b *DistributedEventBus) deliverContext(ctx context.Context, event interface{}) error {
+	err := deliverContext(ctx, b.EventBus, event)
+	b.broadcast.PublishContext(ctx, event)
+	b.relay([]interface{}{event})
+	return err
+}
+
+func (b *DistributedEventBus) relay(args []interface{}) {
+	encoded, err := encodeArgs(b.opts.Registry, args)
+	if err != nil {
+		// Not every event is meant to leave the node
+		return
+	}
+	payload, err := json.Marshal(envelope{Node: b.node, Args: encoded})
+	if err != nil {
+		b.opts.Logger.WithError(err).Error("eventbus: failed to encode event for relay")
+		return
+	}
+	if len(payload) > maxNotifyPayload {
+		b.opts.Logger.Warnf("eventbus: event %s is too large to relay (%d bytes)", encoded[0].Type, len(payload))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := b.opts.Pool.Exec(ctx, "SELECT pg_notify($1, $2)", b.opts.Channel, string(payload)); err != nil {
+		b.opts.Logger.WithError(err).Error("eventbus: failed to relay event")
+	}
+}
+
+func (b *DistributedEventBus) Clear() {
+	b.EventBus.Clear()
+	b.broadcast.Clear()
+}
+
+func (b *DistributedEventBus) SubscribersCount() int {
+	return b.EventBus.SubscribersCount() + b.broadcast.SubscribersCount()
+}
+
+// Start listens for events relayed by other nodes until Stop is called.
+func (b *DistributedEventBus) Start(ctx context.Context) {
+	ctx, b.cancel = context.WithCancel(ctx)
+	b.wg.Add(1)
+	go b.listen(ctx)
+}
+
+func (b *DistributedEventBus) Stop() {
+	if b.cancel != nil {
+		b.cancel()
+	}
+	b.wg.Wait()
+}
+
+func (b *DistributedEventBus) listen(ctx context.Context) {
+	defer b.wg.Done()
+
+	backoff := time.Second
+	for {
+		err := b.listenOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		b.opts.Logger.WithError(err).Warnf("eventbus: relay listener disconnected, reconnecting in %s", backoff)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff < time.Minute {
+			backoff *= 2
+		}
+	}
+}
+
+func (b *DistributedEventBus) listenOnce(ctx context.Context) error {
+	pooled, err := b.opts.Pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// Take the connection out of the pool so the LISTEN state never leaks to other users
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{b.opts.Channel}.Sanitize()); err != nil {
+		return err
+	}
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		b.receive([]byte(notification.Payload))
+	}
+}
+
+func (b *DistributedEventBus) receive(payload []byte) {
+	var e envelope
+	if err := json.Unmarshal(payload, &e); err != nil {
+		b.opts.Logger.WithError(err).Error("eventbus: failed to decode relayed event")
+		return
+	}
+	if e.Node == b.node {
+		return
+	}
+	args, err := decodeArgs(b.opts.Registry, e.Args)
+	if err != nil {
+		b.opts.Logger.WithError(err).Error("eventbus: failed to decode relayed event")
+		return
+	}
+	b.broadcast.Publish(args...)
+}
@@ -0,0 +1,87 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/pkg/logging"
+)
+
+type distributedTestEvent struct {
+	ID int `json:"id"`
+}
+
+func newTestDistributedBus(registry *Registry) *DistributedEventBus {
+	logger := logging.ConsoleLogger(logrus.FatalLevel)
+	return NewDistributedEventPublisher(DistributedOptions{
+		Local:    NewEventPublisher(logger),
+		Registry: registry,
+		Logger:   logger,
+	})
+}
+
+func relayedPayload(t *testing.T, registry *Registry, node string, event interface{}) []byte {
+	t.Helper()
+	encoded, err := encodeArgs(registry, []interface{}{event})
+	require.NoError(t, err)
+	payload, err := json.Marshal(envelope{Node: node, Args: encoded})
+	require.NoError(t, err)
+	return payload
+}
+
+func TestDistributedEventBus_ReceiveDeliversToBroadcastSubscribersOnly(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("test.distributed", &distributedTestEvent{})
+	bus := newTestDistributedBus(registry)
+
+	var local, broadcast []int
+	bus.Subscribe(func(e *distributedTestEvent) { local = append(local, e.ID) })
+	SubscribeBroadcast(bus, func(e *distributedTestEvent) { broadcast = append(broadcast, e.ID) })
+
+	bus.receive(relayedPayload(t, registry, "other-node", &distributedTestEvent{ID: 7}))
+
+	assert.Empty(t, local)
+	assert.Equal(t, []int{7}, broadcast)
+}
+
+func TestDistributedEventBus_ReceiveSkipsOwnEvents(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("test.distributed", &distributedTestEvent{})
+	bus := newTestDistributedBus(registry)
+
+	calls := 0
+	SubscribeBroadcast(bus, func(e *distributedTestEvent) { calls++ })
+
+	bus.receive(relayedPayload(t, registry, bus.node, &distributedTestEvent{ID: 1}))
+
+	assert.Zero(t, calls)
+}
+
+func TestDistributedEventBus_PublishUnregisteredStaysLocal(t *testing.T) {
+	bus := newTestDistributedBus(NewRegistry())
+
+	var local, broadcast int
+	bus.Subscribe(func(e *distributedTestEvent) { local++ })
+	SubscribeBroadcast(bus, func(e *distributedTestEvent) { broadcast++ })
+
+	// Pool is nil, so relaying an unregistered event would panic
+	bus.Publish(&distributedTestEvent{ID: 1})
+
+	assert.Equal(t, 1, local)
+	assert.Equal(t, 1, broadcast)
+	assert.Equal(t, 2, bus.SubscribersCount())
+}
+
+func TestSubscribeBroadcast_FallsBackToSubscribe(t *testing.T) {
+	bus := NewEventPublisher(logging.ConsoleLogger(logrus.FatalLevel))
+
+	calls := 0
+	SubscribeBroadcast(bus, func(e *distributedTestEvent) { calls++ })
+	bus.Publish(&distributedTestEvent{})
+
+	assert.Equal(t, 1, calls)
+}
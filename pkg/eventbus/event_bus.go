@@ -1,15 +1,73 @@
 package eventbus
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"reflect"
+	"runtime"
 
 	"github.com/sirupsen/logrus"
 )
 
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
 type Subscriber struct {
 	Handler interface{}
+	// Name identifies the subscriber in logs and dead letters. Defaults to the handler function name.
+	Name string
+	// Retry is used by asynchronous event buses when the handler fails.
+	Retry RetryPolicy
+	// Priority orders typed subscribers of the same event, see WithPriority.
+	Priority int
+
+	id     uint64
+	invoke HandlerFunc
+}
+
+// call delivers args to the subscriber, passing ctx to typed handlers.
+func (s Subscriber) call(ctx context.Context, args []interface{}) (err error) {
+	if s.invoke == nil {
+		in := make([]reflect.Value, len(args))
+		for i, arg := range args {
+			in[i] = reflect.ValueOf(arg)
+		}
+		return callHandler(reflect.ValueOf(s.Handler), in)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler %s panicked: %v", s.Name, r)
+		}
+	}()
+	return s.invoke(ctx, args[0])
+}
+
+// HandlerName returns the fully qualified name of a handler function.
+func HandlerName(handler interface{}) string {
+	v := reflect.ValueOf(handler)
+	if fn := runtime.FuncForPC(v.Pointer()); fn != nil {
+		return fn.Name()
+	}
+	return v.Type().String()
+}
+
+// callHandler invokes handler and converts both panics and a returned non-nil
+// error into an error. Handlers may return nothing or a single error.
+func callHandler(handler reflect.Value, in []reflect.Value) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler %s panicked: %v", handler.Type().String(), r)
+		}
+	}()
+	out := handler.Call(in)
+	if len(out) > 0 && out[len(out)-1].Type().Implements(errorType) && !out[len(out)-1].IsNil() {
+		return out[len(out)-1].Interface().(error)
+	}
+	return nil
 }
 
+// EventBus matches handlers to events by reflecting on their signatures at publish time.
+// New code should prefer the generic Subscribe and Publish functions.
 type EventBus interface {
 	Publish(args ...interface{})
 	Subscribe(handler interface{})
@@ -21,6 +79,9 @@ type EventBus interface {
 type publisherImpl struct {
 	log         *logrus.Logger
 	Subscribers []Subscriber
+	typed       map[reflect.Type][]Subscriber
+	// quiet suppresses the warning about events without subscribers
+	quiet bool
 }
 
 func NewEventPublisher(log *logrus.Logger) EventBus {
@@ -67,35 +128,65 @@ func MatchSignature(handler interface{}, args []interface{}) bool {
 }
 
 func (p *publisherImpl) Publish(args ...interface{}) {
-	in := make([]reflect.Value, len(args))
-	for i, arg := range args {
-		in[i] = reflect.ValueOf(arg)
+	p.publish(context.Background(), args)
+}
+
+func (p *publisherImpl) PublishContext(ctx context.Context, event interface{}) {
+	p.publish(ctx, []interface{}{event})
+}
+
+func (p *publisherImpl) publish(ctx context.Context, args []interface{}) {
+	_ = p.deliver(ctx, args)
+}
+
+func (p *publisherImpl) deliverContext(ctx context.Context, event interface{}) error {
+	return p.deliver(ctx, []interface{}{event})
+}
+
+// deliver calls the matching subscribers and returns the joined errors of those that failed.
+func (p *publisherImpl) deliver(ctx context.Context, args []interface{}) error {
+	var subscribers []Subscriber
+	if len(args) == 1 && args[0] != nil {
+		subscribers = p.typed[reflect.TypeOf(args[0])]
 	}
 
+	var errs []error
 	handled := false
+	for _, subscriber := range subscribers {
+		if err := subscriber.call(ctx, args); err != nil {
+			p.log.Errorf("eventbus: handler %s failed with args %v: %v", subscriber.Name, args, err)
+			errs = append(errs, fmt.Errorf("%s: %w", subscriber.Name, err))
+			continue
+		}
+		handled = true
+	}
 	for _, subscriber := range p.Subscribers {
-		v := reflect.ValueOf(subscriber.Handler)
 		if !MatchSignature(subscriber.Handler, args) {
 			continue
 		}
-		// Wrap handler invocation with panic recovery
-		func() {
-			defer func() {
-				if r := recover(); r != nil {
-					handlerName := v.Type().String()
-					// Log panic with error level and include event args for debugging
-					p.log.Errorf("eventbus: handler %s panicked with args %v: %v", handlerName, args, r)
-				}
-			}()
-			v.Call(in)
-			// Only mark as handled if handler completed successfully without panic
-			handled = true
-		}()
+		// Only mark as handled if handler completed successfully
+		if err := subscriber.call(ctx, args); err != nil {
+			p.log.Errorf("eventbus: handler %s failed with args %v: %v", subscriber.Name, args, err)
+			errs = append(errs, fmt.Errorf("%s: %w", subscriber.Name, err))
+			continue
+		}
+		handled = true
+	}
+
+	if !handled && !p.quiet {
+		p.log.Warnf("eventbus.Publish: no matching subscribers for event with args: %v", args)
 	}
+	return errors.Join(errs...)
+}
 
-	if !handled {
-		p.log.Warnf("eventbus.Publish: no matching subscribers for event with args: %v", in)
-		return
+func (p *publisherImpl) SubscribeType(eventType reflect.Type, handler HandlerFunc, opts ...SubscribeOption) func() {
+	s := newTypedSubscriber(handler, RetryPolicy{}, opts)
+	if p.typed == nil {
+		p.typed = make(map[reflect.Type][]Subscriber)
+	}
+	p.typed[eventType] = insertByPriority(p.typed[eventType], s)
+	return func() {
+		p.typed[eventType] = removeSubscriber(p.typed[eventType], s.id)
 	}
 }
 
@@ -106,7 +197,7 @@ func (p *publisherImpl) Subscribe(handler interface{}) {
 	}
 	p.Subscribers = append(
 		p.Subscribers,
-		Subscriber{Handler: handler},
+		Subscriber{Handler: handler, Name: HandlerName(handler)},
 	)
 }
 
@@ -121,8 +212,13 @@ func (p *publisherImpl) Unsubscribe(handler interface{}) {
 
 func (p *publisherImpl) Clear() {
 	p.Subscribers = []Subscriber{}
+	p.typed = nil
 }
 
 func (p *publisherImpl) SubscribersCount() int {
-	return len(p.Subscribers)
+	count := len(p.Subscribers)
+	for _, subscribers := range p.typed {
+		count += len(subscribers)
+	}
+	return count
 }
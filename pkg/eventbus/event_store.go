@@ -0,0 +1,121 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+var ErrVersionConflict = errors.New("eventbus: aggregate version conflict")
+
+// AnyVersion disables the optimistic concurrency check of EventStore.Append.
+const AnyVersion = -1
+
+// defaultReplayBatchSize is the number of stored events read per query during a replay.
+const defaultReplayBatchSize = 500
+
+// StoredEvent is a domain event recorded in the event store.
+type StoredEvent struct {
+	// Position is the global, monotonically increasing position of the event.
+	Position      int64
+	TenantID      uuid.UUID
+	AggregateType string
+	AggregateID   string
+	// Version is the position of the event within its aggregate, starting at 1.
+	Version    int
+	Type       string
+	Payload    []byte
+	OccurredAt time.Time
+}
+
+type EventStoreReadParams struct {
+	// After excludes events at or before this position.
+	After int64
+	// Since excludes events that occurred before this time.
+	Since time.Time
+	// TenantID limits the events to a single tenant. The zero value reads all tenants.
+	TenantID      uuid.UUID
+	AggregateType string
+	AggregateID   string
+	// Types limits the events to the given registered type names.
+	Types []string
+	Limit int
+}
+
+// EventStore is an append-only log of domain events, grouped by aggregate and
+// ordered by version within an aggregate and by position globally.
+type EventStore interface {
+	// Append records events for an aggregate using the transaction found in ctx
+	// and returns the new aggregate version. It fails with ErrVersionConflict when
+	// the current version is not expectedVersion, unless AnyVersion is passed.
+	// Events are recorded for the tenant in ctx.
+	Append(ctx context.Context, aggregateType, aggregateID string, expectedVersion int, events ...interface{}) (int, error)
+	// Load returns the events of an aggregate of the tenant in ctx in version order.
+	Load(ctx context.Context, aggregateType, aggregateID string) ([]*StoredEvent, error)
+	// Read returns events in position order. It holds up appends until the
+	// transaction of ctx ends, so that events committed later never get
+	// positions before the ones returned; keep that transaction short.
+	Read(ctx context.Context, params *EventStoreReadParams) ([]*StoredEvent, error)
+}
+
+// ReplayHandler receives decoded events in position order. Its context carries
+// the tenant the event was recorded for.
+type ReplayHandler func(ctx context.Context, event interface{}, stored *StoredEvent) error
+
+// ReplayEvents reads the events matching params from store in batches, decodes
+// them with registry and passes them to handler. It stops at the first error and
+// returns the position of the last event handled.
+//
+// Feeding a newly added subscriber with the events of the last week:
+//
+//	_, err := eventbus.ReplayEvents(ctx, store, registry, eventbus.EventStoreReadParams{
+//		Since: time.Now().AddDate(0, 0, -7),
+//	}, eventbus.ReplayTo(bus))
+func ReplayEvents(
+	ctx context.Context,
+	store EventStore,
+	registry *Registry,
+	params EventStoreReadParams,
+	handler ReplayHandler,
+) (int64, error) {
+	if params.Limit <= 0 {
+		params.Limit = defaultReplayBatchSize
+	}
+	last := params.After
+	for {
+		batch, err := store.Read(ctx, &params)
+		if err != nil {
+			return last, err
+		}
+		for _, stored := range batch {
+			event, err := registry.Decode(stored.Type, stored.Payload)
+			if err != nil {
+				return last, err
+			}
+			eventCtx := ctx
+			if stored.TenantID != uuid.Nil {
+				eventCtx = composables.WithTenantID(ctx, stored.TenantID)
+			}
+			if err := handler(eventCtx, event, stored); err != nil {
+				return last, err
+			}
+			last = stored.Position
+		}
+		if len(batch) < params.Limit {
+			return last, nil
+		}
+		params.After = last
+	}
+}
+
+// ReplayTo returns a ReplayHandler that publishes replayed events to bus.
+func ReplayTo(bus EventBus) ReplayHandler {
+	return func(ctx context.Context, event interface{}, _ *StoredEvent) error {
+		publishContext(ctx, bus, event)
+		return nil
+	}
+}
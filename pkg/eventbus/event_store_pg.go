@@ -0,0 +1,205 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+const (
+	selectStoredEventsQuery = `
+		SELECT position, tenant_id, aggregate_type, aggregate_id, version, event_type, payload, occurred_at
+		FROM eventbus_events`
+
+	selectAggregateVersionQuery = `
+		SELECT COALESCE(MAX(version), 0)
+		FROM eventbus_events
+		WHERE aggregate_type = $1 AND aggregate_id = $2`
+
+	insertStoredEventQuery = `
+		INSERT INTO eventbus_events (tenant_id, aggregate_type, aggregate_id, version, event_type, payload)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	// Appends to the same aggregate are serialized so that versions are checked
+	// and assigned one append at a time
+	lockAggregateQuery = `SELECT pg_advisory_xact_lock(hashtext($1), hashtext($2))`
+
+	// Appends to different aggregates share the event store lock and run side by
+	// side, so their positions may become visible out of order. Reads take it
+	// exclusively, which waits for in-flight appends to commit, so that readers
+	// polling by position never skip an event.
+	shareEventStoreQuery = `SELECT pg_advisory_xact_lock_shared(hashtext('eventbus_events'))`
+	lockEventStoreQuery  = `SELECT pg_advisory_xact_lock(hashtext('eventbus_events'))`
+)
+
+const uniqueViolationCode = "23505"
+
+func NewEventStore(registry *Registry) EventStore {
+	return &pgEventStore{registry: registry}
+}
+
+type pgEventStore struct {
+	registry *Registry
+}
+
+func (s *pgEventStore) Append(
+	ctx context.Context,
+	aggregateType, aggregateID string,
+	expectedVersion int,
+	events ...interface{},
+) (int, error) {
+	type encoded struct {
+		name    string
+		payload []byte
+	}
+	records := make([]encoded, len(events))
+	for i, event := range events {
+		name, payload, err := s.registry.Encode(event)
+		if err != nil {
+			return 0, err
+		}
+		records[i] = encoded{name: name, payload: payload}
+	}
+
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(ctx, shareEventStoreQuery); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(ctx, lockAggregateQuery, aggregateType, aggregateID); err != nil {
+		return 0, err
+	}
+
+	var version int
+	if err := tx.QueryRow(ctx, selectAggregateVersionQuery, aggregateType, aggregateID).Scan(&version); err != nil {
+		return 0, err
+	}
+	if expectedVersion != AnyVersion && version != expectedVersion {
+		return 0, fmt.Errorf("%w: %s %s is at version %d, expected %d", ErrVersionConflict, aggregateType, aggregateID, version, expectedVersion)
+	}
+
+	var tenantID *uuid.UUID
+	if id, err := composables.UseTenantID(ctx); err == nil {
+		tenantID = &id
+	}
+
+	for _, r := range records {
+		version++
+		if _, err := tx.Exec(ctx, insertStoredEventQuery, tenantID, aggregateType, aggregateID, version, r.name, r.payload); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+				return 0, fmt.Errorf("%w: %s %s", ErrVersionConflict, aggregateType, aggregateID)
+			}
+			return 0, fmt.Errorf("eventbus: failed to append event %q: %w", r.name, err)
+		}
+	}
+	return version, nil
+}
+
+func (s *pgEventStore) Load(ctx context.Context, aggregateType, aggregateID string) ([]*StoredEvent, error) {
+	params := &EventStoreReadParams{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+	}
+	if tenantID, err := composables.UseTenantID(ctx); err == nil {
+		params.TenantID = tenantID
+	}
+	where, args := buildStoredEventFilters(params)
+	return s.query(ctx, repo.Join(selectStoredEventsQuery, where, "ORDER BY version"), args...)
+}
+
+func (s *pgEventStore) Read(ctx context.Context, params *EventStoreReadParams) ([]*StoredEvent, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, lockEventStoreQuery); err != nil {
+		return nil, err
+	}
+
+	where, args := buildStoredEventFilters(params)
+	query := repo.Join(
+		selectStoredEventsQuery,
+		where,
+		"ORDER BY position",
+		repo.FormatLimitOffset(params.Limit, 0),
+	)
+	return s.query(ctx, query, args...)
+}
+
+func (s *pgEventStore) query(ctx context.Context, query string, args ...interface{}) ([]*StoredEvent, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*StoredEvent, error) {
+		var (
+			e        StoredEvent
+			tenantID *uuid.UUID
+		)
+		if err := row.Scan(
+			&e.Position,
+			&tenantID,
+			&e.AggregateType,
+			&e.AggregateID,
+			&e.Version,
+			&e.Type,
+			&e.Payload,
+			&e.OccurredAt,
+		); err != nil {
+			return nil, err
+		}
+		if tenantID != nil {
+			e.TenantID = *tenantID
+		}
+		return &e, nil
+	})
+}
+
+func buildStoredEventFilters(params *EventStoreReadParams) (string, []interface{}) {
+	var (
+		where []string
+		args  []interface{}
+	)
+	if params.After > 0 {
+		args = append(args, params.After)
+		where = append(where, fmt.Sprintf("position > $%d", len(args)))
+	}
+	if !params.Since.IsZero() {
+		args = append(args, params.Since)
+		where = append(where, fmt.Sprintf("occurred_at >= $%d", len(args)))
+	}
+	if params.TenantID != uuid.Nil {
+		args = append(args, params.TenantID)
+		where = append(where, fmt.Sprintf("tenant_id = $%d", len(args)))
+	}
+	if params.AggregateType != "" {
+		args = append(args, params.AggregateType)
+		where = append(where, fmt.Sprintf("aggregate_type = $%d", len(args)))
+	}
+	if params.AggregateID != "" {
+		args = append(args, params.AggregateID)
+		where = append(where, fmt.Sprintf("aggregate_id = $%d", len(args)))
+	}
+	if len(params.Types) > 0 {
+		args = append(args, params.Types)
+		where = append(where, fmt.Sprintf("event_type = ANY($%d)", len(args)))
+	}
+	if len(where) == 0 {
+		return "", nil
+	}
+	return repo.JoinWhere(where...), args
+}
@@ -0,0 +1,125 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/logging"
+)
+
+type storeTestEvent struct {
+	N int `json:"n"`
+}
+
+// memoryEventStore is an in-memory EventStore supporting the read params used by ReplayEvents.
+type memoryEventStore struct {
+	events []*StoredEvent
+	reads  int
+}
+
+func (s *memoryEventStore) Append(_ context.Context, _, _ string, _ int, _ ...interface{}) (int, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (s *memoryEventStore) Load(_ context.Context, _, _ string) ([]*StoredEvent, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *memoryEventStore) Read(_ context.Context, params *EventStoreReadParams) ([]*StoredEvent, error) {
+	s.reads++
+	var result []*StoredEvent
+	for _, e := range s.events {
+		if e.Position <= params.After {
+			continue
+		}
+		if params.Limit > 0 && len(result) == params.Limit {
+			break
+		}
+		result = append(result, e)
+	}
+	return result, nil
+}
+
+func newMemoryEventStore(t *testing.T, registry *Registry, tenantID uuid.UUID, n int) *memoryEventStore {
+	t.Helper()
+	store := &memoryEventStore{}
+	for i := 1; i <= n; i++ {
+		name, payload, err := registry.Encode(&storeTestEvent{N: i})
+		require.NoError(t, err)
+		store.events = append(store.events, &StoredEvent{
+			Position:      int64(i),
+			TenantID:      tenantID,
+			AggregateType: "test",
+			AggregateID:   "1",
+			Version:       i,
+			Type:          name,
+			Payload:       payload,
+		})
+	}
+	return store
+}
+
+func TestReplayEvents_ReadsInBatches(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("test.store", &storeTestEvent{})
+	tenantID := uuid.New()
+	store := newMemoryEventStore(t, registry, tenantID, 5)
+
+	var got []int
+	last, err := ReplayEvents(context.Background(), store, registry, EventStoreReadParams{Limit: 2},
+		func(ctx context.Context, event interface{}, stored *StoredEvent) error {
+			id, err := composables.UseTenantID(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tenantID, id)
+			got = append(got, event.(*storeTestEvent).N)
+			return nil
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), last)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, got)
+	assert.Equal(t, 3, store.reads)
+}
+
+func TestReplayEvents_StopsOnError(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("test.store", &storeTestEvent{})
+	store := newMemoryEventStore(t, registry, uuid.Nil, 5)
+	boom := errors.New("boom")
+
+	last, err := ReplayEvents(context.Background(), store, registry, EventStoreReadParams{After: 1},
+		func(_ context.Context, event interface{}, _ *StoredEvent) error {
+			if event.(*storeTestEvent).N == 4 {
+				return boom
+			}
+			return nil
+		})
+
+	require.ErrorIs(t, err, boom)
+	assert.Equal(t, int64(3), last)
+}
+
+func TestReplayTo_PublishesToBus(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("test.store", &storeTestEvent{})
+	store := newMemoryEventStore(t, registry, uuid.Nil, 3)
+	bus := NewEventPublisher(logging.ConsoleLogger(logrus.FatalLevel))
+
+	var got []int
+	Subscribe(bus, func(_ context.Context, e *storeTestEvent) error {
+		got = append(got, e.N)
+		return nil
+	})
+
+	_, err := ReplayEvents(context.Background(), store, registry, EventStoreReadParams{}, ReplayTo(bus))
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, got)
+}
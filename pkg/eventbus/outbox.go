@@ -0,0 +1,96 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/constants"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+const insertOutboxMessageQuery = `
+	INSERT INTO eventbus_outbox (tenant_id, event_type, dedup_key, payload)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (dedup_key) DO NOTHING`
+
+// Outbox records events in the same database transaction as the business
+// change that produced them. Committed events are later delivered to
+// subscribers by an OutboxDispatcher.
+type Outbox interface {
+	// Enqueue stores event using the transaction found in ctx and fails with
+	// composables.ErrNoTx outside of one. If the transaction rolls back, the
+	// event is discarded with it.
+	Enqueue(ctx context.Context, event interface{}, opts ...EnqueueOption) error
+}
+
+type enqueueOptions struct {
+	dedupKey string
+}
+
+type EnqueueOption func(o *enqueueOptions)
+
+// WithDedupKey sets the de-duplication key of an outbox message.
+// Enqueueing another event with the same key is a no-op, which makes retries
+// of the producing operation safe. When omitted, a random key is generated.
+// Handlers read the key with OutboxKey.
+func WithDedupKey(key string) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.dedupKey = key
+	}
+}
+
+func NewOutbox(registry *Registry) Outbox {
+	return &outbox{registry: registry}
+}
+
+type outboxKey struct{}
+
+func withOutboxKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, outboxKey{}, key)
+}
+
+// OutboxKey returns the de-duplication key of the outbox message whose event
+// is being handled. Delivery is at-least-once, so handlers with side effects
+// can record the key to skip messages they have already processed.
+func OutboxKey(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(outboxKey{}).(string)
+	return key, ok
+}
+
+type outbox struct {
+	registry *Registry
+}
+
+func (o *outbox) Enqueue(ctx context.Context, event interface{}, opts ...EnqueueOption) error {
+	options := &enqueueOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.dedupKey == "" {
+		options.dedupKey = uuid.NewString()
+	}
+
+	name, payload, err := o.registry.Encode(event)
+	if err != nil {
+		return err
+	}
+
+	// UseTx would fall back to the pool, committing the event on its own
+	tx, ok := ctx.Value(constants.TxKey).(repo.Tx)
+	if !ok {
+		return fmt.Errorf("eventbus: failed to enqueue event %q: %w", name, composables.ErrNoTx)
+	}
+
+	var tenantID *uuid.UUID
+	if id, err := composables.UseTenantID(ctx); err == nil {
+		tenantID = &id
+	}
+
+	if _, err := tx.Exec(ctx, insertOutboxMessageQuery, tenantID, name, options.dedupKey, payload); err != nil {
+		return fmt.Errorf("eventbus: failed to enqueue event %q: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// claimOutboxMessagesQuery leases pending messages by pushing their next attempt
+	// past the lease, so the claim commits before any subscriber runs
+	claimOutboxMessagesQuery = `
+		UPDATE eventbus_outbox
+		SET attempts = attempts + 1, next_attempt_at = NOW() + make_interval(secs => $3)
+		WHERE id IN (
+			SELECT id FROM eventbus_outbox
+			WHERE dispatched_at IS NULL AND attempts < $1 AND next_attempt_at <= NOW()
+			ORDER BY id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, tenant_id, event_type, dedup_key, payload, attempts`
+
+	markOutboxMessageDispatchedQuery = `
+		UPDATE eventbus_outbox
+		SET dispatched_at = NOW(), last_error = NULL
+		WHERE id = $1`
+
+	markOutboxMessageFailedQuery = `
+		UPDATE eventbus_outbox
+		SET last_error = $2, next_attempt_at = NOW() + make_interval(secs => $3)
+		WHERE id = $1`
+
+	releaseOutboxMessagesQuery = `
+		UPDATE eventbus_outbox
+		SET attempts = attempts - 1, next_attempt_at = NOW()
+		WHERE id = ANY($1)`
+
+	selectExhaustedOutboxMessagesQuery = `
+		SELECT id, tenant_id, event_type, dedup_key, payload, attempts, last_error, created_at
+		FROM eventbus_outbox
+		WHERE dispatched_at IS NULL AND attempts >= $1
+		ORDER BY id
+		LIMIT $2`
+
+	purgeOutboxMessagesQuery = `
+		DELETE FROM eventbus_outbox
+		WHERE (dispatched_at IS NOT NULL AND dispatched_at < $1)
+			OR (dispatched_at IS NULL AND attempts >= $2 AND next_attempt_at < $1)`
+)
+
+// ExhaustedOutboxMessage is an outbox message that used up its attempts without
+// being delivered. It stays in the outbox until it is purged.
+type ExhaustedOutboxMessage struct {
+	ID        int64
+	TenantID  *uuid.UUID
+	EventType string
+	DedupKey  string
+	Payload   []byte
+	Attempts  int
+	LastError string
+	CreatedAt time.Time
+}
+
+type OutboxDispatcherOptions struct {
+	Pool     *pgxpool.Pool
+	EventBus EventBus
+	Registry *Registry
+	Logger   *logrus.Logger
+	// PollInterval is the delay between polls when the outbox is drained. Defaults to one second.
+	PollInterval time.Duration
+	// BatchSize is the maximum number of messages claimed per poll. Defaults to 100.
+	BatchSize int
+	// MaxAttempts after which a message that cannot be decoded or delivered is no longer claimed. Defaults to 10.
+	MaxAttempts int
+	// Retry spaces out the attempts of failed messages; only its backoff is used. Defaults to DefaultRetryPolicy.
+	Retry RetryPolicy
+	// Lease bounds the delivery of a claimed batch. Messages left undelivered when
+	// it runs out are released, and messages of a dispatcher that died are claimed
+	// again once it has passed. Defaults to five minutes.
+	Lease time.Duration
+	// Retention is how long dispatched messages, and messages that used up their
+	// attempts, are kept. Zero keeps them forever.
+	Retention time.Duration
+}
+
+// OutboxDispatcher delivers committed outbox messages to the event bus.
+//
+// Messages are leased with FOR UPDATE SKIP LOCKED in a short transaction of their
+// own, so several replicas may run a dispatcher concurrently and no row stays
+// locked while subscribers run. Delivery is at-least-once: a message is retried
+// with backoff while any subscriber fails, and a crash between publishing and
+// marking it as dispatched publishes it again once its lease has passed. Subscribers that succeeded see the
+// message again on retries, so handlers should be idempotent, e.g. by recording
+// OutboxKey. Event buses that deliver asynchronously retry failed subscribers
+// on their own, so for them a message is done once it is published.
+type OutboxDispatcher struct {
+	opts   OutboxDispatcherOptions
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewOutboxDispatcher(opts OutboxDispatcherOptions) *OutboxDispatcher {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 10
+	}
+	if opts.Retry.InitialBackoff <= 0 {
+		opts.Retry = DefaultRetryPolicy()
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = 5 * time.Minute
+	}
+	return &OutboxDispatcher{opts: opts}
+}
+
+func (d *OutboxDispatcher) Start(ctx context.Context) {
+	ctx, d.cancel = context.WithCancel(ctx)
+	d.wg.Add(1)
+	go d.run(ctx)
+}
+
+func (d *OutboxDispatcher) Stop() {
+	if d.cancel != nil {
+		d.cancel()
+	}
+	d.wg.Wait()
+}
+
+func (d *OutboxDispatcher) run(ctx context.Context) {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := d.DispatchBatch(ctx)
+		if err != nil && ctx.Err() == nil {
+			d.opts.Logger.WithError(err).Error("eventbus: outbox dispatch failed")
+		}
+		// Keep draining without waiting while full batches are returned
+		if err == nil && n == d.opts.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if d.opts.Retention > 0 {
+				if err := d.Purge(ctx, time.Now().Add(-d.opts.Retention)); err != nil && ctx.Err() == nil {
+					d.opts.Logger.WithError(err).Error("eventbus: outbox purge failed")
+				}
+			}
+		}
+	}
+}
+
+// DispatchBatch claims up to BatchSize pending messages and publishes them.
+// Delivered messages are marked as dispatched, failed ones are scheduled for
+// another attempt. It returns the number of claimed messages.
+func (d *OutboxDispatcher) DispatchBatch(ctx context.Context) (int, error) {
+	rows, err := d.opts.Pool.Query(ctx, claimOutboxMessagesQuery, d.opts.MaxAttempts, d.opts.BatchSize, d.opts.Lease.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	type message struct {
+		id        int64
+		tenantID  *uuid.UUID
+		eventType string
+		dedupKey  string
+		payload   []byte
+		attempts  int
+	}
+	messages, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (message, error) {
+		var m message
+		err := row.Scan(&m.id, &m.tenantID, &m.eventType, &m.dedupKey, &m.payload, &m.attempts)
+		return m, err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	leaseCtx, cancel := context.WithTimeout(ctx, d.opts.Lease)
+	defer cancel()
+	// Outcomes are recorded even when ctx is canceled mid-batch, so that
+	// delivered messages are not published again
+	markCtx := context.WithoutCancel(ctx)
+
+	for i, m := range messages {
+		if leaseCtx.Err() != nil {
+			ids := make([]int64, 0, len(messages)-i)
+			for _, rest := range messages[i:] {
+				ids = append(ids, rest.id)
+			}
+			if _, err := d.opts.Pool.Exec(markCtx, releaseOutboxMessagesQuery, ids); err != nil {
+				return i, err
+			}
+			return i, leaseCtx.Err()
+		}
+
+		logger := d.opts.Logger.WithField("outbox_id", m.id)
+		event, err := d.opts.Registry.Decode(m.eventType, m.payload)
+		if err != nil {
+			logger.WithError(err).Error("eventbus: failed to decode outbox message")
+			if err := d.markFailed(markCtx, m.id, m.attempts, err); err != nil {
+				return i, err
+			}
+			continue
+		}
+		// Handlers see the tenant the event was recorded for and the key of the message
+		eventCtx := withOutboxKey(composables.WithPool(leaseCtx, d.opts.Pool), m.dedupKey)
+		if m.tenantID != nil {
+			eventCtx = composables.WithTenantID(eventCtx, *m.tenantID)
+		}
+		if err := deliverContext(eventCtx, d.opts.EventBus, event); err != nil {
+			logger.WithError(err).Error("eventbus: failed to deliver outbox message")
+			if err := d.markFailed(markCtx, m.id, m.attempts, err); err != nil {
+				return i, err
+			}
+			continue
+		}
+		if _, err := d.opts.Pool.Exec(markCtx, markOutboxMessageDispatchedQuery, m.id); err != nil {
+			return i, err
+		}
+	}
+	return len(messages), nil
+}
+
+// markFailed records cause and schedules the next attempt of the message after the given attempts.
+func (d *OutboxDispatcher) markFailed(ctx context.Context, id int64, attempts int, cause error) error {
+	if attempts >= d.opts.MaxAttempts {
+		d.opts.Logger.WithField("outbox_id", id).Error("eventbus: outbox message used up its attempts")
+	}
+	backoff := d.opts.Retry.Backoff(attempts)
+	_, err := d.opts.Pool.Exec(ctx, markOutboxMessageFailedQuery, id, cause.Error(), backoff.Seconds())
+	return err
+}
+
+// Exhausted lists up to limit messages that used up their attempts, oldest first.
+func (d *OutboxDispatcher) Exhausted(ctx context.Context, limit int) ([]ExhaustedOutboxMessage, error) {
+	rows, err := d.opts.Pool.Query(ctx, selectExhaustedOutboxMessagesQuery, d.opts.MaxAttempts, limit)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (ExhaustedOutboxMessage, error) {
+		var (
+			m         ExhaustedOutboxMessage
+			lastError *string
+		)
+		err := row.Scan(&m.ID, &m.TenantID, &m.EventType, &m.DedupKey, &m.Payload, &m.Attempts, &lastError, &m.CreatedAt)
+		if lastError != nil {
+			m.LastError = *lastError
+		}
+		return m, err
+	})
+}
+
+// Purge deletes messages dispatched before the given time, and messages that
+// used up their attempts and were last due before it.
+func (d *OutboxDispatcher) Purge(ctx context.Context, before time.Time) error {
+	_, err := d.opts.Pool.Exec(ctx, purgeOutboxMessagesQuery, before, d.opts.MaxAttempts)
+	return err
+}
@@ -0,0 +1,65 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/logging"
+)
+
+func TestOutbox_EnqueueRequiresTx(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("test.created", &registryTestEvent{})
+
+	err := NewOutbox(registry).Enqueue(context.Background(), &registryTestEvent{ID: 1})
+	require.ErrorIs(t, err, composables.ErrNoTx)
+}
+
+func TestOutboxKey(t *testing.T) {
+	_, ok := OutboxKey(context.Background())
+	assert.False(t, ok)
+
+	key, ok := OutboxKey(withOutboxKey(context.Background(), "key-1"))
+	assert.True(t, ok)
+	assert.Equal(t, "key-1", key)
+}
+
+func TestDeliverContext_ReturnsHandlerErrors(t *testing.T) {
+	bus := NewEventPublisher(logging.ConsoleLogger(logrus.FatalLevel))
+	failure := errors.New("boom")
+
+	var keys []string
+	Subscribe(bus, func(ctx context.Context, e *typedTestEvent) error {
+		key, _ := OutboxKey(ctx)
+		keys = append(keys, key)
+		return nil
+	})
+	Subscribe(bus, func(_ context.Context, e *typedTestEvent) error {
+		return failure
+	})
+
+	err := deliverContext(withOutboxKey(context.Background(), "key-1"), bus, &typedTestEvent{ID: 1})
+	require.ErrorIs(t, err, failure)
+	assert.Equal(t, []string{"key-1"}, keys)
+
+	require.NoError(t, deliverContext(context.Background(), bus, &otherTypedTestEvent{}))
+}
+
+func TestDeliverContext_AsyncBus(t *testing.T) {
+	bus := NewAsyncEventPublisher(AsyncOptions{Logger: logging.ConsoleLogger(logrus.FatalLevel)})
+	defer func() {
+		require.NoError(t, bus.Shutdown(context.Background()))
+	}()
+	Subscribe(bus, func(_ context.Context, e *typedTestEvent) error {
+		return errors.New("boom")
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+
+	// Asynchronous buses retry failed subscribers themselves
+	require.NoError(t, deliverContext(context.Background(), bus, &typedTestEvent{ID: 1}))
+}
@@ -0,0 +1,139 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+const (
+	selectProjectionCheckpointQuery = `
+		SELECT position FROM eventbus_projection_checkpoints WHERE name = $1`
+
+	upsertProjectionCheckpointQuery = `
+		INSERT INTO eventbus_projection_checkpoints (name, position)
+		VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE
+		SET position = EXCLUDED.position, updated_at = NOW()`
+)
+
+// Projection builds a read model from stored events.
+type Projection interface {
+	// Name identifies the projection's checkpoint and must be stable.
+	Name() string
+	// Handle applies a single event. It runs in the transaction that advances
+	// the checkpoint, so writes made through ctx are committed exactly once.
+	Handle(ctx context.Context, event interface{}, stored *StoredEvent) error
+	// Reset removes everything the projection has built so far.
+	Reset(ctx context.Context) error
+}
+
+// Projector keeps projections up to date with the event store and rebuilds them from history.
+type Projector struct {
+	pool      *pgxpool.Pool
+	store     EventStore
+	registry  *Registry
+	batchSize int
+}
+
+func NewProjector(pool *pgxpool.Pool, store EventStore, registry *Registry) *Projector {
+	return &Projector{
+		pool:      pool,
+		store:     store,
+		registry:  registry,
+		batchSize: defaultReplayBatchSize,
+	}
+}
+
+// Checkpoint returns the position of the last event applied to the projection.
+func (p *Projector) Checkpoint(ctx context.Context, projection Projection) (int64, error) {
+	var position int64
+	err := p.pool.QueryRow(ctx, selectProjectionCheckpointQuery, projection.Name()).Scan(&position)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	return position, err
+}
+
+// CatchUp applies the events recorded since the projection's checkpoint.
+// Each batch is applied and checkpointed in its own transaction.
+func (p *Projector) CatchUp(ctx context.Context, projection Projection) error {
+	after, err := p.Checkpoint(ctx, projection)
+	if err != nil {
+		return fmt.Errorf("eventbus: failed to read checkpoint of %s: %w", projection.Name(), err)
+	}
+	for {
+		n, last, err := p.applyBatch(ctx, projection, after)
+		if err != nil {
+			return fmt.Errorf("eventbus: projection %s failed after position %d: %w", projection.Name(), after, err)
+		}
+		if n < p.batchSize {
+			return nil
+		}
+		after = last
+	}
+}
+
+// Rebuild resets the projection and replays the whole event history into it.
+func (p *Projector) Rebuild(ctx context.Context, projection Projection) error {
+	err := composables.InTx(composables.WithPool(ctx, p.pool), func(txCtx context.Context) error {
+		if err := projection.Reset(txCtx); err != nil {
+			return err
+		}
+		tx, err := composables.UseTx(txCtx)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(txCtx, upsertProjectionCheckpointQuery, projection.Name(), 0)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("eventbus: failed to reset projection %s: %w", projection.Name(), err)
+	}
+	return p.CatchUp(ctx, projection)
+}
+
+func (p *Projector) applyBatch(ctx context.Context, projection Projection, after int64) (int, int64, error) {
+	var batch []*StoredEvent
+	ctx = composables.WithPool(ctx, p.pool)
+	// The batch is read in a transaction of its own, since reads hold up appends
+	err := composables.InTx(ctx, func(txCtx context.Context) error {
+		var err error
+		batch, err = p.store.Read(txCtx, &EventStoreReadParams{After: after, Limit: p.batchSize})
+		return err
+	})
+	if err != nil || len(batch) == 0 {
+		return 0, after, err
+	}
+
+	last := after
+	err = composables.InTx(ctx, func(txCtx context.Context) error {
+		for _, stored := range batch {
+			event, err := p.registry.Decode(stored.Type, stored.Payload)
+			if err != nil {
+				return err
+			}
+			eventCtx := txCtx
+			if stored.TenantID != uuid.Nil {
+				eventCtx = composables.WithTenantID(txCtx, stored.TenantID)
+			}
+			if err := projection.Handle(eventCtx, event, stored); err != nil {
+				return err
+			}
+			last = stored.Position
+		}
+		tx, err := composables.UseTx(txCtx)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(txCtx, upsertProjectionCheckpointQuery, projection.Name(), last)
+		return err
+	})
+	return len(batch), last, err
+}
@@ -0,0 +1,128 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Codec converts events of a single type to and from bytes.
+// Events holding interfaces (user.User, chat.Chat, ...) cannot round-trip through
+// plain JSON and need a codec that, for example, encodes ids and reloads the entity.
+type Codec interface {
+	Encode(event interface{}) ([]byte, error)
+	Decode(payload []byte) (interface{}, error)
+}
+
+// Registry maps stable event type names to Go types so that events can be
+// serialized on one side (outbox, remote node) and decoded on the other.
+type Registry struct {
+	mu     sync.RWMutex
+	byName map[string]reflect.Type
+	byType map[reflect.Type]string
+	codecs map[string]Codec
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		byName: make(map[string]reflect.Type),
+		byType: make(map[reflect.Type]string),
+		codecs: make(map[string]Codec),
+	}
+}
+
+// Register associates name with the type of prototype.
+// Pointer and value prototypes are both accepted; events decoded from a
+// registered pointer type are returned as pointers.
+//
+// Example usage:
+//
+//	registry.Register("core.user.created", &user.CreatedEvent{})
+func (r *Registry) Register(name string, prototype interface{}) {
+	r.RegisterCodec(name, prototype, jsonCodec{t: reflect.TypeOf(prototype)})
+}
+
+// RegisterCodec associates name with the type of prototype and encodes it using codec.
+func (r *Registry) RegisterCodec(name string, prototype interface{}, codec Codec) {
+	if name == "" {
+		panic("eventbus: event type name must not be empty")
+	}
+	t := reflect.TypeOf(prototype)
+	if t == nil {
+		panic("eventbus: event prototype must not be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.byName[name]; ok && existing != t {
+		panic(fmt.Sprintf("eventbus: event type %q already registered for %s", name, existing))
+	}
+	r.byName[name] = t
+	r.byType[t] = name
+	r.codecs[name] = codec
+}
+
+// NameOf returns the registered name for the type of event.
+func (r *Registry) NameOf(event interface{}) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	name, ok := r.byType[reflect.TypeOf(event)]
+	return name, ok
+}
+
+// Encode serializes event and returns its registered name alongside the payload.
+func (r *Registry) Encode(event interface{}) (string, []byte, error) {
+	r.mu.RLock()
+	name, ok := r.byType[reflect.TypeOf(event)]
+	codec := r.codecs[name]
+	r.mu.RUnlock()
+	if !ok {
+		return "", nil, fmt.Errorf("eventbus: event type %T is not registered", event)
+	}
+	payload, err := codec.Encode(event)
+	if err != nil {
+		return "", nil, fmt.Errorf("eventbus: failed to encode event %q: %w", name, err)
+	}
+	return name, payload, nil
+}
+
+// Decode builds a new event of the type registered under name from payload.
+func (r *Registry) Decode(name string, payload []byte) (interface{}, error) {
+	r.mu.RLock()
+	codec, ok := r.codecs[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("eventbus: event type %q is not registered", name)
+	}
+	event, err := codec.Decode(payload)
+	if err != nil {
+		return nil, fmt.Errorf("eventbus: failed to decode event %q: %w", name, err)
+	}
+	return event, nil
+}
+
+// jsonCodec is the default codec used by Registry.Register.
+type jsonCodec struct {
+	t reflect.Type
+}
+
+func (c jsonCodec) Encode(event interface{}) ([]byte, error) {
+	return json.Marshal(event)
+}
+
+func (c jsonCodec) Decode(payload []byte) (interface{}, error) {
+	isPtr := c.t.Kind() == reflect.Ptr
+	target := c.t
+	if isPtr {
+		target = c.t.Elem()
+	}
+	v := reflect.New(target)
+	if err := json.Unmarshal(payload, v.Interface()); err != nil {
+		return nil, err
+	}
+	if isPtr {
+		return v.Interface(), nil
+	}
+	return v.Elem().Interface(), nil
+}
@@ -0,0 +1,59 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type registryTestEvent struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestRegistry_EncodeDecode(t *testing.T) {
+	t.Run("PointerEvent", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register("test.created", &registryTestEvent{})
+
+		name, payload, err := registry.Encode(&registryTestEvent{ID: 1, Name: "test"})
+		require.NoError(t, err)
+		assert.Equal(t, "test.created", name)
+
+		event, err := registry.Decode(name, payload)
+		require.NoError(t, err)
+		assert.Equal(t, &registryTestEvent{ID: 1, Name: "test"}, event)
+	})
+
+	t.Run("ValueEvent", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register("test.created", registryTestEvent{})
+
+		name, payload, err := registry.Encode(registryTestEvent{ID: 2})
+		require.NoError(t, err)
+
+		event, err := registry.Decode(name, payload)
+		require.NoError(t, err)
+		assert.Equal(t, registryTestEvent{ID: 2}, event)
+	})
+
+	t.Run("UnregisteredEvent", func(t *testing.T) {
+		registry := NewRegistry()
+
+		_, _, err := registry.Encode(&registryTestEvent{})
+		require.Error(t, err)
+
+		_, err = registry.Decode("unknown", []byte("{}"))
+		require.Error(t, err)
+	})
+
+	t.Run("DuplicateNamePanics", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register("test.created", &registryTestEvent{})
+
+		assert.Panics(t, func() {
+			registry.Register("test.created", &args{})
+		})
+	})
+}
@@ -0,0 +1,152 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// HandlerFunc is the type-erased form of a typed handler. The event passed to it
+// always has the dynamic type the handler was subscribed for.
+type HandlerFunc func(ctx context.Context, event interface{}) error
+
+// TypedEventBus dispatches events by their dynamic type in O(1) and passes the
+// publisher's context (tenant, user, transaction) to handlers.
+//
+// Use the generic Subscribe and Publish functions rather than calling these methods directly.
+type TypedEventBus interface {
+	EventBus
+	// SubscribeType registers handler for events whose dynamic type is exactly eventType
+	// and returns a function that removes the subscription.
+	SubscribeType(eventType reflect.Type, handler HandlerFunc, opts ...SubscribeOption) (unsubscribe func())
+	// PublishContext delivers event to the typed subscribers of its type and to
+	// legacy subscribers whose signature matches.
+	PublishContext(ctx context.Context, event interface{})
+}
+
+var (
+	_ TypedEventBus = (*publisherImpl)(nil)
+	_ TypedEventBus = (*asyncPublisher)(nil)
+	_ TypedEventBus = (*DistributedEventBus)(nil)
+)
+
+var subscriberIDs atomic.Uint64
+
+// WithPriority orders typed subscribers of the same event. Higher priorities
+// run first; subscribers with equal priority run in subscription order.
+// Asynchronous buses start deliveries in that order but may finish them in any order.
+func WithPriority(priority int) SubscribeOption {
+	return func(s *Subscriber) {
+		s.Priority = priority
+	}
+}
+
+// Subscribe registers handler for events of type T. The type is checked at
+// compile time, so a handler cannot silently stop matching when an event changes.
+// Legacy publishers calling bus.Publish(event) reach the handler too, with a
+// background context.
+//
+// Example usage:
+//
+//	eventbus.Subscribe(app.EventPublisher(), func(ctx context.Context, e *user.CreatedEvent) error {
+//		return notify(ctx, e.Result)
+//	})
+func Subscribe[T any](bus EventBus, handler func(ctx context.Context, event T) error, opts ...SubscribeOption) (unsubscribe func()) {
+	eventType := reflect.TypeOf((*T)(nil)).Elem()
+	if eventType.Kind() == reflect.Interface {
+		panic(fmt.Sprintf("eventbus: Subscribe requires a concrete event type, got %s", eventType))
+	}
+	opts = append([]SubscribeOption{WithSubscriberName(HandlerName(handler))}, opts...)
+	return subscribeType(bus, eventType, func(ctx context.Context, event interface{}) error {
+		return handler(ctx, event.(T))
+	}, opts...)
+}
+
+// Publish delivers event to the subscribers of its dynamic type, passing ctx to typed handlers.
+func Publish[T any](ctx context.Context, bus EventBus, event T) {
+	publishContext(ctx, bus, event)
+}
+
+func subscribeType(bus EventBus, eventType reflect.Type, handler HandlerFunc, opts ...SubscribeOption) func() {
+	if typed, ok := bus.(TypedEventBus); ok {
+		return typed.SubscribeType(eventType, handler, opts...)
+	}
+	// Buses without typed dispatch get a handler with the matching signature
+	fn := reflect.MakeFunc(
+		reflect.FuncOf([]reflect.Type{eventType}, []reflect.Type{errorType}, false),
+		func(in []reflect.Value) []reflect.Value {
+			err := handler(context.Background(), in[0].Interface())
+			out := reflect.New(errorType).Elem()
+			if err != nil {
+				out.Set(reflect.ValueOf(err))
+			}
+			return []reflect.Value{out}
+		},
+	).Interface()
+	bus.Subscribe(fn)
+	return func() {
+		bus.Unsubscribe(fn)
+	}
+}
+
+func publishContext(ctx context.Context, bus EventBus, event interface{}) {
+	if typed, ok := bus.(TypedEventBus); ok {
+		typed.PublishContext(ctx, event)
+		return
+	}
+	bus.Publish(event)
+}
+
+// deliverer is implemented by event buses that call subscribers synchronously
+// and can report their failures, which lets the outbox dispatcher retry them.
+type deliverer interface {
+	deliverContext(ctx context.Context, event interface{}) error
+}
+
+// deliverContext publishes event and returns the errors of failed subscribers
+// when bus calls them synchronously. Other buses handle failures on their own,
+// e.g. asynchronous ones with retries and dead letters.
+func deliverContext(ctx context.Context, bus EventBus, event interface{}) error {
+	if d, ok := bus.(deliverer); ok {
+		return d.deliverContext(ctx, event)
+	}
+	publishContext(ctx, bus, event)
+	return nil
+}
+
+func newTypedSubscriber(handler HandlerFunc, defaults RetryPolicy, opts []SubscribeOption) Subscriber {
+	s := Subscriber{
+		Handler: handler,
+		Name:    HandlerName(handler),
+		Retry:   defaults,
+		id:      subscriberIDs.Add(1),
+		invoke:  handler,
+	}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
+// insertByPriority keeps subscribers sorted by descending priority, preserving
+// subscription order within the same priority.
+func insertByPriority(subscribers []Subscriber, s Subscriber) []Subscriber {
+	i := len(subscribers)
+	for i > 0 && subscribers[i-1].Priority < s.Priority {
+		i--
+	}
+	out := make([]Subscriber, 0, len(subscribers)+1)
+	out = append(out, subscribers[:i]...)
+	out = append(out, s)
+	return append(out, subscribers[i:]...)
+}
+
+func removeSubscriber(subscribers []Subscriber, id uint64) []Subscriber {
+	for i, s := range subscribers {
+		if s.id == id {
+			return append(subscribers[:i:i], subscribers[i+1:]...)
+		}
+	}
+	return subscribers
+}
@@ -0,0 +1,165 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/logging"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+type typedTestEvent struct {
+	ID int
+}
+
+type otherTypedTestEvent struct{}
+
+func TestSubscribe_DispatchesByType(t *testing.T) {
+	bus := NewEventPublisher(logging.ConsoleLogger(logrus.FatalLevel))
+
+	var got []int
+	Subscribe(bus, func(_ context.Context, e *typedTestEvent) error {
+		got = append(got, e.ID)
+		return nil
+	})
+	others := 0
+	Subscribe(bus, func(_ context.Context, e *otherTypedTestEvent) error {
+		others++
+		return nil
+	})
+
+	Publish(context.Background(), bus, &typedTestEvent{ID: 1})
+	// Legacy publishers reach typed subscribers too
+	bus.Publish(&typedTestEvent{ID: 2})
+	// Value and pointer types are distinct events
+	Publish(context.Background(), bus, typedTestEvent{ID: 3})
+
+	assert.Equal(t, []int{1, 2}, got)
+	assert.Zero(t, others)
+	assert.Equal(t, 2, bus.SubscribersCount())
+}
+
+func TestSubscribe_PropagatesContext(t *testing.T) {
+	bus := NewEventPublisher(logging.ConsoleLogger(logrus.FatalLevel))
+	tenantID := uuid.New()
+
+	var got uuid.UUID
+	Subscribe(bus, func(ctx context.Context, _ *typedTestEvent) error {
+		id, err := composables.UseTenantID(ctx)
+		got = id
+		return err
+	})
+
+	Publish(composables.WithTenantID(context.Background(), tenantID), bus, &typedTestEvent{})
+
+	assert.Equal(t, tenantID, got)
+}
+
+func TestSubscribe_Priority(t *testing.T) {
+	bus := NewEventPublisher(logging.ConsoleLogger(logrus.FatalLevel))
+
+	var order []string
+	record := func(name string) func(context.Context, *typedTestEvent) error {
+		return func(context.Context, *typedTestEvent) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+	Subscribe(bus, record("default-1"))
+	Subscribe(bus, record("low"), WithPriority(-10))
+	Subscribe(bus, record("high"), WithPriority(10))
+	Subscribe(bus, record("default-2"))
+
+	Publish(context.Background(), bus, &typedTestEvent{})
+
+	assert.Equal(t, []string{"high", "default-1", "default-2", "low"}, order)
+}
+
+func TestSubscribe_UnsubscribeAndFailures(t *testing.T) {
+	bus := NewEventPublisher(logging.ConsoleLogger(logrus.FatalLevel))
+
+	calls := 0
+	Subscribe(bus, func(context.Context, *typedTestEvent) error {
+		panic("boom")
+	})
+	Subscribe(bus, func(context.Context, *typedTestEvent) error {
+		return errors.New("failed")
+	})
+	unsubscribe := Subscribe(bus, func(context.Context, *typedTestEvent) error {
+		calls++
+		return nil
+	})
+
+	Publish(context.Background(), bus, &typedTestEvent{})
+	unsubscribe()
+	Publish(context.Background(), bus, &typedTestEvent{})
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 2, bus.SubscribersCount())
+}
+
+func TestSubscribe_InterfaceTypePanics(t *testing.T) {
+	bus := NewEventPublisher(logging.ConsoleLogger(logrus.FatalLevel))
+	assert.Panics(t, func() {
+		Subscribe(bus, func(context.Context, interface{}) error { return nil })
+	})
+}
+
+func TestSubscribe_Async(t *testing.T) {
+	bus := NewAsyncEventPublisher(AsyncOptions{
+		Logger: logging.ConsoleLogger(logrus.FatalLevel),
+		Retry:  RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, Multiplier: 1},
+	})
+	tenantID := uuid.New()
+
+	var (
+		mu       sync.Mutex
+		attempts int
+		got      uuid.UUID
+	)
+	Subscribe(bus, func(ctx context.Context, _ *typedTestEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts == 1 {
+			return errors.New("transient")
+		}
+		got, _ = composables.UseTenantID(ctx)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(composables.WithTenantID(context.Background(), tenantID))
+	Publish(ctx, bus, &typedTestEvent{})
+	// Deliveries outlive the publisher's context
+	cancel()
+	require.NoError(t, bus.Shutdown(context.Background()))
+
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, tenantID, got)
+}
+
+func TestInvalidateCache(t *testing.T) {
+	bus := NewEventPublisher(logging.ConsoleLogger(logrus.FatalLevel))
+	cache := repo.NewLRUCache(repo.LRUCacheOptions{})
+	require.NoError(t, cache.SetWithTags("a", 1, repo.CacheTag("item", 1)))
+	require.NoError(t, cache.SetWithTags("b", 2, repo.CacheTag("item", 2)))
+
+	InvalidateCache(bus, cache, func(e *typedTestEvent) []string {
+		return []string{repo.CacheTag("item", e.ID)}
+	})
+	Publish(context.Background(), bus, &typedTestEvent{ID: 1})
+
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+	_, ok = cache.Get("b")
+	assert.True(t, ok)
+}
@@ -0,0 +1,274 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
+)
+
+const (
+	defaultCrudLimit = 20
+	maxCrudLimit     = 100
+)
+
+// CrudPermissions are the permissions the queries and mutations of a crud resource
+// require. Operations with a nil permission only require an authenticated user.
+type CrudPermissions struct {
+	Read   rbac.Permission
+	Create rbac.Permission
+	Update rbac.Permission
+	Delete rbac.Permission
+}
+
+// CrudResource exposes the entities of a crud schema in a CrudSchema.
+// Entities are passed around as the field values of the schema.
+type CrudResource interface {
+	// Name is the name of the crud schema, which the GraphQL names derive from
+	Name() string
+	Fields() crud.Fields
+	Permissions() CrudPermissions
+	// List returns the entities matching params and the cursor of the next page,
+	// which is empty on the last page
+	List(ctx context.Context, params *crud.FindParams) ([][]crud.FieldValue, string, error)
+	Count(ctx context.Context, params *crud.FindParams) (int64, error)
+	Get(ctx context.Context, key crud.FieldValue) ([]crud.FieldValue, error)
+	Save(ctx context.Context, fieldValues []crud.FieldValue) ([]crud.FieldValue, error)
+	Delete(ctx context.Context, key crud.FieldValue) ([]crud.FieldValue, error)
+}
+
+// CrudResourceOption defines options for NewCrudResource
+type CrudResourceOption func(*crudResourceOptions)
+
+type crudResourceOptions struct {
+	permissions CrudPermissions
+}
+
+// WithCrudPermissions makes the operations of a resource require permissions
+func WithCrudPermissions(permissions CrudPermissions) CrudResourceOption {
+	return func(o *crudResourceOptions) {
+		o.permissions = permissions
+	}
+}
+
+// NewCrudResource exposes the schema of builder through its service
+func NewCrudResource[TEntity any](builder crud.Builder[TEntity], opts ...CrudResourceOption) CrudResource {
+	options := &crudResourceOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return &crudResource[TEntity]{
+		schema:      builder.Schema(),
+		service:     builder.Service(),
+		permissions: options.permissions,
+	}
+}
+
+type crudResource[TEntity any] struct {
+	schema      crud.Schema[TEntity]
+	service     crud.Service[TEntity]
+	permissions CrudPermissions
+}
+
+func (r *crudResource[TEntity]) Name() string {
+	return r.schema.Name()
+}
+
+func (r *crudResource[TEntity]) Fields() crud.Fields {
+	return r.schema.Fields()
+}
+
+func (r *crudResource[TEntity]) Permissions() CrudPermissions {
+	return r.permissions
+}
+
+func (r *crudResource[TEntity]) List(ctx context.Context, params *crud.FindParams) ([][]crud.FieldValue, string, error) {
+	// Fetch one extra entity to find out whether there is another page
+	p := *params
+	p.Limit++
+	entities, err := r.service.List(ctx, &p)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextCursor string
+	if len(entities) > params.Limit {
+		entities = entities[:params.Limit]
+		nextCursor, err = crud.NextCursor(ctx, r.schema, params.SortBy, entities[len(entities)-1])
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	if len(entities) == 0 {
+		return nil, nextCursor, nil
+	}
+
+	rows, err := r.schema.Mapper().ToFieldValuesList(ctx, entities...)
+	if err != nil {
+		return nil, "", err
+	}
+	return rows, nextCursor, nil
+}
+
+func (r *crudResource[TEntity]) Count(ctx context.Context, params *crud.FindParams) (int64, error) {
+	return r.service.Count(ctx, params)
+}
+
+func (r *crudResource[TEntity]) Get(ctx context.Context, key crud.FieldValue) ([]crud.FieldValue, error) {
+	entity, err := r.service.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return r.schema.Mapper().ToFieldValues(ctx, entity)
+}
+
+func (r *crudResource[TEntity]) Save(ctx context.Context, fieldValues []crud.FieldValue) ([]crud.FieldValue, error) {
+	entity, err := r.schema.Mapper().ToEntity(ctx, fieldValues)
+	if err != nil {
+		return nil, err
+	}
+	saved, err := r.service.Save(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+	return r.schema.Mapper().ToFieldValues(ctx, saved)
+}
+
+func (r *crudResource[TEntity]) Delete(ctx context.Context, key crud.FieldValue) ([]crud.FieldValue, error) {
+	entity, err := r.service.Delete(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return r.schema.Mapper().ToFieldValues(ctx, entity)
+}
+
+type crudOperation string
+
+const (
+	crudList   crudOperation = "list"
+	crudGet    crudOperation = "get"
+	crudCreate crudOperation = "create"
+	crudUpdate crudOperation = "update"
+	crudDelete crudOperation = "delete"
+)
+
+type crudRootField struct {
+	operation crudOperation
+	resource  CrudResource
+}
+
+// CrudSchema is an executable GraphQL schema generated from crud resources at
+// runtime. For a schema named order_items it declares:
+//
+//	type OrderItems            the entity, with the fields returned by crud.APIFields
+//	type OrderItemsPage        a page of entities: data, nextCursor and total
+//	input OrderItemsInput      the writable fields, relations take target keys
+//	input OrderItemsFilter     a filter input per field, e.g. {price: {gte: 10}}
+//	input OrderItemsSort       a field and direction to sort by
+//
+//	listOrderItems(filter, sort, q, limit, offset, cursor): OrderItemsPage
+//	getOrderItems(id): OrderItems
+//	createOrderItems(input): OrderItems
+//	updateOrderItems(id, input): OrderItems
+//	deleteOrderItems(id): OrderItems
+//
+// Update changes the fields present in the input and keeps the others. Errors
+// carry a code extension, and validation failures also carry the
+// serrors.ValidationError of every invalid field under errors.
+type CrudSchema struct {
+	sdl    string
+	schema *ast.Schema
+	roots  map[string]map[string]crudRootField
+}
+
+var _ graphql.ExecutableSchema = (*CrudSchema)(nil)
+
+// NewCrudSchema creates the GraphQL schema of resources. Register it with
+// application.RegisterGraphSchema:
+//
+//	schema, err := graphql.NewCrudSchema(
+//		graphql.NewCrudResource(productsBuilder),
+//		graphql.NewCrudResource(ordersBuilder, graphql.WithCrudPermissions(orderPermissions)),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	app.RegisterGraphSchema(application.GraphSchema{Value: schema, BasePath: "/shop"})
+func NewCrudSchema(resources ...CrudResource) (*CrudSchema, error) {
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("crud schema requires at least one resource")
+	}
+
+	sdl := crudSDL(resources)
+	schema, err := gqlparser.LoadSchema(&ast.Source{Name: "crud.graphql", Input: sdl})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load crud schema: %w", err)
+	}
+
+	roots := map[string]map[string]crudRootField{
+		"Query":    {},
+		"Mutation": {},
+	}
+	for _, r := range resources {
+		typeName := crudTypeName(r.Name())
+		roots["Query"]["list"+typeName] = crudRootField{operation: crudList, resource: r}
+		roots["Query"]["get"+typeName] = crudRootField{operation: crudGet, resource: r}
+		roots["Mutation"]["create"+typeName] = crudRootField{operation: crudCreate, resource: r}
+		roots["Mutation"]["update"+typeName] = crudRootField{operation: crudUpdate, resource: r}
+		roots["Mutation"]["delete"+typeName] = crudRootField{operation: crudDelete, resource: r}
+	}
+
+	return &CrudSchema{
+		sdl:    sdl,
+		schema: schema,
+		roots:  roots,
+	}, nil
+}
+
+// SDL returns the schema definition
+func (s *CrudSchema) SDL() string {
+	return s.sdl
+}
+
+func (s *CrudSchema) Schema() *ast.Schema {
+	return s.schema
+}
+
+func (s *CrudSchema) Complexity(typeName, fieldName string, childComplexity int, args map[string]any) (int, bool) {
+	return 0, false
+}
+
+func (s *CrudSchema) Exec(ctx context.Context) graphql.ResponseHandler {
+	opCtx := graphql.GetOperationContext(ctx)
+
+	var object string
+	switch opCtx.Operation.Operation {
+	case ast.Query:
+		object = "Query"
+	case ast.Mutation:
+		object = "Mutation"
+	case ast.Subscription:
+		return graphql.OneShot(graphql.ErrorResponse(ctx, "subscriptions are not supported"))
+	}
+
+	first := true
+	return func(ctx context.Context) *graphql.Response {
+		if !first {
+			return nil
+		}
+		first = false
+
+		e := &crudExecution{schema: s, opCtx: opCtx}
+		data, err := json.Marshal(e.root(ctx, object, opCtx.Operation.SelectionSet))
+		if err != nil {
+			return graphql.ErrorResponse(ctx, "failed to marshal response: %v", err)
+		}
+		return &graphql.Response{Data: data}
+	}
+}
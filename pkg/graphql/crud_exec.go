@@ -0,0 +1,486 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+	"github.com/iota-uz/iota-sdk/pkg/rbac"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+)
+
+// crudObject is a JSON object that keeps the order of the selected fields
+type crudObject []crudObjectField
+
+type crudObjectField struct {
+	key   string
+	value any
+}
+
+func (o crudObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(f.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// crudExecution resolves the selections of an operation on a CrudSchema
+type crudExecution struct {
+	schema *CrudSchema
+	opCtx  *graphql.OperationContext
+}
+
+// object resolves the fields selected on an object of typeName with resolve
+func (e *crudExecution) object(typeName string, sel ast.SelectionSet, resolve func(f graphql.CollectedField) any) crudObject {
+	fields := graphql.CollectFields(e.opCtx, sel, []string{typeName})
+	result := make(crudObject, 0, len(fields))
+	for _, f := range fields {
+		var value any
+		if f.Name == "__typename" {
+			value = typeName
+		} else {
+			value = resolve(f)
+		}
+		result = append(result, crudObjectField{key: f.Alias, value: value})
+	}
+	return result
+}
+
+// root resolves the root fields of an operation. Failed fields resolve to null
+// and add an error to the response.
+func (e *crudExecution) root(ctx context.Context, object string, sel ast.SelectionSet) crudObject {
+	return e.object(object, sel, func(f graphql.CollectedField) any {
+		args := f.ArgumentMap(e.opCtx.Variables)
+		ctx := graphql.WithFieldContext(ctx, &graphql.FieldContext{
+			Object: object,
+			Field:  f,
+			Args:   args,
+		})
+
+		switch f.Name {
+		case "__schema", "__type":
+			if e.opCtx.DisableIntrospection {
+				graphql.AddError(ctx, gqlerror.Errorf("introspection disabled"))
+				return nil
+			}
+			return e.introspect(f, args)
+		}
+
+		root, ok := e.schema.roots[object][f.Name]
+		if !ok {
+			graphql.AddError(ctx, gqlerror.Errorf("unknown field %s.%s", object, f.Name))
+			return nil
+		}
+		value, err := e.resolve(ctx, root, f, args)
+		if err != nil {
+			graphql.AddError(ctx, crudError(ctx, err))
+			return nil
+		}
+		return value
+	})
+}
+
+func (e *crudExecution) resolve(ctx context.Context, root crudRootField, f graphql.CollectedField, args map[string]any) (any, error) {
+	if _, err := composables.UseUser(ctx); err != nil {
+		return nil, serrors.UnauthorizedGQLError(graphql.GetPath(ctx))
+	}
+
+	r := root.resource
+	permissions := r.Permissions()
+	typeName := crudTypeName(r.Name())
+
+	switch root.operation {
+	case crudList:
+		if err := authorize(ctx, permissions.Read); err != nil {
+			return nil, err
+		}
+		return e.list(ctx, r, f, args)
+	case crudGet:
+		if err := authorize(ctx, permissions.Read); err != nil {
+			return nil, err
+		}
+		key, err := crudKey(r, args)
+		if err != nil {
+			return nil, err
+		}
+		fieldValues, err := r.Get(ctx, key)
+		if errors.Is(err, crud.ErrNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return e.entity(ctx, typeName, fieldValues, f.Selections), nil
+	case crudCreate:
+		if err := authorize(ctx, permissions.Create); err != nil {
+			return nil, err
+		}
+		fieldValues, err := crudInput(ctx, r, args)
+		if err != nil {
+			return nil, err
+		}
+		present := make(map[string]bool, len(fieldValues))
+		for _, fv := range fieldValues {
+			present[fv.Field().Name()] = true
+		}
+		for _, field := range r.Fields().Fields() {
+			if !present[field.Name()] {
+				fieldValues = append(fieldValues, field.Value(field.InitialValue(ctx)))
+			}
+		}
+		return e.save(ctx, r, fieldValues, f.Selections)
+	case crudUpdate:
+		if err := authorize(ctx, permissions.Update); err != nil {
+			return nil, err
+		}
+		key, err := crudKey(r, args)
+		if err != nil {
+			return nil, err
+		}
+		stored, err := r.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		fieldValues, err := crudInput(ctx, r, args)
+		if err != nil {
+			return nil, err
+		}
+
+		// The key is taken from the arguments, the fields missing from the input from the stored entity
+		fieldValues = slices.DeleteFunc(fieldValues, func(fv crud.FieldValue) bool {
+			return fv.Field().Key()
+		})
+		fieldValues = append(fieldValues, key)
+		present := make(map[string]bool, len(fieldValues))
+		for _, fv := range fieldValues {
+			present[fv.Field().Name()] = true
+		}
+		for _, fv := range stored {
+			if !present[fv.Field().Name()] {
+				fieldValues = append(fieldValues, fv)
+			}
+		}
+		return e.save(ctx, r, fieldValues, f.Selections)
+	case crudDelete:
+		if err := authorize(ctx, permissions.Delete); err != nil {
+			return nil, err
+		}
+		key, err := crudKey(r, args)
+		if err != nil {
+			return nil, err
+		}
+		fieldValues, err := r.Delete(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		return e.entity(ctx, typeName, fieldValues, f.Selections), nil
+	}
+	return nil, fmt.Errorf("unknown crud operation %q", root.operation)
+}
+
+// save validates fieldValues and saves the entity they make up
+func (e *crudExecution) save(ctx context.Context, r CrudResource, fieldValues []crud.FieldValue, sel ast.SelectionSet) (any, error) {
+	if errs := crud.ValidateFieldValues(fieldValues); len(errs) > 0 {
+		return nil, serrors.ValidationGQLError(graphql.GetPath(ctx), errs)
+	}
+	saved, err := r.Save(ctx, fieldValues)
+	if err != nil {
+		return nil, err
+	}
+	return e.entity(ctx, crudTypeName(r.Name()), saved, sel), nil
+}
+
+func (e *crudExecution) list(ctx context.Context, r CrudResource, f graphql.CollectedField, args map[string]any) (any, error) {
+	typeName := crudTypeName(r.Name())
+	params, err := crudFindParams(ctx, r, args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the relations selected on the entities of the page
+	page := graphql.CollectFields(e.opCtx, f.Selections, []string{typeName + "Page"})
+	for _, pf := range page {
+		if pf.Name != "data" {
+			continue
+		}
+		for _, df := range graphql.CollectFields(e.opCtx, pf.Selections, []string{typeName}) {
+			field, err := r.Fields().Field(df.Name)
+			if err == nil && crud.IsVirtualField(field) && !slices.Contains(params.Include, df.Name) {
+				params.Include = append(params.Include, df.Name)
+			}
+		}
+	}
+
+	rows, nextCursor, err := r.List(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for _, pf := range page {
+		if pf.Name == "total" {
+			if total, err = r.Count(ctx, params); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	return e.object(typeName+"Page", f.Selections, func(pf graphql.CollectedField) any {
+		switch pf.Name {
+		case "data":
+			data := make([]any, 0, len(rows))
+			for _, row := range rows {
+				data = append(data, e.entity(ctx, typeName, row, pf.Selections))
+			}
+			return data
+		case "nextCursor":
+			if nextCursor == "" {
+				return nil
+			}
+			return nextCursor
+		case "total":
+			return total
+		}
+		return nil
+	}), nil
+}
+
+// entity resolves the fields selected on an entity, which are null when the
+// user in ctx may not read them
+func (e *crudExecution) entity(ctx context.Context, typeName string, fieldValues []crud.FieldValue, sel ast.SelectionSet) crudObject {
+	values := make(map[string]crud.FieldValue, len(fieldValues))
+	for _, fv := range fieldValues {
+		values[fv.Field().Name()] = fv
+	}
+
+	return e.object(typeName, sel, func(f graphql.CollectedField) any {
+		fv, ok := values[f.Name]
+		if !ok || !crud.CanReadField(ctx, fv.Field()) {
+			return nil
+		}
+		value := crud.APIValue(fv)
+		records, ok := value.([]crud.APIRelatedRecord)
+		if !ok {
+			return value
+		}
+		result := make([]any, 0, len(records))
+		for _, record := range records {
+			result = append(result, e.object("RelatedRecord", f.Selections, func(rf graphql.CollectedField) any {
+				switch rf.Name {
+				case "key":
+					return fmt.Sprint(record.Key)
+				case "label":
+					return record.Label
+				}
+				return nil
+			}))
+		}
+		return result
+	})
+}
+
+func authorize(ctx context.Context, perm rbac.Permission) error {
+	if perm == nil {
+		return nil
+	}
+	return composables.CanUserAll(ctx, perm)
+}
+
+// crudKey parses the key argument of get, update and delete
+func crudKey(r CrudResource, args map[string]any) (crud.FieldValue, error) {
+	key := r.Fields().KeyField()
+	return crud.ParseAPIValue(key, crudArgValue(args[key.Name()]))
+}
+
+// crudInput parses the input argument of create and update into values of the
+// writable fields it holds. Inputs with fields the user in ctx may not write are
+// forbidden.
+func crudInput(ctx context.Context, r CrudResource, args map[string]any) ([]crud.FieldValue, error) {
+	input, _ := args["input"].(map[string]any)
+	fieldValues := make([]crud.FieldValue, 0, len(input))
+	errs := make(serrors.ValidationErrors)
+	for _, f := range crudWritableFields(r.Fields()) {
+		value, ok := input[f.Name()]
+		if !ok {
+			continue
+		}
+		if !crud.CanWriteField(ctx, f) {
+			return nil, serrors.ForbiddenGQLError(graphql.GetPath(ctx))
+		}
+		fv, err := crud.ParseAPIValue(f, crudArgValue(value))
+		if err != nil {
+			errs[f.Name()] = crud.NewFieldValidationError(f, err)
+			continue
+		}
+		fieldValues = append(fieldValues, fv)
+	}
+	if len(errs) > 0 {
+		return nil, serrors.ValidationGQLError(graphql.GetPath(ctx), errs)
+	}
+	return fieldValues, nil
+}
+
+// crudFindParams builds the list parameters from the arguments of a list query
+func crudFindParams(ctx context.Context, r CrudResource, args map[string]any) (*crud.FindParams, error) {
+	params := &crud.FindParams{Limit: defaultCrudLimit}
+	if v, ok := args["q"].(string); ok {
+		params.Query = v
+	}
+	if v, ok := args["cursor"].(string); ok {
+		params.Cursor = v
+	}
+	if v, ok := crudInt(args["limit"]); ok {
+		if v < 1 {
+			return nil, fmt.Errorf("%w: limit %d", crud.ErrInvalidAPIValue, v)
+		}
+		params.Limit = min(v, maxCrudLimit)
+	}
+	if v, ok := crudInt(args["offset"]); ok {
+		if v < 0 {
+			return nil, fmt.Errorf("%w: offset %d", crud.ErrInvalidAPIValue, v)
+		}
+		params.Offset = v
+	}
+
+	sort, _ := args["sort"].([]any)
+	for _, s := range sort {
+		s, _ := s.(map[string]any)
+		name, _ := s["field"].(string)
+		desc, _ := s["desc"].(bool)
+		if field, err := r.Fields().Field(name); err == nil && !crud.CanReadField(ctx, field) {
+			return nil, fmt.Errorf("%w: can't sort by %q", composables.ErrForbidden, name)
+		}
+		params.SortBy.Fields = append(params.SortBy.Fields, repo.SortByField[string]{
+			Field:     name,
+			Ascending: !desc,
+		})
+	}
+
+	filter, _ := args["filter"].(map[string]any)
+	for _, f := range crudFilterableFields(r.Fields()) {
+		ops, _ := filter[f.Name()].(map[string]any)
+		if len(ops) > 0 && !crud.CanReadField(ctx, f) {
+			return nil, fmt.Errorf("%w: can't filter by %q", composables.ErrForbidden, f.Name())
+		}
+		for _, op := range []string{"eq", "ne", "gt", "gte", "lt", "lte", "in", "like"} {
+			value, ok := ops[op]
+			if !ok || value == nil {
+				continue
+			}
+			values := []any{crudArgValue(value)}
+			if op == "in" {
+				values, _ = crudArgValue(value).([]any)
+			}
+			filter, err := crud.APIFilter(f, op, values...)
+			if err != nil {
+				return nil, err
+			}
+			params.Filters = append(params.Filters, crud.Filter{Column: f.Name(), Filter: filter})
+		}
+	}
+
+	return params, nil
+}
+
+// crudArgValue converts the numbers of argument values, which are int64 and
+// float64 in literals and json.Number in variables, to json.Number as expected
+// by crud.ParseAPIValue, including those in lists and objects
+func crudArgValue(value any) any {
+	switch v := value.(type) {
+	case int:
+		return json.Number(strconv.Itoa(v))
+	case int64:
+		return json.Number(strconv.FormatInt(v, 10))
+	case float64:
+		return json.Number(strconv.FormatFloat(v, 'f', -1, 64))
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = crudArgValue(item)
+		}
+		return result
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for k, item := range v {
+			result[k] = crudArgValue(item)
+		}
+		return result
+	}
+	return value
+}
+
+func crudInt(value any) (int, bool) {
+	if value == nil {
+		return 0, false
+	}
+	n, ok := crudArgValue(value).(json.Number)
+	if !ok {
+		return 0, false
+	}
+	i, err := n.Int64()
+	return int(i), err == nil
+}
+
+// crudError converts errors of resolvers to GraphQL errors with a code extension
+func crudError(ctx context.Context, err error) error {
+	path := graphql.GetPath(ctx)
+	var gqlErr *gqlerror.Error
+	var conflict *serrors.ConflictError
+	switch {
+	case errors.As(err, &gqlErr):
+		return gqlErr
+	case errors.Is(err, composables.ErrForbidden):
+		return serrors.ForbiddenGQLError(path)
+	case errors.Is(err, crud.ErrNotFound):
+		return crudGQLError(path, "NOT_FOUND", err.Error())
+	case errors.As(err, &conflict):
+		return crudGQLError(path, conflict.Code, conflict.Message)
+	case errors.Is(err, crud.ErrValidation):
+		if errs := crud.ValidationErrorsOf(err); len(errs) > 0 {
+			return serrors.ValidationGQLError(path, errs)
+		}
+		return crudGQLError(path, "VALIDATION_ERROR", err.Error())
+	case errors.Is(err, crud.ErrInvalidAPIValue), errors.Is(err, repo.ErrInvalidCursor):
+		return crudGQLError(path, "BAD_USER_INPUT", err.Error())
+	}
+	composables.UseLogger(ctx).WithError(err).Error("crud GraphQL operation failed")
+	return crudGQLError(path, "INTERNAL", "internal server error")
+}
+
+func crudGQLError(path ast.Path, code, message string) *gqlerror.Error {
+	return &gqlerror.Error{
+		Path:    path,
+		Message: message,
+		Extensions: map[string]interface{}{
+			"code": code,
+		},
+	}
+}
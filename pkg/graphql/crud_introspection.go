@@ -0,0 +1,185 @@
+package graphql
+
+import (
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/introspection"
+)
+
+// introspect resolves the __schema and __type root fields
+func (e *crudExecution) introspect(f graphql.CollectedField, args map[string]any) any {
+	if f.Name == "__schema" {
+		return e.introspectSchema(introspection.WrapSchema(e.schema.schema), f)
+	}
+	name, _ := args["name"].(string)
+	def := e.schema.schema.Types[name]
+	if def == nil {
+		return nil
+	}
+	return e.introspectType(introspection.WrapTypeFromDef(e.schema.schema, def), f)
+}
+
+func (e *crudExecution) introspectSchema(s *introspection.Schema, f graphql.CollectedField) any {
+	return e.object("__Schema", f.Selections, func(sf graphql.CollectedField) any {
+		switch sf.Name {
+		case "description":
+			return s.Description()
+		case "types":
+			types := s.Types()
+			result := make([]any, len(types))
+			for i := range types {
+				result[i] = e.introspectType(&types[i], sf)
+			}
+			return result
+		case "queryType":
+			return e.introspectType(s.QueryType(), sf)
+		case "mutationType":
+			return e.introspectType(s.MutationType(), sf)
+		case "subscriptionType":
+			return e.introspectType(s.SubscriptionType(), sf)
+		case "directives":
+			directives := s.Directives()
+			result := make([]any, len(directives))
+			for i := range directives {
+				result[i] = e.introspectDirective(&directives[i], sf)
+			}
+			return result
+		}
+		return nil
+	})
+}
+
+func (e *crudExecution) introspectType(t *introspection.Type, f graphql.CollectedField) any {
+	if t == nil {
+		return nil
+	}
+	return e.object("__Type", f.Selections, func(tf graphql.CollectedField) any {
+		includeDeprecated, _ := tf.ArgumentMap(e.opCtx.Variables)["includeDeprecated"].(bool)
+		switch tf.Name {
+		case "kind":
+			return t.Kind()
+		case "name":
+			return t.Name()
+		case "description":
+			return t.Description()
+		case "specifiedByURL":
+			return t.SpecifiedByURL()
+		case "fields":
+			fields := t.Fields(includeDeprecated)
+			if fields == nil {
+				return nil
+			}
+			result := make([]any, len(fields))
+			for i := range fields {
+				result[i] = e.introspectField(&fields[i], tf)
+			}
+			return result
+		case "interfaces", "possibleTypes":
+			types := t.Interfaces()
+			if tf.Name == "possibleTypes" {
+				types = t.PossibleTypes()
+			}
+			if types == nil {
+				return nil
+			}
+			result := make([]any, len(types))
+			for i := range types {
+				result[i] = e.introspectType(&types[i], tf)
+			}
+			return result
+		case "enumValues":
+			values := t.EnumValues(includeDeprecated)
+			if values == nil {
+				return nil
+			}
+			result := make([]any, len(values))
+			for i := range values {
+				result[i] = e.introspectEnumValue(&values[i], tf)
+			}
+			return result
+		case "inputFields":
+			fields := t.InputFields()
+			if fields == nil {
+				return nil
+			}
+			return e.introspectInputValues(fields, tf)
+		case "ofType":
+			return e.introspectType(t.OfType(), tf)
+		}
+		return nil
+	})
+}
+
+func (e *crudExecution) introspectField(field *introspection.Field, f graphql.CollectedField) any {
+	return e.object("__Field", f.Selections, func(ff graphql.CollectedField) any {
+		switch ff.Name {
+		case "name":
+			return field.Name
+		case "description":
+			return field.Description()
+		case "args":
+			return e.introspectInputValues(field.Args, ff)
+		case "type":
+			return e.introspectType(field.Type, ff)
+		case "isDeprecated":
+			return field.IsDeprecated()
+		case "deprecationReason":
+			return field.DeprecationReason()
+		}
+		return nil
+	})
+}
+
+func (e *crudExecution) introspectInputValues(values []introspection.InputValue, f graphql.CollectedField) []any {
+	result := make([]any, len(values))
+	for i := range values {
+		value := &values[i]
+		result[i] = e.object("__InputValue", f.Selections, func(vf graphql.CollectedField) any {
+			switch vf.Name {
+			case "name":
+				return value.Name
+			case "description":
+				return value.Description()
+			case "type":
+				return e.introspectType(value.Type, vf)
+			case "defaultValue":
+				return value.DefaultValue
+			}
+			return nil
+		})
+	}
+	return result
+}
+
+func (e *crudExecution) introspectEnumValue(value *introspection.EnumValue, f graphql.CollectedField) any {
+	return e.object("__EnumValue", f.Selections, func(vf graphql.CollectedField) any {
+		switch vf.Name {
+		case "name":
+			return value.Name
+		case "description":
+			return value.Description()
+		case "isDeprecated":
+			return value.IsDeprecated()
+		case "deprecationReason":
+			return value.DeprecationReason()
+		}
+		return nil
+	})
+}
+
+func (e *crudExecution) introspectDirective(d *introspection.Directive, f graphql.CollectedField) any {
+	return e.object("__Directive", f.Selections, func(df graphql.CollectedField) any {
+		switch df.Name {
+		case "name":
+			return d.Name
+		case "description":
+			return d.Description()
+		case "locations":
+			return d.Locations
+		case "args":
+			return e.introspectInputValues(d.Args, df)
+		case "isRepeatable":
+			return d.IsRepeatable
+		}
+		return nil
+	})
+}
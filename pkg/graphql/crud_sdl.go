@@ -0,0 +1,210 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+)
+
+// crudScalarsSDL declares the scalars and types shared by all crud resources
+const crudScalarsSDL = `"""A date formatted as YYYY-MM-DD"""
+scalar Date
+
+"""A time of day formatted as HH:MM:SS"""
+scalar Time
+
+"""A date and time formatted as RFC 3339"""
+scalar DateTime
+
+scalar UUID
+
+"""A decimal number formatted as a string to keep its precision"""
+scalar Decimal
+
+scalar JSON
+
+"""An amount of money as {amount, currency} with the amount in minor units, e.g. cents.
+Inputs also take amounts in major units as strings, e.g. "12.50"."""
+scalar Money
+
+"""Coordinates as {lat, lng}. Inputs also take them as "lat,lng" strings."""
+scalar GeoPoint
+
+"""A record linked to an entity by a relation field"""
+type RelatedRecord {
+  key: ID!
+  label: String!
+}
+
+input BooleanFilter {
+  eq: Boolean
+  ne: Boolean
+}
+`
+
+// crudFilterScalars are the scalars filter inputs are declared for besides Boolean
+var crudFilterScalars = []string{"String", "Int", "Float", "Decimal", "Date", "Time", "DateTime", "UUID", "Money"}
+
+// crudTypeName converts a schema name to a GraphQL type name, e.g. order_items to OrderItems
+func crudTypeName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-' || r == '.'
+	}) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// crudScalar returns the GraphQL type of the values of field
+func crudScalar(field crud.Field) string {
+	switch field.Type() {
+	case crud.StringFieldType:
+		return "String"
+	case crud.IntFieldType:
+		return "Int"
+	case crud.BoolFieldType:
+		return "Boolean"
+	case crud.FloatFieldType:
+		return "Float"
+	case crud.DecimalFieldType:
+		return "Decimal"
+	case crud.DateFieldType:
+		return "Date"
+	case crud.TimeFieldType:
+		return "Time"
+	case crud.DateTimeFieldType, crud.TimestampFieldType:
+		return "DateTime"
+	case crud.UUIDFieldType:
+		return "UUID"
+	case crud.JSONFieldType:
+		return "JSON"
+	case crud.RelationFieldType:
+		return "[RelatedRecord!]"
+	case crud.MoneyFieldType:
+		return "Money"
+	case crud.EnumFieldType:
+		return "String"
+	case crud.FileFieldType:
+		return "Int"
+	case crud.GeoPointFieldType:
+		return "GeoPoint"
+	}
+	return "String"
+}
+
+// crudFilterable reports whether lists can be filtered and sorted by field
+func crudFilterable(field crud.Field) bool {
+	return !crud.IsVirtualField(field) && field.Type() != crud.JSONFieldType && field.Type() != crud.GeoPointFieldType
+}
+
+// crudFilterType returns the filter input of field
+func crudFilterType(field crud.Field) string {
+	return crudScalar(field) + "Filter"
+}
+
+// crudInputType returns the GraphQL type of field in inputs. Virtual relations
+// take the keys of the target records.
+func crudInputType(field crud.Field) string {
+	if crud.IsVirtualField(field) {
+		return "[ID!]"
+	}
+	return crudScalar(field)
+}
+
+func crudWritableFields(fields crud.Fields) []crud.Field {
+	var result []crud.Field
+	for _, f := range crud.APIFields(fields) {
+		if crud.IsAPIWritable(f) {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+func crudFilterableFields(fields crud.Fields) []crud.Field {
+	var result []crud.Field
+	for _, f := range crud.APIFields(fields) {
+		if crudFilterable(f) {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// crudSDL writes the schema definition of resources
+func crudSDL(resources []CrudResource) string {
+	var b strings.Builder
+	b.WriteString(crudScalarsSDL)
+	for _, scalar := range crudFilterScalars {
+		fmt.Fprintf(&b, "\ninput %sFilter {\n", scalar)
+		for _, op := range []string{"eq", "ne", "gt", "gte", "lt", "lte"} {
+			fmt.Fprintf(&b, "  %s: %s\n", op, scalar)
+		}
+		fmt.Fprintf(&b, "  in: [%s!]\n", scalar)
+		if scalar == "String" {
+			b.WriteString("  \"\"\"Case-insensitive substring match\"\"\"\n  like: String\n")
+		}
+		b.WriteString("}\n")
+	}
+
+	var queries, mutations strings.Builder
+	for _, r := range resources {
+		typeName := crudTypeName(r.Name())
+		fields := r.Fields()
+		key := fields.KeyField()
+		writable := crudWritableFields(fields)
+		filterable := crudFilterableFields(fields)
+
+		fmt.Fprintf(&b, "\ntype %s {\n", typeName)
+		for _, f := range crud.APIFields(fields) {
+			t := crudScalar(f)
+			if f.Key() {
+				t += "!"
+			}
+			fmt.Fprintf(&b, "  %s: %s\n", f.Name(), t)
+		}
+		b.WriteString("}\n")
+
+		fmt.Fprintf(&b, "\ntype %sPage {\n  data: [%s!]!\n", typeName, typeName)
+		b.WriteString("  \"\"\"Cursor of the next page, null on the last page\"\"\"\n  nextCursor: String\n")
+		b.WriteString("  \"\"\"Number of entities matching the filter\"\"\"\n  total: Int!\n}\n")
+
+		listArgs := []string{"q: String", "limit: Int = " + fmt.Sprint(defaultCrudLimit), "offset: Int", "cursor: String"}
+		if len(filterable) > 0 {
+			fmt.Fprintf(&b, "\ninput %sFilter {\n", typeName)
+			for _, f := range filterable {
+				fmt.Fprintf(&b, "  %s: %s\n", f.Name(), crudFilterType(f))
+			}
+			fmt.Fprintf(&b, "}\n\nenum %sSortField {\n", typeName)
+			for _, f := range filterable {
+				fmt.Fprintf(&b, "  %s\n", f.Name())
+			}
+			fmt.Fprintf(&b, "}\n\ninput %sSort {\n  field: %sSortField!\n  desc: Boolean = false\n}\n", typeName, typeName)
+			listArgs = append([]string{
+				fmt.Sprintf("filter: %sFilter", typeName),
+				fmt.Sprintf("sort: [%sSort!]", typeName),
+			}, listArgs...)
+		}
+
+		keyArg := fmt.Sprintf("%s: %s!", key.Name(), crudScalar(key))
+		fmt.Fprintf(&queries, "  list%s(%s): %sPage\n", typeName, strings.Join(listArgs, ", "), typeName)
+		fmt.Fprintf(&queries, "  get%s(%s): %s\n", typeName, keyArg, typeName)
+
+		if len(writable) > 0 {
+			fmt.Fprintf(&b, "\ninput %sInput {\n", typeName)
+			for _, f := range writable {
+				fmt.Fprintf(&b, "  %s: %s\n", f.Name(), crudInputType(f))
+			}
+			b.WriteString("}\n")
+			fmt.Fprintf(&mutations, "  create%s(input: %sInput!): %s\n", typeName, typeName, typeName)
+			fmt.Fprintf(&mutations, "  update%s(%s, input: %sInput!): %s\n", typeName, keyArg, typeName, typeName)
+		}
+		fmt.Fprintf(&mutations, "  delete%s(%s): %s\n", typeName, keyArg, typeName)
+	}
+
+	fmt.Fprintf(&b, "\ntype Query {\n%s}\n", queries.String())
+	fmt.Fprintf(&b, "\ntype Mutation {\n%s}\n", mutations.String())
+	return b.String()
+}
@@ -0,0 +1,311 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/executor"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/introspection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/internet"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/crud"
+)
+
+type denyPermission struct{}
+
+func (denyPermission) Can(user.User) bool {
+	return false
+}
+
+// reportsResource keeps reports in memory and records the calls it gets
+type reportsResource struct {
+	fields      crud.Fields
+	rows        [][]crud.FieldValue
+	permissions CrudPermissions
+	params      *crud.FindParams
+	saved       []crud.FieldValue
+}
+
+func newReportsResource() *reportsResource {
+	fields := crud.NewFields([]crud.Field{
+		crud.NewIntField("id", crud.WithKey(), crud.WithReadonly()),
+		crud.NewStringField("title", crud.WithRequired()),
+		crud.NewDecimalField("amount"),
+		crud.NewBoolField("published"),
+		crud.NewStringField("tenant_id", crud.WithHidden()),
+	})
+	r := &reportsResource{fields: fields}
+	for i, title := range []string{"Q1", "Q2"} {
+		r.rows = append(r.rows, r.values(map[string]any{
+			"id":        i + 1,
+			"title":     title,
+			"amount":    "10.50",
+			"published": i == 0,
+			"tenant_id": "tenant",
+		}))
+	}
+	return r
+}
+
+func (r *reportsResource) values(values map[string]any) []crud.FieldValue {
+	result := make([]crud.FieldValue, 0, len(values))
+	for _, f := range r.fields.Fields() {
+		result = append(result, f.Value(values[f.Name()]))
+	}
+	return result
+}
+
+func (r *reportsResource) Name() string                 { return "monthly_reports" }
+func (r *reportsResource) Fields() crud.Fields          { return r.fields }
+func (r *reportsResource) Permissions() CrudPermissions { return r.permissions }
+
+func (r *reportsResource) List(_ context.Context, params *crud.FindParams) ([][]crud.FieldValue, string, error) {
+	r.params = params
+	return r.rows, "next", nil
+}
+
+func (r *reportsResource) Count(context.Context, *crud.FindParams) (int64, error) {
+	return int64(len(r.rows)), nil
+}
+
+func (r *reportsResource) Get(_ context.Context, key crud.FieldValue) ([]crud.FieldValue, error) {
+	for _, row := range r.rows {
+		if row[0].Value() == key.Value() {
+			return row, nil
+		}
+	}
+	return nil, crud.ErrNotFound
+}
+
+func (r *reportsResource) Save(_ context.Context, fieldValues []crud.FieldValue) ([]crud.FieldValue, error) {
+	r.saved = fieldValues
+	return fieldValues, nil
+}
+
+func (r *reportsResource) Delete(ctx context.Context, key crud.FieldValue) ([]crud.FieldValue, error) {
+	return r.Get(ctx, key)
+}
+
+func userContext() context.Context {
+	email, _ := internet.NewEmail("john.doe@example.com")
+	return composables.WithUser(context.Background(), user.New("John", "Doe", email, "en"))
+}
+
+func execute(t *testing.T, ctx context.Context, schema *CrudSchema, query string, variables map[string]any) (map[string]any, []map[string]any) {
+	t.Helper()
+	exec := executor.New(schema)
+	exec.Use(extension.Introspection{})
+	ctx = graphql.StartOperationTrace(ctx)
+	opCtx, errs := exec.CreateOperationContext(ctx, &graphql.RawParams{Query: query, Variables: variables})
+	require.Empty(t, errs)
+	handler, ctx := exec.DispatchOperation(ctx, opCtx)
+	response := handler(ctx)
+
+	b, err := json.Marshal(response)
+	require.NoError(t, err)
+	var result struct {
+		Data   map[string]any   `json:"data"`
+		Errors []map[string]any `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(b, &result))
+	return result.Data, result.Errors
+}
+
+func TestNewCrudSchema(t *testing.T) {
+	schema, err := NewCrudSchema(newReportsResource())
+	require.NoError(t, err)
+
+	sdl := schema.SDL()
+	assert.Contains(t, sdl, "type MonthlyReports {\n  id: Int!\n  title: String\n  amount: Decimal\n  published: Boolean\n}")
+	assert.Contains(t, sdl, "input MonthlyReportsInput {\n  title: String\n  amount: Decimal\n  published: Boolean\n}")
+	assert.Contains(t, sdl, "listMonthlyReports(filter: MonthlyReportsFilter, sort: [MonthlyReportsSort!], q: String, limit: Int = 20, offset: Int, cursor: String): MonthlyReportsPage")
+	assert.Contains(t, sdl, "updateMonthlyReports(id: Int!, input: MonthlyReportsInput!): MonthlyReports")
+	assert.NotContains(t, sdl, "tenant_id")
+
+	_, err = NewCrudSchema()
+	require.Error(t, err)
+}
+
+func TestCrudSchema_Query(t *testing.T) {
+	resource := newReportsResource()
+	schema, err := NewCrudSchema(resource)
+	require.NoError(t, err)
+
+	t.Run("lists entities", func(t *testing.T) {
+		data, errs := execute(t, userContext(), schema, `query ($min: Decimal) {
+			listMonthlyReports(
+				filter: {title: {like: "Q"}, amount: {gte: $min}, id: {in: [1, 2]}}
+				sort: [{field: title, desc: true}]
+				limit: 500
+			) {
+				data { id title amount published __typename }
+				nextCursor
+				total
+			}
+		}`, map[string]any{"min": "5"})
+		require.Empty(t, errs)
+
+		page := data["listMonthlyReports"].(map[string]any)
+		assert.Equal(t, "next", page["nextCursor"])
+		assert.InDelta(t, 2, page["total"], 0)
+		assert.Equal(t, map[string]any{
+			"id":         float64(1),
+			"title":      "Q1",
+			"amount":     "10.50",
+			"published":  true,
+			"__typename": "MonthlyReports",
+		}, page["data"].([]any)[0])
+
+		require.NotNil(t, resource.params)
+		assert.Equal(t, 100, resource.params.Limit)
+		assert.Len(t, resource.params.Filters, 3)
+		require.Len(t, resource.params.SortBy.Fields, 1)
+		assert.Equal(t, "title", resource.params.SortBy.Fields[0].Field)
+		assert.False(t, resource.params.SortBy.Fields[0].Ascending)
+	})
+
+	t.Run("gets entities by key", func(t *testing.T) {
+		data, errs := execute(t, userContext(), schema, `{
+			found: getMonthlyReports(id: 2) { title }
+			missing: getMonthlyReports(id: 3) { title }
+		}`, nil)
+		require.Empty(t, errs)
+		assert.Equal(t, map[string]any{"title": "Q2"}, data["found"])
+		assert.Nil(t, data["missing"])
+	})
+
+	t.Run("requires a user", func(t *testing.T) {
+		data, errs := execute(t, context.Background(), schema, `{ getMonthlyReports(id: 1) { title } }`, nil)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "UNAUTHORIZED", errs[0]["extensions"].(map[string]any)["code"])
+		assert.Nil(t, data["getMonthlyReports"])
+	})
+
+	t.Run("requires permissions", func(t *testing.T) {
+		resource.permissions = CrudPermissions{Read: denyPermission{}}
+		defer func() { resource.permissions = CrudPermissions{} }()
+
+		_, errs := execute(t, userContext(), schema, `{ getMonthlyReports(id: 1) { title } }`, nil)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "FORBIDDEN", errs[0]["extensions"].(map[string]any)["code"])
+		assert.Equal(t, []any{"getMonthlyReports"}, errs[0]["path"])
+	})
+}
+
+func TestCrudSchema_Mutation(t *testing.T) {
+	resource := newReportsResource()
+	schema, err := NewCrudSchema(resource)
+	require.NoError(t, err)
+
+	t.Run("updates the fields in the input", func(t *testing.T) {
+		data, errs := execute(t, userContext(), schema, `mutation {
+			updateMonthlyReports(id: 1, input: {amount: "99.90"}) { id title amount }
+		}`, nil)
+		require.Empty(t, errs)
+		assert.Equal(t, map[string]any{"id": float64(1), "title": "Q1", "amount": "99.90"}, data["updateMonthlyReports"])
+
+		saved := make(map[string]any)
+		for _, fv := range resource.saved {
+			saved[fv.Field().Name()] = fv.Value()
+		}
+		assert.Equal(t, "tenant", saved["tenant_id"])
+	})
+
+	t.Run("reports invalid fields", func(t *testing.T) {
+		data, errs := execute(t, userContext(), schema, `mutation {
+			createMonthlyReports(input: {amount: "lots"}) { id }
+		}`, nil)
+		require.Len(t, errs, 1)
+		assert.Nil(t, data["createMonthlyReports"])
+
+		extensions := errs[0]["extensions"].(map[string]any)
+		assert.Equal(t, "VALIDATION_ERROR", extensions["code"])
+		assert.Contains(t, extensions["errors"], "amount")
+	})
+
+	t.Run("validates field rules", func(t *testing.T) {
+		_, errs := execute(t, userContext(), schema, `mutation ($input: MonthlyReportsInput!) {
+			createMonthlyReports(input: $input) { id }
+		}`, map[string]any{"input": map[string]any{"amount": "1"}})
+		require.Len(t, errs, 1)
+
+		extensions := errs[0]["extensions"].(map[string]any)
+		assert.Equal(t, "VALIDATION_ERROR", extensions["code"])
+		assert.Contains(t, extensions["errors"], "title")
+	})
+}
+
+func TestCrudSchema_FieldPermissions(t *testing.T) {
+	resource := newReportsResource()
+	fields := resource.fields.Fields()
+	resource.fields = crud.NewFields([]crud.Field{
+		fields[0],
+		fields[1],
+		crud.NewDecimalField("amount", crud.WithReadPermission(denyPermission{})),
+		crud.NewBoolField("published", crud.WithWritePermission(denyPermission{})),
+		fields[4],
+	})
+	for i, row := range resource.rows {
+		values := make(map[string]any, len(row))
+		for _, fv := range row {
+			values[fv.Field().Name()] = fv.Value()
+		}
+		resource.rows[i] = resource.values(values)
+	}
+	schema, err := NewCrudSchema(resource)
+	require.NoError(t, err)
+
+	t.Run("resolves unreadable fields to null", func(t *testing.T) {
+		data, errs := execute(t, userContext(), schema, `{ getMonthlyReports(id: 1) { title amount published } }`, nil)
+		require.Empty(t, errs)
+		assert.Equal(t, map[string]any{"title": "Q1", "amount": nil, "published": true}, data["getMonthlyReports"])
+	})
+
+	t.Run("forbids filtering and sorting by unreadable fields", func(t *testing.T) {
+		for _, query := range []string{
+			`{ listMonthlyReports(filter: {amount: {gte: "5"}}) { total } }`,
+			`{ listMonthlyReports(sort: [{field: amount}]) { total } }`,
+		} {
+			_, errs := execute(t, userContext(), schema, query, nil)
+			require.Len(t, errs, 1)
+			assert.Equal(t, "FORBIDDEN", errs[0]["extensions"].(map[string]any)["code"])
+		}
+	})
+
+	t.Run("forbids writing fields", func(t *testing.T) {
+		_, errs := execute(t, userContext(), schema, `mutation {
+			updateMonthlyReports(id: 1, input: {published: false}) { id }
+		}`, nil)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "FORBIDDEN", errs[0]["extensions"].(map[string]any)["code"])
+
+		_, errs = execute(t, userContext(), schema, `mutation {
+			updateMonthlyReports(id: 1, input: {title: "Q1 2025"}) { id }
+		}`, nil)
+		require.Empty(t, errs)
+	})
+}
+
+func TestCrudSchema_Introspection(t *testing.T) {
+	schema, err := NewCrudSchema(newReportsResource())
+	require.NoError(t, err)
+
+	data, errs := execute(t, context.Background(), schema, introspection.Query, nil)
+	require.Empty(t, errs)
+
+	s := data["__schema"].(map[string]any)
+	assert.Equal(t, map[string]any{"name": "Query"}, s["queryType"])
+
+	var names []string
+	for _, typ := range s["types"].([]any) {
+		names = append(names, typ.(map[string]any)["name"].(string))
+	}
+	assert.Subset(t, names, []string{"MonthlyReports", "MonthlyReportsPage", "MonthlyReportsInput", "DecimalFilter", "__Schema"})
+}
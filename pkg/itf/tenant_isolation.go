@@ -0,0 +1,83 @@
+package itf
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/jackc/pgx/v5"
+)
+
+// AssertTenantIsolation proves that the row-level security policies of tables hide
+// the rows of the environment tenant from every other tenant. Each table needs at
+// least one row of te.Tenant, seeded through te.Tx beforehand.
+//
+// Test databases are usually accessed as a superuser, which bypasses row-level
+// security, so the checks run as a temporary unprivileged role inside a savepoint
+// of te.Tx that is rolled back afterwards.
+//
+// Example usage:
+//
+//	env := itf.NewTestContext().WithModules(modules.BuiltInModules...).Build(t)
+//	_, err := userRepo.Create(env.Ctx, newUser)
+//	require.NoError(t, err)
+//	env.AssertTenantIsolation(t, "users")
+func (te *TestEnvironment) AssertTenantIsolation(tb testing.TB, tables ...string) {
+	tb.Helper()
+
+	for _, table := range tables {
+		ident := pgx.Identifier{table}.Sanitize()
+
+		var own int
+		if err := te.Tx.QueryRow(te.Ctx, fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE tenant_id = $1", ident), te.Tenant.ID).Scan(&own); err != nil {
+			tb.Fatalf("%s: failed to count tenant rows: %v", table, err)
+		}
+		if own == 0 {
+			tb.Fatalf("%s: no rows of tenant %s to check isolation with, seed some first", table, te.Tenant.ID)
+		}
+
+		sp, err := te.Tx.Begin(te.Ctx)
+		if err != nil {
+			tb.Fatalf("%s: failed to create savepoint: %v", table, err)
+		}
+
+		role := pgx.Identifier{"itf_rls_" + strings.ReplaceAll(uuid.NewString(), "-", "")}.Sanitize()
+		for _, stmt := range []string{
+			"CREATE ROLE " + role + " NOSUPERUSER NOBYPASSRLS NOLOGIN",
+			"GRANT SELECT ON " + ident + " TO " + role,
+			"SET LOCAL ROLE " + role,
+		} {
+			if _, err := sp.Exec(te.Ctx, stmt); err != nil {
+				_ = sp.Rollback(te.Ctx)
+				tb.Fatalf("%s: %s: %v", table, stmt, err)
+			}
+		}
+
+		count := func(tenantID uuid.UUID) int {
+			ctx := composables.WithTenantID(te.Ctx, tenantID)
+			if err := composables.SetTxTenant(ctx, sp); err != nil {
+				_ = sp.Rollback(te.Ctx)
+				tb.Fatalf("%s: failed to set %s: %v", table, composables.TenantSetting, err)
+			}
+			var n int
+			if err := sp.QueryRow(ctx, fmt.Sprintf("SELECT COUNT(*) FROM %s", ident)).Scan(&n); err != nil {
+				_ = sp.Rollback(te.Ctx)
+				tb.Fatalf("%s: failed to count rows: %v", table, err)
+			}
+			return n
+		}
+
+		if n := count(uuid.New()); n != 0 {
+			tb.Errorf("%s: another tenant can read %d rows, expected none; is row-level security enabled?", table, n)
+		}
+		if n := count(te.Tenant.ID); n != own {
+			tb.Errorf("%s: tenant %s reads %d rows, expected its own %d", table, te.Tenant.ID, n, own)
+		}
+
+		if err := sp.Rollback(te.Ctx); err != nil {
+			tb.Fatalf("%s: failed to roll back savepoint: %v", table, err)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+const (
+	jobColumns = `id, tenant_id, queue, kind, payload, status, priority, attempts, max_attempts,
+		unique_key, last_error, run_at, locked_at, locked_by, finished_at, created_at, updated_at`
+
+	insertJobQuery = `
+		INSERT INTO jobs (tenant_id, queue, kind, payload, priority, max_attempts, unique_key, run_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (kind, unique_key) WHERE status IN ('pending', 'running') DO NOTHING
+		RETURNING ` + jobColumns
+
+	selectActiveUniqueJobQuery = `
+		SELECT ` + jobColumns + `
+		FROM jobs
+		WHERE kind = $1 AND unique_key = $2 AND status IN ('pending', 'running')`
+)
+
+type enqueueOptions struct {
+	queue       string
+	priority    int
+	maxAttempts int
+	uniqueKey   string
+	runAt       time.Time
+}
+
+type EnqueueOption func(o *enqueueOptions)
+
+// WithQueue places the job on a named queue so it can be served by dedicated workers.
+func WithQueue(queue string) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.queue = queue
+	}
+}
+
+// WithPriority makes workers claim the job before ready jobs of lower priority.
+func WithPriority(priority int) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.priority = priority
+	}
+}
+
+// WithAttempts overrides the number of attempts set by the handler.
+func WithAttempts(n int) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.maxAttempts = n
+	}
+}
+
+// WithUniqueKey prevents enqueueing a job while another job of the same kind
+// and key is pending or running. Enqueue then returns the existing job.
+func WithUniqueKey(key string) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.uniqueKey = key
+	}
+}
+
+// WithRunAt schedules the job to run no earlier than t.
+func WithRunAt(t time.Time) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.runAt = t
+	}
+}
+
+// WithDelay schedules the job to run after d.
+func WithDelay(d time.Duration) EnqueueOption {
+	return func(o *enqueueOptions) {
+		o.runAt = time.Now().Add(d)
+	}
+}
+
+// Client adds jobs to the queue.
+type Client interface {
+	// Enqueue stores a job using the transaction found in ctx, so the job is
+	// only visible to workers once the surrounding transaction commits.
+	// The tenant in ctx is restored when the job runs.
+	Enqueue(ctx context.Context, args Args, opts ...EnqueueOption) (*Job, error)
+}
+
+func NewClient(registry *Registry) Client {
+	return &client{registry: registry}
+}
+
+type client struct {
+	registry *Registry
+}
+
+func (c *client) Enqueue(ctx context.Context, args Args, opts ...EnqueueOption) (*Job, error) {
+	options := &enqueueOptions{
+		queue:       DefaultQueue,
+		maxAttempts: defaultMaxAttempts,
+		runAt:       time.Now(),
+	}
+	if h, ok := c.registry.handler(args.Kind()); ok {
+		options.maxAttempts = h.maxAttempts
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	payload, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("jobs: failed to encode args of %q: %w", args.Kind(), err)
+	}
+
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var tenantID *uuid.UUID
+	if id, err := composables.UseTenantID(ctx); err == nil {
+		tenantID = &id
+	}
+	var uniqueKey *string
+	if options.uniqueKey != "" {
+		uniqueKey = &options.uniqueKey
+	}
+
+	rows, err := tx.Query(
+		ctx,
+		insertJobQuery,
+		tenantID,
+		options.queue,
+		args.Kind(),
+		payload,
+		options.priority,
+		options.maxAttempts,
+		uniqueKey,
+		options.runAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("jobs: failed to enqueue %q: %w", args.Kind(), err)
+	}
+	job, err := pgx.CollectExactlyOneRow(rows, scanJob)
+	if errors.Is(err, pgx.ErrNoRows) {
+		rows, err = tx.Query(ctx, selectActiveUniqueJobQuery, args.Kind(), options.uniqueKey)
+		if err != nil {
+			return nil, err
+		}
+		job, err = pgx.CollectExactlyOneRow(rows, scanJob)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("jobs: failed to enqueue %q: %w", args.Kind(), err)
+	}
+	return job, nil
+}
+
+func scanJob(row pgx.CollectableRow) (*Job, error) {
+	var (
+		job       Job
+		tenantID  *uuid.UUID
+		uniqueKey *string
+		lastError *string
+		lockedBy  *string
+		status    string
+	)
+	if err := row.Scan(
+		&job.ID,
+		&tenantID,
+		&job.Queue,
+		&job.Kind,
+		&job.Payload,
+		&status,
+		&job.Priority,
+		&job.Attempts,
+		&job.MaxAttempts,
+		&uniqueKey,
+		&lastError,
+		&job.RunAt,
+		&job.LockedAt,
+		&lockedBy,
+		&job.FinishedAt,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	job.Status = Status(status)
+	if tenantID != nil {
+		job.TenantID = *tenantID
+	}
+	if uniqueKey != nil {
+		job.UniqueKey = *uniqueKey
+	}
+	if lastError != nil {
+		job.LastError = *lastError
+	}
+	if lockedBy != nil {
+		job.LockedBy = *lockedBy
+	}
+	return &job, nil
+}
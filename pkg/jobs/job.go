@@ -0,0 +1,82 @@
+// Package jobs provides a durable, Postgres-backed background job queue.
+//
+// Jobs are rows in the jobs table claimed by workers with FOR UPDATE SKIP LOCKED,
+// so any number of worker processes can share a queue. Handlers are typed:
+//
+//	type ExportArgs struct {
+//		QueryID string `json:"query_id"`
+//	}
+//
+//	func (ExportArgs) Kind() string { return "core.export" }
+//
+//	jobs.Register(app.JobRegistry(), func(ctx context.Context, args ExportArgs) error {
+//		return exportService.Export(ctx, args.QueryID)
+//	})
+//
+//	_, err := app.Jobs().Enqueue(ctx, ExportArgs{QueryID: id}, jobs.WithUniqueKey(id))
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrJobNotFound    = errors.New("jobs: job not found")
+	ErrUnknownJobKind = errors.New("jobs: unknown job kind")
+)
+
+// Args is the payload of a job. Kind identifies the handler and must be stable
+// across releases; it is usually implemented on a struct with a value receiver.
+type Args interface {
+	Kind() string
+}
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	// StatusFailed marks jobs that exhausted their attempts.
+	StatusFailed Status = "failed"
+)
+
+const DefaultQueue = "default"
+
+type Job struct {
+	ID          int64
+	TenantID    uuid.UUID
+	Queue       string
+	Kind        string
+	Payload     json.RawMessage
+	Status      Status
+	Priority    int
+	Attempts    int
+	MaxAttempts int
+	UniqueKey   string
+	LastError   string
+	RunAt       time.Time
+	LockedAt    *time.Time
+	LockedBy    string
+	FinishedAt  *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type jobCtxKey struct{}
+
+// WithJob returns a context carrying the job being processed.
+func WithJob(ctx context.Context, job *Job) context.Context {
+	return context.WithValue(ctx, jobCtxKey{}, job)
+}
+
+// UseJob returns the job being processed, for handlers that need its attempt or id.
+func UseJob(ctx context.Context) (*Job, bool) {
+	job, ok := ctx.Value(jobCtxKey{}).(*Job)
+	return job, ok
+}
@@ -0,0 +1,137 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxAttempts = 5
+	defaultTimeout     = 30 * time.Minute
+)
+
+// BackoffFunc returns the delay before retrying a job that failed on the given attempt (1-based).
+type BackoffFunc func(attempt int) time.Duration
+
+// ExponentialBackoff waits 10s, 20s, 40s, ... between attempts, capped at one hour.
+func ExponentialBackoff(attempt int) time.Duration {
+	backoff := 10 * time.Second
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+		if backoff >= time.Hour {
+			return time.Hour
+		}
+	}
+	return backoff
+}
+
+type handler struct {
+	kind        string
+	run         func(ctx context.Context, payload []byte) error
+	maxAttempts int
+	timeout     time.Duration
+	backoff     BackoffFunc
+}
+
+type HandlerOption func(h *handler)
+
+// WithMaxAttempts sets the default number of attempts for jobs of this kind.
+// Enqueue options take precedence.
+func WithMaxAttempts(n int) HandlerOption {
+	return func(h *handler) {
+		h.maxAttempts = n
+	}
+}
+
+// WithTimeout bounds a single attempt. Defaults to 30 minutes.
+func WithTimeout(timeout time.Duration) HandlerOption {
+	return func(h *handler) {
+		h.timeout = timeout
+	}
+}
+
+func WithBackoff(backoff BackoffFunc) HandlerOption {
+	return func(h *handler) {
+		h.backoff = backoff
+	}
+}
+
+// Registry maps job kinds to handlers. Modules register their handlers on the
+// application's registry during Register.
+type Registry struct {
+	mu       sync.RWMutex
+	handlers map[string]*handler
+}
+
+func NewRegistry() *Registry {
+	return &Registry{handlers: make(map[string]*handler)}
+}
+
+// Register adds a handler for jobs with args of type T.
+func Register[T Args](r *Registry, fn func(ctx context.Context, args T) error, opts ...HandlerOption) {
+	var zero T
+	if reflect.TypeOf(zero) == nil {
+		panic("jobs: job args must be a concrete type")
+	}
+	h := &handler{
+		kind: zero.Kind(),
+		run: func(ctx context.Context, payload []byte) error {
+			var args T
+			if err := json.Unmarshal(payload, &args); err != nil {
+				return fmt.Errorf("jobs: failed to decode args: %w", err)
+			}
+			return fn(ctx, args)
+		},
+		maxAttempts: defaultMaxAttempts,
+		timeout:     defaultTimeout,
+		backoff:     ExponentialBackoff,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	if h.kind == "" {
+		panic("jobs: job kind must not be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.handlers[h.kind]; ok {
+		panic(fmt.Sprintf("jobs: handler for %q already registered", h.kind))
+	}
+	r.handlers[h.kind] = h
+}
+
+// Kinds returns the registered job kinds in alphabetical order.
+func (r *Registry) Kinds() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	kinds := make([]string, 0, len(r.handlers))
+	for kind := range r.handlers {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// Run runs job with its registered handler in the calling goroutine, without
+// claiming or updating its row. Tests use it to process jobs enqueued in a
+// transaction that workers cannot see.
+func (r *Registry) Run(ctx context.Context, job *Job) error {
+	h, ok := r.handler(job.Kind)
+	if !ok {
+		return ErrUnknownJobKind
+	}
+	return runHandler(WithJob(ctx, job), h, job.Payload)
+}
+
+func (r *Registry) handler(kind string) (*handler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	h, ok := r.handlers[kind]
+	return h, ok
+}
@@ -0,0 +1,141 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testArgs struct {
+	Name string `json:"name"`
+}
+
+func (testArgs) Kind() string { return "test.job" }
+
+type otherArgs struct{}
+
+func (otherArgs) Kind() string { return "test.other" }
+
+func TestRegister(t *testing.T) {
+	t.Run("DecodesPayload", func(t *testing.T) {
+		registry := NewRegistry()
+		var got testArgs
+		Register(registry, func(ctx context.Context, args testArgs) error {
+			got = args
+			return nil
+		})
+
+		h, ok := registry.handler("test.job")
+		require.True(t, ok)
+		require.NoError(t, h.run(context.Background(), []byte(`{"name":"report"}`)))
+		assert.Equal(t, testArgs{Name: "report"}, got)
+	})
+
+	t.Run("InvalidPayload", func(t *testing.T) {
+		registry := NewRegistry()
+		Register(registry, func(ctx context.Context, args testArgs) error {
+			return nil
+		})
+
+		h, _ := registry.handler("test.job")
+		assert.Error(t, h.run(context.Background(), []byte(`{`)))
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		registry := NewRegistry()
+		Register(registry, func(ctx context.Context, args testArgs) error {
+			return nil
+		}, WithMaxAttempts(2), WithTimeout(time.Minute))
+
+		h, _ := registry.handler("test.job")
+		assert.Equal(t, 2, h.maxAttempts)
+		assert.Equal(t, time.Minute, h.timeout)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		registry := NewRegistry()
+		Register(registry, func(ctx context.Context, args testArgs) error {
+			return nil
+		})
+		assert.Panics(t, func() {
+			Register(registry, func(ctx context.Context, args testArgs) error {
+				return nil
+			})
+		})
+	})
+
+	t.Run("InterfaceArgs", func(t *testing.T) {
+		assert.Panics(t, func() {
+			Register(NewRegistry(), func(ctx context.Context, args Args) error {
+				return nil
+			})
+		})
+	})
+}
+
+func TestRegistry_Kinds(t *testing.T) {
+	registry := NewRegistry()
+	Register(registry, func(ctx context.Context, args testArgs) error { return nil })
+	Register(registry, func(ctx context.Context, args otherArgs) error { return nil })
+
+	assert.Equal(t, []string{"test.job", "test.other"}, registry.Kinds())
+}
+
+func TestRegistry_Run(t *testing.T) {
+	registry := NewRegistry()
+	var (
+		got    testArgs
+		gotJob *Job
+	)
+	Register(registry, func(ctx context.Context, args testArgs) error {
+		got = args
+		gotJob, _ = UseJob(ctx)
+		return nil
+	})
+
+	job := &Job{ID: 7, Kind: "test.job", Payload: []byte(`{"name":"report"}`)}
+	require.NoError(t, registry.Run(context.Background(), job))
+	assert.Equal(t, testArgs{Name: "report"}, got)
+	assert.Same(t, job, gotJob)
+
+	err := registry.Run(context.Background(), &Job{Kind: "test.other"})
+	assert.ErrorIs(t, err, ErrUnknownJobKind)
+}
+
+func TestRunHandler_RecoversPanic(t *testing.T) {
+	h := &handler{
+		kind: "test.job",
+		run: func(ctx context.Context, payload []byte) error {
+			panic("boom")
+		},
+	}
+	err := runHandler(context.Background(), h, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+
+	h.run = func(ctx context.Context, payload []byte) error {
+		return errors.New("failed")
+	}
+	assert.EqualError(t, runHandler(context.Background(), h, nil), "failed")
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, ExponentialBackoff(1))
+	assert.Equal(t, 20*time.Second, ExponentialBackoff(2))
+	assert.Equal(t, 80*time.Second, ExponentialBackoff(4))
+	assert.Equal(t, time.Hour, ExponentialBackoff(20))
+}
+
+func TestUseJob(t *testing.T) {
+	_, ok := UseJob(context.Background())
+	assert.False(t, ok)
+
+	job := &Job{ID: 1, Kind: "test.job"}
+	got, ok := UseJob(WithJob(context.Background(), job))
+	require.True(t, ok)
+	assert.Same(t, job, got)
+}
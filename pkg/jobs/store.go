@@ -0,0 +1,147 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+const (
+	selectJobsQuery = `SELECT ` + jobColumns + ` FROM jobs`
+
+	countJobsQuery = `SELECT COUNT(*) FROM jobs`
+
+	retryFailedJobQuery = `
+		UPDATE jobs
+		SET status = 'pending', attempts = 0, last_error = NULL, run_at = NOW(), finished_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND status = 'failed'`
+
+	runPendingJobNowQuery = `
+		UPDATE jobs
+		SET run_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'pending'`
+)
+
+type FindParams struct {
+	Limit     int
+	Offset    int
+	Queue     string
+	Kind      string
+	Status    Status
+	UniqueKey string
+	TenantID  uuid.UUID
+}
+
+// Store gives administrative access to the jobs table.
+type Store interface {
+	Get(ctx context.Context, id int64) (*Job, error)
+	// List returns jobs matching params, most recently updated first.
+	List(ctx context.Context, params *FindParams) ([]*Job, error)
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	// Retry makes a failed job pending again with a fresh set of attempts.
+	Retry(ctx context.Context, id int64) error
+	// RunNow makes a pending job ready immediately.
+	RunNow(ctx context.Context, id int64) error
+}
+
+func NewPgStore(pool *pgxpool.Pool) Store {
+	return &pgStore{pool: pool}
+}
+
+type pgStore struct {
+	pool *pgxpool.Pool
+}
+
+func (s *pgStore) Get(ctx context.Context, id int64) (*Job, error) {
+	rows, err := s.pool.Query(ctx, repo.Join(selectJobsQuery, "WHERE id = $1"), id)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := pgx.CollectRows(rows, scanJob)
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, ErrJobNotFound
+	}
+	return jobs[0], nil
+}
+
+func (s *pgStore) List(ctx context.Context, params *FindParams) ([]*Job, error) {
+	where, args := buildJobFilters(params)
+	query := repo.Join(
+		selectJobsQuery,
+		where,
+		"ORDER BY updated_at DESC, id DESC",
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	)
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, scanJob)
+}
+
+func (s *pgStore) Count(ctx context.Context, params *FindParams) (int64, error) {
+	where, args := buildJobFilters(params)
+	var count int64
+	if err := s.pool.QueryRow(ctx, repo.Join(countJobsQuery, where), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *pgStore) Retry(ctx context.Context, id int64) error {
+	return s.exec(ctx, retryFailedJobQuery, id)
+}
+
+func (s *pgStore) RunNow(ctx context.Context, id int64) error {
+	return s.exec(ctx, runPendingJobNowQuery, id)
+}
+
+func (s *pgStore) exec(ctx context.Context, query string, id int64) error {
+	tag, err := s.pool.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrJobNotFound
+	}
+	return nil
+}
+
+func buildJobFilters(params *FindParams) (string, []interface{}) {
+	var (
+		where []string
+		args  []interface{}
+	)
+	if params.Queue != "" {
+		args = append(args, params.Queue)
+		where = append(where, fmt.Sprintf("queue = $%d", len(args)))
+	}
+	if params.Kind != "" {
+		args = append(args, params.Kind)
+		where = append(where, fmt.Sprintf("kind = $%d", len(args)))
+	}
+	if params.Status != "" {
+		args = append(args, string(params.Status))
+		where = append(where, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if params.UniqueKey != "" {
+		args = append(args, params.UniqueKey)
+		where = append(where, fmt.Sprintf("unique_key = $%d", len(args)))
+	}
+	if params.TenantID != uuid.Nil {
+		args = append(args, params.TenantID)
+		where = append(where, fmt.Sprintf("tenant_id = $%d", len(args)))
+	}
+	if len(where) == 0 {
+		return "", nil
+	}
+	return repo.JoinWhere(where...), args
+}
@@ -0,0 +1,269 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+const (
+	claimJobsQuery = `
+		UPDATE jobs
+		SET status = 'running', attempts = attempts + 1, locked_at = NOW(), locked_by = $1, updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE status = 'pending' AND run_at <= NOW() AND queue = ANY($2) AND kind = ANY($3)
+			ORDER BY priority DESC, run_at, id
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+
+	completeJobQuery = `
+		UPDATE jobs
+		SET status = 'completed', last_error = NULL, locked_at = NULL, locked_by = NULL,
+			finished_at = NOW(), updated_at = NOW()
+		WHERE id = $1`
+
+	retryJobQuery = `
+		UPDATE jobs
+		SET status = 'pending', last_error = $2, run_at = $3, locked_at = NULL, locked_by = NULL, updated_at = NOW()
+		WHERE id = $1`
+
+	failJobQuery = `
+		UPDATE jobs
+		SET status = 'failed', last_error = $2, locked_at = NULL, locked_by = NULL,
+			finished_at = NOW(), updated_at = NOW()
+		WHERE id = $1`
+
+	// Jobs locked by a worker that died are made available again, unless they used
+	// up their attempts, since claims count as attempts and they could crash forever
+	rescueJobsQuery = `
+		UPDATE jobs
+		SET status = CASE WHEN attempts >= max_attempts THEN 'failed' ELSE 'pending' END,
+			last_error = CASE WHEN attempts >= max_attempts THEN 'abandoned by its worker' ELSE last_error END,
+			finished_at = CASE WHEN attempts >= max_attempts THEN NOW() ELSE finished_at END,
+			locked_at = NULL, locked_by = NULL, updated_at = NOW()
+		WHERE status = 'running' AND locked_at < $1`
+
+	purgeJobsQuery = `
+		DELETE FROM jobs
+		WHERE status = 'completed' AND finished_at < $1`
+)
+
+type WorkerOptions struct {
+	Pool     *pgxpool.Pool
+	Registry *Registry
+	Logger   *logrus.Logger
+	// Queues served by the worker. Defaults to the default queue.
+	Queues []string
+	// Concurrency is the number of jobs processed at the same time. Defaults to 4.
+	Concurrency int
+	// PollInterval is the delay between polls when no job is ready. Defaults to one second.
+	PollInterval time.Duration
+	// RescueAfter is how long a job may stay running before it is considered
+	// abandoned and claimed again, or failed when it has no attempts left. It must exceed
+	// the longest handler timeout. Defaults to one hour.
+	RescueAfter time.Duration
+	// Retention is how long completed jobs are kept. Zero keeps them forever.
+	Retention time.Duration
+}
+
+// Worker claims ready jobs and runs their handlers. Several workers, in one or
+// many processes, can serve the same queues.
+type Worker struct {
+	opts   WorkerOptions
+	id     string
+	slots  chan struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	jobs   sync.WaitGroup
+}
+
+func NewWorker(opts WorkerOptions) *Worker {
+	if len(opts.Queues) == 0 {
+		opts.Queues = []string{DefaultQueue}
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 4
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+	if opts.RescueAfter <= 0 {
+		opts.RescueAfter = time.Hour
+	}
+	hostname, _ := os.Hostname()
+	return &Worker{
+		opts:  opts,
+		id:    fmt.Sprintf("%s/%s", hostname, uuid.NewString()[:8]),
+		slots: make(chan struct{}, opts.Concurrency),
+	}
+}
+
+// Start polls for jobs in the background until Stop is called.
+func (w *Worker) Start(ctx context.Context) {
+	ctx, w.cancel = context.WithCancel(ctx)
+	w.wg.Add(1)
+	go w.run(ctx)
+}
+
+// Stop stops claiming jobs and waits for running jobs to finish.
+func (w *Worker) Stop() {
+	if w.cancel != nil {
+		w.cancel()
+	}
+	w.wg.Wait()
+	w.jobs.Wait()
+}
+
+func (w *Worker) run(ctx context.Context) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.opts.PollInterval)
+	defer ticker.Stop()
+	maintenance := time.NewTicker(time.Minute)
+	defer maintenance.Stop()
+
+	w.maintain(ctx)
+	for {
+		n, err := w.Work(ctx)
+		if err != nil && ctx.Err() == nil {
+			w.opts.Logger.WithError(err).Error("jobs: failed to claim jobs")
+		}
+		// Keep claiming while jobs are ready and slots are free
+		if err == nil && n > 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-maintenance.C:
+			w.maintain(ctx)
+		}
+	}
+}
+
+func (w *Worker) maintain(ctx context.Context) {
+	if _, err := w.opts.Pool.Exec(ctx, rescueJobsQuery, time.Now().Add(-w.opts.RescueAfter)); err != nil && ctx.Err() == nil {
+		w.opts.Logger.WithError(err).Error("jobs: failed to rescue abandoned jobs")
+	}
+	if w.opts.Retention > 0 {
+		if _, err := w.opts.Pool.Exec(ctx, purgeJobsQuery, time.Now().Add(-w.opts.Retention)); err != nil && ctx.Err() == nil {
+			w.opts.Logger.WithError(err).Error("jobs: failed to purge completed jobs")
+		}
+	}
+}
+
+// Work claims as many ready jobs as there are free slots and starts them.
+// It blocks until at least one slot is free and returns the number of claimed jobs.
+func (w *Worker) Work(ctx context.Context) (int, error) {
+	select {
+	case w.slots <- struct{}{}:
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+	free := 1
+fill:
+	for free < w.opts.Concurrency {
+		select {
+		case w.slots <- struct{}{}:
+			free++
+		default:
+			break fill
+		}
+	}
+
+	claimed, err := w.claim(ctx, free)
+	for i := len(claimed); i < free; i++ {
+		<-w.slots
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	for _, job := range claimed {
+		w.jobs.Add(1)
+		go func(job *Job) {
+			defer func() {
+				<-w.slots
+				w.jobs.Done()
+			}()
+			w.process(job)
+		}(job)
+	}
+	return len(claimed), nil
+}
+
+func (w *Worker) claim(ctx context.Context, limit int) ([]*Job, error) {
+	rows, err := w.opts.Pool.Query(ctx, claimJobsQuery, w.id, w.opts.Queues, w.opts.Registry.Kinds(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, scanJob)
+}
+
+func (w *Worker) process(job *Job) {
+	logger := w.opts.Logger.WithFields(logrus.Fields{
+		"job_id":  job.ID,
+		"kind":    job.Kind,
+		"attempt": job.Attempts,
+	})
+
+	h, ok := w.opts.Registry.handler(job.Kind)
+	if !ok {
+		w.finish(job, nil, ErrUnknownJobKind, logger)
+		return
+	}
+
+	// Jobs are not tied to the worker's lifetime; Stop waits for them instead
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+	ctx = composables.WithPool(ctx, w.opts.Pool)
+	if job.TenantID != uuid.Nil {
+		ctx = composables.WithTenantID(ctx, job.TenantID)
+	}
+	ctx = WithJob(ctx, job)
+
+	w.finish(job, h, runHandler(ctx, h, job.Payload), logger)
+}
+
+func runHandler(ctx context.Context, h *handler, payload []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("jobs: handler %s panicked: %v", h.kind, r)
+		}
+	}()
+	return h.run(ctx, payload)
+}
+
+func (w *Worker) finish(job *Job, h *handler, jobErr error, logger *logrus.Entry) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var err error
+	switch {
+	case jobErr == nil:
+		_, err = w.opts.Pool.Exec(ctx, completeJobQuery, job.ID)
+	case h != nil && job.Attempts < job.MaxAttempts:
+		logger.WithError(jobErr).Warn("jobs: job failed, retrying")
+		_, err = w.opts.Pool.Exec(ctx, retryJobQuery, job.ID, jobErr.Error(), time.Now().Add(h.backoff(job.Attempts)))
+	default:
+		logger.WithError(jobErr).Error("jobs: job failed, giving up")
+		_, err = w.opts.Pool.Exec(ctx, failJobQuery, job.ID, jobErr.Error())
+	}
+	if err != nil {
+		logger.WithError(err).Error("jobs: failed to record job result")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/iota-uz/iota-sdk/pkg/lens"
@@ -15,6 +16,8 @@ import (
 // PostgreSQLDataSource implements DataSource for PostgreSQL databases
 type PostgreSQLDataSource struct {
 	pool     *pgxpool.Pool
+	replicas []*pgxpool.Pool
+	next     atomic.Uint64
 	metadata datasource.DataSourceMetadata
 	config   Config
 }
@@ -27,6 +30,8 @@ type Config struct {
 	MaxConnLifetime  time.Duration // Maximum connection lifetime
 	MaxConnIdleTime  time.Duration // Maximum connection idle time
 	QueryTimeout     time.Duration // Default query timeout
+	// ReplicaConnectionStrings are read replicas that queries are balanced over instead of the primary
+	ReplicaConnectionStrings []string
 }
 
 // NewPostgreSQLDataSource creates a new PostgreSQL data source
@@ -35,8 +40,52 @@ func NewPostgreSQLDataSource(config Config) (*PostgreSQLDataSource, error) {
 		return nil, fmt.Errorf("connection string is required")
 	}
 
+	pool, err := newPool(config.ConnectionString, config)
+	if err != nil {
+		return nil, err
+	}
+
+	replicas := make([]*pgxpool.Pool, 0, len(config.ReplicaConnectionStrings))
+	for _, connString := range config.ReplicaConnectionStrings {
+		replica, err := newPool(connString, config)
+		if err != nil {
+			pool.Close()
+			for _, r := range replicas {
+				r.Close()
+			}
+			return nil, fmt.Errorf("failed to create replica pool: %w", err)
+		}
+		replicas = append(replicas, replica)
+	}
+
+	// Set default timeout
+	if config.QueryTimeout == 0 {
+		config.QueryTimeout = 30 * time.Second
+	}
+
+	ds := &PostgreSQLDataSource{
+		pool:     pool,
+		replicas: replicas,
+		config:   config,
+		metadata: datasource.DataSourceMetadata{
+			Type:        datasource.TypePostgreSQL,
+			Name:        "PostgreSQL",
+			Version:     "1.0.0",
+			Description: "PostgreSQL database data source using pgxpool",
+			Capabilities: []datasource.Capability{
+				datasource.CapabilityQuery,
+				datasource.CapabilityMetrics,
+			},
+		},
+	}
+
+	return ds, nil
+}
+
+// newPool creates a connection pool for connString with the limits of config
+func newPool(connString string, config Config) (*pgxpool.Pool, error) {
 	// Parse connection string and create pool config
-	poolConfig, err := pgxpool.ParseConfig(config.ConnectionString)
+	poolConfig, err := pgxpool.ParseConfig(connString)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse connection string: %w", err)
 	}
@@ -60,28 +109,16 @@ func NewPostgreSQLDataSource(config Config) (*PostgreSQLDataSource, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection pool: %w", err)
 	}
+	return pool, nil
+}
 
-	// Set default timeout
-	if config.QueryTimeout == 0 {
-		config.QueryTimeout = 30 * time.Second
-	}
-
-	ds := &PostgreSQLDataSource{
-		pool:   pool,
-		config: config,
-		metadata: datasource.DataSourceMetadata{
-			Type:        datasource.TypePostgreSQL,
-			Name:        "PostgreSQL",
-			Version:     "1.0.0",
-			Description: "PostgreSQL database data source using pgxpool",
-			Capabilities: []datasource.Capability{
-				datasource.CapabilityQuery,
-				datasource.CapabilityMetrics,
-			},
-		},
+// readPool returns the next replica in round-robin order, or the primary when there are none
+func (ds *PostgreSQLDataSource) readPool() *pgxpool.Pool {
+	if len(ds.replicas) == 0 {
+		return ds.pool
 	}
-
-	return ds, nil
+	n := ds.next.Add(1) - 1
+	return ds.replicas[n%uint64(len(ds.replicas))]
 }
 
 // Query executes a query and returns the result
@@ -132,7 +169,7 @@ func (ds *PostgreSQLDataSource) executeTableQuery(ctx context.Context, query str
 		query = fmt.Sprintf("%s LIMIT %d", query, originalQuery.MaxDataPoints)
 	}
 
-	rows, err := ds.pool.Query(ctx, query)
+	rows, err := ds.readPool().Query(ctx, query)
 	if err != nil {
 		return nil, ds.handleQueryError(err, query)
 	}
@@ -209,7 +246,7 @@ func (ds *PostgreSQLDataSource) executeTimeSeriesQuery(ctx context.Context, quer
 		query = fmt.Sprintf("%s LIMIT %d", query, originalQuery.MaxDataPoints)
 	}
 
-	rows, err := ds.pool.Query(ctx, query)
+	rows, err := ds.readPool().Query(ctx, query)
 	if err != nil {
 		return nil, ds.handleQueryError(err, query)
 	}
@@ -358,6 +395,9 @@ func (ds *PostgreSQLDataSource) Close() error {
 	if ds.pool != nil {
 		ds.pool.Close()
 	}
+	for _, r := range ds.replicas {
+		r.Close()
+	}
 	return nil
 }
 
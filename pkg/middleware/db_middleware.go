@@ -2,11 +2,44 @@ package middleware
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 )
 
+// PrimaryCookieName is set after writes to keep the session reading from the primary.
+const PrimaryCookieName = "db_primary"
+
+// ReadReplicas provides read replicas to handlers and keeps a session on the primary
+// for stickiness after any request that may write (anything but GET, HEAD and OPTIONS),
+// so it reads its own writes while replicas catch up. The cookie makes this work
+// across instances behind a load balancer.
+func ReadReplicas(replicas *composables.ReadReplicas, stickiness time.Duration) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := composables.WithReplicas(r.Context(), replicas)
+			if _, err := r.Cookie(PrimaryCookieName); err == nil {
+				ctx = composables.WithPrimary(ctx)
+			}
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+			default:
+				ctx = composables.WithPrimary(ctx)
+				http.SetCookie(w, &http.Cookie{
+					Name:     PrimaryCookieName,
+					Value:    "1",
+					Path:     "/",
+					MaxAge:   max(int(stickiness.Seconds()), 1),
+					HttpOnly: true,
+					SameSite: http.SameSiteLaxMode,
+				})
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
 // WithTransaction is deprecated and will be removed in the future.
 func WithTransaction() mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
@@ -0,0 +1,677 @@
+package repo
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Expression is a piece of SQL that binds its own values.
+// Placeholders are numbered when the enclosing query is built, so expressions
+// can be nested and combined freely without tracking $n indexes by hand.
+type Expression interface {
+	build(args *queryArgs) string
+}
+
+// Condition is an Expression used in WHERE, HAVING and JOIN ... ON clauses.
+type Condition = Expression
+
+// queryArgs collects bound values while a query is built.
+type queryArgs struct {
+	values []any
+}
+
+func (a *queryArgs) next() int {
+	return len(a.values) + 1
+}
+
+func (a *queryArgs) add(values ...any) {
+	a.values = append(a.values, values...)
+}
+
+// bind renders a value: expressions are inlined, anything else becomes a placeholder.
+func (a *queryArgs) bind(value any) string {
+	if e, ok := value.(Expression); ok {
+		return e.build(a)
+	}
+	a.add(value)
+	return "$" + strconv.Itoa(len(a.values))
+}
+
+var placeholderRe = regexp.MustCompile(`\$(\d+)`)
+
+// renumber shifts $1..$n placeholders in sql so that $1 becomes $argIdx.
+func renumber(sql string, argIdx int) string {
+	if argIdx == 1 {
+		return sql
+	}
+	return placeholderRe.ReplaceAllStringFunc(sql, func(p string) string {
+		n, err := strconv.Atoi(p[1:])
+		if err != nil {
+			return p
+		}
+		return "$" + strconv.Itoa(n+argIdx-1)
+	})
+}
+
+// =========================
+// === Conditions        ===
+// =========================
+
+type filterCondition struct {
+	column string
+	filter Filter
+}
+
+func (c *filterCondition) build(args *queryArgs) string {
+	switch f := c.filter.(type) {
+	case *orFilter:
+		return buildGroup(args, " OR ", filterConditions(c.column, f.filters))
+	case *andFilter:
+		return buildGroup(args, " AND ", filterConditions(c.column, f.filters))
+	case *rawFilter:
+		return Expr(f.sql, f.values...).build(args)
+	case *existsFilter:
+		return Expr(f.subquery, f.values...).build(args)
+	case *subqueryFilter:
+		return c.column + " IN (" + Expr(f.subquery, f.values...).build(args) + ")"
+	}
+	sql := c.filter.String(c.column, args.next())
+	args.add(c.filter.Value()...)
+	return sql
+}
+
+func filterConditions(column string, filters []Filter) []Condition {
+	conds := make([]Condition, len(filters))
+	for i, f := range filters {
+		conds[i] = &filterCondition{column: column, filter: f}
+	}
+	return conds
+}
+
+type groupCondition struct {
+	op    string
+	conds []Condition
+}
+
+func (c *groupCondition) build(args *queryArgs) string {
+	sql := buildGroup(args, c.op, c.conds)
+	// Skipping an OR without alternatives would match every row instead of none
+	if sql == "" && c.op == " OR " {
+		return "FALSE"
+	}
+	return sql
+}
+
+func buildGroup(args *queryArgs, op string, conds []Condition) string {
+	parts := make([]string, 0, len(conds))
+	for _, cond := range conds {
+		if cond == nil {
+			continue
+		}
+		if sql := cond.build(args); sql != "" {
+			parts = append(parts, sql)
+		}
+	}
+	switch len(parts) {
+	case 0:
+		return ""
+	case 1:
+		return parts[0]
+	default:
+		return "(" + strings.Join(parts, op) + ")"
+	}
+}
+
+type notCondition struct {
+	cond Condition
+}
+
+func (c *notCondition) build(args *queryArgs) string {
+	sql := c.cond.build(args)
+	if sql == "" {
+		return ""
+	}
+	return "NOT (" + sql + ")"
+}
+
+type rawExpression struct {
+	sql    string
+	values []any
+}
+
+func (e *rawExpression) build(args *queryArgs) string {
+	sql := renumber(e.sql, args.next())
+	args.add(e.values...)
+	return sql
+}
+
+// literal is SQL without placeholders that is used as is.
+type literal string
+
+func (l literal) build(*queryArgs) string {
+	return string(l)
+}
+
+type existsCondition struct {
+	query *SelectBuilder
+	not   bool
+}
+
+func (c *existsCondition) build(args *queryArgs) string {
+	sql := "EXISTS " + c.query.build(args)
+	if c.not {
+		return "NOT " + sql
+	}
+	return sql
+}
+
+type inQueryCondition struct {
+	column string
+	query  *SelectBuilder
+}
+
+func (c *inQueryCondition) build(args *queryArgs) string {
+	return c.column + " IN " + c.query.build(args)
+}
+
+// Cond applies a Filter to a column. Or and And filters are expanded into
+// groups so that every nested value gets its own placeholder.
+//
+// Example usage:
+//
+//	repo.Cond("u.email", repo.ILike("%@example.com"))
+func Cond(column string, filter Filter) Condition {
+	return &filterCondition{column: column, filter: filter}
+}
+
+// AllOf joins conditions with AND. Nil and empty conditions are skipped.
+func AllOf(conds ...Condition) Condition {
+	return &groupCondition{op: " AND ", conds: conds}
+}
+
+// AnyOf joins conditions with OR. Nil and empty conditions are skipped, and
+// when none is left it renders FALSE, matching no rows.
+func AnyOf(conds ...Condition) Condition {
+	return &groupCondition{op: " OR ", conds: conds}
+}
+
+// Not negates a condition.
+func Not(cond Condition) Condition {
+	return &notCondition{cond: cond}
+}
+
+// Expr is a raw SQL expression with its own $1..$n placeholders,
+// which are renumbered to fit the query it ends up in.
+//
+// Example usage:
+//
+//	repo.Expr("wp.created_at BETWEEN $1 AND $2", from, to)
+//	repo.Expr("NOW()")
+func Expr(sql string, values ...any) Expression {
+	return &rawExpression{sql: sql, values: values}
+}
+
+// WhereExists matches when the subquery returns at least one row.
+func WhereExists(query *SelectBuilder) Condition {
+	return &existsCondition{query: query}
+}
+
+// WhereNotExists matches when the subquery returns no rows.
+func WhereNotExists(query *SelectBuilder) Condition {
+	return &existsCondition{query: query, not: true}
+}
+
+// InQuery matches when column is among the values returned by the subquery.
+func InQuery(column string, query *SelectBuilder) Condition {
+	return &inQueryCondition{column: column, query: query}
+}
+
+// FieldFilters converts typed field filters into a condition using the same
+// field-to-column mapping as SortBy.ToSQL. Unknown fields are reported as an error.
+//
+// Example usage:
+//
+//	cond, err := repo.FieldFilters(fieldMap, params.Filters)
+func FieldFilters[T comparable](mapping map[T]string, filters []FieldFilter[T]) (Condition, error) {
+	conds := make([]Condition, 0, len(filters))
+	for _, f := range filters {
+		column, ok := mapping[f.Column]
+		if !ok || column == "" {
+			return nil, fmt.Errorf("unknown filter field: %v", f.Column)
+		}
+		conds = append(conds, Cond(column, f.Filter))
+	}
+	return AllOf(conds...), nil
+}
+
+// =========================
+// === SELECT            ===
+// =========================
+
+type joinClause struct {
+	kind  string
+	table string
+	on    Condition
+}
+
+// SelectBuilder composes a SELECT query.
+//
+// Example usage:
+//
+//	query, args := repo.NewSelect("u.id", "COUNT(s.id)").
+//		From("users u").
+//		LeftJoin("sessions s", repo.Expr("s.user_id = u.id")).
+//		Where(repo.Cond("u.tenant_id", repo.Eq(tenantID))).
+//		GroupBy("u.id").
+//		Having(repo.Expr("COUNT(s.id) > $1", 1)).
+//		OrderBy(sortBy.Columns(fieldMap)...).
+//		Limit(10).
+//		Build()
+type SelectBuilder struct {
+	distinct bool
+	columns  []string
+	from     string
+	joins    []joinClause
+	where    []Condition
+	groupBy  []string
+	having   []Condition
+	orderBy  []Expression
+	limit    int
+	offset   int
+	suffix   string
+}
+
+// NewSelect starts a SELECT of the given columns, or * when none are given.
+func NewSelect(columns ...string) *SelectBuilder {
+	return &SelectBuilder{columns: columns}
+}
+
+func (b *SelectBuilder) Distinct() *SelectBuilder {
+	b.distinct = true
+	return b
+}
+
+func (b *SelectBuilder) Columns(columns ...string) *SelectBuilder {
+	b.columns = append(b.columns, columns...)
+	return b
+}
+
+func (b *SelectBuilder) From(table string) *SelectBuilder {
+	b.from = table
+	return b
+}
+
+func (b *SelectBuilder) Join(table string, on Condition) *SelectBuilder {
+	return b.join("JOIN", table, on)
+}
+
+func (b *SelectBuilder) LeftJoin(table string, on Condition) *SelectBuilder {
+	return b.join("LEFT JOIN", table, on)
+}
+
+func (b *SelectBuilder) RightJoin(table string, on Condition) *SelectBuilder {
+	return b.join("RIGHT JOIN", table, on)
+}
+
+func (b *SelectBuilder) join(kind, table string, on Condition) *SelectBuilder {
+	b.joins = append(b.joins, joinClause{kind: kind, table: table, on: on})
+	return b
+}
+
+// Where adds conditions that are ANDed with the existing ones.
+func (b *SelectBuilder) Where(conds ...Condition) *SelectBuilder {
+	b.where = append(b.where, conds...)
+	return b
+}
+
+func (b *SelectBuilder) GroupBy(columns ...string) *SelectBuilder {
+	b.groupBy = append(b.groupBy, columns...)
+	return b
+}
+
+// Having adds conditions on aggregates that are ANDed with the existing ones.
+func (b *SelectBuilder) Having(conds ...Condition) *SelectBuilder {
+	b.having = append(b.having, conds...)
+	return b
+}
+
+// OrderBy adds ORDER BY expressions, e.g. the result of SortBy.Columns.
+func (b *SelectBuilder) OrderBy(expressions ...string) *SelectBuilder {
+	for _, e := range expressions {
+		b.orderBy = append(b.orderBy, literal(e))
+	}
+	return b
+}
+
+// OrderByExpr adds ORDER BY expressions binding their own values, e.g. a search rank.
+func (b *SelectBuilder) OrderByExpr(expressions ...Expression) *SelectBuilder {
+	b.orderBy = append(b.orderBy, expressions...)
+	return b
+}
+
+func (b *SelectBuilder) Limit(limit int) *SelectBuilder {
+	b.limit = limit
+	return b
+}
+
+func (b *SelectBuilder) Offset(offset int) *SelectBuilder {
+	b.offset = offset
+	return b
+}
+
+// Suffix appends raw SQL to the end of the query, e.g. "FOR UPDATE SKIP LOCKED".
+func (b *SelectBuilder) Suffix(sql string) *SelectBuilder {
+	b.suffix = sql
+	return b
+}
+
+// Build returns the query and its arguments.
+func (b *SelectBuilder) Build() (string, []any) {
+	args := &queryArgs{}
+	return b.sql(args), args.values
+}
+
+// build renders the query as a parenthesized subquery of an enclosing one.
+func (b *SelectBuilder) build(args *queryArgs) string {
+	return "(" + b.sql(args) + ")"
+}
+
+func (b *SelectBuilder) sql(args *queryArgs) string {
+	parts := []string{"SELECT"}
+	if b.distinct {
+		parts = append(parts, "DISTINCT")
+	}
+	if len(b.columns) > 0 {
+		parts = append(parts, strings.Join(b.columns, ", "))
+	} else {
+		parts = append(parts, "*")
+	}
+	if b.from != "" {
+		parts = append(parts, "FROM", b.from)
+	}
+	for _, j := range b.joins {
+		parts = append(parts, j.kind, j.table)
+		if j.on != nil {
+			if on := j.on.build(args); on != "" {
+				parts = append(parts, "ON", on)
+			}
+		}
+	}
+	if where := buildClause(args, "WHERE", b.where); where != "" {
+		parts = append(parts, where)
+	}
+	if len(b.groupBy) > 0 {
+		parts = append(parts, "GROUP BY", strings.Join(b.groupBy, ", "))
+	}
+	if having := buildClause(args, "HAVING", b.having); having != "" {
+		parts = append(parts, having)
+	}
+	if len(b.orderBy) > 0 {
+		orderBy := make([]string, len(b.orderBy))
+		for i, e := range b.orderBy {
+			orderBy[i] = e.build(args)
+		}
+		parts = append(parts, "ORDER BY", strings.Join(orderBy, ", "))
+	}
+	if limitOffset := FormatLimitOffset(b.limit, b.offset); limitOffset != "" {
+		parts = append(parts, limitOffset)
+	}
+	if b.suffix != "" {
+		parts = append(parts, b.suffix)
+	}
+	return Join(parts...)
+}
+
+// buildClause renders conditions joined with AND after keyword, or "" when there are none.
+func buildClause(args *queryArgs, keyword string, conds []Condition) string {
+	parts := make([]string, 0, len(conds))
+	for _, cond := range conds {
+		if cond == nil {
+			continue
+		}
+		if sql := cond.build(args); sql != "" {
+			parts = append(parts, sql)
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return keyword + " " + strings.Join(parts, " AND ")
+}
+
+// =========================
+// === INSERT            ===
+// =========================
+
+// InsertBuilder composes an INSERT query with one or more rows.
+//
+// Example usage:
+//
+//	query, args := repo.NewInsert("warehouse_units").
+//		Columns("title", "short_title", "created_at").
+//		Values("Kilogram", "kg", repo.Expr("NOW()")).
+//		Returning("id").
+//		Build()
+//	// query = "INSERT INTO warehouse_units (title, short_title, created_at) VALUES ($1, $2, NOW()) RETURNING id"
+type InsertBuilder struct {
+	table      string
+	columns    []string
+	rows       [][]any
+	onConflict string
+	returning  []string
+}
+
+func NewInsert(table string) *InsertBuilder {
+	return &InsertBuilder{table: table}
+}
+
+func (b *InsertBuilder) Columns(columns ...string) *InsertBuilder {
+	b.columns = append(b.columns, columns...)
+	return b
+}
+
+// Values adds a row. Expression values are inlined instead of being bound.
+func (b *InsertBuilder) Values(values ...any) *InsertBuilder {
+	b.rows = append(b.rows, values)
+	return b
+}
+
+// OnConflict adds an ON CONFLICT clause, e.g. "(code) DO NOTHING".
+func (b *InsertBuilder) OnConflict(clause string) *InsertBuilder {
+	b.onConflict = clause
+	return b
+}
+
+func (b *InsertBuilder) Returning(columns ...string) *InsertBuilder {
+	b.returning = append(b.returning, columns...)
+	return b
+}
+
+// Build returns the query and its arguments.
+// Panics if a row doesn't have a value for every column, like BatchInsertQueryN.
+func (b *InsertBuilder) Build() (string, []any) {
+	args := &queryArgs{}
+	rows := make([]string, len(b.rows))
+	for i, row := range b.rows {
+		if len(row) != len(b.columns) {
+			panic(fmt.Sprintf("insert into %s: row %d has %d values for %d columns", b.table, i, len(row), len(b.columns)))
+		}
+		values := make([]string, len(row))
+		for j, v := range row {
+			values[j] = args.bind(v)
+		}
+		rows[i] = "(" + strings.Join(values, ", ") + ")"
+	}
+	parts := []string{
+		"INSERT INTO", b.table,
+		"(" + strings.Join(b.columns, ", ") + ")",
+		"VALUES", strings.Join(rows, ", "),
+	}
+	if b.onConflict != "" {
+		parts = append(parts, "ON CONFLICT", b.onConflict)
+	}
+	if len(b.returning) > 0 {
+		parts = append(parts, "RETURNING", strings.Join(b.returning, ", "))
+	}
+	return Join(parts...), args.values
+}
+
+// =========================
+// === UPDATE            ===
+// =========================
+
+type setClause struct {
+	column string
+	value  any
+}
+
+// UpdateBuilder composes an UPDATE query.
+//
+// Example usage:
+//
+//	query, args := repo.NewUpdate("users").
+//		Set("first_name", firstName).
+//		Set("updated_at", repo.Expr("NOW()")).
+//		Where(repo.Cond("id", repo.Eq(id)), repo.Cond("tenant_id", repo.Eq(tenantID))).
+//		Returning("updated_at").
+//		Build()
+type UpdateBuilder struct {
+	table     string
+	sets      []setClause
+	from      string
+	where     []Condition
+	all       bool
+	returning []string
+}
+
+func NewUpdate(table string) *UpdateBuilder {
+	return &UpdateBuilder{table: table}
+}
+
+// Set assigns a column. Expression values are inlined instead of being bound.
+func (b *UpdateBuilder) Set(column string, value any) *UpdateBuilder {
+	b.sets = append(b.sets, setClause{column: column, value: value})
+	return b
+}
+
+// SetMap assigns every column of values in the order of columns.
+func (b *UpdateBuilder) SetMap(columns []string, values map[string]any) *UpdateBuilder {
+	for _, c := range columns {
+		b.Set(c, values[c])
+	}
+	return b
+}
+
+// From adds an UPDATE ... FROM table list for joined updates.
+func (b *UpdateBuilder) From(tables string) *UpdateBuilder {
+	b.from = tables
+	return b
+}
+
+// Where adds conditions that are ANDed with the existing ones.
+func (b *UpdateBuilder) Where(conds ...Condition) *UpdateBuilder {
+	b.where = append(b.where, conds...)
+	return b
+}
+
+// All allows the query to update every row when it has no conditions.
+func (b *UpdateBuilder) All() *UpdateBuilder {
+	b.all = true
+	return b
+}
+
+func (b *UpdateBuilder) Returning(columns ...string) *UpdateBuilder {
+	b.returning = append(b.returning, columns...)
+	return b
+}
+
+// Build returns the query and its arguments.
+// Panics if the query has no conditions and All wasn't called.
+func (b *UpdateBuilder) Build() (string, []any) {
+	args := &queryArgs{}
+	sets := make([]string, len(b.sets))
+	for i, s := range b.sets {
+		sets[i] = s.column + " = " + args.bind(s.value)
+	}
+	parts := []string{"UPDATE", b.table, "SET", strings.Join(sets, ", ")}
+	if b.from != "" {
+		parts = append(parts, "FROM", b.from)
+	}
+	if where := buildClause(args, "WHERE", b.where); where != "" {
+		parts = append(parts, where)
+	} else if !b.all {
+		panic(fmt.Sprintf("update %s: no conditions, call All to update every row", b.table))
+	}
+	if len(b.returning) > 0 {
+		parts = append(parts, "RETURNING", strings.Join(b.returning, ", "))
+	}
+	return Join(parts...), args.values
+}
+
+// =========================
+// === DELETE            ===
+// =========================
+
+// DeleteBuilder composes a DELETE query.
+//
+// Example usage:
+//
+//	query, args := repo.NewDelete("warehouse_positions").
+//		Where(repo.Cond("id", repo.Eq(id)), repo.Cond("tenant_id", repo.Eq(tenantID))).
+//		Returning("id").
+//		Build()
+type DeleteBuilder struct {
+	table     string
+	using     string
+	where     []Condition
+	all       bool
+	returning []string
+}
+
+func NewDelete(table string) *DeleteBuilder {
+	return &DeleteBuilder{table: table}
+}
+
+// Using adds a DELETE ... USING table list for joined deletes.
+func (b *DeleteBuilder) Using(tables string) *DeleteBuilder {
+	b.using = tables
+	return b
+}
+
+// Where adds conditions that are ANDed with the existing ones.
+func (b *DeleteBuilder) Where(conds ...Condition) *DeleteBuilder {
+	b.where = append(b.where, conds...)
+	return b
+}
+
+// All allows the query to delete every row when it has no conditions.
+func (b *DeleteBuilder) All() *DeleteBuilder {
+	b.all = true
+	return b
+}
+
+func (b *DeleteBuilder) Returning(columns ...string) *DeleteBuilder {
+	b.returning = append(b.returning, columns...)
+	return b
+}
+
+// Build returns the query and its arguments.
+// Panics if the query has no conditions and All wasn't called.
+func (b *DeleteBuilder) Build() (string, []any) {
+	args := &queryArgs{}
+	parts := []string{"DELETE FROM", b.table}
+	if b.using != "" {
+		parts = append(parts, "USING", b.using)
+	}
+	if where := buildClause(args, "WHERE", b.where); where != "" {
+		parts = append(parts, where)
+	} else if !b.all {
+		panic(fmt.Sprintf("delete from %s: no conditions, call All to delete every row", b.table))
+	}
+	if len(b.returning) > 0 {
+		parts = append(parts, "RETURNING", strings.Join(b.returning, ", "))
+	}
+	return Join(parts...), args.values
+}
@@ -0,0 +1,174 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectBuilder(t *testing.T) {
+	t.Run("select all", func(t *testing.T) {
+		query, args := NewSelect().From("users").Build()
+		assert.Equal(t, "SELECT * FROM users", query)
+		assert.Empty(t, args)
+	})
+
+	t.Run("joins, groups and aggregates", func(t *testing.T) {
+		sortBy := SortBy[string]{Fields: []SortByField[string]{
+			{Field: "sessions", Ascending: false, NullsLast: true},
+			{Field: "unknown", Ascending: true},
+		}}
+		query, args := NewSelect("u.id", "COUNT(s.id) AS sessions").
+			From("users u").
+			LeftJoin("sessions s", AllOf(Expr("s.user_id = u.id"), Cond("s.expires_at", Gt("2025-01-01")))).
+			Where(Cond("u.tenant_id", Eq("t1"))).
+			GroupBy("u.id").
+			Having(Expr("COUNT(s.id) > $1", 2)).
+			OrderBy(sortBy.Columns(map[string]string{"sessions": "sessions"})...).
+			Limit(10).
+			Offset(20).
+			Build()
+		assert.Equal(t,
+			"SELECT u.id, COUNT(s.id) AS sessions FROM users u "+
+				"LEFT JOIN sessions s ON (s.user_id = u.id AND s.expires_at > $1) "+
+				"WHERE u.tenant_id = $2 GROUP BY u.id HAVING COUNT(s.id) > $3 "+
+				"ORDER BY sessions DESC NULLS LAST LIMIT 10 OFFSET 20",
+			query,
+		)
+		assert.Equal(t, []any{"2025-01-01", "t1", 2}, args)
+	})
+
+	t.Run("nested groups number every value", func(t *testing.T) {
+		query, args := NewSelect("id").
+			From("orders").
+			Where(
+				Cond("status", Or(Eq("pending"), And(Gt(5), Lt(10)))),
+				AnyOf(Cond("total", Between(1, 2)), Not(Cond("code", In([]string{"a", "b"})))),
+				AllOf(),
+				nil,
+			).
+			Build()
+		assert.Equal(t,
+			"SELECT id FROM orders WHERE (status = $1 OR (status > $2 AND status < $3)) "+
+				"AND (total BETWEEN $4 AND $5 OR NOT (code IN ($6, $7)))",
+			query,
+		)
+		assert.Equal(t, []any{"pending", 5, 10, 1, 2, "a", "b"}, args)
+	})
+
+	t.Run("AnyOf without conditions matches nothing", func(t *testing.T) {
+		query, args := NewSelect("id").
+			From("orders").
+			Where(Cond("tenant_id", Eq("t1")), AnyOf(nil, AllOf())).
+			Build()
+		assert.Equal(t, "SELECT id FROM orders WHERE tenant_id = $1 AND FALSE", query)
+		assert.Equal(t, []any{"t1"}, args)
+	})
+
+	t.Run("subqueries and raw filters", func(t *testing.T) {
+		items := NewSelect("1").
+			From("warehouse_order_items oi").
+			Where(Expr("oi.warehouse_product_id = wp.id"), Cond("oi.warehouse_order_id", Eq(7)))
+		query, args := NewSelect("wp.id").
+			From("warehouse_products wp").
+			Where(
+				Cond("wp.tenant_id", Eq("t1")),
+				WhereExists(items),
+				InQuery("wp.position_id", NewSelect("id").From("warehouse_positions").Where(Cond("title", ILike("%a%")))),
+				Cond("", RawFilter("wp.rfid = $1 OR wp.rfid = $2", "x", "y")),
+			).
+			Suffix("FOR UPDATE").
+			Build()
+		assert.Equal(t,
+			"SELECT wp.id FROM warehouse_products wp WHERE wp.tenant_id = $1 "+
+				"AND EXISTS (SELECT 1 FROM warehouse_order_items oi WHERE oi.warehouse_product_id = wp.id AND oi.warehouse_order_id = $2) "+
+				"AND wp.position_id IN (SELECT id FROM warehouse_positions WHERE title ILIKE $3) "+
+				"AND wp.rfid = $4 OR wp.rfid = $5 FOR UPDATE",
+			query,
+		)
+		assert.Equal(t, []any{"t1", 7, "%a%", "x", "y"}, args)
+	})
+}
+
+func TestFieldFilters(t *testing.T) {
+	mapping := map[string]string{"name": "u.name", "age": "u.age"}
+
+	cond, err := FieldFilters(mapping, []FieldFilter[string]{
+		{Column: "name", Filter: ILike("%a%")},
+		{Column: "age", Filter: Gte(18)},
+	})
+	require.NoError(t, err)
+	query, args := NewSelect().From("users u").Where(cond).Build()
+	assert.Equal(t, "SELECT * FROM users u WHERE (u.name ILIKE $1 AND u.age >= $2)", query)
+	assert.Equal(t, []any{"%a%", 18}, args)
+
+	_, err = FieldFilters(mapping, []FieldFilter[string]{{Column: "unknown", Filter: Eq(1)}})
+	require.Error(t, err)
+}
+
+func TestInsertBuilder(t *testing.T) {
+	query, args := NewInsert("warehouse_units").
+		Columns("title", "short_title", "created_at").
+		Values("Kilogram", "kg", Expr("NOW()")).
+		Values("Meter", "m", Expr("NOW()")).
+		OnConflict("(title) DO NOTHING").
+		Returning("id").
+		Build()
+	assert.Equal(t,
+		"INSERT INTO warehouse_units (title, short_title, created_at) VALUES ($1, $2, NOW()), ($3, $4, NOW()) "+
+			"ON CONFLICT (title) DO NOTHING RETURNING id",
+		query,
+	)
+	assert.Equal(t, []any{"Kilogram", "kg", "Meter", "m"}, args)
+
+	assert.Panics(t, func() {
+		NewInsert("units").Columns("title").Values("a", "b").Build()
+	})
+}
+
+func TestUpdateBuilder(t *testing.T) {
+	query, args := NewUpdate("users").
+		Set("first_name", "John").
+		Set("updated_at", Expr("NOW()")).
+		Set("visits", Expr("visits + $1", 1)).
+		Where(Cond("id", Eq(3)), Cond("tenant_id", Eq("t1"))).
+		Returning("updated_at").
+		Build()
+	assert.Equal(t,
+		"UPDATE users SET first_name = $1, updated_at = NOW(), visits = visits + $2 "+
+			"WHERE id = $3 AND tenant_id = $4 RETURNING updated_at",
+		query,
+	)
+	assert.Equal(t, []any{"John", 1, 3, "t1"}, args)
+
+	assert.Panics(t, func() {
+		NewUpdate("users").Set("first_name", "John").Build()
+	})
+	// Conditions that render nothing don't count
+	assert.Panics(t, func() {
+		NewUpdate("users").Set("first_name", "John").Where(nil, Expr("")).Build()
+	})
+	query, _ = NewUpdate("users").Set("first_name", "John").All().Build()
+	assert.Equal(t, "UPDATE users SET first_name = $1", query)
+}
+
+func TestDeleteBuilder(t *testing.T) {
+	query, args := NewDelete("warehouse_position_images i").
+		Using("warehouse_positions p").
+		Where(Expr("i.warehouse_position_id = p.id"), Cond("p.tenant_id", Eq("t1"))).
+		Returning("i.upload_id").
+		Build()
+	assert.Equal(t,
+		"DELETE FROM warehouse_position_images i USING warehouse_positions p "+
+			"WHERE i.warehouse_position_id = p.id AND p.tenant_id = $1 RETURNING i.upload_id",
+		query,
+	)
+	assert.Equal(t, []any{"t1"}, args)
+
+	assert.Panics(t, func() {
+		NewDelete("users").Build()
+	})
+	query, _ = NewDelete("users").All().Build()
+	assert.Equal(t, "DELETE FROM users", query)
+}
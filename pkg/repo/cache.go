@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"hash/fnv"
 	"math"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -23,6 +25,26 @@ type Cache interface {
 	Clear()
 }
 
+// TaggedCache is a Cache whose entries can be labelled with tags and evicted
+// together, e.g. every cached query touching an entity or a tenant.
+type TaggedCache interface {
+	Cache
+	// SetWithTags stores a value and associates the key with every tag.
+	SetWithTags(key string, value any, tags ...string) error
+	// InvalidateTags removes every value associated with any of the tags.
+	// It fails when some of them may still be cached.
+	InvalidateTags(tags ...string) error
+}
+
+// CacheTag builds a tag from its parts, e.g. CacheTag("user", id) or CacheTag("tenant", tenantID).
+func CacheTag(parts ...any) string {
+	strs := make([]string, len(parts))
+	for i, p := range parts {
+		strs[i] = fmt.Sprint(p)
+	}
+	return strings.Join(strs, ":")
+}
+
 func CacheKey(keys ...interface{}) string {
 	h := fnv.New64a()
 
@@ -130,7 +152,10 @@ func UseCache(ctx context.Context) (Cache, bool) {
 	return c, true
 }
 
+// InMemoryCache is an unbounded map based Cache that is safe for concurrent use.
+// Prefer NewLRUCache for long-lived caches.
 type InMemoryCache struct {
+	mu sync.RWMutex
 	// cache is a map of string to any type
 	cache map[string]any
 }
@@ -142,6 +167,8 @@ func NewInMemoryCache() Cache {
 }
 
 func (c *InMemoryCache) Get(key string) (any, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	if value, ok := c.cache[key]; ok {
 		return value, true
 	}
@@ -149,16 +176,20 @@ func (c *InMemoryCache) Get(key string) (any, bool) {
 }
 
 func (c *InMemoryCache) Set(key string, value any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.cache[key] = value
 	return nil
 }
 
 func (c *InMemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	delete(c.cache, key)
 }
 
 func (c *InMemoryCache) Clear() {
-	for k := range c.cache {
-		delete(c.cache, k)
-	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.cache)
 }
@@ -0,0 +1,161 @@
+package repo
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRUCacheOptions configures NewLRUCache.
+type LRUCacheOptions struct {
+	// Size is the maximum number of entries. The least recently used entry is
+	// evicted when it is exceeded. Zero means unbounded.
+	Size int
+	// TTL is how long an entry stays valid after it is set. Zero means forever.
+	TTL time.Duration
+	// Now returns the current time. Defaults to time.Now; override in tests.
+	Now func() time.Time
+}
+
+type lruEntry struct {
+	key       string
+	value     any
+	tags      []string
+	expiresAt time.Time
+}
+
+// LRUCache is a size-bounded, TTL-aware TaggedCache that is safe for concurrent use.
+type LRUCache struct {
+	mu      sync.Mutex
+	opts    LRUCacheOptions
+	order   *list.List
+	entries map[string]*list.Element
+	tags    map[string]map[string]struct{}
+}
+
+var _ TaggedCache = (*LRUCache)(nil)
+
+// NewLRUCache creates an in-process cache bounded by opts.Size entries whose values expire after opts.TTL.
+//
+// Example usage:
+//
+//	cache := repo.NewLRUCache(repo.LRUCacheOptions{Size: 10_000, TTL: 5 * time.Minute})
+func NewLRUCache(opts LRUCacheOptions) *LRUCache {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &LRUCache{
+		opts:    opts,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+		tags:    make(map[string]map[string]struct{}),
+	}
+}
+
+func (c *LRUCache) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*lruEntry)
+	if c.expired(entry) {
+		c.remove(el)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return entry.value, true
+}
+
+func (c *LRUCache) Set(key string, value any) error {
+	return c.SetWithTags(key, value)
+}
+
+func (c *LRUCache) SetWithTags(key string, value any, tags ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+
+	entry := &lruEntry{key: key, value: value, tags: tags}
+	if c.opts.TTL > 0 {
+		entry.expiresAt = c.opts.Now().Add(c.opts.TTL)
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for _, tag := range tags {
+		keys, ok := c.tags[tag]
+		if !ok {
+			keys = make(map[string]struct{})
+			c.tags[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+
+	if c.opts.Size > 0 {
+		for c.order.Len() > c.opts.Size {
+			c.remove(c.order.Back())
+		}
+	}
+	return nil
+}
+
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+}
+
+func (c *LRUCache) InvalidateTags(tags ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, tag := range tags {
+		for key := range c.tags[tag] {
+			if el, ok := c.entries[key]; ok {
+				c.remove(el)
+			}
+		}
+		delete(c.tags, tag)
+	}
+	return nil
+}
+
+func (c *LRUCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	clear(c.entries)
+	clear(c.tags)
+}
+
+// Len returns the number of entries, including expired ones that haven't been evicted yet.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *LRUCache) expired(entry *lruEntry) bool {
+	return !entry.expiresAt.IsZero() && !c.opts.Now().Before(entry.expiresAt)
+}
+
+// remove unlinks an entry from the list, the key index and its tags. The caller must hold mu.
+func (c *LRUCache) remove(el *list.Element) {
+	entry := c.order.Remove(el).(*lruEntry)
+	delete(c.entries, entry.key)
+	for _, tag := range entry.tags {
+		keys := c.tags[tag]
+		delete(keys, entry.key)
+		if len(keys) == 0 {
+			delete(c.tags, tag)
+		}
+	}
+}
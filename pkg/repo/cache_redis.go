@@ -0,0 +1,197 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// CacheCodec serializes cached values for caches living outside the process.
+type CacheCodec interface {
+	Marshal(value any) ([]byte, error)
+	Unmarshal(data []byte) (any, error)
+}
+
+// GobCodec encodes values with encoding/gob. Concrete types stored behind
+// interfaces have to be registered with gob.Register before use.
+type GobCodec struct{}
+
+func (GobCodec) Marshal(value any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (GobCodec) Unmarshal(data []byte) (any, error) {
+	var value any
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// RedisCacheOptions configures NewRedisCache.
+type RedisCacheOptions struct {
+	// Prefix namespaces the keys of this cache. Defaults to "repo_cache".
+	Prefix string
+	// TTL is how long an entry stays valid after it is set. Zero means forever.
+	TTL time.Duration
+	// Timeout bounds every Redis round trip. Defaults to one second.
+	Timeout time.Duration
+	// Codec serializes values. Defaults to GobCodec.
+	Codec CacheCodec
+	// Logger reports failures of Delete and Clear, which can't return them.
+	// Defaults to the standard logrus logger.
+	Logger *logrus.Logger
+}
+
+// RedisCache is a TaggedCache shared by every instance connected to the same Redis.
+// Redis errors are treated as cache misses on reads, so a Redis outage degrades
+// to querying the database instead of failing requests.
+type RedisCache struct {
+	client *redis.Client
+	opts   RedisCacheOptions
+}
+
+var _ TaggedCache = (*RedisCache)(nil)
+
+// NewRedisCache creates a cache backed by client.
+//
+// Example usage:
+//
+//	client := redis.NewClient(&redis.Options{Addr: conf.RedisURL})
+//	cache := repo.NewRedisCache(client, repo.RedisCacheOptions{Prefix: "users", TTL: 10 * time.Minute})
+func NewRedisCache(client *redis.Client, opts RedisCacheOptions) *RedisCache {
+	if opts.Prefix == "" {
+		opts.Prefix = "repo_cache"
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = time.Second
+	}
+	if opts.Codec == nil {
+		opts.Codec = GobCodec{}
+	}
+	if opts.Logger == nil {
+		opts.Logger = logrus.StandardLogger()
+	}
+	return &RedisCache{client: client, opts: opts}
+}
+
+func (c *RedisCache) Get(key string) (any, bool) {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	data, err := c.client.Get(ctx, c.key(key)).Bytes()
+	if err != nil {
+		return nil, false
+	}
+	value, err := c.opts.Codec.Unmarshal(data)
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+func (c *RedisCache) Set(key string, value any) error {
+	return c.SetWithTags(key, value)
+}
+
+func (c *RedisCache) SetWithTags(key string, value any, tags ...string) error {
+	data, err := c.opts.Codec.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, c.key(key), data, c.opts.TTL)
+		for _, tag := range tags {
+			pipe.SAdd(ctx, c.tagKey(tag), key)
+			if c.opts.TTL > 0 {
+				// A tag lives as long as its longest-lived key
+				pipe.ExpireGT(ctx, c.tagKey(tag), c.opts.TTL)
+				pipe.ExpireNX(ctx, c.tagKey(tag), c.opts.TTL)
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+func (c *RedisCache) Delete(key string) {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	if err := c.client.Del(ctx, c.key(key)).Err(); err != nil {
+		c.opts.Logger.WithError(err).WithField("key", key).Error("repo: failed to delete cache entry")
+	}
+}
+
+func (c *RedisCache) InvalidateTags(tags ...string) error {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	var errs []error
+	for _, tag := range tags {
+		keys, err := c.client.SMembers(ctx, c.tagKey(tag)).Result()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read cache tag %s: %w", tag, err))
+			continue
+		}
+		del := make([]string, 0, len(keys)+1)
+		for _, k := range keys {
+			del = append(del, c.key(k))
+		}
+		del = append(del, c.tagKey(tag))
+		if err := c.client.Del(ctx, del...).Err(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to invalidate cache tag %s: %w", tag, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Clear removes every key under the cache prefix, including tags.
+func (c *RedisCache) Clear() {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	var errs []error
+	iter := c.client.Scan(ctx, 0, c.opts.Prefix+":*", 100).Iterator()
+	var batch []string
+	for iter.Next(ctx) {
+		batch = append(batch, iter.Val())
+		if len(batch) == 100 {
+			errs = append(errs, c.client.Del(ctx, batch...).Err())
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		errs = append(errs, c.client.Del(ctx, batch...).Err())
+	}
+	errs = append(errs, iter.Err())
+	if err := errors.Join(errs...); err != nil {
+		c.opts.Logger.WithError(err).Error("repo: failed to clear cache")
+	}
+}
+
+func (c *RedisCache) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.opts.Timeout)
+}
+
+func (c *RedisCache) key(key string) string {
+	return c.opts.Prefix + ":k:" + key
+}
+
+func (c *RedisCache) tagKey(tag string) string {
+	return c.opts.Prefix + ":t:" + tag
+}
@@ -1,10 +1,16 @@
 package repo
 
 import (
+	"encoding/gob"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCacheKey(t *testing.T) {
@@ -142,3 +148,134 @@ func TestCacheKey(t *testing.T) {
 		}
 	})
 }
+
+func TestInMemoryCache_Concurrent(t *testing.T) {
+	cache := NewInMemoryCache()
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				key := CacheKey(i, j)
+				assert.NoError(t, cache.Set(key, j))
+				cache.Get(key)
+				cache.Delete(key)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestLRUCache(t *testing.T) {
+	t.Run("EvictsLeastRecentlyUsed", func(t *testing.T) {
+		cache := NewLRUCache(LRUCacheOptions{Size: 2})
+		require.NoError(t, cache.Set("a", 1))
+		require.NoError(t, cache.Set("b", 2))
+		_, ok := cache.Get("a")
+		require.True(t, ok)
+
+		require.NoError(t, cache.Set("c", 3))
+		_, ok = cache.Get("b")
+		assert.False(t, ok, "b was the least recently used entry")
+		_, ok = cache.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, 2, cache.Len())
+	})
+
+	t.Run("ExpiresEntries", func(t *testing.T) {
+		now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		cache := NewLRUCache(LRUCacheOptions{TTL: time.Minute, Now: func() time.Time { return now }})
+		require.NoError(t, cache.Set("a", 1))
+
+		value, ok := cache.Get("a")
+		require.True(t, ok)
+		assert.Equal(t, 1, value)
+
+		now = now.Add(time.Minute)
+		_, ok = cache.Get("a")
+		assert.False(t, ok)
+		assert.Equal(t, 0, cache.Len())
+	})
+
+	t.Run("InvalidatesTags", func(t *testing.T) {
+		cache := NewLRUCache(LRUCacheOptions{})
+		require.NoError(t, cache.SetWithTags("user:1", 1, CacheTag("user", 1), CacheTag("tenant", "t1")))
+		require.NoError(t, cache.SetWithTags("user:2", 2, CacheTag("user", 2), CacheTag("tenant", "t1")))
+		require.NoError(t, cache.SetWithTags("user:3", 3, CacheTag("user", 3), CacheTag("tenant", "t2")))
+
+		require.NoError(t, cache.InvalidateTags(CacheTag("user", 1)))
+		_, ok := cache.Get("user:1")
+		assert.False(t, ok)
+		_, ok = cache.Get("user:2")
+		assert.True(t, ok)
+
+		require.NoError(t, cache.InvalidateTags(CacheTag("tenant", "t1")))
+		_, ok = cache.Get("user:2")
+		assert.False(t, ok)
+		_, ok = cache.Get("user:3")
+		assert.True(t, ok)
+	})
+
+	t.Run("OverwriteDropsOldTags", func(t *testing.T) {
+		cache := NewLRUCache(LRUCacheOptions{})
+		require.NoError(t, cache.SetWithTags("k", 1, "old"))
+		require.NoError(t, cache.SetWithTags("k", 2, "new"))
+
+		require.NoError(t, cache.InvalidateTags("old"))
+		value, ok := cache.Get("k")
+		require.True(t, ok)
+		assert.Equal(t, 2, value)
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		cache := NewLRUCache(LRUCacheOptions{Size: 50, TTL: time.Minute})
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := range 200 {
+					assert.NoError(t, cache.SetWithTags(CacheKey(i, j), j, CacheTag("worker", i)))
+					cache.Get(CacheKey(i, j-1))
+					if j%50 == 0 {
+						assert.NoError(t, cache.InvalidateTags(CacheTag("worker", i)))
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		assert.LessOrEqual(t, cache.Len(), 50)
+	})
+}
+
+type gobCachedUser struct {
+	ID   int
+	Name string
+}
+
+func TestGobCodec(t *testing.T) {
+	gob.Register(gobCachedUser{})
+
+	codec := GobCodec{}
+	data, err := codec.Marshal(gobCachedUser{ID: 1, Name: "Alice"})
+	require.NoError(t, err)
+	value, err := codec.Unmarshal(data)
+	require.NoError(t, err)
+	assert.Equal(t, gobCachedUser{ID: 1, Name: "Alice"}, value)
+}
+
+func TestRedisCache_Unavailable(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	defer client.Close()
+	logger, hook := logrustest.NewNullLogger()
+	cache := NewRedisCache(client, RedisCacheOptions{Timeout: 100 * time.Millisecond, Logger: logger})
+
+	_, ok := cache.Get("k")
+	assert.False(t, ok)
+	require.Error(t, cache.InvalidateTags("tag"))
+
+	cache.Delete("k")
+	require.NotNil(t, hook.LastEntry())
+	assert.Equal(t, logrus.ErrorLevel, hook.LastEntry().Level)
+}
@@ -0,0 +1,222 @@
+package repo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor is returned when a cursor can't be decoded or doesn't match the sort order it is used with.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor holds the sort key values of the last row of a page, in the order of the SortBy fields.
+// It is passed between requests in its opaque encoded form, see EncodeCursor and DecodeCursor.
+type Cursor []any
+
+type cursorValue struct {
+	Type  string `json:"t"`
+	Value string `json:"v,omitempty"`
+}
+
+const (
+	cursorNull   = "n"
+	cursorString = "s"
+	cursorInt    = "i"
+	cursorFloat  = "f"
+	cursorBool   = "b"
+	cursorTime   = "t"
+)
+
+// EncodeCursor encodes sort key values into an opaque, URL-safe cursor.
+// Integers, floats, booleans, strings, times and nil keep their type when decoded,
+// any other value is encoded as its string representation.
+//
+// Example usage:
+//
+//	cursor := repo.EncodeCursor(last.CreatedAt(), last.ID())
+func EncodeCursor(values ...any) string {
+	if len(values) == 0 {
+		return ""
+	}
+	encoded := make([]cursorValue, len(values))
+	for i, v := range values {
+		encoded[i] = encodeCursorValue(v)
+	}
+	data, err := json.Marshal(encoded)
+	if err != nil {
+		// cursorValue only holds strings, so marshalling can't fail
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodes a cursor produced by EncodeCursor. An empty string decodes to a nil cursor.
+func DecodeCursor(s string) (Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	var encoded []cursorValue
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	cursor := make(Cursor, len(encoded))
+	for i, v := range encoded {
+		value, err := decodeCursorValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+		}
+		cursor[i] = value
+	}
+	return cursor, nil
+}
+
+func encodeCursorValue(v any) cursorValue {
+	if v == nil {
+		return cursorValue{Type: cursorNull}
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return cursorValue{Type: cursorNull}
+		}
+		rv = rv.Elem()
+	}
+	if t, ok := rv.Interface().(time.Time); ok {
+		return cursorValue{Type: cursorTime, Value: t.UTC().Format(time.RFC3339Nano)}
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		return cursorValue{Type: cursorString, Value: rv.String()}
+	case reflect.Bool:
+		return cursorValue{Type: cursorBool, Value: strconv.FormatBool(rv.Bool())}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cursorValue{Type: cursorInt, Value: strconv.FormatInt(rv.Int(), 10)}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cursorValue{Type: cursorInt, Value: strconv.FormatUint(rv.Uint(), 10)}
+	case reflect.Float32, reflect.Float64:
+		return cursorValue{Type: cursorFloat, Value: strconv.FormatFloat(rv.Float(), 'g', -1, 64)}
+	default:
+		return cursorValue{Type: cursorString, Value: fmt.Sprint(rv.Interface())}
+	}
+}
+
+func decodeCursorValue(v cursorValue) (any, error) {
+	switch v.Type {
+	case cursorNull:
+		return nil, nil
+	case cursorString:
+		return v.Value, nil
+	case cursorBool:
+		return strconv.ParseBool(v.Value)
+	case cursorInt:
+		return strconv.ParseInt(v.Value, 10, 64)
+	case cursorFloat:
+		return strconv.ParseFloat(v.Value, 64)
+	case cursorTime:
+		return time.Parse(time.RFC3339Nano, v.Value)
+	default:
+		return nil, fmt.Errorf("unknown cursor value type %q", v.Type)
+	}
+}
+
+// WithTiebreaker returns a copy of the sort order with field appended in ascending order,
+// unless the sort order already contains it. Keyset pagination needs the last sort key
+// to be unique, so field should be the primary key.
+func (s *SortBy[T]) WithTiebreaker(field T) SortBy[T] {
+	fields := slices.Clone(s.Fields)
+	if !slices.ContainsFunc(fields, func(f SortByField[T]) bool { return f.Field == field }) {
+		fields = append(fields, SortByField[T]{Field: field, Ascending: true})
+	}
+	return SortBy[T]{Fields: fields}
+}
+
+// Cursor encodes the sort key values of a row, as returned by value for each sort field.
+// Pass the last row of a page to get the cursor of the next one.
+//
+// Example usage:
+//
+//	next := sortBy.Cursor(func(f user.Field) any { return user.FieldValue(last, f) })
+func (s *SortBy[T]) Cursor(value func(field T) any) string {
+	values := make([]any, len(s.Fields))
+	for i, f := range s.Fields {
+		values[i] = value(f.Field)
+	}
+	return EncodeCursor(values...)
+}
+
+// Seek generates a condition matching the rows that come after cursor in this sort order,
+// for use in a WHERE clause in place of an OFFSET. Parameters are numbered from argIdx.
+// NULLs are placed the way ToSQL orders them: last when ascending or NullsLast is set, first otherwise.
+// The last sort field has to be unique and NOT NULL, see WithTiebreaker.
+//
+// Example usage:
+//
+//	sortBy := params.SortBy.WithTiebreaker(IDField)
+//	cond, condArgs, err := sortBy.Seek(fieldMap, cursor, len(args)+1)
+//	// cond = "(u.created_at < $2 OR (u.created_at = $2 AND u.id > $3))"
+func (s *SortBy[T]) Seek(mapping map[T]string, cursor Cursor, argIdx int) (string, []any, error) {
+	if len(cursor) != len(s.Fields) {
+		return "", nil, fmt.Errorf("%w: expected %d values, got %d", ErrInvalidCursor, len(s.Fields), len(cursor))
+	}
+
+	var (
+		args   []any
+		equals []string
+		terms  []string
+	)
+	for i, f := range s.Fields {
+		column := mapping[f.Field]
+		// Skip invalid fields the same way ToSQL does
+		if column == "" {
+			continue
+		}
+		value := cursor[i]
+		nullsLast := f.Ascending || f.NullsLast
+		nullable := i < len(s.Fields)-1
+
+		var after, equal string
+		if value == nil {
+			equal = column + " IS NULL"
+			if !nullsLast {
+				after = column + " IS NOT NULL"
+			}
+		} else {
+			placeholder := fmt.Sprintf("$%d", argIdx+len(args))
+			args = append(args, value)
+			op := ">"
+			if !f.Ascending {
+				op = "<"
+			}
+			equal = fmt.Sprintf("%s = %s", column, placeholder)
+			after = fmt.Sprintf("%s %s %s", column, op, placeholder)
+			if nullable && nullsLast {
+				after = fmt.Sprintf("(%s OR %s IS NULL)", after, column)
+			}
+		}
+
+		if after != "" {
+			if len(equals) == 0 {
+				terms = append(terms, after)
+			} else {
+				conds := append(slices.Clone(equals), after)
+				terms = append(terms, "("+strings.Join(conds, " AND ")+")")
+			}
+		}
+		equals = append(equals, equal)
+	}
+
+	if len(terms) == 0 {
+		return "FALSE", args, nil
+	}
+	return "(" + strings.Join(terms, " OR ") + ")", args, nil
+}
@@ -0,0 +1,145 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	createdAt := time.Date(2025, 3, 14, 15, 9, 26, 535897000, time.FixedZone("UTC+5", 5*60*60))
+	id := uuid.MustParse("1b4e28ba-2fa1-11d2-883f-0016d3cca427")
+	var missing *string
+
+	encoded := EncodeCursor("Alice", 42, uint(7), 1.5, true, createdAt, id, nil, missing)
+	assert.NotContains(t, encoded, "=", "cursor should be URL-safe without padding")
+
+	cursor, err := DecodeCursor(encoded)
+	require.NoError(t, err)
+	require.Len(t, cursor, 9)
+	assert.Equal(t, "Alice", cursor[0])
+	assert.Equal(t, int64(42), cursor[1])
+	assert.Equal(t, int64(7), cursor[2])
+	assert.InDelta(t, 1.5, cursor[3], 0)
+	assert.Equal(t, true, cursor[4])
+	assert.True(t, createdAt.Equal(cursor[5].(time.Time)))
+	assert.Equal(t, id.String(), cursor[6])
+	assert.Nil(t, cursor[7])
+	assert.Nil(t, cursor[8])
+}
+
+func TestCursor_Decode(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		cursor, err := DecodeCursor("")
+		require.NoError(t, err)
+		assert.Nil(t, cursor)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, s := range []string{"not base64!", "bm90IGpzb24", "W3sidCI6IngifV0"} {
+			_, err := DecodeCursor(s)
+			require.ErrorIs(t, err, ErrInvalidCursor, s)
+		}
+	})
+}
+
+func TestSortBy_WithTiebreaker(t *testing.T) {
+	sortBy := SortBy[string]{Fields: []SortByField[string]{{Field: "name"}}}
+
+	withID := sortBy.WithTiebreaker("id")
+	assert.Equal(t, []SortByField[string]{{Field: "name"}, {Field: "id", Ascending: true}}, withID.Fields)
+	assert.Len(t, sortBy.Fields, 1, "original sort order should not change")
+
+	again := withID.WithTiebreaker("id")
+	assert.Equal(t, withID.Fields, again.Fields)
+}
+
+func TestSortBy_Seek(t *testing.T) {
+	mapping := map[string]string{
+		"name":       "u.name",
+		"created_at": "u.created_at",
+		"id":         "u.id",
+	}
+
+	t.Run("single ascending key", func(t *testing.T) {
+		sortBy := SortBy[string]{Fields: []SortByField[string]{{Field: "id", Ascending: true}}}
+		sql, args, err := sortBy.Seek(mapping, Cursor{int64(10)}, 2)
+		require.NoError(t, err)
+		assert.Equal(t, "(u.id > $2)", sql)
+		assert.Equal(t, []any{int64(10)}, args)
+	})
+
+	t.Run("mixed directions", func(t *testing.T) {
+		sortBy := SortBy[string]{Fields: []SortByField[string]{
+			{Field: "created_at", Ascending: false},
+			{Field: "id", Ascending: true},
+		}}
+		sql, args, err := sortBy.Seek(mapping, Cursor{"2025-01-01", int64(3)}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "(u.created_at < $1 OR (u.created_at = $1 AND u.id > $2))", sql)
+		assert.Equal(t, []any{"2025-01-01", int64(3)}, args)
+	})
+
+	t.Run("nulls last", func(t *testing.T) {
+		sortBy := SortBy[string]{Fields: []SortByField[string]{
+			{Field: "name", Ascending: false, NullsLast: true},
+			{Field: "id", Ascending: true},
+		}}
+		sql, args, err := sortBy.Seek(mapping, Cursor{"Bob", int64(3)}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "((u.name < $1 OR u.name IS NULL) OR (u.name = $1 AND u.id > $2))", sql)
+		assert.Equal(t, []any{"Bob", int64(3)}, args)
+	})
+
+	t.Run("null value sorted last", func(t *testing.T) {
+		sortBy := SortBy[string]{Fields: []SortByField[string]{
+			{Field: "name", Ascending: true},
+			{Field: "id", Ascending: true},
+		}}
+		sql, args, err := sortBy.Seek(mapping, Cursor{nil, int64(3)}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "((u.name IS NULL AND u.id > $1))", sql)
+		assert.Equal(t, []any{int64(3)}, args)
+	})
+
+	t.Run("null value sorted first", func(t *testing.T) {
+		sortBy := SortBy[string]{Fields: []SortByField[string]{
+			{Field: "name", Ascending: false},
+			{Field: "id", Ascending: true},
+		}}
+		sql, _, err := sortBy.Seek(mapping, Cursor{nil, int64(3)}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "(u.name IS NOT NULL OR (u.name IS NULL AND u.id > $1))", sql)
+	})
+
+	t.Run("unmapped fields are skipped", func(t *testing.T) {
+		sortBy := SortBy[string]{Fields: []SortByField[string]{
+			{Field: "unknown", Ascending: true},
+			{Field: "id", Ascending: false},
+		}}
+		sql, args, err := sortBy.Seek(mapping, Cursor{"x", int64(3)}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "(u.id < $1)", sql)
+		assert.Equal(t, []any{int64(3)}, args)
+	})
+
+	t.Run("cursor does not match sort order", func(t *testing.T) {
+		sortBy := SortBy[string]{Fields: []SortByField[string]{{Field: "id", Ascending: true}}}
+		_, _, err := sortBy.Seek(mapping, Cursor{int64(1), int64(2)}, 1)
+		require.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("cursor from row", func(t *testing.T) {
+		sortBy := SortBy[string]{Fields: []SortByField[string]{
+			{Field: "name", Ascending: true},
+			{Field: "id", Ascending: true},
+		}}
+		row := map[string]any{"name": "Carol", "id": 9}
+		cursor, err := DecodeCursor(sortBy.Cursor(func(field string) any { return row[field] }))
+		require.NoError(t, err)
+		assert.Equal(t, Cursor{"Carol", int64(9)}, cursor)
+	})
+}
@@ -35,9 +35,17 @@ type SortBy[T sortFieldKey] struct {
 }
 
 func (s *SortBy[T]) ToSQL(mapping map[T]string) string {
-	if len(s.Fields) == 0 {
+	fields := s.Columns(mapping)
+	// Return empty if no valid fields found
+	if len(fields) == 0 {
 		return ""
 	}
+	return fmt.Sprintf("ORDER BY %s", strings.Join(fields, ", "))
+}
+
+// Columns returns the ORDER BY expressions without the ORDER BY keyword,
+// e.g. for SelectBuilder.OrderBy. Fields without a mapping are skipped.
+func (s *SortBy[T]) Columns(mapping map[T]string) []string {
 	fields := make([]string, 0, len(s.Fields))
 	for _, sort := range s.Fields {
 		field := mapping[sort.Field]
@@ -56,11 +64,7 @@ func (s *SortBy[T]) ToSQL(mapping map[T]string) string {
 		}
 		fields = append(fields, field)
 	}
-	// Return empty if no valid fields found
-	if len(fields) == 0 {
-		return ""
-	}
-	return fmt.Sprintf("ORDER BY %s", strings.Join(fields, ", "))
+	return fields
 }
 
 // Filter defines a query filter with a SQL clause generator and bound value.
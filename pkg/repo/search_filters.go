@@ -0,0 +1,209 @@
+package repo
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+)
+
+// DefaultTextSearchConfig is the text search configuration used by FullText and TSMatch.
+// It doesn't stem words, so it works the same for every language.
+const DefaultTextSearchConfig = "simple"
+
+var textSearchConfigRe = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// ValidateTextSearchConfig returns an error if config can't be used by FullText
+// and TSMatch. Empty configs stand for DefaultTextSearchConfig.
+func ValidateTextSearchConfig(config string) error {
+	if config != "" && !textSearchConfigRe.MatchString(config) {
+		return fmt.Errorf("invalid text search config %q", config)
+	}
+	return nil
+}
+
+// textSearchConfig quotes a text search configuration name for inlining into SQL.
+// Configurations can't be bound as parameters without a cast, so names are restricted
+// to identifiers and anything else panics, the same as In with a non-slice value.
+func textSearchConfig(config string) string {
+	if err := ValidateTextSearchConfig(config); err != nil {
+		panic(err.Error())
+	}
+	if config == "" {
+		config = DefaultTextSearchConfig
+	}
+	return "'" + config + "'"
+}
+
+// ===========================
+// === Full-text search    ===
+// ===========================
+
+type fullTextFilter struct {
+	config string
+	query  string
+	vector bool
+}
+
+func (f *fullTextFilter) document(column string) string {
+	if f.vector {
+		return column
+	}
+	return fmt.Sprintf("to_tsvector(%s, %s)", f.config, column)
+}
+
+func (f *fullTextFilter) String(column string, argIdx int) string {
+	return fmt.Sprintf("%s @@ websearch_to_tsquery(%s, $%d)", f.document(column), f.config, argIdx)
+}
+
+func (f *fullTextFilter) Value() []any {
+	return []any{f.query}
+}
+
+// Rank returns a ts_rank expression scoring how well column matches the filter,
+// reusing the placeholder at argIdx bound by String.
+func (f *fullTextFilter) Rank(column string, argIdx int) string {
+	return fmt.Sprintf("ts_rank(%s, websearch_to_tsquery(%s, $%d))", f.document(column), f.config, argIdx)
+}
+
+// Ranker is implemented by filters that can score matching rows, such as FullText.
+type Ranker interface {
+	Filter
+	Rank(column string, argIdx int) string
+}
+
+// ===========================
+// === JSONB               ===
+// ===========================
+
+type jsonContainsFilter struct {
+	value string
+}
+
+func (f *jsonContainsFilter) String(column string, argIdx int) string {
+	return fmt.Sprintf("%s @> $%d::jsonb", column, argIdx)
+}
+
+func (f *jsonContainsFilter) Value() []any {
+	return []any{f.value}
+}
+
+type jsonPathFilter struct {
+	path string
+}
+
+func (f *jsonPathFilter) String(column string, argIdx int) string {
+	return fmt.Sprintf("%s @? $%d::jsonpath", column, argIdx)
+}
+
+func (f *jsonPathFilter) Value() []any {
+	return []any{f.path}
+}
+
+type jsonHasKeyFilter struct {
+	key string
+}
+
+func (f *jsonHasKeyFilter) String(column string, argIdx int) string {
+	return fmt.Sprintf("%s ? $%d", column, argIdx)
+}
+
+func (f *jsonHasKeyFilter) Value() []any {
+	return []any{f.key}
+}
+
+// ===========================
+// === Arrays              ===
+// ===========================
+
+type arrayFilter struct {
+	op    string
+	value any
+}
+
+func (f *arrayFilter) String(column string, argIdx int) string {
+	return fmt.Sprintf("%s %s $%d", column, f.op, argIdx)
+}
+
+func (f *arrayFilter) Value() []any {
+	return []any{f.value}
+}
+
+// ===========================
+// === Trigram similarity  ===
+// ===========================
+
+type similarFilter struct {
+	value any
+	word  bool
+}
+
+func (f *similarFilter) String(column string, argIdx int) string {
+	if f.word {
+		return fmt.Sprintf("$%d <%% %s", argIdx, column)
+	}
+	return fmt.Sprintf("%s %% $%d", column, argIdx)
+}
+
+func (f *similarFilter) Value() []any {
+	return []any{f.value}
+}
+
+// ==============================
+// === Search Constructors    ===
+// ==============================
+
+// FullText matches a text column against a web search style query
+// ("quoted phrases", or, -excluded) using the given text search config,
+// or DefaultTextSearchConfig when config is empty.
+// Example: FullText("english", `"late payment" -refund`)
+func FullText(config, query string) Ranker {
+	return &fullTextFilter{config: textSearchConfig(config), query: query}
+}
+
+// TSMatch is like FullText for columns that already hold a tsvector.
+func TSMatch(config, query string) Ranker {
+	return &fullTextFilter{config: textSearchConfig(config), query: query, vector: true}
+}
+
+// JSONContains matches JSONB columns containing value, which is marshalled to JSON.
+// Panics if value can't be marshalled.
+// Example: JSONContains(map[string]any{"status": "active"})
+func JSONContains(value any) Filter {
+	data, err := json.Marshal(value)
+	if err != nil {
+		panic(fmt.Sprintf("JSONContains: %v", err))
+	}
+	return &jsonContainsFilter{string(data)}
+}
+
+// JSONPath matches JSONB columns for which the SQL/JSON path returns any item.
+// Example: JSONPath(`$.tags[*] ? (@ == "vip")`)
+func JSONPath(path string) Filter { return &jsonPathFilter{path} }
+
+// JSONHasKey matches JSONB columns having key as a top-level key.
+func JSONHasKey(key string) Filter { return &jsonHasKeyFilter{key} }
+
+// ArrayOverlap matches array columns sharing at least one element with values.
+func ArrayOverlap(values any) Filter { return &arrayFilter{"&&", arrayValue(values)} }
+
+// ArrayContains matches array columns containing every element of values.
+func ArrayContains(values any) Filter { return &arrayFilter{"@>", arrayValue(values)} }
+
+// ArrayContainedBy matches array columns whose elements are all in values.
+func ArrayContainedBy(values any) Filter { return &arrayFilter{"<@", arrayValue(values)} }
+
+func arrayValue(values any) any {
+	if reflect.ValueOf(values).Kind() != reflect.Slice {
+		panic("value must be a slice")
+	}
+	return values
+}
+
+// Similar matches columns whose trigram similarity to value is above
+// pg_trgm.similarity_threshold. Requires the pg_trgm extension.
+func Similar(value any) Filter { return &similarFilter{value: value} }
+
+// WordSimilar matches columns containing a word similar to value, which suits
+// searching for a short query in longer text. Requires the pg_trgm extension.
+func WordSimilar(value any) Filter { return &similarFilter{value: value, word: true} }
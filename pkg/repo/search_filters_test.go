@@ -0,0 +1,76 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFullTextFilter(t *testing.T) {
+	t.Run("text column", func(t *testing.T) {
+		filter := FullText("english", `"late payment" -refund`)
+		assert.Equal(t, "to_tsvector('english', column) @@ websearch_to_tsquery('english', $2)", filter.String("column", 2))
+		assert.Equal(t, "ts_rank(to_tsvector('english', column), websearch_to_tsquery('english', $2))", filter.Rank("column", 2))
+		assert.Equal(t, []any{`"late payment" -refund`}, filter.Value())
+	})
+
+	t.Run("default config", func(t *testing.T) {
+		filter := FullText("", "invoice")
+		assert.Equal(t, "to_tsvector('simple', column) @@ websearch_to_tsquery('simple', $1)", filter.String("column", 1))
+	})
+
+	t.Run("tsvector column", func(t *testing.T) {
+		filter := TSMatch("", "invoice")
+		assert.Equal(t, "search @@ websearch_to_tsquery('simple', $1)", filter.String("search", 1))
+		assert.Equal(t, "ts_rank(search, websearch_to_tsquery('simple', $1))", filter.Rank("search", 1))
+	})
+
+	t.Run("panic on invalid config", func(t *testing.T) {
+		assert.Panics(t, func() {
+			FullText("english'); DROP TABLE users; --", "x")
+		})
+	})
+}
+
+func TestValidateTextSearchConfig(t *testing.T) {
+	assert.NoError(t, ValidateTextSearchConfig(""))
+	assert.NoError(t, ValidateTextSearchConfig("english"))
+	assert.Error(t, ValidateTextSearchConfig("english'"))
+	assert.Error(t, ValidateTextSearchConfig("English"))
+}
+
+func TestJSONFilters(t *testing.T) {
+	contains := JSONContains(map[string]any{"status": "active"})
+	assert.Equal(t, "column @> $1::jsonb", contains.String("column", 1))
+	assert.Equal(t, []any{`{"status":"active"}`}, contains.Value())
+
+	path := JSONPath(`$.tags[*] ? (@ == "vip")`)
+	assert.Equal(t, "column @? $3::jsonpath", path.String("column", 3))
+	assert.Equal(t, []any{`$.tags[*] ? (@ == "vip")`}, path.Value())
+
+	hasKey := JSONHasKey("phone")
+	assert.Equal(t, "column ? $1", hasKey.String("column", 1))
+	assert.Equal(t, []any{"phone"}, hasKey.Value())
+
+	assert.Panics(t, func() {
+		JSONContains(make(chan int))
+	})
+}
+
+func TestArrayFilters(t *testing.T) {
+	tags := []string{"a", "b"}
+	assert.Equal(t, "column && $1", ArrayOverlap(tags).String("column", 1))
+	assert.Equal(t, "column @> $1", ArrayContains(tags).String("column", 1))
+	assert.Equal(t, "column <@ $1", ArrayContainedBy(tags).String("column", 1))
+	assert.Equal(t, []any{tags}, ArrayOverlap(tags).Value(), "the slice is bound as a single array parameter")
+
+	assert.Panics(t, func() {
+		ArrayOverlap("not a slice")
+	})
+}
+
+func TestTrigramFilters(t *testing.T) {
+	assert.Equal(t, "column % $1", Similar("jonh").String("column", 1))
+	assert.Equal(t, "$2 <% column", WordSimilar("jonh").String("column", 2))
+	assert.Equal(t, []any{"jonh"}, WordSimilar("jonh").Value())
+}
@@ -0,0 +1,75 @@
+package repo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DeletedAtColumn marks rows of soft-deletable tables as deleted. Rows with a NULL
+// deleted_at are live, the others are in the trash until they are restored or purged.
+const DeletedAtColumn = "deleted_at"
+
+// NotDeleted matches rows of the table aliased as alias that aren't in the trash.
+// An empty alias refers to the column unqualified.
+//
+// Example usage:
+//
+//	where := []string{repo.NotDeleted("cp"), "cp.tenant_id = $1"}
+//	// Returns: "cp.deleted_at IS NULL"
+func NotDeleted(alias string) string {
+	return deletedAtColumn(alias) + " IS NULL"
+}
+
+// OnlyDeleted matches rows of the table aliased as alias that are in the trash.
+func OnlyDeleted(alias string) string {
+	return deletedAtColumn(alias) + " IS NOT NULL"
+}
+
+// SoftDelete creates a query moving the live rows of tableName matching where to the trash.
+//
+// Example usage:
+//
+//	query := repo.SoftDelete("counterparty", "id = $1")
+//	// Returns: "UPDATE counterparty SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL"
+func SoftDelete(tableName string, where ...string) string {
+	return fmt.Sprintf(
+		"UPDATE %s SET %s = NOW() %s",
+		tableName,
+		DeletedAtColumn,
+		JoinWhere(append(where[:len(where):len(where)], NotDeleted(""))...),
+	)
+}
+
+// Restore creates a query bringing the rows of tableName matching where back from the trash.
+//
+// Example usage:
+//
+//	query := repo.Restore("counterparty", "id = $1")
+//	// Returns: "UPDATE counterparty SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL"
+func Restore(tableName string, where ...string) string {
+	return fmt.Sprintf(
+		"UPDATE %s SET %s = NULL %s",
+		tableName,
+		DeletedAtColumn,
+		JoinWhere(append(where[:len(where):len(where)], OnlyDeleted(""))...),
+	)
+}
+
+// PurgeDeleted creates a query removing the rows of tableName that were moved to
+// the trash before $1 and match where, whose placeholders therefore start at $2.
+//
+// Example usage:
+//
+//	query := repo.PurgeDeleted("counterparty", "tenant_id = $2")
+//	// Returns: "DELETE FROM counterparty WHERE deleted_at < $1 AND tenant_id = $2"
+func PurgeDeleted(tableName string, where ...string) string {
+	conditions := append([]string{DeletedAtColumn + " < $1"}, where...)
+	return fmt.Sprintf("DELETE FROM %s WHERE %s", tableName, strings.Join(conditions, " AND "))
+}
+
+func deletedAtColumn(alias string) string {
+	if alias == "" {
+		return DeletedAtColumn
+	}
+	return alias + "." + DeletedAtColumn
+}
@@ -0,0 +1,37 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSoftDeleteQueries(t *testing.T) {
+	assert.Equal(t, "cp.deleted_at IS NULL", NotDeleted("cp"))
+	assert.Equal(t, "deleted_at IS NOT NULL", OnlyDeleted(""))
+
+	assert.Equal(t,
+		"UPDATE counterparty SET deleted_at = NOW() WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL",
+		SoftDelete("counterparty", "id = $1", "tenant_id = $2"),
+	)
+	assert.Equal(t,
+		"UPDATE counterparty SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL",
+		Restore("counterparty", "id = $1"),
+	)
+	assert.Equal(t,
+		"DELETE FROM counterparty WHERE deleted_at < $1 AND tenant_id = $2",
+		PurgeDeleted("counterparty", "tenant_id = $2"),
+	)
+	assert.Equal(t, "DELETE FROM counterparty WHERE deleted_at < $1", PurgeDeleted("counterparty"))
+}
+
+func TestSoftDeleteKeepsWhere(t *testing.T) {
+	where := make([]string, 1, 4)
+	where[0] = "id = $1"
+
+	SoftDelete("clients", where...)
+	Restore("clients", where...)
+
+	// The spare capacity of the caller's slice must not be written to
+	assert.Equal(t, []string{"id = $1", ""}, where[:2])
+}
@@ -0,0 +1,71 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// VersionColumn is the optimistic concurrency column of versioned tables. It starts
+// at 1 and every update increments it, so an update can tell whether the row it is
+// about to overwrite is still the one the user has seen.
+const VersionColumn = "version"
+
+// ErrVersionRequired is returned for updates of versioned rows without the version
+// they were read at, which would otherwise overwrite concurrent changes unnoticed.
+var ErrVersionRequired = errors.New("version is required")
+
+// VersionCondition matches rows that are still at the version bound to $argIdx.
+// Check the version with RequireVersion first, since no row is at version 0.
+//
+// Example usage:
+//
+//	query := "UPDATE money_accounts SET name = $1, version = version + 1 WHERE id = $2 AND " +
+//		repo.VersionCondition(repo.VersionColumn, 3)
+func VersionCondition(column string, argIdx int) string {
+	return fmt.Sprintf("%s = $%d", column, argIdx)
+}
+
+// RequireVersion rejects updates of entity from an unknown version, i.e. below 1,
+// with ErrVersionRequired.
+func RequireVersion(entity string, version int) error {
+	if version < 1 {
+		return fmt.Errorf("failed to update %s: %w", entity, ErrVersionRequired)
+	}
+	return nil
+}
+
+// CheckVersion turns a versioned update that matched no row into an error. The row
+// is either gone, reported as notFound, or was updated concurrently, reported as a
+// *serrors.ConflictError; existsQuery, a SELECT EXISTS run with args, tells them apart.
+//
+// Example usage:
+//
+//	err := repo.CheckVersion(ctx, tx, tag, "money account", id, version, ErrAccountNotFound,
+//		repo.Exists("SELECT 1 FROM money_accounts WHERE id = $1 AND tenant_id = $2"), id, tenantID)
+func CheckVersion(
+	ctx context.Context,
+	tx Tx,
+	tag pgconn.CommandTag,
+	entity string,
+	id any,
+	version int,
+	notFound error,
+	existsQuery string,
+	args ...any,
+) error {
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+	var exists bool
+	if err := tx.QueryRow(ctx, existsQuery, args...).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check if %s exists: %w", entity, err)
+	}
+	if !exists {
+		return notFound
+	}
+	return serrors.NewConflictError(entity, id, version)
+}
@@ -0,0 +1,71 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionCondition(t *testing.T) {
+	assert.Equal(t, "wp.version = $4", VersionCondition("wp.version", 4))
+}
+
+func TestRequireVersion(t *testing.T) {
+	require.NoError(t, RequireVersion("money account", 1))
+	require.ErrorIs(t, RequireVersion("money account", 0), ErrVersionRequired)
+	require.ErrorIs(t, RequireVersion("money account", -1), ErrVersionRequired)
+}
+
+type existsTx struct {
+	Tx
+	exists bool
+}
+
+func (tx existsTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return existsRow(tx.exists)
+}
+
+type existsRow bool
+
+func (r existsRow) Scan(dest ...any) error {
+	*dest[0].(*bool) = bool(r)
+	return nil
+}
+
+func TestCheckVersion(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	errNotFound := errors.New("money account not found")
+	existsQuery := Exists("SELECT 1 FROM money_accounts WHERE id = $1")
+
+	t.Run("Updated", func(t *testing.T) {
+		tag := pgconn.NewCommandTag("UPDATE 1")
+		require.NoError(t, CheckVersion(ctx, existsTx{}, tag, "money account", id, 3, errNotFound, existsQuery, id))
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		tag := pgconn.NewCommandTag("UPDATE 0")
+		err := CheckVersion(ctx, existsTx{exists: true}, tag, "money account", id, 3, errNotFound, existsQuery, id)
+		require.Error(t, err)
+		assert.True(t, serrors.IsConflict(err))
+
+		var conflict *serrors.ConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, id.String(), conflict.ID)
+		assert.Equal(t, 3, conflict.Version)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		tag := pgconn.NewCommandTag("UPDATE 0")
+		err := CheckVersion(ctx, existsTx{exists: false}, tag, "money account", id, 3, errNotFound, existsQuery, id)
+		require.ErrorIs(t, err, errNotFound)
+		assert.False(t, serrors.IsConflict(err))
+	})
+}
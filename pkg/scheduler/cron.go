@@ -0,0 +1,219 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule returns the next activation time strictly after t.
+type Schedule interface {
+	Next(t time.Time) time.Time
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var dayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+type field struct {
+	name       string
+	min, max   int
+	names      map[string]int
+	bits       uint64
+	restricted bool
+}
+
+// Parse parses a standard five-field cron expression (minute, hour, day of
+// month, month, day of week), one of the @yearly, @monthly, @weekly, @daily
+// and @hourly descriptors, or "@every <duration>".
+// Fields accept *, lists, ranges, steps and three-letter month and day names.
+func Parse(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if d, ok := strings.CutPrefix(expr, "@every "); ok {
+		interval, err := time.ParseDuration(strings.TrimSpace(d))
+		if err != nil {
+			return nil, fmt.Errorf("scheduler: invalid interval in %q: %w", expr, err)
+		}
+		if interval < time.Second {
+			return nil, fmt.Errorf("scheduler: interval in %q must be at least one second", expr)
+		}
+		return everySchedule{interval: interval}, nil
+	}
+	if spec, ok := descriptors[expr]; ok {
+		expr = spec
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("scheduler: expected 5 fields in %q, got %d", expr, len(parts))
+	}
+	fields := []*field{
+		{name: "minute", min: 0, max: 59},
+		{name: "hour", min: 0, max: 23},
+		{name: "day of month", min: 1, max: 31},
+		{name: "month", min: 1, max: 12, names: monthNames},
+		{name: "day of week", min: 0, max: 7, names: dayNames},
+	}
+	for i, f := range fields {
+		if err := f.parse(parts[i]); err != nil {
+			return nil, fmt.Errorf("scheduler: invalid %s in %q: %w", f.name, expr, err)
+		}
+	}
+	// Both 0 and 7 mean Sunday
+	dow := fields[4].bits
+	if dow&(1<<7) != 0 {
+		dow |= 1
+	}
+	return &cronSchedule{
+		minute:        fields[0].bits,
+		hour:          fields[1].bits,
+		dom:           fields[2].bits,
+		month:         fields[3].bits,
+		dow:           dow,
+		domRestricted: fields[2].restricted,
+		dowRestricted: fields[4].restricted,
+	}, nil
+}
+
+// MustParse is like Parse but panics on invalid expressions.
+func MustParse(expr string) Schedule {
+	s, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (f *field) parse(spec string) error {
+	for _, part := range strings.Split(spec, ",") {
+		rangeSpec, stepSpec, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepSpec)
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid step %q", stepSpec)
+			}
+			step = n
+		}
+
+		var lo, hi int
+		switch {
+		case rangeSpec == "*":
+			lo, hi = f.min, f.max
+			if hasStep {
+				f.restricted = true
+			}
+		case strings.Contains(rangeSpec, "-"):
+			from, to, _ := strings.Cut(rangeSpec, "-")
+			var err error
+			if lo, err = f.value(from); err != nil {
+				return err
+			}
+			if hi, err = f.value(to); err != nil {
+				return err
+			}
+			if lo > hi {
+				return fmt.Errorf("invalid range %q", rangeSpec)
+			}
+			f.restricted = true
+		default:
+			var err error
+			if lo, err = f.value(rangeSpec); err != nil {
+				return err
+			}
+			hi = lo
+			if hasStep {
+				hi = f.max
+			}
+			f.restricted = true
+		}
+
+		for v := lo; v <= hi; v += step {
+			f.bits |= 1 << uint(v)
+		}
+	}
+	return nil
+}
+
+func (f *field) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, f.min, f.max)
+	}
+	return v, nil
+}
+
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domRestricted, dowRestricted  bool
+}
+
+// Next searches forward field by field, giving up after five years for
+// expressions that never match, such as "0 0 30 2 *".
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches follows cron semantics: when both day fields are restricted a day
+// matching either of them is accepted.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domRestricted && s.dowRestricted {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+type everySchedule struct {
+	interval time.Duration
+}
+
+func (s everySchedule) Next(t time.Time) time.Time {
+	return t.Truncate(time.Second).Add(s.interval)
+}
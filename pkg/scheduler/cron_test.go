@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Next(t *testing.T) {
+	from := time.Date(2025, time.March, 14, 10, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		expr string
+		want time.Time
+	}{
+		{"EveryMinute", "* * * * *", time.Date(2025, 3, 14, 10, 31, 0, 0, time.UTC)},
+		{"Step", "*/20 * * * *", time.Date(2025, 3, 14, 10, 40, 0, 0, time.UTC)},
+		{"Daily", "@daily", time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"HourRange", "0 9-17 * * *", time.Date(2025, 3, 14, 11, 0, 0, 0, time.UTC)},
+		{"List", "15,45 * * * *", time.Date(2025, 3, 14, 10, 45, 0, 0, time.UTC)},
+		{"DayNames", "0 6 * * mon-fri", time.Date(2025, 3, 17, 6, 0, 0, 0, time.UTC)},
+		{"SundaySeven", "0 0 * * 7", time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{"MonthName", "0 0 1 jun *", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"Monthly", "@monthly", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		// Restricted day of month and day of week match either
+		{"DayOr", "0 0 20 * fri", time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC)},
+		{"LeapDay", "0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"Every", "@every 90m", time.Date(2025, 3, 14, 12, 0, 15, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.Next(from))
+		})
+	}
+}
+
+func TestParse_Never(t *testing.T) {
+	s, err := Parse("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, s.Next(time.Now()).IsZero())
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * foo *",
+		"@every soon",
+		"@every 10ms",
+	} {
+		_, err := Parse(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry := NewRegistry()
+	noop := func(ctx context.Context) error { return nil }
+
+	registry.Register("b", "@hourly", noop, ForEachTenant(), WithoutCatchUp())
+	registry.Register("a", "@daily", noop)
+
+	tasks := registry.Tasks()
+	require.Len(t, tasks, 2)
+	assert.Equal(t, "a", tasks[0].Name)
+	assert.True(t, tasks[0].CatchUp)
+	assert.False(t, tasks[0].PerTenant)
+	assert.True(t, tasks[1].PerTenant)
+	assert.False(t, tasks[1].CatchUp)
+
+	assert.Panics(t, func() { registry.Register("a", "@daily", noop) })
+	assert.Panics(t, func() { registry.Register("c", "not a schedule", noop) })
+}
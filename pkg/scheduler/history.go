@@ -0,0 +1,319 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+var (
+	ErrRunNotFound  = errors.New("scheduler: run not found")
+	ErrTaskNotFound = errors.New("scheduler: task not found")
+)
+
+type Trigger string
+
+const (
+	TriggerSchedule Trigger = "schedule"
+	// TriggerManual marks runs requested from the run history, e.g. a retry.
+	TriggerManual Trigger = "manual"
+)
+
+// Run is a single execution of a task, for one tenant when the task runs per tenant.
+type Run struct {
+	ID          int64
+	Task        string
+	TenantID    uuid.UUID
+	TriggeredBy Trigger
+	ScheduledAt time.Time
+	Status      RunStatus
+	Error       string
+	StackTrace  string
+	Node        string
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+}
+
+// Duration returns how long the run took, or has been running so far.
+func (r *Run) Duration() time.Duration {
+	if r.StartedAt == nil {
+		return 0
+	}
+	if r.FinishedAt == nil {
+		return time.Since(*r.StartedAt)
+	}
+	return r.FinishedAt.Sub(*r.StartedAt)
+}
+
+// TaskInfo describes a task registered on the current leader.
+type TaskInfo struct {
+	Name       string
+	Spec       string
+	PerTenant  bool
+	CatchUp    bool
+	NextRunAt  *time.Time
+	UpdatedAt  time.Time
+	LastStatus RunStatus
+	LastRunAt  *time.Time
+}
+
+type RunFindParams struct {
+	Limit    int
+	Offset   int
+	Task     string
+	TenantID uuid.UUID
+	Status   RunStatus
+}
+
+// History gives access to the task catalogue and run history. Runs requested
+// through it are picked up by the leader, wherever it runs.
+type History interface {
+	Tasks(ctx context.Context) ([]*TaskInfo, error)
+	GetRun(ctx context.Context, id int64) (*Run, error)
+	// ListRuns returns runs matching params, newest first.
+	ListRuns(ctx context.Context, params *RunFindParams) ([]*Run, error)
+	CountRuns(ctx context.Context, params *RunFindParams) (int64, error)
+	// Trigger requests a run of task. Tasks running per tenant are requested for
+	// tenantID, or for every active tenant when it is uuid.Nil.
+	// It returns the number of requested runs.
+	Trigger(ctx context.Context, task string, tenantID uuid.UUID) (int, error)
+	// Retry requests a new run of the task and tenant of a finished run.
+	Retry(ctx context.Context, id int64) (*Run, error)
+}
+
+const (
+	runColumns = `id, task, tenant_id, triggered_by, scheduled_at, status, error, stack_trace, node,
+		created_at, started_at, finished_at`
+
+	selectRunsQuery = `SELECT ` + runColumns + ` FROM scheduler_runs`
+
+	countRunsQuery = `SELECT COUNT(*) FROM scheduler_runs`
+
+	selectTasksQuery = `
+		SELECT t.name, t.spec, t.per_tenant, t.catch_up, t.next_run_at, t.updated_at, r.status, r.created_at
+		FROM scheduler_tasks t
+		LEFT JOIN LATERAL (
+			SELECT status, created_at FROM scheduler_runs
+			WHERE task = t.name
+			ORDER BY created_at DESC, id DESC
+			LIMIT 1
+		) r ON TRUE
+		ORDER BY t.name`
+
+	selectTaskModeQuery = `SELECT per_tenant FROM scheduler_tasks WHERE name = $1`
+
+	requestRunQuery = `
+		INSERT INTO scheduler_runs (task, tenant_id, triggered_by, scheduled_at, status)
+		VALUES ($1, $2, 'manual', NOW(), 'pending')
+		RETURNING ` + runColumns
+
+	requestTenantRunsQuery = `
+		INSERT INTO scheduler_runs (task, tenant_id, triggered_by, scheduled_at, status)
+		SELECT $1, id, 'manual', NOW(), 'pending' FROM tenants WHERE is_active`
+
+	upsertTaskQuery = `
+		INSERT INTO scheduler_tasks (name, spec, per_tenant, catch_up, next_run_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (name) DO UPDATE
+		SET spec = EXCLUDED.spec,
+			per_tenant = EXCLUDED.per_tenant,
+			catch_up = EXCLUDED.catch_up,
+			next_run_at = EXCLUDED.next_run_at,
+			updated_at = NOW()`
+
+	deleteStaleTasksQuery = `DELETE FROM scheduler_tasks WHERE NOT (name = ANY($1))`
+
+	updateNextRunQuery = `UPDATE scheduler_tasks SET next_run_at = $2, updated_at = NOW() WHERE name = $1`
+)
+
+func NewPgHistory(pool *pgxpool.Pool) History {
+	return &pgHistory{pool: pool}
+}
+
+type pgHistory struct {
+	pool *pgxpool.Pool
+}
+
+func (h *pgHistory) Tasks(ctx context.Context) ([]*TaskInfo, error) {
+	rows, err := h.pool.Query(ctx, selectTasksQuery)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*TaskInfo, error) {
+		var (
+			t          TaskInfo
+			lastStatus *string
+		)
+		if err := row.Scan(&t.Name, &t.Spec, &t.PerTenant, &t.CatchUp, &t.NextRunAt, &t.UpdatedAt, &lastStatus, &t.LastRunAt); err != nil {
+			return nil, err
+		}
+		if lastStatus != nil {
+			t.LastStatus = RunStatus(*lastStatus)
+		}
+		return &t, nil
+	})
+}
+
+func (h *pgHistory) GetRun(ctx context.Context, id int64) (*Run, error) {
+	rows, err := h.pool.Query(ctx, repo.Join(selectRunsQuery, "WHERE id = $1"), id)
+	if err != nil {
+		return nil, err
+	}
+	runs, err := pgx.CollectRows(rows, scanRun)
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return nil, ErrRunNotFound
+	}
+	return runs[0], nil
+}
+
+func (h *pgHistory) ListRuns(ctx context.Context, params *RunFindParams) ([]*Run, error) {
+	where, args := buildRunFilters(params)
+	query := repo.Join(
+		selectRunsQuery,
+		where,
+		"ORDER BY created_at DESC, id DESC",
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	)
+	rows, err := h.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, scanRun)
+}
+
+func (h *pgHistory) CountRuns(ctx context.Context, params *RunFindParams) (int64, error) {
+	where, args := buildRunFilters(params)
+	var count int64
+	if err := h.pool.QueryRow(ctx, repo.Join(countRunsQuery, where), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (h *pgHistory) Trigger(ctx context.Context, task string, tenantID uuid.UUID) (int, error) {
+	var perTenant bool
+	if err := h.pool.QueryRow(ctx, selectTaskModeQuery, task).Scan(&perTenant); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrTaskNotFound
+		}
+		return 0, err
+	}
+
+	if !perTenant {
+		tenantID = uuid.Nil
+	}
+	if perTenant && tenantID == uuid.Nil {
+		tag, err := h.pool.Exec(ctx, requestTenantRunsQuery, task)
+		if err != nil {
+			return 0, err
+		}
+		return int(tag.RowsAffected()), nil
+	}
+	if _, err := h.requestRun(ctx, task, tenantID); err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
+
+func (h *pgHistory) Retry(ctx context.Context, id int64) (*Run, error) {
+	run, err := h.GetRun(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if run.Status == RunStatusPending || run.Status == RunStatusRunning {
+		return nil, fmt.Errorf("scheduler: run %d has not finished", id)
+	}
+	return h.requestRun(ctx, run.Task, run.TenantID)
+}
+
+func (h *pgHistory) requestRun(ctx context.Context, task string, tenantID uuid.UUID) (*Run, error) {
+	rows, err := h.pool.Query(ctx, requestRunQuery, task, nullableTenant(tenantID))
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectExactlyOneRow(rows, scanRun)
+}
+
+func buildRunFilters(params *RunFindParams) (string, []interface{}) {
+	var (
+		where []string
+		args  []interface{}
+	)
+	if params.Task != "" {
+		args = append(args, params.Task)
+		where = append(where, fmt.Sprintf("task = $%d", len(args)))
+	}
+	if params.TenantID != uuid.Nil {
+		args = append(args, params.TenantID)
+		where = append(where, fmt.Sprintf("tenant_id = $%d", len(args)))
+	}
+	if params.Status != "" {
+		args = append(args, string(params.Status))
+		where = append(where, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if len(where) == 0 {
+		return "", nil
+	}
+	return repo.JoinWhere(where...), args
+}
+
+func scanRun(row pgx.CollectableRow) (*Run, error) {
+	var (
+		run         Run
+		tenantID    *uuid.UUID
+		triggeredBy string
+		status      string
+		runError    *string
+		stackTrace  *string
+		node        *string
+	)
+	if err := row.Scan(
+		&run.ID,
+		&run.Task,
+		&tenantID,
+		&triggeredBy,
+		&run.ScheduledAt,
+		&status,
+		&runError,
+		&stackTrace,
+		&node,
+		&run.CreatedAt,
+		&run.StartedAt,
+		&run.FinishedAt,
+	); err != nil {
+		return nil, err
+	}
+	run.TriggeredBy = Trigger(triggeredBy)
+	run.Status = RunStatus(status)
+	if tenantID != nil {
+		run.TenantID = *tenantID
+	}
+	if runError != nil {
+		run.Error = *runError
+	}
+	if stackTrace != nil {
+		run.StackTrace = *stackTrace
+	}
+	if node != nil {
+		run.Node = *node
+	}
+	return &run, nil
+}
+
+func nullableTenant(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
@@ -0,0 +1,112 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const defaultTaskTimeout = time.Hour
+
+// TaskFunc runs a scheduled task. Tasks registered with ForEachTenant find
+// the tenant in ctx through composables.UseTenantID.
+type TaskFunc func(ctx context.Context) error
+
+type Task struct {
+	Name     string
+	Spec     string
+	Schedule Schedule
+	Fn       TaskFunc
+	// PerTenant runs Fn once for every active tenant.
+	PerTenant bool
+	// CatchUp runs a missed activation once when the scheduler comes back.
+	CatchUp bool
+	Timeout time.Duration
+}
+
+type TaskOption func(t *Task)
+
+// ForEachTenant runs the task once for every active tenant, with the tenant set in ctx.
+func ForEachTenant() TaskOption {
+	return func(t *Task) {
+		t.PerTenant = true
+	}
+}
+
+// WithoutCatchUp skips activations missed while no scheduler was running.
+func WithoutCatchUp() TaskOption {
+	return func(t *Task) {
+		t.CatchUp = false
+	}
+}
+
+// WithTimeout bounds a single run. Defaults to one hour.
+func WithTimeout(timeout time.Duration) TaskOption {
+	return func(t *Task) {
+		t.Timeout = timeout
+	}
+}
+
+// Registry holds the tasks registered by modules on the application's registry during Register.
+type Registry struct {
+	mu    sync.RWMutex
+	tasks map[string]*Task
+}
+
+func NewRegistry() *Registry {
+	return &Registry{tasks: make(map[string]*Task)}
+}
+
+// Register adds a task running on the given cron expression (see Parse).
+// It panics on an invalid expression or a duplicate name.
+func (r *Registry) Register(name, spec string, fn TaskFunc, opts ...TaskOption) {
+	schedule, err := Parse(spec)
+	if err != nil {
+		panic(err)
+	}
+	if name == "" {
+		panic("scheduler: task name must not be empty")
+	}
+	task := &Task{
+		Name:     name,
+		Spec:     spec,
+		Schedule: schedule,
+		Fn:       fn,
+		CatchUp:  true,
+		Timeout:  defaultTaskTimeout,
+	}
+	for _, opt := range opts {
+		opt(task)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.tasks[name]; ok {
+		panic(fmt.Sprintf("scheduler: task %q already registered", name))
+	}
+	r.tasks[name] = task
+}
+
+// Tasks returns the registered tasks ordered by name.
+func (r *Registry) Tasks() []*Task {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tasks := make([]*Task, 0, len(r.tasks))
+	for _, t := range r.tasks {
+		tasks = append(tasks, t)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].Name < tasks[j].Name
+	})
+	return tasks
+}
+
+// Get returns the task registered under name.
+func (r *Registry) Get(name string) (*Task, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.tasks[name]
+	return t, ok
+}
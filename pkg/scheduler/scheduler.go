@@ -0,0 +1,473 @@
+// Package scheduler runs cron tasks registered by modules once per cluster.
+//
+// Every replica runs a Scheduler, but only the one holding a Postgres advisory
+// lock triggers tasks; the others take over when its connection goes away.
+// Runs are recorded in scheduler_runs, one per tenant for tasks running per
+// tenant, which also lets a new leader catch up on activations missed while no
+// replica was running. Runs requested through History are executed by the leader:
+//
+//	app.SchedulerRegistry().Register("fleet.check_due_maintenance", "0 6 * * *",
+//		func(ctx context.Context) error {
+//			tenantID, err := composables.UseTenantID(ctx)
+//			if err != nil {
+//				return err
+//			}
+//			return notificationService.CheckDueMaintenance(ctx, tenantID)
+//		},
+//		scheduler.ForEachTenant(),
+//	)
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/tenant"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+const (
+	tryLockQuery = `SELECT pg_try_advisory_lock(hashtext($1))`
+	unlockQuery  = `SELECT pg_advisory_unlock(hashtext($1))`
+
+	lastRunsQuery = `
+		SELECT task, MAX(scheduled_at) FROM scheduler_runs
+		WHERE triggered_by = 'schedule'
+		GROUP BY task`
+
+	startRunQuery = `
+		INSERT INTO scheduler_runs (task, tenant_id, triggered_by, scheduled_at, status, node, started_at)
+		VALUES ($1, $2, 'schedule', $3, 'running', $4, NOW())
+		ON CONFLICT (task, scheduled_at, tenant_id) DO NOTHING
+		RETURNING id`
+
+	claimRequestedRunsQuery = `
+		UPDATE scheduler_runs
+		SET status = 'running', node = $1, started_at = NOW()
+		WHERE id IN (
+			SELECT id FROM scheduler_runs
+			WHERE status = 'pending'
+			ORDER BY id
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + runColumns
+
+	finishRunQuery = `
+		UPDATE scheduler_runs
+		SET status = $2, error = $3, stack_trace = $4, finished_at = NOW()
+		WHERE id = $1`
+
+	// Only the leader runs tasks, so runs left running belong to a previous leader that died
+	interruptRunsQuery = `
+		UPDATE scheduler_runs
+		SET status = 'failed', error = 'interrupted', finished_at = NOW()
+		WHERE status = 'running'`
+
+	purgeRunsQuery = `DELETE FROM scheduler_runs WHERE created_at < $1 AND status <> 'pending'`
+)
+
+type RunStatus string
+
+const (
+	// RunStatusPending marks runs requested from the history and not yet claimed by the leader.
+	RunStatusPending   RunStatus = "pending"
+	RunStatusRunning   RunStatus = "running"
+	RunStatusSucceeded RunStatus = "succeeded"
+	RunStatusFailed    RunStatus = "failed"
+)
+
+type Options struct {
+	Pool     *pgxpool.Pool
+	Registry *Registry
+	// Tenants lists the tenants of tasks registered with ForEachTenant.
+	Tenants tenant.Repository
+	Logger  *logrus.Logger
+	// Location is the time zone of cron expressions. Defaults to the local time zone.
+	Location *time.Location
+	// LockName identifies the advisory lock. Deployments sharing a database
+	// but not their tasks need different names. Defaults to "scheduler".
+	LockName string
+	// ElectionInterval is how often followers try to become leader and the
+	// leader checks its connection. Defaults to 15 seconds.
+	ElectionInterval time.Duration
+	// HistoryRetention is how long runs are kept. Zero keeps them forever.
+	HistoryRetention time.Duration
+}
+
+type Scheduler struct {
+	opts     Options
+	node     string
+	leader   atomic.Bool
+	inFlight sync.Map
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func New(opts Options) *Scheduler {
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	if opts.LockName == "" {
+		opts.LockName = "scheduler"
+	}
+	if opts.ElectionInterval <= 0 {
+		opts.ElectionInterval = 15 * time.Second
+	}
+	hostname, _ := os.Hostname()
+	return &Scheduler{
+		opts: opts,
+		node: fmt.Sprintf("%s/%s", hostname, uuid.NewString()[:8]),
+	}
+}
+
+// Start takes part in leader election in the background until Stop is called.
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+// Stop releases leadership, cancelling running tasks, and waits for them to return.
+func (s *Scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+// IsLeader reports whether this replica currently triggers tasks.
+func (s *Scheduler) IsLeader() bool {
+	return s.leader.Load()
+}
+
+// Node identifies this replica in the run history.
+func (s *Scheduler) Node() string {
+	return s.node
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	defer s.wg.Done()
+	for {
+		if err := s.lead(ctx); err != nil && ctx.Err() == nil {
+			s.opts.Logger.WithError(err).Error("scheduler: leadership lost")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.opts.ElectionInterval):
+		}
+	}
+}
+
+// lead returns immediately unless this replica wins the advisory lock, in which
+// case it schedules tasks until ctx is done or the lock connection fails.
+func (s *Scheduler) lead(ctx context.Context) error {
+	conn, err := s.opts.Pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, tryLockQuery, s.opts.LockName).Scan(&acquired); err != nil {
+		return err
+	}
+	if !acquired {
+		return nil
+	}
+	defer func() {
+		unlockCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := conn.Exec(unlockCtx, unlockQuery, s.opts.LockName); err != nil {
+			// Closing the session releases the lock as well
+			_ = conn.Conn().Close(unlockCtx)
+		}
+	}()
+
+	s.leader.Store(true)
+	defer s.leader.Store(false)
+	s.opts.Logger.WithField("node", s.node).Info("scheduler: acquired leadership")
+
+	ctx, cancel := context.WithCancel(ctx)
+	var running sync.WaitGroup
+	defer running.Wait()
+	defer cancel()
+
+	if _, err := s.opts.Pool.Exec(ctx, interruptRunsQuery); err != nil {
+		return err
+	}
+	next, err := s.initialActivations(ctx)
+	if err != nil {
+		return err
+	}
+	if err := s.syncTasks(ctx, next); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	requests := time.NewTicker(5 * time.Second)
+	defer requests.Stop()
+	election := time.NewTicker(s.opts.ElectionInterval)
+	defer election.Stop()
+	maintenance := time.NewTicker(time.Hour)
+	defer maintenance.Stop()
+
+	s.purge(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-election.C:
+			if _, err := conn.Exec(ctx, "SELECT 1"); err != nil {
+				return err
+			}
+		case <-maintenance.C:
+			s.purge(ctx)
+		case <-requests.C:
+			s.runRequested(ctx, &running)
+		case now := <-ticker.C:
+			s.trigger(ctx, next, now.In(s.opts.Location), &running)
+		}
+	}
+}
+
+// initialActivations resumes every task from its last scheduled run, so missed
+// activations of tasks with catch-up are due right away.
+func (s *Scheduler) initialActivations(ctx context.Context) (map[string]time.Time, error) {
+	rows, err := s.opts.Pool.Query(ctx, lastRunsQuery)
+	if err != nil {
+		return nil, err
+	}
+	last := make(map[string]time.Time)
+	for rows.Next() {
+		var (
+			name        string
+			scheduledAt time.Time
+		)
+		if err := rows.Scan(&name, &scheduledAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		last[name] = scheduledAt
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(s.opts.Location)
+	next := make(map[string]time.Time)
+	for _, task := range s.opts.Registry.Tasks() {
+		t, ok := last[task.Name]
+		if !ok {
+			next[task.Name] = task.Schedule.Next(now)
+			continue
+		}
+		n := task.Schedule.Next(t.In(s.opts.Location))
+		if !n.After(now) && !task.CatchUp {
+			n = task.Schedule.Next(now)
+		}
+		next[task.Name] = n
+	}
+	return next, nil
+}
+
+// syncTasks replaces the task catalogue with the tasks registered on the leader.
+func (s *Scheduler) syncTasks(ctx context.Context, next map[string]time.Time) error {
+	tasks := s.opts.Registry.Tasks()
+	names := make([]string, len(tasks))
+	batch := &pgx.Batch{}
+	for i, task := range tasks {
+		names[i] = task.Name
+		batch.Queue(upsertTaskQuery, task.Name, task.Spec, task.PerTenant, task.CatchUp, nullableTime(next[task.Name]))
+	}
+	batch.Queue(deleteStaleTasksQuery, names)
+	return s.opts.Pool.SendBatch(ctx, batch).Close()
+}
+
+func (s *Scheduler) trigger(ctx context.Context, next map[string]time.Time, now time.Time, running *sync.WaitGroup) {
+	for _, task := range s.opts.Registry.Tasks() {
+		scheduledAt, ok := next[task.Name]
+		if !ok {
+			// Registered after leadership was acquired
+			next[task.Name] = task.Schedule.Next(now)
+			continue
+		}
+		if scheduledAt.IsZero() || scheduledAt.After(now) {
+			continue
+		}
+		// Several missed activations are coalesced into the latest one
+		for {
+			n := task.Schedule.Next(scheduledAt)
+			if n.IsZero() || n.After(now) {
+				break
+			}
+			scheduledAt = n
+		}
+		next[task.Name] = task.Schedule.Next(scheduledAt)
+		if _, err := s.opts.Pool.Exec(ctx, updateNextRunQuery, task.Name, nullableTime(next[task.Name])); err != nil {
+			s.opts.Logger.WithError(err).WithField("task", task.Name).Warn("scheduler: failed to update next run")
+		}
+
+		if _, busy := s.inFlight.LoadOrStore(task.Name, struct{}{}); busy {
+			s.opts.Logger.WithField("task", task.Name).Warn("scheduler: previous run still in progress, skipping")
+			continue
+		}
+		running.Add(1)
+		go func(task *Task, scheduledAt time.Time) {
+			defer running.Done()
+			defer s.inFlight.Delete(task.Name)
+			s.activate(ctx, task, scheduledAt)
+		}(task, scheduledAt)
+	}
+}
+
+// activate runs a scheduled activation of task, once per active tenant for tasks running per tenant.
+func (s *Scheduler) activate(ctx context.Context, task *Task, scheduledAt time.Time) {
+	if !task.PerTenant {
+		s.runScheduled(ctx, task, scheduledAt, uuid.Nil)
+		return
+	}
+
+	tenants, err := s.opts.Tenants.List(composables.WithPool(ctx, s.opts.Pool))
+	if err != nil {
+		s.opts.Logger.WithError(err).WithField("task", task.Name).Error("scheduler: failed to list tenants")
+		return
+	}
+	for _, t := range tenants {
+		if ctx.Err() != nil {
+			return
+		}
+		if t.IsActive() {
+			s.runScheduled(ctx, task, scheduledAt, t.ID())
+		}
+	}
+}
+
+func (s *Scheduler) runScheduled(ctx context.Context, task *Task, scheduledAt time.Time, tenantID uuid.UUID) {
+	var id int64
+	err := s.opts.Pool.QueryRow(ctx, startRunQuery, task.Name, nullableTenant(tenantID), scheduledAt, s.node).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Another leader already ran this activation
+		return
+	}
+	if err != nil {
+		s.opts.Logger.WithError(err).WithField("task", task.Name).Error("scheduler: failed to record run")
+		return
+	}
+	s.execute(ctx, task, &Run{ID: id, Task: task.Name, TenantID: tenantID, ScheduledAt: scheduledAt})
+}
+
+// runRequested claims runs requested from the history and executes them.
+func (s *Scheduler) runRequested(ctx context.Context, running *sync.WaitGroup) {
+	rows, err := s.opts.Pool.Query(ctx, claimRequestedRunsQuery, s.node, 10)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.opts.Logger.WithError(err).Error("scheduler: failed to claim requested runs")
+		}
+		return
+	}
+	runs, err := pgx.CollectRows(rows, scanRun)
+	if err != nil {
+		s.opts.Logger.WithError(err).Error("scheduler: failed to claim requested runs")
+		return
+	}
+
+	for _, run := range runs {
+		task, ok := s.opts.Registry.Get(run.Task)
+		if !ok {
+			s.finish(run, ErrTaskNotFound, "")
+			continue
+		}
+		running.Add(1)
+		go func(task *Task, run *Run) {
+			defer running.Done()
+			s.execute(ctx, task, run)
+		}(task, run)
+	}
+}
+
+func (s *Scheduler) execute(ctx context.Context, task *Task, run *Run) {
+	runCtx, cancel := context.WithTimeout(ctx, task.Timeout)
+	defer cancel()
+	runCtx = composables.WithPool(runCtx, s.opts.Pool)
+	if run.TenantID != uuid.Nil {
+		runCtx = composables.WithTenantID(runCtx, run.TenantID)
+	}
+
+	stack, runErr := call(runCtx, task)
+	if runErr != nil {
+		s.opts.Logger.WithError(runErr).WithFields(logrus.Fields{
+			"task":         task.Name,
+			"tenant_id":    run.TenantID,
+			"scheduled_at": run.ScheduledAt,
+		}).Error("scheduler: task failed")
+	}
+	s.finish(run, runErr, stack)
+}
+
+func (s *Scheduler) finish(run *Run, runErr error, stack string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	status := RunStatusSucceeded
+	var message, stackTrace *string
+	if runErr != nil {
+		status = RunStatusFailed
+		msg := runErr.Error()
+		message = &msg
+		if stack != "" {
+			stackTrace = &stack
+		}
+	}
+	if _, err := s.opts.Pool.Exec(ctx, finishRunQuery, run.ID, string(status), message, stackTrace); err != nil {
+		s.opts.Logger.WithError(err).WithField("task", run.Task).Error("scheduler: failed to record run result")
+	}
+}
+
+// call runs the task and returns its error with a stack trace: the panicking
+// goroutine's stack, or the one recorded by github.com/pkg/errors if any.
+func call(ctx context.Context, task *Task) (stack string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("scheduler: task %s panicked: %v", task.Name, r)
+			stack = string(debug.Stack())
+		}
+	}()
+	if err := task.Fn(ctx); err != nil {
+		if detailed := fmt.Sprintf("%+v", err); detailed != err.Error() {
+			return detailed, err
+		}
+		return "", err
+	}
+	return "", nil
+}
+
+func (s *Scheduler) purge(ctx context.Context) {
+	if s.opts.HistoryRetention <= 0 {
+		return
+	}
+	if _, err := s.opts.Pool.Exec(ctx, purgeRunsQuery, time.Now().Add(-s.opts.HistoryRetention)); err != nil && ctx.Err() == nil {
+		s.opts.Logger.WithError(err).Error("scheduler: failed to purge run history")
+	}
+}
+
+func nullableTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,42 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCall(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		stack, err := call(context.Background(), &Task{Name: "ok", Fn: func(ctx context.Context) error { return nil }})
+		require.NoError(t, err)
+		assert.Empty(t, stack)
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		stack, err := call(context.Background(), &Task{Name: "boom", Fn: func(ctx context.Context) error { panic("boom") }})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "boom")
+		assert.Contains(t, stack, "goroutine")
+	})
+
+	t.Run("ErrorWithStack", func(t *testing.T) {
+		stack, err := call(context.Background(), &Task{Name: "failed", Fn: func(ctx context.Context) error {
+			return errors.New("failed")
+		}})
+		require.EqualError(t, err, "failed")
+		assert.Contains(t, stack, "TestCall")
+	})
+}
+
+func TestRun_Duration(t *testing.T) {
+	started := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	finished := started.Add(90 * time.Second)
+
+	assert.Zero(t, (&Run{}).Duration())
+	assert.Equal(t, 90*time.Second, (&Run{StartedAt: &started, FinishedAt: &finished}).Duration())
+}
@@ -122,6 +122,9 @@ func (c *Collector) StoreMigrations(upChanges, downChanges *common.ChangeSet) er
 			buffer.WriteString(fmt.Sprintf("-- Change CREATE_TABLE: %s\n", node.Table.TableName))
 			buffer.WriteString(pPrinter.Pretty(node))
 			buffer.WriteString(";\n\n")
+			if hasTenantColumn(node) {
+				buffer.WriteString(fmt.Sprintf("SELECT enable_tenant_isolation('%s');\n\n", node.Table.TableName))
+			}
 
 		case *tree.AlterTable:
 			// Handle each command in the AlterTable
@@ -211,3 +214,13 @@ func (c *Collector) StoreMigrations(upChanges, downChanges *common.ChangeSet) er
 	c.logger.Infof("Successfully stored migrations to %s", filepath)
 	return nil
 }
+
+// hasTenantColumn reports whether a new table is tenant-owned and gets the tenant_isolation RLS policy.
+func hasTenantColumn(node *tree.CreateTable) bool {
+	for _, def := range node.Defs {
+		if col, ok := def.(*tree.ColumnTableDef); ok && col.Name == "tenant_id" {
+			return true
+		}
+	}
+	return false
+}
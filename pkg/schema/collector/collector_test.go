@@ -225,3 +225,39 @@ func TestTableFormattingInGeneratedSQL(t *testing.T) {
 	assert.NotContains(t, sqlContent, "DROP TABLE public.public.test_table")
 	assert.NotContains(t, sqlContent, "DROP TABLE public.test_table")
 }
+
+func TestTenantIsolationInGeneratedSQL(t *testing.T) {
+	migrationsDir := t.TempDir()
+	collector := New(Config{
+		MigrationsPath: migrationsDir,
+		LogLevel:       logrus.DebugLevel,
+	})
+
+	newTable := func(name string, columns ...string) *tree.CreateTable {
+		defs := make(tree.TableDefs, 0, len(columns))
+		for _, col := range columns {
+			defs = append(defs, &tree.ColumnTableDef{Name: tree.Name(col), Type: types.Int})
+		}
+		return &tree.CreateTable{Table: tree.MakeUnqualifiedTableName(tree.Name(name)), Defs: defs}
+	}
+
+	upChanges := &common.ChangeSet{
+		Changes:   []interface{}{newTable("tenant_owned", "id", "tenant_id"), newTable("global", "id")},
+		Timestamp: 12345678,
+	}
+	downChanges := &common.ChangeSet{
+		Changes: []interface{}{&tree.DropTable{Names: tree.TableNames{
+			tree.MakeUnqualifiedTableName(tree.Name("tenant_owned")),
+			tree.MakeUnqualifiedTableName(tree.Name("global")),
+		}}},
+		Timestamp: 12345678,
+	}
+
+	require.NoError(t, collector.StoreMigrations(upChanges, downChanges))
+
+	content, err := os.ReadFile(filepath.Join(migrationsDir, "changes-12345678.sql"))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(content), "SELECT enable_tenant_isolation('tenant_owned');")
+	assert.NotContains(t, string(content), "enable_tenant_isolation('global')")
+}
@@ -0,0 +1,40 @@
+package serrors
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ConflictError is returned by repositories when an update was based on a stale
+// version of an aggregate, i.e. someone else saved it after it was read.
+type ConflictError struct {
+	BaseError
+	Entity  string `json:"entity"`
+	ID      string `json:"id"`
+	Version int    `json:"version"`
+}
+
+// NewConflictError creates a conflict error for the entity with id whose update expected version.
+func NewConflictError(entity string, id any, version int) *ConflictError {
+	return &ConflictError{
+		BaseError: BaseError{
+			Code:      "CONFLICT",
+			Message:   fmt.Sprintf("%s %v was modified concurrently, expected version %d", entity, id, version),
+			LocaleKey: "Errors.Conflict",
+			TemplateData: map[string]string{
+				"Entity":  entity,
+				"Version": strconv.Itoa(version),
+			},
+		},
+		Entity:  entity,
+		ID:      fmt.Sprint(id),
+		Version: version,
+	}
+}
+
+// IsConflict reports whether err or any error it wraps is a *ConflictError.
+func IsConflict(err error) bool {
+	var conflict *ConflictError
+	return errors.As(err, &conflict)
+}
@@ -14,3 +14,25 @@ func UnauthorizedGQLError(path ast.Path) *gqlerror.Error {
 		},
 	}
 }
+
+func ForbiddenGQLError(path ast.Path) *gqlerror.Error {
+	return &gqlerror.Error{
+		Path:    path,
+		Message: "forbidden",
+		Extensions: map[string]interface{}{
+			"code": "FORBIDDEN",
+		},
+	}
+}
+
+// ValidationGQLError reports invalid fields of an input under the errors extension
+func ValidationGQLError(path ast.Path, errs ValidationErrors) *gqlerror.Error {
+	return &gqlerror.Error{
+		Path:    path,
+		Message: "validation failed",
+		Extensions: map[string]interface{}{
+			"code":   "VALIDATION_ERROR",
+			"errors": errs,
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package shared
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/a-h/templ"
+	"github.com/iota-uz/go-i18n/v2/i18n"
+
+	"github.com/iota-uz/iota-sdk/components/base/dialog"
+	"github.com/iota-uz/iota-sdk/pkg/htmx"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/serrors"
+)
+
+// RenderConflict answers a save that failed with a *serrors.ConflictError and reports
+// whether it did. HTMX requests get a dialog appended to the page that offers to
+// reload the latest version, other requests a 409 Conflict.
+//
+// Example usage:
+//
+//	if _, err := c.moneyAccountService.Update(r.Context(), entity); err != nil {
+//		if shared.RenderConflict(w, r, err) {
+//			return
+//		}
+//		http.Error(w, err.Error(), http.StatusInternalServerError)
+//		return
+//	}
+func RenderConflict(w http.ResponseWriter, r *http.Request, err error) bool {
+	var conflict *serrors.ConflictError
+	if !errors.As(err, &conflict) {
+		return false
+	}
+
+	l, ok := intl.UseLocalizer(r.Context())
+	if !ok {
+		http.Error(w, conflict.Error(), http.StatusConflict)
+		return true
+	}
+	if !htmx.IsHxRequest(r) {
+		http.Error(w, conflict.Localize(l), http.StatusConflict)
+		return true
+	}
+
+	// htmx doesn't swap 4xx responses, so the dialog goes out with 200
+	htmx.Retarget(w, "body")
+	htmx.Reswap(w, "beforeend")
+	templ.Handler(dialog.Conflict(dialog.ConflictProps{
+		Heading:    l.MustLocalize(&i18n.LocalizeConfig{MessageID: "ConflictDialog.Title"}),
+		Text:       conflict.Localize(l),
+		ReloadText: l.MustLocalize(&i18n.LocalizeConfig{MessageID: "ConflictDialog.Reload"}),
+		CancelText: l.MustLocalize(&i18n.LocalizeConfig{MessageID: "ConflictDialog.Cancel"}),
+	})).ServeHTTP(w, r)
+	return true
+}
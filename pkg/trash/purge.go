@@ -0,0 +1,28 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PurgeTask returns a scheduler task purging the items of bins deleted more than
+// retention ago. It should run for each tenant, see scheduler.ForEachTenant.
+// A zero or negative retention keeps items in the trash forever.
+func PurgeTask(retention time.Duration, bins ...Bin) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if retention <= 0 {
+			return nil
+		}
+		before := time.Now().Add(-retention)
+
+		var errs []error
+		for _, bin := range bins {
+			if _, err := bin.Purge(ctx, before); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", bin.Name(), err))
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
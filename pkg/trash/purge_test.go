@@ -0,0 +1,45 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBin struct {
+	name   string
+	err    error
+	before time.Time
+}
+
+func (b *fakeBin) Name() string                                 { return b.name }
+func (b *fakeBin) List(ctx context.Context) ([]Item, error)     { return nil, nil }
+func (b *fakeBin) Restore(ctx context.Context, id string) error { return nil }
+func (b *fakeBin) Purge(ctx context.Context, before time.Time) (int64, error) {
+	b.before = before
+	return 1, b.err
+}
+
+func TestPurgeTask(t *testing.T) {
+	t.Run("purges items older than retention from every bin", func(t *testing.T) {
+		failing := &fakeBin{name: "Clients", err: errors.New("still referenced")}
+		ok := &fakeBin{name: "Products"}
+
+		err := PurgeTask(24*time.Hour, failing, ok)(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Clients: still referenced")
+
+		assert.WithinDuration(t, time.Now().Add(-24*time.Hour), ok.before, time.Minute)
+		assert.Equal(t, failing.before, ok.before)
+	})
+
+	t.Run("keeps items forever without retention", func(t *testing.T) {
+		bin := &fakeBin{name: "Clients"}
+		require.NoError(t, PurgeTask(0, bin)(context.Background()))
+		assert.True(t, bin.before.IsZero())
+	})
+}
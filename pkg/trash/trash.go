@@ -0,0 +1,215 @@
+// Package trash lists, restores and purges soft-deleted entities.
+//
+// Soft-deletable tables have a nullable deleted_at column (see repo.DeletedAtColumn).
+// Modules describe each of them with a Bin, show the bins on a trash page and purge
+// them on a schedule once the retention period is over:
+//
+//	bins := []trash.Bin{
+//		trash.NewTableBin("Counterparties", "counterparty", "name"),
+//	}
+//	app.SchedulerRegistry().Register("finance.purge_trash", "@daily",
+//		trash.PurgeTask(conf.TrashRetention, bins...), scheduler.ForEachTenant())
+package trash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+// ErrNotFound is returned when restoring an item that isn't in the trash.
+var ErrNotFound = errors.New("item not found in trash")
+
+// Item is a soft-deleted entity.
+type Item struct {
+	ID        string
+	Title     string
+	DeletedAt time.Time
+}
+
+// Bin holds the soft-deleted entities of one kind, scoped to the tenant of the context.
+type Bin interface {
+	// Name is the kind of entities, used as the bin identifier in URLs and as a locale key under Trash.Bins.
+	Name() string
+	// List returns the items in the bin, most recently deleted first.
+	List(ctx context.Context) ([]Item, error)
+	// Restore brings the item with id back from the bin.
+	Restore(ctx context.Context, id string) error
+	// Purge removes the items deleted before the given time for good and returns how many it removed.
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+// TableBinOption configures a bin created by NewTableBin.
+type TableBinOption func(b *tableBin)
+
+// WithPurgeFirst runs queries before each purged row is removed, e.g. to delete
+// rows referencing it that the database won't cascade to. Each query gets the id
+// of the row as $1.
+func WithPurgeFirst(queries ...string) TableBinOption {
+	return func(b *tableBin) {
+		b.purgeFirst = append(b.purgeFirst, queries...)
+	}
+}
+
+// WithPermission makes listing and restoring items require perm. Scheduled purges
+// run without a user and aren't affected.
+func WithPermission(perm *permission.Permission) TableBinOption {
+	return func(b *tableBin) {
+		b.permission = perm
+	}
+}
+
+// NewTableBin creates a bin over table, which needs id, tenant_id and deleted_at
+// columns. title is the SQL expression items are shown with.
+func NewTableBin(name, table, title string, opts ...TableBinOption) Bin {
+	b := &tableBin{
+		name:  name,
+		table: table,
+		title: title,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+type tableBin struct {
+	name       string
+	table      string
+	title      string
+	purgeFirst []string
+	permission *permission.Permission
+}
+
+func (b *tableBin) Name() string {
+	return b.name
+}
+
+func (b *tableBin) List(ctx context.Context) ([]Item, error) {
+	if err := b.can(ctx); err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := repo.Join(
+		fmt.Sprintf("SELECT id::text, %s, %s FROM %s", b.title, repo.DeletedAtColumn, b.table),
+		repo.JoinWhere("tenant_id = $1", repo.OnlyDeleted("")),
+		"ORDER BY", repo.DeletedAtColumn, "DESC",
+	)
+	rows, err := tx.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s trash: %w", b.table, err)
+	}
+	defer rows.Close()
+
+	var items []Item
+	for rows.Next() {
+		var item Item
+		if err := rows.Scan(&item.ID, &item.Title, &item.DeletedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func (b *tableBin) Restore(ctx context.Context, id string) error {
+	if err := b.can(ctx); err != nil {
+		return err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, repo.Restore(b.table, "id::text = $1", "tenant_id = $2"), id, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s %s: %w", b.table, id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Purge removes every row in its own transaction, so rows the database refuses
+// to remove because they are still referenced don't hold back the others.
+func (b *tableBin) Purge(ctx context.Context, before time.Time) (int64, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	query := repo.Join(
+		fmt.Sprintf("SELECT id FROM %s", b.table),
+		repo.JoinWhere("tenant_id = $1", repo.DeletedAtColumn+" < $2"),
+	)
+	rows, err := tx.Query(ctx, query, tenantID, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find expired %s: %w", b.table, err)
+	}
+	var ids []any
+	for rows.Next() {
+		var id any
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	var purged int64
+	var errs []error
+	for _, id := range ids {
+		err := composables.InTx(ctx, func(txCtx context.Context) error {
+			tx, err := composables.UseTx(txCtx)
+			if err != nil {
+				return err
+			}
+			for _, q := range b.purgeFirst {
+				if _, err := tx.Exec(txCtx, q, id); err != nil {
+					return err
+				}
+			}
+			_, err = tx.Exec(txCtx, repo.PurgeDeleted(b.table, "id = $2"), before, id)
+			return err
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to purge %s %v: %w", b.table, id, err))
+			continue
+		}
+		purged++
+	}
+	return purged, errors.Join(errs...)
+}
+
+func (b *tableBin) can(ctx context.Context) error {
+	if b.permission == nil {
+		return nil
+	}
+	return composables.CanUser(ctx, b.permission)
+}